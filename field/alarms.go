@@ -0,0 +1,194 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Tracking of the health of the PLC and networking hardware, raising alarms for the FTA when they fail.
+
+package field
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+)
+
+const (
+	// How long a component must be in an error state before an alarm is raised, to ride out momentary glitches.
+	alarmRaiseDelaySec = 3
+	// How long a component may stay in a transitional state (e.g. while being configured) before it is alarmed on.
+	alarmStuckDelaySec = 60
+)
+
+// Identifiers of the components that are monitored for alarms.
+const (
+	PlcAlarm         = "plc"
+	AccessPointAlarm = "accessPoint"
+	SwitchAlarm      = "switch"
+)
+
+// Alarm is the client-facing representation of an alarm that is active or has yet to be acknowledged.
+type Alarm struct {
+	model.AlarmEntry
+	Active       bool
+	Acknowledged bool
+}
+
+// Tracks the health of a single component and the alarm currently raised against it, if any.
+type alarmMonitor struct {
+	component  string
+	faultSince time.Time
+	alarm      *model.AlarmEntry
+}
+
+// Returns the alarm monitors for each component, in the order in which their alarms should be displayed.
+func newAlarmMonitors() []*alarmMonitor {
+	return []*alarmMonitor{{component: PlcAlarm}, {component: AccessPointAlarm}, {component: SwitchAlarm}}
+}
+
+// Checks the health of each monitored component and raises or clears alarms as they change state.
+func (arena *Arena) updateAlarms(now time.Time) {
+	arena.alarmsMutex.Lock()
+	changed := false
+	for _, monitor := range arena.alarmMonitors {
+		faulted, description, delay := arena.checkComponentHealth(monitor.component)
+		if !faulted {
+			monitor.faultSince = time.Time{}
+			if monitor.alarm != nil && monitor.alarm.IsActive() {
+				log.Printf("Alarm cleared: %s", monitor.alarm.Description)
+				monitor.alarm.ClearedAt = now
+				arena.saveAlarm(monitor.alarm)
+				if monitor.alarm.IsAcknowledged() {
+					monitor.alarm = nil
+				}
+				changed = true
+			}
+			continue
+		}
+
+		if monitor.faultSince.IsZero() {
+			monitor.faultSince = now
+		}
+		if (monitor.alarm == nil || !monitor.alarm.IsActive()) && now.Sub(monitor.faultSince) >= delay {
+			// A recurrence of a cleared but unacknowledged alarm replaces it, so that each occurrence is recorded.
+			log.Printf("Alarm raised: %s", description)
+			monitor.alarm = &model.AlarmEntry{
+				Component:   monitor.component,
+				Description: description,
+				Critical:    arena.isAlarmCritical(monitor.component),
+				RaisedAt:    now,
+			}
+			if err := arena.Database.CreateAlarmEntry(monitor.alarm); err != nil {
+				log.Printf("Failed to record alarm: %v", err)
+			}
+			changed = true
+		}
+	}
+	// The notifier reads the alarms back, so the lock must be released first.
+	arena.alarmsMutex.Unlock()
+	if changed {
+		arena.AlarmsNotifier.Notify()
+	}
+}
+
+// Returns whether the given component is in a fault state, along with a description of the fault and how long it must
+// persist before it is alarmed on.
+func (arena *Arena) checkComponentHealth(component string) (bool, string, time.Duration) {
+	switch component {
+	case PlcAlarm:
+		if arena.Plc.IsEnabled() && !arena.Plc.IsHealthy() {
+			return true, "PLC is not responding", alarmRaiseDelaySec * time.Second
+		}
+	case AccessPointAlarm:
+		status := arena.accessPoint.GetStatus()
+		if arena.EventSettings.NetworkSecurityEnabled && status != "ACTIVE" {
+			delay := alarmStuckDelaySec * time.Second
+			if status == "ERROR" {
+				delay = alarmRaiseDelaySec * time.Second
+			}
+			return true, fmt.Sprintf("Access point status is %s", status), delay
+		}
+	case SwitchAlarm:
+		if arena.EventSettings.NetworkSecurityEnabled && arena.networkSwitch.GetStatus() == "ERROR" {
+			return true, "Switch failed to configure team networks", alarmRaiseDelaySec * time.Second
+		}
+	}
+	return false, "", 0
+}
+
+// Returns true if an alarm for the given component is configured to prevent matches from starting until it is
+// acknowledged.
+func (arena *Arena) isAlarmCritical(component string) bool {
+	switch component {
+	case PlcAlarm:
+		return arena.EventSettings.AlarmCriticalPlc
+	case AccessPointAlarm:
+		return arena.EventSettings.AlarmCriticalAccessPoint
+	case SwitchAlarm:
+		return arena.EventSettings.AlarmCriticalSwitch
+	}
+	return false
+}
+
+// AcknowledgeAlarm marks the current alarm for the given component as acknowledged by the given user. An alarm that
+// has already cleared is then dismissed.
+func (arena *Arena) AcknowledgeAlarm(component, username string) error {
+	if err := arena.acknowledgeAlarm(component, username); err != nil {
+		return err
+	}
+	arena.AlarmsNotifier.Notify()
+	return nil
+}
+
+func (arena *Arena) acknowledgeAlarm(component, username string) error {
+	arena.alarmsMutex.Lock()
+	defer arena.alarmsMutex.Unlock()
+	for _, monitor := range arena.alarmMonitors {
+		if monitor.component != component {
+			continue
+		}
+		if monitor.alarm == nil {
+			return fmt.Errorf("no alarm is raised for component %q", component)
+		}
+		if !monitor.alarm.IsAcknowledged() {
+			monitor.alarm.AcknowledgedAt = time.Now()
+			monitor.alarm.AcknowledgedBy = username
+			arena.saveAlarm(monitor.alarm)
+		}
+		if !monitor.alarm.IsActive() {
+			monitor.alarm = nil
+		}
+		return nil
+	}
+	return fmt.Errorf("invalid alarm component %q", component)
+}
+
+// Returns the alarms that are active or have not yet been acknowledged.
+func (arena *Arena) GetAlarms() []Alarm {
+	arena.alarmsMutex.Lock()
+	defer arena.alarmsMutex.Unlock()
+	alarms := make([]Alarm, 0)
+	for _, monitor := range arena.alarmMonitors {
+		if monitor.alarm != nil {
+			alarm := Alarm{*monitor.alarm, monitor.alarm.IsActive(), monitor.alarm.IsAcknowledged()}
+			alarm.Critical = arena.isAlarmCritical(monitor.component)
+			alarms = append(alarms, alarm)
+		}
+	}
+	return alarms
+}
+
+// Returns an error if there is an active critical alarm that has not been acknowledged.
+func (arena *Arena) checkCriticalAlarms() error {
+	for _, alarm := range arena.GetAlarms() {
+		if alarm.Critical && alarm.Active && !alarm.Acknowledged {
+			return fmt.Errorf("cannot start match until the critical alarm %q is acknowledged", alarm.Description)
+		}
+	}
+	return nil
+}
+
+func (arena *Arena) saveAlarm(alarm *model.AlarmEntry) {
+	if err := arena.Database.UpdateAlarmEntry(alarm); err != nil {
+		log.Printf("Failed to record alarm: %v", err)
+	}
+}
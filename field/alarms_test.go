@@ -0,0 +1,198 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package field
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/plc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlarmRaiseAndClear(t *testing.T) {
+	arena := setupTestArena(t)
+	accessPoint := new(FakeAccessPoint)
+	arena.accessPoint = accessPoint
+	arena.EventSettings.NetworkSecurityEnabled = true
+	accessPoint.status = "ACTIVE"
+	now := time.Now()
+
+	arena.updateAlarms(now)
+	assert.Empty(t, arena.GetAlarms())
+
+	// A transient error shouldn't raise an alarm.
+	accessPoint.status = "ERROR"
+	arena.updateAlarms(now)
+	arena.updateAlarms(now.Add(time.Second))
+	assert.Empty(t, arena.GetAlarms())
+	accessPoint.status = "ACTIVE"
+	arena.updateAlarms(now.Add(2 * time.Second))
+	accessPoint.status = "ERROR"
+	arena.updateAlarms(now.Add(3 * time.Second))
+	assert.Empty(t, arena.GetAlarms())
+
+	// A persistent error should.
+	arena.updateAlarms(now.Add(6 * time.Second))
+	alarms := arena.GetAlarms()
+	if assert.Equal(t, 1, len(alarms)) {
+		assert.Equal(t, AccessPointAlarm, alarms[0].Component)
+		assert.Equal(t, "Access point status is ERROR", alarms[0].Description)
+		assert.True(t, alarms[0].Active)
+		assert.False(t, alarms[0].Acknowledged)
+	}
+
+	// The alarm should persist after the fault clears until it is acknowledged.
+	accessPoint.status = "ACTIVE"
+	arena.updateAlarms(now.Add(7 * time.Second))
+	alarms = arena.GetAlarms()
+	if assert.Equal(t, 1, len(alarms)) {
+		assert.False(t, alarms[0].Active)
+	}
+	assert.Nil(t, arena.AcknowledgeAlarm(AccessPointAlarm, "fta"))
+	assert.Empty(t, arena.GetAlarms())
+
+	// Check that the history was recorded.
+	alarmEntries, err := arena.Database.GetAllAlarmEntries()
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(alarmEntries)) {
+		assert.Equal(t, now.Add(6*time.Second).Unix(), alarmEntries[0].RaisedAt.Unix())
+		assert.Equal(t, now.Add(7*time.Second).Unix(), alarmEntries[0].ClearedAt.Unix())
+		assert.Equal(t, "fta", alarmEntries[0].AcknowledgedBy)
+	}
+
+	err = arena.AcknowledgeAlarm(AccessPointAlarm, "fta")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "no alarm is raised")
+	}
+	err = arena.AcknowledgeAlarm("blah", "fta")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "invalid alarm component")
+	}
+}
+
+func TestAlarmTransitionalStates(t *testing.T) {
+	arena := setupTestArena(t)
+	accessPoint := new(FakeAccessPoint)
+	arena.accessPoint = accessPoint
+	networkSwitch := new(FakeSwitch)
+	arena.networkSwitch = networkSwitch
+	now := time.Now()
+
+	// Nothing should be alarmed on while network security is disabled.
+	accessPoint.status = "UNKNOWN"
+	networkSwitch.status = "ERROR"
+	arena.updateAlarms(now)
+	arena.updateAlarms(now.Add(time.Hour))
+	assert.Empty(t, arena.GetAlarms())
+
+	// An access point that is configuring should be given longer to finish before an alarm is raised.
+	arena.EventSettings.NetworkSecurityEnabled = true
+	networkSwitch.status = "ACTIVE"
+	accessPoint.status = "CONFIGURING"
+	arena.updateAlarms(now)
+	arena.updateAlarms(now.Add(30 * time.Second))
+	assert.Empty(t, arena.GetAlarms())
+	arena.updateAlarms(now.Add(60 * time.Second))
+	alarms := arena.GetAlarms()
+	if assert.Equal(t, 1, len(alarms)) {
+		assert.Equal(t, "Access point status is CONFIGURING", alarms[0].Description)
+	}
+
+	networkSwitch.status = "ERROR"
+	arena.updateAlarms(now.Add(61 * time.Second))
+	arena.updateAlarms(now.Add(64 * time.Second))
+	alarms = arena.GetAlarms()
+	if assert.Equal(t, 2, len(alarms)) {
+		assert.Equal(t, SwitchAlarm, alarms[1].Component)
+	}
+}
+
+func TestAlarmPlc(t *testing.T) {
+	arena := setupTestArena(t)
+	modbusPlc := new(plc.ModbusPlc)
+	modbusPlc.SetAddress("10.0.100.10")
+	arena.Plc = modbusPlc
+	now := time.Now()
+
+	arena.updateAlarms(now)
+	arena.updateAlarms(now.Add(3 * time.Second))
+	alarms := arena.GetAlarms()
+	if assert.Equal(t, 1, len(alarms)) {
+		assert.Equal(t, PlcAlarm, alarms[0].Component)
+		assert.Equal(t, "PLC is not responding", alarms[0].Description)
+	}
+
+	// Acknowledging an active alarm should keep it displayed until it clears.
+	assert.Nil(t, arena.AcknowledgeAlarm(PlcAlarm, "fta"))
+	alarms = arena.GetAlarms()
+	if assert.Equal(t, 1, len(alarms)) {
+		assert.True(t, alarms[0].Acknowledged)
+	}
+	modbusPlc.SetAddress("")
+	arena.updateAlarms(now.Add(4 * time.Second))
+	assert.Empty(t, arena.GetAlarms())
+}
+
+func TestAlarmCriticalBlocksMatchStart(t *testing.T) {
+	arena := setupTestArena(t)
+	accessPoint := new(FakeAccessPoint)
+	arena.accessPoint = accessPoint
+	for _, allianceStation := range arena.AllianceStations {
+		allianceStation.Bypass = true
+	}
+	arena.EventSettings.NetworkSecurityEnabled = true
+	accessPoint.status = "ERROR"
+	now := time.Now()
+	arena.updateAlarms(now)
+	arena.updateAlarms(now.Add(3 * time.Second))
+	assert.Equal(t, 1, len(arena.GetAlarms()))
+
+	// A non-critical alarm shouldn't block the match.
+	assert.Nil(t, arena.checkCanStartMatch())
+
+	arena.EventSettings.AlarmCriticalAccessPoint = true
+	err := arena.checkCanStartMatch()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "cannot start match until the critical alarm")
+	}
+	assert.NotNil(t, arena.StartMatch())
+
+	// Acknowledging the alarm should allow the match to start even if the fault persists.
+	assert.Nil(t, arena.AcknowledgeAlarm(AccessPointAlarm, "fta"))
+	assert.Nil(t, arena.checkCanStartMatch())
+	assert.Nil(t, arena.StartMatch())
+}
+
+func TestAlarmAcknowledgeConcurrentWithUpdates(t *testing.T) {
+	arena := setupTestArena(t)
+	accessPoint := new(FakeAccessPoint)
+	arena.accessPoint = accessPoint
+	arena.EventSettings.NetworkSecurityEnabled = true
+	accessPoint.status = "ERROR"
+	now := time.Now()
+	arena.updateAlarms(now)
+	arena.updateAlarms(now.Add(3 * time.Second))
+
+	// Acknowledgements arrive from the web goroutines while the arena loop keeps checking the alarms.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = arena.AcknowledgeAlarm(AccessPointAlarm, "fta")
+			arena.GetAlarms()
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		arena.updateAlarms(now.Add(time.Duration(4+i) * time.Second))
+	}
+	wg.Wait()
+
+	alarms := arena.GetAlarms()
+	if assert.Equal(t, 1, len(alarms)) {
+		assert.True(t, alarms[0].Active)
+		assert.True(t, alarms[0].Acknowledged)
+	}
+}
@@ -55,12 +55,16 @@ type Arena struct {
 	Database         *model.Database
 	EventSettings    *model.EventSettings
 	accessPoint      network.AccessPoint
-	networkSwitch    *network.Switch
+	networkSwitch    network.Switch
 	redSCC           *network.SCCSwitch
 	blueSCC          *network.SCCSwitch
 	Plc              plc.Plc
+	modbusPlc        *plc.ModbusPlc
+	simulatedPlc     *plc.SimulatedPlc
 	TbaClient        *partner.TbaClient
+	TbaOutbox        *partner.TbaOutbox
 	NexusClient      *partner.NexusClient
+	FrcEventsClient  *partner.FrcEventsClient
 	BlackmagicClient *partner.BlackmagicClient
 	AllianceStations map[string]*AllianceStation
 	Displays         map[string]*Display
@@ -70,10 +74,14 @@ type Arena struct {
 	MatchState
 	lastMatchState                    MatchState
 	CurrentMatch                      *model.Match
+	CurrentPlayNumber                 int
+	CurrentReplayReason               string
+	MatchLoadId                       int
 	MatchStartTime                    time.Time
 	LastMatchTimeSec                  float64
 	RedRealtimeScore                  *RealtimeScore
 	BlueRealtimeScore                 *RealtimeScore
+	scoreTimeline                     scoreTimeline
 	lastDsPacketTime                  time.Time
 	lastPeriodicTaskTime              time.Time
 	EventStatus                       EventStatus
@@ -96,6 +104,14 @@ type Arena struct {
 	soundsPlayed                      map[*game.MatchSound]struct{}
 	breakDescription                  string
 	preloadedTeams                    *[6]*model.Team
+	nexusStatusUpdates                chan partner.NexusEventStatus
+	nexusPitNotes                     map[int]string
+	nexusPitNotesMutex                sync.Mutex
+	alarmMonitors                     []*alarmMonitor
+	alarmsMutex                       sync.Mutex
+	broadcastRankings                 BroadcastRankings
+	broadcastTeamRanks                map[int]int
+	broadcastRankingsMutex            sync.Mutex
 }
 
 type AllianceStation struct {
@@ -113,7 +129,11 @@ type AllianceStation struct {
 func NewArena(dbPath string) (*Arena, error) {
 	arena := new(Arena)
 	arena.configureNotifiers()
-	arena.Plc = new(plc.ModbusPlc)
+	arena.modbusPlc = new(plc.ModbusPlc)
+	arena.simulatedPlc = plc.NewSimulatedPlc()
+	arena.Plc = arena.modbusPlc
+	arena.accessPoint = new(network.VividHostingAccessPoint)
+	arena.alarmMonitors = newAlarmMonitors()
 
 	arena.AllianceStations = make(map[string]*AllianceStation)
 	arena.AllianceStations["R1"] = new(AllianceStation)
@@ -126,6 +146,8 @@ func NewArena(dbPath string) (*Arena, error) {
 	arena.Displays = make(map[string]*Display)
 
 	arena.TeamSigns = NewTeamSigns()
+	arena.TbaOutbox = partner.NewTbaOutbox()
+	arena.nexusStatusUpdates = make(chan partner.NexusEventStatus, 1)
 
 	var err error
 	arena.Database, err = model.OpenDatabase(dbPath)
@@ -137,7 +159,7 @@ func NewArena(dbPath string) (*Arena, error) {
 		return nil, err
 	}
 
-	arena.ScoringPanelRegistry.initialize()
+	arena.ScoringPanelRegistry.initialize(func() bool { return arena.Plc.IsEnabled() })
 
 	// Load empty match as current.
 	arena.MatchState = PreMatch
@@ -186,14 +208,54 @@ func (arena *Arena) LoadSettings() error {
 		settings.NetworkSecurityEnabled,
 		accessPointWifiStatuses,
 	)
-	arena.networkSwitch = network.NewSwitch(settings.SwitchAddress, settings.SwitchPassword)
+	switchTeamVlans, err := network.ParseSwitchTeamVlans(settings.SwitchTeamVlans)
+	if err != nil {
+		log.Printf("%v; falling back to the default team VLANs.", err)
+		switchTeamVlans = network.DefaultSwitchTeamVlans
+	}
+	switchTeamGatewayAddress := settings.SwitchTeamGatewayAddress
+	if switchTeamGatewayAddress == 0 {
+		// The setting is absent from databases created before it was introduced.
+		switchTeamGatewayAddress = network.DefaultSwitchTeamGatewayAddress
+	}
+	if settings.SwitchType == network.SshSwitchType {
+		arena.networkSwitch = network.NewSshSwitch(
+			settings.SwitchAddress,
+			settings.SwitchUsername,
+			settings.SwitchPassword,
+			settings.SwitchCommandTemplate,
+			switchTeamVlans,
+			switchTeamGatewayAddress,
+		)
+	} else {
+		arena.networkSwitch = network.NewCiscoSwitch(
+			settings.SwitchAddress, settings.SwitchPassword, switchTeamVlans, switchTeamGatewayAddress,
+		)
+	}
 	sccUpCommands := strings.Split(settings.SCCUpCommands, "\n")
 	sccDownCommands := strings.Split(settings.SCCDownCommands, "\n")
 	arena.redSCC = network.NewSCCSwitch(settings.RedSCCAddress, settings.SCCUsername, settings.SCCPassword, sccUpCommands, sccDownCommands)
 	arena.blueSCC = network.NewSCCSwitch(settings.BlueSCCAddress, settings.SCCUsername, settings.SCCPassword, sccUpCommands, sccDownCommands)
-	arena.Plc.SetAddress(settings.PlcAddress)
+	plcIoMap, err := plc.ParseIoMap(settings.PlcIoMap)
+	if err != nil {
+		log.Printf("%v; falling back to the default PLC I/O map.", err)
+		plcIoMap = plc.DefaultIoMap()
+	}
+	arena.modbusPlc.SetIoMap(plcIoMap)
+	arena.simulatedPlc.SetIoMap(plcIoMap)
+	if settings.PlcSimulated {
+		arena.modbusPlc.SetAddress("")
+		arena.Plc = arena.simulatedPlc
+	} else {
+		arena.Plc = arena.modbusPlc
+		arena.Plc.SetAddress(settings.PlcAddress)
+	}
 	arena.TbaClient = partner.NewTbaClient(settings.TbaEventCode, settings.TbaSecretId, settings.TbaSecret)
-	arena.NexusClient = partner.NewNexusClient(settings.TbaEventCode)
+	arena.TbaOutbox.Configure(arena.Database, arena.TbaClient, settings.TbaPublishingEnabled)
+	arena.NexusClient = partner.NewNexusClient(settings.TbaEventCode, settings.NexusApiKey)
+	arena.FrcEventsClient = partner.NewFrcEventsClient(
+		settings.TbaEventCode, settings.FrcEventsUsername, settings.FrcEventsAuthKey,
+	)
 	arena.BlackmagicClient = partner.NewBlackmagicClient(settings.BlackmagicAddresses)
 
 	game.MatchTiming.WarmupDurationSec = settings.WarmupDurationSec
@@ -204,10 +266,10 @@ func (arena *Arena) LoadSettings() error {
 	game.UpdateMatchSounds()
 	arena.MatchTimingNotifier.Notify()
 
-	game.AutoBonusCoralThreshold = settings.AutoBonusCoralThreshold
-	game.CoralBonusPerLevelThreshold = settings.CoralBonusPerLevelThreshold
-	game.CoralBonusCoopEnabled = settings.CoralBonusCoopEnabled
-	game.BargeBonusPointThreshold = settings.BargeBonusPointThreshold
+	game.CurrentGame.ApplySettings(settings.GameSettings)
+	if err = arena.RefreshBroadcastRankings(); err != nil {
+		return err
+	}
 
 	// Reconstruct the playoff tournament in memory.
 	if err = arena.CreatePlayoffTournament(); err != nil {
@@ -248,11 +310,29 @@ func (arena *Arena) UpdatePlayoffTournament() error {
 
 // Sets up the arena for the given match.
 func (arena *Arena) LoadMatch(match *model.Match) error {
+	return arena.loadMatch(match, "")
+}
+
+// Sets up the arena for the given match, recording the reason if it is being replayed.
+func (arena *Arena) loadMatch(match *model.Match, replayReason string) error {
 	if arena.MatchState != PreMatch && arena.MatchState != TimeoutActive {
 		return fmt.Errorf("cannot load match while there is a match still in progress or with results pending")
 	}
 
 	arena.CurrentMatch = match
+	arena.CurrentReplayReason = replayReason
+
+	// Number this play of the match one past the most recent result, so that replays are distinguishable.
+	arena.CurrentPlayNumber = 1
+	if match.Type != model.Test {
+		matchResult, err := arena.Database.GetMatchResultForMatch(match.Id)
+		if err != nil {
+			return err
+		}
+		if matchResult != nil {
+			arena.CurrentPlayNumber = matchResult.PlayNumber + 1
+		}
+	}
 
 	loadedByNexus := false
 	if match.ShouldAllowNexusSubstitution() && arena.EventSettings.NexusEnabled {
@@ -314,9 +394,12 @@ func (arena *Arena) LoadMatch(match *model.Match) error {
 
 	// Reset the arena state and realtime scores.
 	arena.soundsPlayed = make(map[*game.MatchSound]struct{})
+	arena.MatchLoadId++
 	arena.RedRealtimeScore = NewRealtimeScore()
 	arena.BlueRealtimeScore = NewRealtimeScore()
+	arena.resetScoreTimeline()
 	arena.ScoringPanelRegistry.resetScoreCommitted()
+	arena.ScoringPanelRegistry.resetScorerViews()
 	arena.Plc.ResetMatch()
 
 	// Notify any listeners about the new match.
@@ -325,6 +408,7 @@ func (arena *Arena) LoadMatch(match *model.Match) error {
 	arena.AllianceStationDisplayMode = "match"
 	arena.AllianceStationDisplayModeNotifier.Notify()
 	arena.ScoringStatusNotifier.Notify()
+	arena.publishNexusStatus()
 
 	return nil
 }
@@ -334,6 +418,21 @@ func (arena *Arena) LoadTestMatch() error {
 	return arena.LoadMatch(&model.Match{Type: model.Test, ShortName: "T", LongName: "Test Match"})
 }
 
+// Resets the arena and loads the given match, which has already been played, to be played again for the given reason.
+// The arena is left untouched if the replay is not allowed.
+func (arena *Arena) ReplayMatch(match *model.Match, reason string) error {
+	if !match.IsComplete() {
+		return fmt.Errorf("cannot replay match %s which has not yet been played", match.ShortName)
+	}
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("a reason is required to replay a match")
+	}
+	if err := arena.ResetMatch(); err != nil {
+		return err
+	}
+	return arena.loadMatch(match, strings.TrimSpace(reason))
+}
+
 // Loads the first unplayed match of the current match type.
 func (arena *Arena) LoadNextMatch(startScheduledBreak bool) error {
 	nextMatch, err := arena.getNextMatch(false)
@@ -428,11 +527,14 @@ func (arena *Arena) StartMatch() error {
 			arena.Database.UpdateMatch(arena.CurrentMatch)
 		}
 		arena.updateCycleTime(arena.CurrentMatch.StartedAt)
+		arena.publishNexusStatus()
 
 		// Save the missed packet count to subtract it from the running count.
 		for _, allianceStation := range arena.AllianceStations {
 			if allianceStation.DsConn != nil {
-				err = allianceStation.DsConn.signalMatchStart(arena.CurrentMatch, &allianceStation.WifiStatus)
+				err = allianceStation.DsConn.signalMatchStart(
+					arena.CurrentMatch, arena.CurrentPlayNumber, &allianceStation.WifiStatus,
+				)
 				if err != nil {
 					log.Println(err)
 				}
@@ -582,6 +684,7 @@ func (arena *Arena) Update() {
 		arena.Plc.ResetMatch()
 		arena.FieldVolunteers = false
 		arena.FieldReset = false
+		arena.resetScoreTimeline()
 	case WarmupPeriod:
 		auto = true
 		enabled = false
@@ -673,6 +776,12 @@ func (arena *Arena) Update() {
 	// Handle field sensors/lights/actuators.
 	arena.handlePlcInputOutput()
 
+	// Raise or clear alarms for the field hardware.
+	arena.updateAlarms(time.Now())
+
+	// Record any changes to the realtime scores since the last iteration.
+	arena.recordScoreChanges(matchTimeSec)
+
 	// Handle the team number / timer displays.
 	arena.TeamSigns.Update(arena)
 
@@ -686,7 +795,10 @@ func (arena *Arena) Run() {
 	go arena.listenForDriverStations()
 	go arena.listenForDsUdpPackets()
 	go arena.accessPoint.Run()
-	go arena.Plc.Run()
+	go arena.modbusPlc.Run()
+	go arena.simulatedPlc.Run()
+	go arena.TbaOutbox.Run()
+	go arena.runNexusPublisher()
 
 	for {
 		loopStartTime := time.Now()
@@ -900,7 +1012,7 @@ func (arena *Arena) checkCanStartMatch() error {
 		}
 	}
 
-	return nil
+	return arena.checkCriticalAlarms()
 }
 
 func (arena *Arena) checkAllianceStationsReady(stations ...string) error {
@@ -980,9 +1092,9 @@ func (arena *Arena) handlePlcInputOutput() {
 
 	// Handle in-match PLC functions.
 	redScore := &arena.RedRealtimeScore.CurrentScore
-	oldRedScore := *redScore
+	oldRedScore := redScore.Clone()
 	blueScore := &arena.BlueRealtimeScore.CurrentScore
-	oldBlueScore := *blueScore
+	oldBlueScore := blueScore.Clone()
 	matchStartTime := arena.MatchStartTime
 	currentTime := time.Now()
 	teleopGracePeriod := matchStartTime.Add(game.GetDurationToTeleopEnd() + game.TeleopGracePeriodSec*time.Second)
@@ -1029,49 +1141,22 @@ func (arena *Arena) handlePlcInputOutput() {
 		arena.Plc.SetStackLights(!redAllianceReady, !blueAllianceReady, false, true)
 	}
 
-	// Get all the game-specific inputs and update the score.
-	if arena.MatchState == AutoPeriod || arena.MatchState == PausePeriod || arena.MatchState == TeleopPeriod ||
-		inGracePeriod {
-		redScore.ProcessorAlgae, blueScore.ProcessorAlgae = arena.Plc.GetProcessorCounts()
-	}
+	// Let the game read its scoring sensors into the score and drive its field lights.
+	game.CurrentGame.UpdateField(
+		arena.Plc,
+		game.FieldState{
+			InMatch: arena.MatchState == AutoPeriod || arena.MatchState == PausePeriod ||
+				arena.MatchState == TeleopPeriod,
+			InGracePeriod: inGracePeriod,
+			MatchTimeSec:  arena.MatchTimeSec(),
+			IsPlayoff:     arena.CurrentMatch.Type == model.Playoff,
+		},
+		redScore,
+		blueScore,
+	)
 	if !oldRedScore.Equals(redScore) || !oldBlueScore.Equals(blueScore) {
 		arena.RealtimeScoreNotifier.Notify()
 	}
-
-	// Handle the truss lights.
-	if arena.MatchState == AutoPeriod || arena.MatchState == PausePeriod || arena.MatchState == TeleopPeriod {
-		warningSequenceActive, lights := trussLightWarningSequence(arena.MatchTimeSec())
-		if warningSequenceActive {
-			arena.Plc.SetTrussLights(lights, lights)
-		} else {
-			if !game.CoralBonusCoopEnabled || arena.CurrentMatch.Type == model.Playoff {
-				// Just leave the lights on all match if co-op is not enabled for this match (or event).
-				arena.Plc.SetTrussLights([3]bool{true, true, true}, [3]bool{true, true, true})
-			} else {
-				// Set the lights to reflect co-op status.
-				if arena.RedScoreSummary().CoopertitionBonus && arena.BlueScoreSummary().CoopertitionBonus {
-					arena.Plc.SetTrussLights([3]bool{true, true, true}, [3]bool{true, true, true})
-				} else {
-					arena.Plc.SetTrussLights(
-						[3]bool{
-							arena.RedRealtimeScore.CurrentScore.ProcessorAlgae >= 1,
-							arena.RedRealtimeScore.CurrentScore.ProcessorAlgae >= 2,
-							false,
-						},
-						[3]bool{
-							arena.BlueRealtimeScore.CurrentScore.ProcessorAlgae >= 1,
-							arena.BlueRealtimeScore.CurrentScore.ProcessorAlgae >= 2,
-							false,
-						},
-					)
-				}
-			}
-		}
-	} else {
-		arena.Plc.SetTrussLights(
-			[3]bool{inGracePeriod, inGracePeriod, inGracePeriod}, [3]bool{inGracePeriod, inGracePeriod, inGracePeriod},
-		)
-	}
 }
 
 func (arena *Arena) handleTeamStop(station string, eStopState, aStopState bool) {
@@ -1119,34 +1204,14 @@ func (arena *Arena) PlaySound(name string) {
 
 func (arena *Arena) positionPostMatchScoreReady(position string) bool {
 	numPanels := arena.ScoringPanelRegistry.GetNumPanels(position)
-	return numPanels > 0 && arena.ScoringPanelRegistry.GetNumScoreCommitted(position) >= numPanels
+	return numPanels > 0 && arena.ScoringPanelRegistry.GetNumScoreCommitted(position) >= numPanels &&
+		len(arena.ScoringPanelRegistry.GetScorerDiscrepancies(position)) == 0
 }
 
 // Performs any actions that need to run at the interval specified by periodicTaskPeriodSec.
 func (arena *Arena) runPeriodicTasks() {
 	arena.updateEarlyLateMessage()
 	arena.purgeDisconnectedDisplays()
-}
-
-// trussLightWarningSequence generates the sequence of truss light states during the "sonar ping" warning sound. It
-// returns true if the sequence is active, and an array of booleans indicating the state of each truss light.
-func trussLightWarningSequence(matchTimeSec float64) (bool, [3]bool) {
-	stepTimeSec := 0.2
-	sequence := []int{1, 2, 3, 2, 1, 2, 3, 0, 0, 1, 2, 3, 2, 1, 2, 3, 0, 0}
-	startTime := float64(
-		game.MatchTiming.WarmupDurationSec + game.MatchTiming.AutoDurationSec + game.MatchTiming.PauseDurationSec +
-			game.MatchTiming.TeleopDurationSec - game.MatchTiming.WarningRemainingDurationSec,
-	)
-	lights := [3]bool{false, false, false}
-
-	if matchTimeSec < startTime {
-		// The sequence is not active yet.
-		return false, lights
-	}
-
-	step := int((matchTimeSec - startTime) / stepTimeSec)
-	if step < len(sequence) && sequence[step] > 0 {
-		lights[sequence[step]-1] = true
-	}
-	return step < len(sequence), lights
+	arena.publishNexusStatus()
+	arena.updateNexusPitNotes()
 }
@@ -14,10 +14,17 @@ import (
 )
 
 type ArenaNotifiers struct {
+	AlarmsNotifier                     *websocket.Notifier
 	AllianceSelectionNotifier          *websocket.Notifier
 	AllianceStationDisplayModeNotifier *websocket.Notifier
 	ArenaStatusNotifier                *websocket.Notifier
 	AudienceDisplayModeNotifier        *websocket.Notifier
+	BroadcastAllianceSelectionNotifier *websocket.Notifier
+	BroadcastLineupNotifier            *websocket.Notifier
+	BroadcastLowerThirdNotifier        *websocket.Notifier
+	BroadcastMatchNotifier             *websocket.Notifier
+	BroadcastRankingsNotifier          *websocket.Notifier
+	BroadcastScoreNotifier             *websocket.Notifier
 	DisplayConfigurationNotifier       *websocket.Notifier
 	EventStatusNotifier                *websocket.Notifier
 	LowerThirdNotifier                 *websocket.Notifier
@@ -43,6 +50,7 @@ type audienceAllianceScoreFields struct {
 
 // Instantiates notifiers and configures their message producing methods.
 func (arena *Arena) configureNotifiers() {
+	arena.AlarmsNotifier = websocket.NewNotifier("alarms", arena.generateAlarmsMessage)
 	arena.AllianceSelectionNotifier = websocket.NewNotifier("allianceSelection", arena.generateAllianceSelectionMessage)
 	arena.AllianceStationDisplayModeNotifier = websocket.NewNotifier(
 		"allianceStationDisplayMode", arena.generateAllianceStationDisplayModeMessage,
@@ -64,6 +72,11 @@ func (arena *Arena) configureNotifiers() {
 	arena.ReloadDisplaysNotifier = websocket.NewNotifier("reload", nil)
 	arena.ScorePostedNotifier = websocket.NewNotifier("scorePosted", arena.GenerateScorePostedMessage)
 	arena.ScoringStatusNotifier = websocket.NewNotifier("scoringStatus", arena.generateScoringStatusMessage)
+	arena.configureBroadcastNotifiers()
+}
+
+func (arena *Arena) generateAlarmsMessage() any {
+	return arena.GetAlarms()
 }
 
 func (arena *Arena) generateAllianceSelectionMessage() any {
@@ -102,8 +115,8 @@ func (arena *Arena) generateArenaStatusMessage() any {
 		arena.AllianceStations,
 		arena.MatchState,
 		arena.checkCanStartMatch() == nil,
-		arena.accessPoint.Status,
-		arena.networkSwitch.Status,
+		arena.accessPoint.GetStatus(),
+		arena.networkSwitch.GetStatus(),
 		arena.redSCC.Status,
 		arena.blueSCC.Status,
 		arena.Plc.IsHealthy(),
@@ -182,6 +195,9 @@ func (arena *Arena) GenerateMatchLoadMessage() any {
 		Match             *model.Match
 		AllowSubstitution bool
 		IsReplay          bool
+		PlayNumber        int
+		LoadId            int
+		ReplayReason      string
 		Teams             map[string]*model.Team
 		Rankings          map[string]int
 		Matchup           *playoff.Matchup
@@ -192,6 +208,9 @@ func (arena *Arena) GenerateMatchLoadMessage() any {
 		arena.CurrentMatch,
 		arena.CurrentMatch.ShouldAllowSubstitution(),
 		isReplay,
+		arena.CurrentPlayNumber,
+		arena.MatchLoadId,
+		arena.CurrentReplayReason,
 		teams,
 		rankings,
 		matchup,
@@ -229,17 +248,11 @@ func (arena *Arena) generateRealtimeScoreMessage() any {
 func (arena *Arena) GenerateScorePostedMessage() any {
 	redScoreSummary := arena.SavedMatchResult.RedScoreSummary()
 	blueScoreSummary := arena.SavedMatchResult.BlueScoreSummary()
-	redRankingPoints := redScoreSummary.BonusRankingPoints
-	blueRankingPoints := blueScoreSummary.BonusRankingPoints
-	switch arena.SavedMatch.Status {
-	case game.RedWonMatch:
-		redRankingPoints += 3
-	case game.BlueWonMatch:
-		blueRankingPoints += 3
-	case game.TieMatch:
-		redRankingPoints++
-		blueRankingPoints++
-	}
+	var redRankingFields, blueRankingFields game.RankingFields
+	redRankingFields.AddScoreSummary(redScoreSummary, blueScoreSummary, false)
+	blueRankingFields.AddScoreSummary(blueScoreSummary, redScoreSummary, false)
+	redRankingPoints := redRankingFields.RankingPoints
+	blueRankingPoints := blueRankingFields.RankingPoints
 
 	// For playoff matches, summarize the state of the series.
 	var redWins, blueWins int
@@ -315,7 +328,7 @@ func (arena *Arena) GenerateScorePostedMessage() any {
 		blueWins,
 		redDestination,
 		blueDestination,
-		game.CoralBonusCoopEnabled,
+		game.CurrentGame.CoopertitionEnabled(),
 	}
 }
 
@@ -324,12 +337,14 @@ func (arena *Arena) generateScoringStatusMessage() any {
 		Ready          bool
 		NumPanels      int
 		NumPanelsReady int
+		Discrepancies  []ScorerDiscrepancy
 	}
 	getStatusForPosition := func(position string) positionStatus {
 		return positionStatus{
 			Ready:          arena.positionPostMatchScoreReady(position),
 			NumPanels:      arena.ScoringPanelRegistry.GetNumPanels(position),
 			NumPanelsReady: arena.GetNumScoreCommitted(position),
+			Discrepancies:  arena.ScoringPanelRegistry.GetScorerDiscrepancies(position),
 		}
 	}
 
@@ -5,9 +5,12 @@ package field
 
 import (
 	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/game/reefscape"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/network"
 	"github.com/Team254/cheesy-arena/partner"
 	"github.com/Team254/cheesy-arena/playoff"
+	"github.com/Team254/cheesy-arena/plc"
 	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/Team254/cheesy-arena/websocket"
 	"github.com/stretchr/testify/assert"
@@ -90,6 +93,62 @@ func TestArenaCheckCanStartMatch(t *testing.T) {
 	assert.Nil(t, arena.checkCanStartMatch())
 }
 
+func TestArenaSimulatedPlc(t *testing.T) {
+	arena := setupTestArena(t)
+	for _, allianceStation := range arena.AllianceStations {
+		allianceStation.Bypass = true
+	}
+
+	arena.EventSettings.PlcAddress = "1.2.3.4"
+	arena.EventSettings.PlcSimulated = true
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+	simulatedPlc, ok := arena.Plc.(*plc.SimulatedPlc)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.False(t, arena.modbusPlc.IsEnabled())
+	assert.Nil(t, arena.checkCanStartMatch())
+
+	// Check that a field E-stop pressed on the simulated PLC blocks the match from starting.
+	fieldEStopIndex := -1
+	for i, name := range simulatedPlc.GetInputNames() {
+		if name == "fieldEStop" {
+			fieldEStopIndex = i
+		}
+	}
+	assert.Nil(t, simulatedPlc.SetInput(fieldEStopIndex, false))
+	err := arena.checkCanStartMatch()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "cannot start match while field emergency stop is active")
+	}
+
+	// Check that switching back restores the real PLC.
+	arena.EventSettings.PlcSimulated = false
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+	assert.Equal(t, arena.modbusPlc, arena.Plc)
+	assert.True(t, arena.Plc.IsEnabled())
+}
+
+func TestArenaSwitchType(t *testing.T) {
+	arena := setupTestArena(t)
+	_, ok := arena.networkSwitch.(*network.CiscoSwitch)
+	assert.True(t, ok)
+
+	arena.EventSettings.SwitchType = network.SshSwitchType
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+	_, ok = arena.networkSwitch.(*network.SshSwitch)
+	assert.True(t, ok)
+
+	arena.EventSettings.SwitchType = network.CiscoSwitchType
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+	_, ok = arena.networkSwitch.(*network.CiscoSwitch)
+	assert.True(t, ok)
+}
+
 func TestArenaMatchFlow(t *testing.T) {
 	arena := setupTestArena(t)
 
@@ -453,6 +512,54 @@ func TestLoadNextMatch(t *testing.T) {
 	assert.Equal(t, qualificationMatch2.Id, arena.CurrentMatch.Id)
 }
 
+func TestReplayMatch(t *testing.T) {
+	arena := setupTestArena(t)
+
+	match := model.Match{Type: model.Qualification, TypeOrder: 1, ShortName: "Q1"}
+	assert.Nil(t, arena.Database.CreateMatch(&match))
+	assert.Nil(t, arena.LoadMatch(&match))
+	assert.Equal(t, 1, arena.CurrentPlayNumber)
+	assert.Equal(t, "", arena.CurrentReplayReason)
+
+	// An unplayed match can't be replayed, and the failed attempt shouldn't reset the arena.
+	arena.AllianceStations["R1"].Bypass = true
+	err := arena.ReplayMatch(&match, "Field fault")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "has not yet been played")
+	}
+	assert.True(t, arena.AllianceStations["R1"].Bypass)
+
+	match.Status = game.RedWonMatch
+	assert.Nil(t, arena.Database.UpdateMatch(&match))
+	assert.Nil(t, arena.Database.CreateMatchResult(buildTestMatchResult(match.Id, 1)))
+	err = arena.ReplayMatch(&match, " ")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "reason is required")
+	}
+	assert.True(t, arena.AllianceStations["R1"].Bypass)
+
+	// A replay can't interrupt a match in progress.
+	arena.MatchState = AutoPeriod
+	err = arena.ReplayMatch(&match, "Field fault")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "cannot reset match while it is in progress")
+	}
+	assert.Equal(t, 1, arena.CurrentPlayNumber)
+
+	arena.MatchState = PostMatch
+	assert.Nil(t, arena.ReplayMatch(&match, "Field fault"))
+	assert.Equal(t, PreMatch, arena.MatchState)
+	assert.False(t, arena.AllianceStations["R1"].Bypass)
+	assert.Equal(t, match.Id, arena.CurrentMatch.Id)
+	assert.Equal(t, 2, arena.CurrentPlayNumber)
+	assert.Equal(t, "Field fault", arena.CurrentReplayReason)
+
+	// Loading another match clears the replay state.
+	assert.Nil(t, arena.LoadTestMatch())
+	assert.Equal(t, 1, arena.CurrentPlayNumber)
+	assert.Equal(t, "", arena.CurrentReplayReason)
+}
+
 func TestSubstituteTeam(t *testing.T) {
 	arena := setupTestArena(t)
 	tournament.CreateTestAlliances(arena.Database, 2)
@@ -559,7 +666,7 @@ func TestLoadTeamsFromNexus(t *testing.T) {
 		),
 	)
 	defer nexusServer.Close()
-	arena.NexusClient = partner.NewNexusClient("my_event_code")
+	arena.NexusClient = partner.NewNexusClient("my_event_code", "")
 	arena.NexusClient.BaseUrl = nexusServer.URL
 	arena.EventSettings.NexusEnabled = true
 
@@ -1047,8 +1154,8 @@ func TestPlcMatchCycleGameSpecificWithCoopEnabled(t *testing.T) {
 	arena.Update()
 	redScore := &arena.RedRealtimeScore.CurrentScore
 	blueScore := &arena.BlueRealtimeScore.CurrentScore
-	assert.Equal(t, 0, redScore.ProcessorAlgae)
-	assert.Equal(t, 0, blueScore.ProcessorAlgae)
+	assert.Equal(t, 0, reefscape.ScoreDetails(redScore).ProcessorAlgae)
+	assert.Equal(t, 0, reefscape.ScoreDetails(blueScore).ProcessorAlgae)
 	assert.Equal(t, [3]bool{false, false, false}, plc.redTrussLights)
 	assert.Equal(t, [3]bool{false, false, false}, plc.blueTrussLights)
 	plc.redProcessorCount = 0
@@ -1071,8 +1178,8 @@ func TestPlcMatchCycleGameSpecificWithCoopEnabled(t *testing.T) {
 	// Check the autonomous period.
 	plc.redProcessorCount = 1
 	arena.Update()
-	assert.Equal(t, 1, redScore.ProcessorAlgae)
-	assert.Equal(t, 0, blueScore.ProcessorAlgae)
+	assert.Equal(t, 1, reefscape.ScoreDetails(redScore).ProcessorAlgae)
+	assert.Equal(t, 0, reefscape.ScoreDetails(blueScore).ProcessorAlgae)
 	assert.Equal(t, [3]bool{true, false, false}, plc.redTrussLights)
 	assert.Equal(t, [3]bool{false, false, false}, plc.blueTrussLights)
 
@@ -1084,8 +1191,8 @@ func TestPlcMatchCycleGameSpecificWithCoopEnabled(t *testing.T) {
 	assert.Equal(t, PausePeriod, arena.MatchState)
 	plc.redProcessorCount = 2
 	arena.Update()
-	assert.Equal(t, 2, redScore.ProcessorAlgae)
-	assert.Equal(t, 0, blueScore.ProcessorAlgae)
+	assert.Equal(t, 2, reefscape.ScoreDetails(redScore).ProcessorAlgae)
+	assert.Equal(t, 0, reefscape.ScoreDetails(blueScore).ProcessorAlgae)
 	assert.Equal(t, [3]bool{true, true, false}, plc.redTrussLights)
 	assert.Equal(t, [3]bool{false, false, false}, plc.blueTrussLights)
 
@@ -1099,39 +1206,39 @@ func TestPlcMatchCycleGameSpecificWithCoopEnabled(t *testing.T) {
 
 	plc.blueProcessorCount = 1
 	arena.Update()
-	assert.Equal(t, 2, redScore.ProcessorAlgae)
-	assert.Equal(t, 1, blueScore.ProcessorAlgae)
+	assert.Equal(t, 2, reefscape.ScoreDetails(redScore).ProcessorAlgae)
+	assert.Equal(t, 1, reefscape.ScoreDetails(blueScore).ProcessorAlgae)
 	assert.Equal(t, [3]bool{true, true, false}, plc.redTrussLights)
 	assert.Equal(t, [3]bool{true, false, false}, plc.blueTrussLights)
 
 	plc.redProcessorCount = 3
 	arena.Update()
-	assert.Equal(t, 3, redScore.ProcessorAlgae)
-	assert.Equal(t, 1, blueScore.ProcessorAlgae)
+	assert.Equal(t, 3, reefscape.ScoreDetails(redScore).ProcessorAlgae)
+	assert.Equal(t, 1, reefscape.ScoreDetails(blueScore).ProcessorAlgae)
 	assert.Equal(t, [3]bool{true, true, false}, plc.redTrussLights)
 	assert.Equal(t, [3]bool{true, false, false}, plc.blueTrussLights)
 
 	plc.redProcessorCount = 17
 	arena.Update()
-	assert.Equal(t, 17, redScore.ProcessorAlgae)
-	assert.Equal(t, 1, blueScore.ProcessorAlgae)
+	assert.Equal(t, 17, reefscape.ScoreDetails(redScore).ProcessorAlgae)
+	assert.Equal(t, 1, reefscape.ScoreDetails(blueScore).ProcessorAlgae)
 	assert.Equal(t, [3]bool{true, true, false}, plc.redTrussLights)
 	assert.Equal(t, [3]bool{true, false, false}, plc.blueTrussLights)
-	assert.Equal(t, true, arena.RedScoreSummary().CoopertitionCriteriaMet)
-	assert.Equal(t, false, arena.RedScoreSummary().CoopertitionBonus)
-	assert.Equal(t, false, arena.BlueScoreSummary().CoopertitionCriteriaMet)
-	assert.Equal(t, false, arena.BlueScoreSummary().CoopertitionBonus)
+	assert.Equal(t, true, reefscape.ScoreSummaryDetails(arena.RedScoreSummary()).CoopertitionCriteriaMet)
+	assert.Equal(t, false, reefscape.ScoreSummaryDetails(arena.RedScoreSummary()).CoopertitionBonus)
+	assert.Equal(t, false, reefscape.ScoreSummaryDetails(arena.BlueScoreSummary()).CoopertitionCriteriaMet)
+	assert.Equal(t, false, reefscape.ScoreSummaryDetails(arena.BlueScoreSummary()).CoopertitionBonus)
 
 	plc.blueProcessorCount = 2
 	arena.Update()
-	assert.Equal(t, 17, redScore.ProcessorAlgae)
-	assert.Equal(t, 2, blueScore.ProcessorAlgae)
+	assert.Equal(t, 17, reefscape.ScoreDetails(redScore).ProcessorAlgae)
+	assert.Equal(t, 2, reefscape.ScoreDetails(blueScore).ProcessorAlgae)
 	assert.Equal(t, [3]bool{true, true, true}, plc.redTrussLights)
 	assert.Equal(t, [3]bool{true, true, true}, plc.blueTrussLights)
-	assert.Equal(t, true, arena.RedScoreSummary().CoopertitionCriteriaMet)
-	assert.Equal(t, true, arena.RedScoreSummary().CoopertitionBonus)
-	assert.Equal(t, true, arena.BlueScoreSummary().CoopertitionCriteriaMet)
-	assert.Equal(t, true, arena.BlueScoreSummary().CoopertitionBonus)
+	assert.Equal(t, true, reefscape.ScoreSummaryDetails(arena.RedScoreSummary()).CoopertitionCriteriaMet)
+	assert.Equal(t, true, reefscape.ScoreSummaryDetails(arena.RedScoreSummary()).CoopertitionBonus)
+	assert.Equal(t, true, reefscape.ScoreSummaryDetails(arena.BlueScoreSummary()).CoopertitionCriteriaMet)
+	assert.Equal(t, true, reefscape.ScoreSummaryDetails(arena.BlueScoreSummary()).CoopertitionBonus)
 
 	// Check the truss lights during the "sonar ping" warning sound.
 	durationToWarning := time.Duration(
@@ -1158,8 +1265,8 @@ func TestPlcMatchCycleGameSpecificWithCoopEnabled(t *testing.T) {
 	arena.MatchStartTime = time.Now().Add(-durationToWarning - 5000*time.Millisecond)
 	plc.redProcessorCount = 1
 	arena.Update()
-	assert.Equal(t, 1, redScore.ProcessorAlgae)
-	assert.Equal(t, 2, blueScore.ProcessorAlgae)
+	assert.Equal(t, 1, reefscape.ScoreDetails(redScore).ProcessorAlgae)
+	assert.Equal(t, 2, reefscape.ScoreDetails(blueScore).ProcessorAlgae)
 	assert.Equal(t, [3]bool{true, false, false}, plc.redTrussLights)
 	assert.Equal(t, [3]bool{true, true, false}, plc.blueTrussLights)
 
@@ -1188,7 +1295,7 @@ func TestPlcMatchCycleGameSpecificWithCoopEnabled(t *testing.T) {
 
 func TestPlcMatchCycleGameSpecificWithCoopDisabled(t *testing.T) {
 	defer func() {
-		game.CoralBonusCoopEnabled = true
+		reefscape.CoralBonusCoopEnabled = true
 	}()
 
 	testCases := []struct {
@@ -1213,7 +1320,7 @@ func TestPlcMatchCycleGameSpecificWithCoopDisabled(t *testing.T) {
 				var plc FakePlc
 				plc.isEnabled = true
 				arena.Plc = &plc
-				game.CoralBonusCoopEnabled = tc.coopEnabled
+				reefscape.CoralBonusCoopEnabled = tc.coopEnabled
 				arena.CurrentMatch.Type = tc.matchType
 
 				// Check that no inputs or outputs are active before the match starts.
@@ -1223,8 +1330,8 @@ func TestPlcMatchCycleGameSpecificWithCoopDisabled(t *testing.T) {
 				arena.Update()
 				redScore := &arena.RedRealtimeScore.CurrentScore
 				blueScore := &arena.BlueRealtimeScore.CurrentScore
-				assert.Equal(t, 0, redScore.ProcessorAlgae)
-				assert.Equal(t, 0, blueScore.ProcessorAlgae)
+				assert.Equal(t, 0, reefscape.ScoreDetails(redScore).ProcessorAlgae)
+				assert.Equal(t, 0, reefscape.ScoreDetails(blueScore).ProcessorAlgae)
 				assert.Equal(t, [3]bool{false, false, false}, plc.redTrussLights)
 				assert.Equal(t, [3]bool{false, false, false}, plc.blueTrussLights)
 				plc.redProcessorCount = 0
@@ -1247,8 +1354,8 @@ func TestPlcMatchCycleGameSpecificWithCoopDisabled(t *testing.T) {
 				// Check the autonomous period.
 				plc.redProcessorCount = 1
 				arena.Update()
-				assert.Equal(t, 1, redScore.ProcessorAlgae)
-				assert.Equal(t, 0, blueScore.ProcessorAlgae)
+				assert.Equal(t, 1, reefscape.ScoreDetails(redScore).ProcessorAlgae)
+				assert.Equal(t, 0, reefscape.ScoreDetails(blueScore).ProcessorAlgae)
 				assert.Equal(t, [3]bool{true, true, true}, plc.redTrussLights)
 				assert.Equal(t, [3]bool{true, true, true}, plc.blueTrussLights)
 
@@ -1260,8 +1367,8 @@ func TestPlcMatchCycleGameSpecificWithCoopDisabled(t *testing.T) {
 				assert.Equal(t, PausePeriod, arena.MatchState)
 				plc.redProcessorCount = 2
 				arena.Update()
-				assert.Equal(t, 2, redScore.ProcessorAlgae)
-				assert.Equal(t, 0, blueScore.ProcessorAlgae)
+				assert.Equal(t, 2, reefscape.ScoreDetails(redScore).ProcessorAlgae)
+				assert.Equal(t, 0, reefscape.ScoreDetails(blueScore).ProcessorAlgae)
 				assert.Equal(t, [3]bool{true, true, true}, plc.redTrussLights)
 				assert.Equal(t, [3]bool{true, true, true}, plc.blueTrussLights)
 
@@ -1275,29 +1382,29 @@ func TestPlcMatchCycleGameSpecificWithCoopDisabled(t *testing.T) {
 
 				plc.blueProcessorCount = 1
 				arena.Update()
-				assert.Equal(t, 2, redScore.ProcessorAlgae)
-				assert.Equal(t, 1, blueScore.ProcessorAlgae)
+				assert.Equal(t, 2, reefscape.ScoreDetails(redScore).ProcessorAlgae)
+				assert.Equal(t, 1, reefscape.ScoreDetails(blueScore).ProcessorAlgae)
 				assert.Equal(t, [3]bool{true, true, true}, plc.redTrussLights)
 				assert.Equal(t, [3]bool{true, true, true}, plc.blueTrussLights)
 
 				plc.redProcessorCount = 3
 				arena.Update()
-				assert.Equal(t, 3, redScore.ProcessorAlgae)
-				assert.Equal(t, 1, blueScore.ProcessorAlgae)
+				assert.Equal(t, 3, reefscape.ScoreDetails(redScore).ProcessorAlgae)
+				assert.Equal(t, 1, reefscape.ScoreDetails(blueScore).ProcessorAlgae)
 				assert.Equal(t, [3]bool{true, true, true}, plc.redTrussLights)
 				assert.Equal(t, [3]bool{true, true, true}, plc.blueTrussLights)
 
 				plc.redProcessorCount = 17
 				arena.Update()
-				assert.Equal(t, 17, redScore.ProcessorAlgae)
-				assert.Equal(t, 1, blueScore.ProcessorAlgae)
+				assert.Equal(t, 17, reefscape.ScoreDetails(redScore).ProcessorAlgae)
+				assert.Equal(t, 1, reefscape.ScoreDetails(blueScore).ProcessorAlgae)
 				assert.Equal(t, [3]bool{true, true, true}, plc.redTrussLights)
 				assert.Equal(t, [3]bool{true, true, true}, plc.blueTrussLights)
 
 				plc.blueProcessorCount = 2
 				arena.Update()
-				assert.Equal(t, 17, redScore.ProcessorAlgae)
-				assert.Equal(t, 2, blueScore.ProcessorAlgae)
+				assert.Equal(t, 17, reefscape.ScoreDetails(redScore).ProcessorAlgae)
+				assert.Equal(t, 2, reefscape.ScoreDetails(blueScore).ProcessorAlgae)
 				assert.Equal(t, [3]bool{true, true, true}, plc.redTrussLights)
 				assert.Equal(t, [3]bool{true, true, true}, plc.blueTrussLights)
 
@@ -1326,8 +1433,8 @@ func TestPlcMatchCycleGameSpecificWithCoopDisabled(t *testing.T) {
 				arena.MatchStartTime = time.Now().Add(-durationToWarning - 5000*time.Millisecond)
 				plc.redProcessorCount = 1
 				arena.Update()
-				assert.Equal(t, 1, redScore.ProcessorAlgae)
-				assert.Equal(t, 2, blueScore.ProcessorAlgae)
+				assert.Equal(t, 1, reefscape.ScoreDetails(redScore).ProcessorAlgae)
+				assert.Equal(t, 2, reefscape.ScoreDetails(blueScore).ProcessorAlgae)
 				assert.Equal(t, [3]bool{true, true, true}, plc.redTrussLights)
 				assert.Equal(t, [3]bool{true, true, true}, plc.blueTrussLights)
 
@@ -1356,3 +1463,12 @@ func TestPlcMatchCycleGameSpecificWithCoopDisabled(t *testing.T) {
 		)
 	}
 }
+
+func buildTestMatchResult(matchId int, playNumber int) *model.MatchResult {
+	matchResult := &model.MatchResult{MatchId: matchId, PlayNumber: playNumber, MatchType: model.Qualification}
+	matchResult.RedScore = reefscape.TestScore1()
+	matchResult.BlueScore = reefscape.TestScore2()
+	matchResult.RedCards = map[string]string{"1868": "yellow"}
+	matchResult.BlueCards = map[string]string{}
+	return matchResult
+}
@@ -0,0 +1,373 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Versioned data feed of the event state for driving third-party broadcast graphics (e.g. vMix, OBS, CasparCG) without
+// depending on the internal messages used by the built-in displays. The JSON field names and enumerated string values
+// below are a public contract: new fields may be added at any time, but anything that would break an existing consumer
+// requires incrementing BroadcastFeedVersion.
+
+package field
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/playoff"
+	"github.com/Team254/cheesy-arena/websocket"
+)
+
+// BroadcastFeedVersion is the version of the schema below, which is also part of the URL of each feed endpoint.
+const BroadcastFeedVersion = 1
+
+// Values of BroadcastMatch.State.
+const (
+	BroadcastPreMatch  = "preMatch"
+	BroadcastWarmup    = "warmup"
+	BroadcastAuto      = "auto"
+	BroadcastPause     = "pause"
+	BroadcastTeleop    = "teleop"
+	BroadcastPostMatch = "postMatch"
+	BroadcastTimeout   = "timeout"
+)
+
+// BroadcastFeed is a snapshot of every section of the feed, each of which is also sent over the websocket as a message
+// of the type given by its JSON field name whenever it changes.
+type BroadcastFeed struct {
+	Version           int                        `json:"version"`
+	Match             BroadcastMatch             `json:"match"`
+	Score             BroadcastScore             `json:"score"`
+	Lineup            BroadcastLineup            `json:"lineup"`
+	Rankings          BroadcastRankings          `json:"rankings"`
+	LowerThird        BroadcastLowerThird        `json:"lowerThird"`
+	AllianceSelection BroadcastAllianceSelection `json:"allianceSelection"`
+}
+
+// BroadcastMatch describes the current match and how far into it the field is.
+type BroadcastMatch struct {
+	Id                 int    `json:"id"`
+	Type               string `json:"type"`
+	ShortName          string `json:"shortName"`
+	LongName           string `json:"longName"`
+	NameDetail         string `json:"nameDetail"`
+	PlayNumber         int    `json:"playNumber"`
+	ScheduledStartTime int64  `json:"scheduledStartTime"` // Unix milliseconds; zero for test matches.
+	State              string `json:"state"`
+	TimeRemainingSec   int    `json:"timeRemainingSec"` // Countdown for the current period, as on the audience display.
+	ElapsedSec         int    `json:"elapsedSec"`
+	BreakDescription   string `json:"breakDescription"`
+}
+
+// BroadcastScore holds the realtime score of each alliance in the current match.
+type BroadcastScore struct {
+	Red  BroadcastAllianceScore `json:"red"`
+	Blue BroadcastAllianceScore `json:"blue"`
+}
+
+// BroadcastAllianceScore is the realtime score of one alliance.
+type BroadcastAllianceScore struct {
+	Score              int               `json:"score"`
+	MatchPoints        int               `json:"matchPoints"`
+	FoulPoints         int               `json:"foulPoints"`
+	BonusRankingPoints int               `json:"bonusRankingPoints"`
+	Cards              map[string]string `json:"cards"`       // Card color keyed by team number.
+	GameDetails        any               `json:"gameDetails"` // Season-specific and not covered by the version.
+}
+
+// BroadcastLineup holds the teams in the current match.
+type BroadcastLineup struct {
+	Red  BroadcastAlliance `json:"red"`
+	Blue BroadcastAlliance `json:"blue"`
+}
+
+// BroadcastAlliance describes either one side of the current match or an alliance being formed during alliance
+// selection. For a match, the teams are always one per driver station in order; for alliance selection they are in pick
+// order.
+type BroadcastAlliance struct {
+	AllianceId int             `json:"allianceId"` // Playoff alliance number, or zero outside of playoffs.
+	SeriesWins int             `json:"seriesWins"`
+	Teams      []BroadcastTeam `json:"teams"`
+}
+
+// BroadcastTeam describes a team. An empty driver station or an alliance pick not yet made has a team ID of zero.
+type BroadcastTeam struct {
+	Station   string `json:"station"` // Empty for teams listed outside of a match, such as in alliance selection.
+	Id        int    `json:"id"`
+	Nickname  string `json:"nickname"`
+	Name      string `json:"name"`
+	City      string `json:"city"`
+	StateProv string `json:"stateProv"`
+	Country   string `json:"country"`
+	RobotName string `json:"robotName"`
+	Rank      int    `json:"rank"` // Zero if the team is unranked.
+	AvatarUrl string `json:"avatarUrl"`
+}
+
+// BroadcastRankings holds the qualification rankings in rank order.
+type BroadcastRankings struct {
+	HighestPlayedMatch string             `json:"highestPlayedMatch"`
+	Rankings           []BroadcastRanking `json:"rankings"`
+}
+
+// BroadcastRanking is a single team's qualification ranking.
+type BroadcastRanking struct {
+	Rank          int    `json:"rank"`
+	PreviousRank  int    `json:"previousRank"`
+	TeamId        int    `json:"teamId"`
+	Nickname      string `json:"nickname"`
+	RankingPoints int    `json:"rankingPoints"`
+	Wins          int    `json:"wins"`
+	Losses        int    `json:"losses"`
+	Ties          int    `json:"ties"`
+	Played        int    `json:"played"`
+}
+
+// BroadcastLowerThird is the lower third currently selected for the audience display.
+type BroadcastLowerThird struct {
+	Visible    bool   `json:"visible"`
+	TopText    string `json:"topText"`
+	BottomText string `json:"bottomText"`
+}
+
+// BroadcastAllianceSelection holds the progress of alliance selection.
+type BroadcastAllianceSelection struct {
+	ShowTimer        bool                `json:"showTimer"`
+	TimeRemainingSec int                 `json:"timeRemainingSec"`
+	Alliances        []BroadcastAlliance `json:"alliances"`
+}
+
+// Instantiates the broadcast notifiers on top of the arena notifiers that indicate when each section may have changed.
+func (arena *Arena) configureBroadcastNotifiers() {
+	arena.BroadcastAllianceSelectionNotifier = websocket.NewDerivedNotifier(
+		"allianceSelection", func() any { return arena.generateBroadcastAllianceSelection() },
+		arena.AllianceSelectionNotifier,
+	)
+	arena.BroadcastLineupNotifier = websocket.NewDerivedNotifier(
+		"lineup", func() any { return arena.generateBroadcastLineup() }, arena.MatchLoadNotifier,
+		arena.ScorePostedNotifier,
+	)
+	arena.BroadcastLowerThirdNotifier = websocket.NewDerivedNotifier(
+		"lowerThird", func() any { return arena.generateBroadcastLowerThird() }, arena.LowerThirdNotifier,
+	)
+	arena.BroadcastMatchNotifier = websocket.NewDerivedNotifier(
+		"match", func() any { return arena.generateBroadcastMatch() }, arena.MatchLoadNotifier,
+		arena.MatchTimeNotifier,
+	)
+	arena.BroadcastRankingsNotifier = websocket.NewDerivedNotifier(
+		"rankings", func() any { return arena.generateBroadcastRankings() }, arena.ScorePostedNotifier,
+	)
+	arena.BroadcastScoreNotifier = websocket.NewDerivedNotifier(
+		"score", func() any { return arena.generateBroadcastScore() }, arena.RealtimeScoreNotifier,
+	)
+}
+
+// GenerateBroadcastFeed returns a snapshot of the whole broadcast feed, for clients that poll rather than subscribe.
+func (arena *Arena) GenerateBroadcastFeed() BroadcastFeed {
+	return BroadcastFeed{
+		Version:           BroadcastFeedVersion,
+		Match:             arena.generateBroadcastMatch(),
+		Score:             arena.generateBroadcastScore(),
+		Lineup:            arena.generateBroadcastLineup(),
+		Rankings:          arena.generateBroadcastRankings(),
+		LowerThird:        arena.generateBroadcastLowerThird(),
+		AllianceSelection: arena.generateBroadcastAllianceSelection(),
+	}
+}
+
+func (arena *Arena) generateBroadcastMatch() BroadcastMatch {
+	match := arena.CurrentMatch
+	broadcastMatch := BroadcastMatch{
+		Id:               match.Id,
+		Type:             strings.ToLower(match.Type.String()),
+		ShortName:        match.ShortName,
+		LongName:         match.LongName,
+		NameDetail:       match.NameDetail,
+		PlayNumber:       arena.CurrentPlayNumber,
+		ElapsedSec:       int(arena.MatchTimeSec()),
+		BreakDescription: arena.breakDescription,
+	}
+	if !match.Time.IsZero() {
+		broadcastMatch.ScheduledStartTime = match.Time.UnixMilli()
+	}
+	broadcastMatch.State, broadcastMatch.TimeRemainingSec = getBroadcastMatchState(
+		arena.MatchState, arena.MatchTimeSec(),
+	)
+	return broadcastMatch
+}
+
+// Returns the broadcast state corresponding to the given match state, and the countdown for the current period.
+func getBroadcastMatchState(matchState MatchState, matchTimeSec float64) (string, int) {
+	timing := game.MatchTiming
+	switch matchState {
+	case PreMatch:
+		return BroadcastPreMatch, timing.AutoDurationSec
+	case StartMatch, WarmupPeriod:
+		return BroadcastWarmup, timing.AutoDurationSec
+	case AutoPeriod:
+		return BroadcastAuto, timing.WarmupDurationSec + timing.AutoDurationSec - int(matchTimeSec)
+	case PausePeriod:
+		return BroadcastPause, 0
+	case TeleopPeriod:
+		return BroadcastTeleop, int(game.GetDurationToTeleopEnd().Seconds()) - int(matchTimeSec)
+	case TimeoutActive:
+		return BroadcastTimeout, max(0, timing.TimeoutDurationSec-int(matchTimeSec))
+	case PostTimeout:
+		return BroadcastTimeout, 0
+	default:
+		return BroadcastPostMatch, 0
+	}
+}
+
+func (arena *Arena) generateBroadcastScore() BroadcastScore {
+	return BroadcastScore{
+		Red:  getBroadcastAllianceScore(arena.RedScoreSummary(), arena.RedRealtimeScore.Cards),
+		Blue: getBroadcastAllianceScore(arena.BlueScoreSummary(), arena.BlueRealtimeScore.Cards),
+	}
+}
+
+func getBroadcastAllianceScore(scoreSummary *game.ScoreSummary, cards map[string]string) BroadcastAllianceScore {
+	// Copy the cards so that the map isn't read concurrently with updates from the referee panel while serializing.
+	cardsCopy := make(map[string]string)
+	for teamId, card := range cards {
+		cardsCopy[teamId] = card
+	}
+	return BroadcastAllianceScore{
+		Score:              scoreSummary.Score,
+		MatchPoints:        scoreSummary.MatchPoints,
+		FoulPoints:         scoreSummary.FoulPoints,
+		BonusRankingPoints: scoreSummary.BonusRankingPoints,
+		Cards:              cardsCopy,
+		GameDetails:        scoreSummary.Details,
+	}
+}
+
+func (arena *Arena) generateBroadcastLineup() BroadcastLineup {
+	lineup := BroadcastLineup{
+		Red:  BroadcastAlliance{AllianceId: arena.CurrentMatch.PlayoffRedAlliance},
+		Blue: BroadcastAlliance{AllianceId: arena.CurrentMatch.PlayoffBlueAlliance},
+	}
+	if arena.CurrentMatch.Type == model.Playoff && arena.PlayoffTournament != nil {
+		matchGroup := arena.PlayoffTournament.MatchGroups()[arena.CurrentMatch.PlayoffMatchGroupId]
+		if matchup, ok := matchGroup.(*playoff.Matchup); ok {
+			lineup.Red.SeriesWins = matchup.RedAllianceWins
+			lineup.Blue.SeriesWins = matchup.BlueAllianceWins
+		}
+	}
+	for _, station := range []string{"R1", "R2", "R3"} {
+		lineup.Red.Teams = append(lineup.Red.Teams, arena.getBroadcastTeam(station, arena.AllianceStations[station].Team))
+	}
+	for _, station := range []string{"B1", "B2", "B3"} {
+		lineup.Blue.Teams = append(
+			lineup.Blue.Teams, arena.getBroadcastTeam(station, arena.AllianceStations[station].Team),
+		)
+	}
+	return lineup
+}
+
+// Returns the broadcast details for the given team, which may be nil for an empty station.
+func (arena *Arena) getBroadcastTeam(station string, team *model.Team) BroadcastTeam {
+	broadcastTeam := BroadcastTeam{Station: station}
+	if team == nil {
+		return broadcastTeam
+	}
+	broadcastTeam.Id = team.Id
+	broadcastTeam.Nickname = team.Nickname
+	broadcastTeam.Name = team.Name
+	broadcastTeam.City = team.City
+	broadcastTeam.StateProv = team.StateProv
+	broadcastTeam.Country = team.Country
+	broadcastTeam.RobotName = team.RobotName
+	broadcastTeam.AvatarUrl = fmt.Sprintf("/api/teams/%d/avatar", team.Id)
+	arena.broadcastRankingsMutex.Lock()
+	broadcastTeam.Rank = arena.broadcastTeamRanks[team.Id]
+	arena.broadcastRankingsMutex.Unlock()
+	return broadcastTeam
+}
+
+func (arena *Arena) generateBroadcastRankings() BroadcastRankings {
+	arena.broadcastRankingsMutex.Lock()
+	defer arena.broadcastRankingsMutex.Unlock()
+	return arena.broadcastRankings
+}
+
+// RefreshBroadcastRankings rebuilds the copy of the qualification rankings held for the broadcast feed, which saves the
+// broadcast notifiers from reading them from the database on the arena loop. It must be called whenever the rankings
+// are recalculated or cleared.
+func (arena *Arena) RefreshBroadcastRankings() error {
+	broadcastRankings := BroadcastRankings{Rankings: []BroadcastRanking{}}
+	teamRanks := make(map[int]int)
+	rankings, err := arena.Database.GetAllRankings()
+	if err != nil {
+		return err
+	}
+	teams, err := arena.Database.GetAllTeams()
+	if err != nil {
+		return err
+	}
+	teamNicknames := make(map[int]string)
+	for _, team := range teams {
+		teamNicknames[team.Id] = team.Nickname
+	}
+	for _, ranking := range rankings {
+		broadcastRankings.Rankings = append(
+			broadcastRankings.Rankings,
+			BroadcastRanking{
+				Rank:          ranking.Rank,
+				PreviousRank:  ranking.PreviousRank,
+				TeamId:        ranking.TeamId,
+				Nickname:      teamNicknames[ranking.TeamId],
+				RankingPoints: ranking.RankingPoints,
+				Wins:          ranking.Wins,
+				Losses:        ranking.Losses,
+				Ties:          ranking.Ties,
+				Played:        ranking.Played,
+			},
+		)
+		teamRanks[ranking.TeamId] = ranking.Rank
+	}
+
+	matches, err := arena.Database.GetMatchesByType(model.Qualification, false)
+	if err != nil {
+		return err
+	}
+	for _, match := range matches {
+		if match.IsComplete() {
+			broadcastRankings.HighestPlayedMatch = match.ShortName
+		}
+	}
+
+	arena.broadcastRankingsMutex.Lock()
+	defer arena.broadcastRankingsMutex.Unlock()
+	arena.broadcastRankings = broadcastRankings
+	arena.broadcastTeamRanks = teamRanks
+	return nil
+}
+
+func (arena *Arena) generateBroadcastLowerThird() BroadcastLowerThird {
+	lowerThird := BroadcastLowerThird{Visible: arena.ShowLowerThird}
+	if arena.LowerThird != nil {
+		lowerThird.TopText = arena.LowerThird.TopText
+		lowerThird.BottomText = arena.LowerThird.BottomText
+	}
+	return lowerThird
+}
+
+func (arena *Arena) generateBroadcastAllianceSelection() BroadcastAllianceSelection {
+	allianceSelection := BroadcastAllianceSelection{
+		ShowTimer:        arena.AllianceSelectionShowTimer,
+		TimeRemainingSec: arena.AllianceSelectionTimeRemainingSec,
+		Alliances:        []BroadcastAlliance{},
+	}
+	for _, alliance := range arena.AllianceSelectionAlliances {
+		broadcastAlliance := BroadcastAlliance{AllianceId: alliance.Id, Teams: []BroadcastTeam{}}
+		for _, teamId := range alliance.TeamIds {
+			team, _ := arena.Database.GetTeamById(teamId)
+			if team == nil && teamId > 0 {
+				team = &model.Team{Id: teamId}
+			}
+			broadcastAlliance.Teams = append(broadcastAlliance.Teams, arena.getBroadcastTeam("", team))
+		}
+		allianceSelection.Alliances = append(allianceSelection.Alliances, broadcastAlliance)
+	}
+	return allianceSelection
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package field
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateBroadcastFeed(t *testing.T) {
+	arena := setupTestArena(t)
+	assert.Nil(t, arena.Database.CreateTeam(&model.Team{Id: 254, Nickname: "The Cheesy Poofs", City: "San Jose"}))
+	assert.Nil(t, arena.Database.CreateTeam(&model.Team{Id: 1114, Nickname: "Simbotics"}))
+	assert.Nil(t, arena.Database.CreateRanking(&game.Ranking{TeamId: 1114, Rank: 1}))
+	assert.Nil(t, arena.Database.CreateRanking(&game.Ranking{TeamId: 254, Rank: 2, PreviousRank: 1}))
+
+	// Check that the rankings are only read from the database when they are refreshed.
+	assert.Empty(t, arena.GenerateBroadcastFeed().Rankings.Rankings)
+	assert.Nil(t, arena.RefreshBroadcastRankings())
+
+	matchTime := time.Unix(1700000000, 0)
+	match := model.Match{
+		Type:      model.Qualification,
+		TypeOrder: 12,
+		Time:      matchTime,
+		ShortName: "Q12",
+		LongName:  "Qualification 12",
+		Red2:      254,
+		Blue3:     1114,
+	}
+	assert.Nil(t, arena.Database.CreateMatch(&match))
+	assert.Nil(t, arena.LoadMatch(&match))
+
+	feed := arena.GenerateBroadcastFeed()
+	assert.Equal(t, BroadcastFeedVersion, feed.Version)
+	assert.Equal(t, "qualification", feed.Match.Type)
+	assert.Equal(t, "Q12", feed.Match.ShortName)
+	assert.Equal(t, matchTime.UnixMilli(), feed.Match.ScheduledStartTime)
+	assert.Equal(t, BroadcastPreMatch, feed.Match.State)
+	assert.Equal(t, game.MatchTiming.AutoDurationSec, feed.Match.TimeRemainingSec)
+	assert.Equal(t, 0, feed.Score.Red.Score)
+	if assert.Equal(t, 3, len(feed.Lineup.Red.Teams)) && assert.Equal(t, 3, len(feed.Lineup.Blue.Teams)) {
+		assert.Equal(t, BroadcastTeam{Station: "R1"}, feed.Lineup.Red.Teams[0])
+		assert.Equal(
+			t,
+			BroadcastTeam{
+				Station:   "R2",
+				Id:        254,
+				Nickname:  "The Cheesy Poofs",
+				City:      "San Jose",
+				Rank:      2,
+				AvatarUrl: "/api/teams/254/avatar",
+			},
+			feed.Lineup.Red.Teams[1],
+		)
+		assert.Equal(t, 1114, feed.Lineup.Blue.Teams[2].Id)
+		assert.Equal(t, 1, feed.Lineup.Blue.Teams[2].Rank)
+	}
+	if assert.Equal(t, 2, len(feed.Rankings.Rankings)) {
+		assert.Equal(t, "Simbotics", feed.Rankings.Rankings[0].Nickname)
+		assert.Equal(t, 1, feed.Rankings.Rankings[1].PreviousRank)
+	}
+	assert.False(t, feed.LowerThird.Visible)
+	assert.Empty(t, feed.AllianceSelection.Alliances)
+
+	arena.LowerThird = &model.LowerThird{TopText: "Chairman's Award", BottomText: "Team 254"}
+	arena.ShowLowerThird = true
+	arena.AllianceSelectionAlliances = []model.Alliance{{Id: 1, TeamIds: []int{1114, 0, 0}}}
+	feed = arena.GenerateBroadcastFeed()
+	assert.Equal(
+		t, BroadcastLowerThird{Visible: true, TopText: "Chairman's Award", BottomText: "Team 254"}, feed.LowerThird,
+	)
+	if assert.Equal(t, 1, len(feed.AllianceSelection.Alliances)) {
+		alliance := feed.AllianceSelection.Alliances[0]
+		assert.Equal(t, 1, alliance.AllianceId)
+		if assert.Equal(t, 3, len(alliance.Teams)) {
+			assert.Equal(t, "Simbotics", alliance.Teams[0].Nickname)
+			assert.Equal(t, BroadcastTeam{}, alliance.Teams[1])
+		}
+	}
+}
+
+func TestGetBroadcastMatchState(t *testing.T) {
+	setupTestArena(t)
+
+	state, timeRemainingSec := getBroadcastMatchState(WarmupPeriod, 1)
+	assert.Equal(t, BroadcastWarmup, state)
+	assert.Equal(t, 15, timeRemainingSec)
+	state, timeRemainingSec = getBroadcastMatchState(AutoPeriod, 8)
+	assert.Equal(t, BroadcastAuto, state)
+	assert.Equal(t, 10, timeRemainingSec)
+	state, timeRemainingSec = getBroadcastMatchState(PausePeriod, 19)
+	assert.Equal(t, BroadcastPause, state)
+	assert.Equal(t, 0, timeRemainingSec)
+	state, timeRemainingSec = getBroadcastMatchState(TeleopPeriod, 30)
+	assert.Equal(t, BroadcastTeleop, state)
+	assert.Equal(t, 125, timeRemainingSec)
+	state, _ = getBroadcastMatchState(PostMatch, 0)
+	assert.Equal(t, BroadcastPostMatch, state)
+	state, _ = getBroadcastMatchState(PostTimeout, 0)
+	assert.Equal(t, BroadcastTimeout, state)
+}
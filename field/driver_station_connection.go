@@ -11,6 +11,7 @@ import (
 	"net"
 	"regexp"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/Team254/cheesy-arena/game"
@@ -51,7 +52,15 @@ type DriverStationConnection struct {
 	missedPacketOffset        int
 	tcpConn                   net.Conn
 	udpConn                   net.Conn
-	log                       *TeamMatchLog
+
+	// The match log is replaced by the arena loop at the start of each match and written to by the TCP reader
+	// goroutine.
+	logMutex sync.Mutex
+	log      *TeamMatchLog
+
+	// The software versions are recorded by the TCP reader goroutine and read by the arena loop when a match starts.
+	versionsMutex sync.Mutex
+	versions      map[int]DsDiagnostic
 
 	// WrongStation indicates if the team in the station is the incorrect team
 	// by being non-empty. If the team is in the correct station, or no team is
@@ -151,9 +160,12 @@ func (dsConn *DriverStationConnection) update(arena *Arena) error {
 }
 
 func (dsConn *DriverStationConnection) close() {
+	dsConn.logMutex.Lock()
 	if dsConn.log != nil {
 		dsConn.log.Close()
+		dsConn.log = nil
 	}
+	dsConn.logMutex.Unlock()
 	if dsConn.udpConn != nil {
 		dsConn.udpConn.Close()
 	}
@@ -163,12 +175,28 @@ func (dsConn *DriverStationConnection) close() {
 }
 
 // Called at the start of the match to allow for driver station initialization.
-func (dsConn *DriverStationConnection) signalMatchStart(match *model.Match, wifiStatus *network.TeamWifiStatus) error {
+func (dsConn *DriverStationConnection) signalMatchStart(
+	match *model.Match, playNumber int, wifiStatus *network.TeamWifiStatus,
+) error {
 	// Zero out missed packet count and begin logging.
 	dsConn.missedPacketOffset = dsConn.MissedPacketCount
-	var err error
-	dsConn.log, err = NewTeamMatchLog(dsConn.TeamId, match, wifiStatus)
-	return err
+	teamMatchLog, err := NewTeamMatchLog(dsConn.TeamId, match, playNumber, wifiStatus)
+	if err != nil {
+		return err
+	}
+
+	// The DS only reports software versions when it first connects, so carry them into each match's log.
+	for _, diagnostic := range dsConn.versionDiagnostics() {
+		teamMatchLog.LogDsDiagnostic(0, diagnostic)
+	}
+
+	dsConn.logMutex.Lock()
+	defer dsConn.logMutex.Unlock()
+	if dsConn.log != nil {
+		dsConn.log.Close()
+	}
+	dsConn.log = teamMatchLog
+	return nil
 }
 
 // Serializes the control information into a packet.
@@ -219,7 +247,7 @@ func (dsConn *DriverStationConnection) encodeControlPacket(arena *Arena) [22]byt
 	// Match number.
 	packet[7] = byte(match.TypeOrder >> 8)
 	packet[8] = byte(match.TypeOrder & 0xff)
-	packet[9] = 1 // Match repeat number
+	packet[9] = byte(max(arena.CurrentPlayNumber, 1)) // Match repeat number
 
 	// Current time.
 	currentTime := time.Now()
@@ -375,9 +403,10 @@ func (arena *Arena) listenForDriverStations() {
 
 func (dsConn *DriverStationConnection) handleTcpConnection(arena *Arena) {
 	buffer := make([]byte, maxTcpPacketBytes)
+	var pending []byte
 	for {
 		dsConn.tcpConn.SetReadDeadline(time.Now().Add(time.Second * driverStationTcpLinkTimeoutSec))
-		_, err := dsConn.tcpConn.Read(buffer)
+		n, err := dsConn.tcpConn.Read(buffer)
 		if err != nil {
 			log.Printf("Error reading from connection for Team %d: %v", dsConn.TeamId, err)
 			dsConn.close()
@@ -385,25 +414,62 @@ func (dsConn *DriverStationConnection) handleTcpConnection(arena *Arena) {
 			break
 		}
 
-		packetType := int(buffer[2])
-		switch packetType {
-		case 29:
-			// DS keepalive packet; do nothing.
-			continue
-		case 22:
-			// Robot status packet.
-			var statusPacket [36]byte
-			copy(statusPacket[:], buffer[2:38])
-			dsConn.decodeStatusPacket(statusPacket)
-
-			// Create a log entry if the match is in progress.
-			matchTimeSec := arena.MatchTimeSec()
-			if matchTimeSec > 0 && dsConn.log != nil {
+		// Each packet is prefixed by its two-byte size; a single read may contain several of them or only part of one.
+		pending = append(pending, buffer[:n]...)
+		for len(pending) >= 2 {
+			size := int(pending[0])<<8 + int(pending[1])
+			if len(pending) < size+2 {
+				break
+			}
+			packet := pending[2 : size+2]
+			pending = pending[size+2:]
+			if size > 0 {
+				dsConn.handleTcpPacket(arena, packet)
+			}
+		}
+	}
+}
+
+// Processes a single TCP packet (starting with its type byte) received from the driver station.
+func (dsConn *DriverStationConnection) handleTcpPacket(arena *Arena, packet []byte) {
+	packetType := int(packet[0])
+	matchTimeSec := arena.MatchTimeSec()
+	switch packetType {
+	case 29:
+		// DS keepalive packet; do nothing.
+	case 22:
+		// Robot status packet.
+		var statusPacket [36]byte
+		copy(statusPacket[:], packet)
+		dsConn.decodeStatusPacket(statusPacket)
+
+		// Create a log entry if the match is in progress.
+		if matchTimeSec > 0 {
+			dsConn.logMutex.Lock()
+			if dsConn.log != nil {
 				dsConn.log.LogDsPacket(matchTimeSec, packetType, dsConn)
 			}
-		default:
+			dsConn.logMutex.Unlock()
+		}
+	default:
+		diagnostic, ok := decodeDsDiagnosticPacket(packet)
+		if !ok {
 			log.Printf("Received unknown packet type %d from Team %d", packetType, dsConn.TeamId)
+			return
+		}
+		if diagnostic.Category == "version" {
+			dsConn.versionsMutex.Lock()
+			if dsConn.versions == nil {
+				dsConn.versions = make(map[int]DsDiagnostic)
+			}
+			dsConn.versions[packetType] = diagnostic
+			dsConn.versionsMutex.Unlock()
+		}
+		dsConn.logMutex.Lock()
+		if dsConn.log != nil {
+			dsConn.log.LogDsDiagnostic(matchTimeSec, diagnostic)
 		}
+		dsConn.logMutex.Unlock()
 	}
 }
 
@@ -45,6 +45,12 @@ func TestEncodeControlPacket(t *testing.T) {
 	data = dsConn.encodeControlPacket(arena)
 	assert.Equal(t, byte(5), data[5])
 
+	// Check that the match repeat number reflects the play number.
+	assert.Equal(t, byte(1), data[9])
+	arena.CurrentPlayNumber = 3
+	data = dsConn.encodeControlPacket(arena)
+	assert.Equal(t, byte(3), data[9])
+
 	// Check packet count rollover.
 	dsConn.packetCount = 255
 	data = dsConn.encodeControlPacket(arena)
@@ -220,6 +226,18 @@ func TestListenForDriverStations(t *testing.T) {
 			time.Sleep(time.Millisecond * 10)
 			assert.Equal(t, 103, dsConn.MissedPacketCount)
 			assert.Equal(t, 14, dsConn.DsRobotTripTimeMs)
+
+			// Check that multiple packets in a single write, including one split across writes, all get decoded.
+			dataSend3 := []byte{0, 8, 2, 0, 5, '2', '5', '.', '0', '1', 0, 9, 1, 0, 6, '1', '0'}
+			tcpConn.Write(dataSend3)
+			time.Sleep(time.Millisecond * 10)
+			tcpConn.Write([]byte{'.', '0', '.', '0'})
+			time.Sleep(time.Millisecond * 10)
+			assert.Equal(
+				t,
+				[]DsDiagnostic{{1, "version", "roboRIO 10.0.0"}, {2, "version", "DS 25.01"}},
+				dsConn.versionDiagnostics(),
+			)
 		}
 	}
 }
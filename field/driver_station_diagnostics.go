@@ -0,0 +1,123 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Decoding of the diagnostic tags sent by the driver station over TCP, which are recorded in the team match logs.
+
+package field
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	dsTagUsageReport    = 21
+	dsTagErrorAndEvents = 23
+)
+
+// Names of the components whose software versions the driver station reports, keyed by TCP packet type.
+var dsVersionTagNames = map[int]string{
+	0: "WPILib",
+	1: "roboRIO",
+	2: "DS",
+	3: "PDP",
+	4: "PCM",
+	5: "CANJag",
+	6: "CANTalon",
+	7: "Third Party Device",
+}
+
+// DsDiagnostic represents a single decoded message from a driver station's TCP stream.
+type DsDiagnostic struct {
+	PacketType int
+	Category   string
+	Message    string
+}
+
+// Decodes a diagnostic packet (starting with its type byte) received from the driver station. Returns false if the
+// packet is not a recognized diagnostic tag.
+func decodeDsDiagnosticPacket(packet []byte) (DsDiagnostic, bool) {
+	if len(packet) == 0 {
+		return DsDiagnostic{}, false
+	}
+	packetType := int(packet[0])
+	data := packet[1:]
+
+	if name, ok := dsVersionTagNames[packetType]; ok {
+		// Version tags consist of a length-prefixed status string followed by a length-prefixed version string.
+		status, rest, ok := readLengthPrefixedString(data)
+		version, _, versionOk := readLengthPrefixedString(rest)
+		if !ok || !versionOk {
+			return DsDiagnostic{packetType, "version", fmt.Sprintf("%s %s", name, sanitizeDsString(data))}, true
+		}
+		message := fmt.Sprintf("%s %s", name, version)
+		if status != "" {
+			message += fmt.Sprintf(" (%s)", status)
+		}
+		return DsDiagnostic{packetType, "version", message}, true
+	}
+
+	switch packetType {
+	case dsTagUsageReport:
+		// The usage report leads with the two-byte team number and a byte of unknown purpose.
+		if len(data) < 3 {
+			return DsDiagnostic{}, false
+		}
+		return DsDiagnostic{packetType, "usage", sanitizeDsString(data[3:])}, true
+	case dsTagErrorAndEvents:
+		// Errors and console output forwarded from the robot lead with an eight-byte timestamp.
+		if len(data) < 8 {
+			return DsDiagnostic{}, false
+		}
+		message := sanitizeDsString(data[8:])
+
+		// Flag messages that read like errors so that they stand out from routine console output in the logs.
+		category := "event"
+		if strings.Contains(strings.ToLower(message), "error") {
+			category = "error"
+		}
+		return DsDiagnostic{packetType, category, message}, true
+	}
+	return DsDiagnostic{}, false
+}
+
+// Returns the string prefixed by a one-byte length at the start of the given data, and the remainder of the data.
+func readLengthPrefixedString(data []byte) (string, []byte, bool) {
+	if len(data) < 1 || len(data) < int(data[0])+1 {
+		return "", nil, false
+	}
+	length := int(data[0])
+	return sanitizeDsString(data[1 : length+1]), data[length+1:], true
+}
+
+// Strips control characters and surrounding whitespace from text received from the driver station.
+func sanitizeDsString(data []byte) string {
+	return strings.TrimSpace(
+		strings.Map(
+			func(r rune) rune {
+				if unicode.IsPrint(r) {
+					return r
+				}
+				return -1
+			},
+			string(data),
+		),
+	)
+}
+
+// Returns the software versions most recently reported by the driver station, in a consistent order.
+func (dsConn *DriverStationConnection) versionDiagnostics() []DsDiagnostic {
+	dsConn.versionsMutex.Lock()
+	defer dsConn.versionsMutex.Unlock()
+	var packetTypes []int
+	for packetType := range dsConn.versions {
+		packetTypes = append(packetTypes, packetType)
+	}
+	sort.Ints(packetTypes)
+	diagnostics := make([]DsDiagnostic, len(packetTypes))
+	for i, packetType := range packetTypes {
+		diagnostics[i] = dsConn.versions[packetType]
+	}
+	return diagnostics
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/network"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDecodeDsDiagnosticPacket(t *testing.T) {
+	// Version report with a status string.
+	packet := append([]byte{0, 2}, []byte("OK")...)
+	packet = append(packet, 8)
+	packet = append(packet, []byte("2025.3.2")...)
+	diagnostic, ok := decodeDsDiagnosticPacket(packet)
+	assert.True(t, ok)
+	assert.Equal(t, DsDiagnostic{0, "version", "WPILib 2025.3.2 (OK)"}, diagnostic)
+
+	// Version report without a status string.
+	packet = append([]byte{1, 0, 6}, []byte("10.0.0")...)
+	diagnostic, ok = decodeDsDiagnosticPacket(packet)
+	assert.True(t, ok)
+	assert.Equal(t, DsDiagnostic{1, "version", "roboRIO 10.0.0"}, diagnostic)
+
+	// Malformed version report falls back to the raw text.
+	packet = append([]byte{2, 20}, []byte("25.0")...)
+	diagnostic, ok = decodeDsDiagnosticPacket(packet)
+	assert.True(t, ok)
+	assert.Equal(t, DsDiagnostic{2, "version", "DS 25.0"}, diagnostic)
+
+	// Usage report.
+	packet = append([]byte{21, 0, 254, 0}, []byte("Java\n")...)
+	diagnostic, ok = decodeDsDiagnosticPacket(packet)
+	assert.True(t, ok)
+	assert.Equal(t, DsDiagnostic{21, "usage", "Java"}, diagnostic)
+
+	// Error and event data.
+	packet = append([]byte{23, 0, 0, 0, 0, 0, 0, 0, 1}, []byte("ERROR  1  Loop time of 0.02s overrun")...)
+	diagnostic, ok = decodeDsDiagnosticPacket(packet)
+	assert.True(t, ok)
+	assert.Equal(t, DsDiagnostic{23, "error", "ERROR  1  Loop time of 0.02s overrun"}, diagnostic)
+	packet = append([]byte{23, 0, 0, 0, 0, 0, 0, 0, 1}, []byte("Robot program starting")...)
+	diagnostic, ok = decodeDsDiagnosticPacket(packet)
+	assert.True(t, ok)
+	assert.Equal(t, DsDiagnostic{23, "event", "Robot program starting"}, diagnostic)
+
+	// Truncated and unknown packets.
+	_, ok = decodeDsDiagnosticPacket([]byte{23, 0, 0})
+	assert.False(t, ok)
+	_, ok = decodeDsDiagnosticPacket([]byte{21, 0})
+	assert.False(t, ok)
+	_, ok = decodeDsDiagnosticPacket([]byte{37, 0, 0})
+	assert.False(t, ok)
+	_, ok = decodeDsDiagnosticPacket([]byte{})
+	assert.False(t, ok)
+}
+
+func TestTeamMatchLogReplacedEachMatch(t *testing.T) {
+	arena := setupTestArena(t)
+	baseDir := model.BaseDir
+	model.BaseDir = t.TempDir()
+	defer func() { model.BaseDir = baseDir }()
+	dsConn := &DriverStationConnection{TeamId: 254}
+	dsConn.versions = map[int]DsDiagnostic{0: {0, "version", "WPILib 2025.3.2"}}
+	match := &model.Match{Type: model.Qualification, ShortName: "Q1"}
+	wifiStatus := new(network.TeamWifiStatus)
+
+	// Check that the packet handler can keep writing diagnostics while the arena loop swaps in each match's log.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			dsConn.handleTcpPacket(arena, append([]byte{23, 0, 0, 0, 0, 0, 0, 0, 1}, []byte("ERROR")...))
+		}
+	}()
+	assert.Nil(t, dsConn.signalMatchStart(match, 1, wifiStatus))
+	firstLog := dsConn.log
+	assert.Nil(t, dsConn.signalMatchStart(match, 2, wifiStatus))
+	<-done
+
+	// Check that the previous match's files were closed when the log was replaced.
+	assert.NotSame(t, firstLog, dsConn.log)
+	assert.NotNil(t, firstLog.logFile.Close())
+	assert.NotNil(t, firstLog.diagnosticsLogFile.Close())
+	dsConn.close()
+	assert.Nil(t, dsConn.log)
+}
@@ -67,13 +67,27 @@ func (arena *Arena) updateEarlyLateMessage() {
 
 // Updates the string that indicates how early or late the event is running.
 func (arena *Arena) getEarlyLateMessage() string {
+	minutesLate, ok := arena.getMinutesLate()
+	if !ok {
+		return ""
+	}
+	if minutesLate > earlyLateThresholdMin {
+		return fmt.Sprintf("Event is running %d minutes late", int(minutesLate))
+	} else if minutesLate < -earlyLateThresholdMin {
+		return fmt.Sprintf("Event is running %d minutes early", int(-minutesLate))
+	}
+	return "Event is running on schedule"
+}
+
+// Returns how many minutes late (or early, if negative) the event is running, or false if it can't be determined.
+func (arena *Arena) getMinutesLate() (float64, bool) {
 	currentMatch := arena.CurrentMatch
 	if currentMatch.Type == model.Test {
-		return ""
+		return 0, false
 	}
 	if currentMatch.IsComplete() {
 		// This is a replay or otherwise unpredictable situation.
-		return ""
+		return 0, false
 	}
 
 	var minutesLate float64
@@ -116,10 +130,5 @@ func (arena *Arena) getEarlyLateMessage() string {
 		}
 	}
 
-	if minutesLate > earlyLateThresholdMin {
-		return fmt.Sprintf("Event is running %d minutes late", int(minutesLate))
-	} else if minutesLate < -earlyLateThresholdMin {
-		return fmt.Sprintf("Event is running %d minutes early", int(-minutesLate))
-	}
-	return "Event is running on schedule"
+	return minutesLate, true
 }
@@ -0,0 +1,32 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Contains a fake implementation of the access point interface for testing.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/network"
+)
+
+type FakeAccessPoint struct {
+	status          string
+	configuredTeams [6]*model.Team
+}
+
+func (ap *FakeAccessPoint) SetSettings(
+	address, password string, channel int, networkSecurityEnabled bool, wifiStatuses [6]*network.TeamWifiStatus,
+) {
+}
+
+func (ap *FakeAccessPoint) Run() {
+}
+
+func (ap *FakeAccessPoint) ConfigureTeamWifi(teams [6]*model.Team) error {
+	ap.configuredTeams = teams
+	return nil
+}
+
+func (ap *FakeAccessPoint) GetStatus() string {
+	return ap.status
+}
@@ -6,6 +6,7 @@
 package field
 
 import (
+	"github.com/Team254/cheesy-arena/plc"
 	"github.com/Team254/cheesy-arena/websocket"
 )
 
@@ -100,11 +101,41 @@ func (plc *FakePlc) GetCoilNames() []string {
 	return []string{}
 }
 
-func (plc *FakePlc) GetProcessorCounts() (int, int) {
-	return plc.redProcessorCount, plc.blueProcessorCount
+func (plc *FakePlc) GetInput(name string) bool {
+	return false
 }
 
-func (plc *FakePlc) SetTrussLights(redLights, blueLights [3]bool) {
-	plc.redTrussLights = redLights
-	plc.blueTrussLights = blueLights
+func (plc *FakePlc) GetRegister(name string) int {
+	switch name {
+	case "redProcessor":
+		return plc.redProcessorCount
+	case "blueProcessor":
+		return plc.blueProcessorCount
+	}
+	return 0
+}
+
+func (plc *FakePlc) SetCoil(name string, state bool) {
+	switch name {
+	case "redTrussLightOuter":
+		plc.redTrussLights[0] = state
+	case "redTrussLightMiddle":
+		plc.redTrussLights[1] = state
+	case "redTrussLightInner":
+		plc.redTrussLights[2] = state
+	case "blueTrussLightOuter":
+		plc.blueTrussLights[0] = state
+	case "blueTrussLightMiddle":
+		plc.blueTrussLights[1] = state
+	case "blueTrussLightInner":
+		plc.blueTrussLights[2] = state
+	}
+}
+
+func (*FakePlc) SetIoMap(ioMap *plc.IoMap) error {
+	return nil
+}
+
+func (*FakePlc) GetIoMap() *plc.IoMap {
+	return plc.DefaultIoMap()
 }
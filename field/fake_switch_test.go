@@ -0,0 +1,23 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Contains a fake implementation of the switch interface for testing.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+)
+
+type FakeSwitch struct {
+	status          string
+	configuredTeams [6]*model.Team
+}
+
+func (sw *FakeSwitch) ConfigureTeamEthernet(teams [6]*model.Team) error {
+	sw.configuredTeams = teams
+	return nil
+}
+
+func (sw *FakeSwitch) GetStatus() string {
+	return sw.status
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Functions for keeping Nexus up to date with the progress of the event and pulling pit notes back from it.
+
+package field
+
+import (
+	"log"
+	"maps"
+	"math"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
+)
+
+// Queueing state reported to Nexus for the current match and each of the upcoming ones after it, in order.
+var nexusQueueingStatuses = []string{
+	partner.NexusOnField,
+	partner.NexusOnDeck,
+	partner.NexusNowQueuing,
+	partner.NexusQueuingSoon,
+	partner.NexusQueuingSoon,
+}
+
+// Builds the status to report to Nexus from the current match, the upcoming ones and how late the event is running.
+// Returns false if there is nothing to report, such as when a test match is loaded.
+func (arena *Arena) buildNexusEventStatus() (partner.NexusEventStatus, bool) {
+	currentMatch := arena.CurrentMatch
+	if currentMatch.Type == model.Test {
+		return partner.NexusEventStatus{}, false
+	}
+	matches, err := arena.Database.GetMatchesByType(currentMatch.Type, false)
+	if err != nil {
+		log.Printf("Failed to get matches for Nexus status: %v", err)
+		return partner.NexusEventStatus{}, false
+	}
+
+	minutesLate, _ := arena.getMinutesLate()
+	delay := time.Duration(minutesLate * float64(time.Minute))
+	status := partner.NexusEventStatus{
+		DelayMinutes: int(math.Round(minutesLate)),
+		Message:      arena.EventStatus.EarlyLateMessage,
+	}
+	var previousMatchTime time.Time
+	for _, match := range matches {
+		if match.TypeOrder < currentMatch.TypeOrder || match.Id != currentMatch.Id && match.IsComplete() {
+			continue
+		}
+		if len(status.Matches) > 0 && match.Time.Sub(previousMatchTime) > MaxMatchGapMin*time.Minute {
+			// The delay can't be assumed to carry over a significant gap, and nobody needs to queue for it yet.
+			break
+		}
+		previousMatchTime = match.Time
+
+		matchStatus := partner.NexusMatchStatus{
+			Label:              match.LongName,
+			MatchKey:           match.TbaMatchKey.String(),
+			Status:             nexusQueueingStatuses[len(status.Matches)],
+			ScheduledStartTime: match.Time.UnixMilli(),
+			EstimatedStartTime: match.Time.Add(delay).UnixMilli(),
+		}
+		if match.Id == currentMatch.Id && arena.MatchState > PreMatch && arena.MatchState < PostMatch {
+			matchStatus.EstimatedStartTime = currentMatch.StartedAt.UnixMilli()
+		}
+		if matchStatus.Status == partner.NexusNowQueuing {
+			status.NowQueuing = match.LongName
+		}
+		status.Matches = append(status.Matches, matchStatus)
+		if len(status.Matches) == len(nexusQueueingStatuses) {
+			break
+		}
+	}
+	return status, true
+}
+
+// Queues the current status to be pushed to Nexus, replacing any earlier update that hasn't been sent yet since only
+// the latest one matters.
+func (arena *Arena) publishNexusStatus() {
+	if !arena.EventSettings.NexusStatusEnabled {
+		return
+	}
+	status, ok := arena.buildNexusEventStatus()
+	if !ok {
+		return
+	}
+	select {
+	case <-arena.nexusStatusUpdates:
+	default:
+	}
+	select {
+	case arena.nexusStatusUpdates <- status:
+	default:
+	}
+}
+
+// Loops indefinitely to push queued status updates to Nexus, so that a slow connection doesn't hold up the arena.
+func (arena *Arena) runNexusPublisher() {
+	for status := range arena.nexusStatusUpdates {
+		if err := arena.NexusClient.PushEventStatus(status); err != nil {
+			log.Printf("Failed to push status to Nexus: %v", err)
+		}
+	}
+}
+
+// Pulls the latest pit notes from Nexus.
+func (arena *Arena) updateNexusPitNotes() {
+	if !arena.EventSettings.NexusStatusEnabled {
+		return
+	}
+	pitNotes, err := arena.NexusClient.GetPitNotes()
+	if err != nil {
+		log.Printf("Failed to get pit notes from Nexus: %v", err)
+		return
+	}
+	arena.nexusPitNotesMutex.Lock()
+	defer arena.nexusPitNotesMutex.Unlock()
+	arena.nexusPitNotes = pitNotes
+}
+
+// GetNexusPitNotes returns the pit notes most recently pulled from Nexus, keyed by team number.
+func (arena *Arena) GetNexusPitNotes() map[int]string {
+	arena.nexusPitNotesMutex.Lock()
+	defer arena.nexusPitNotesMutex.Unlock()
+	return maps.Clone(arena.nexusPitNotes)
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package field
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildNexusEventStatus(t *testing.T) {
+	arena := setupTestArena(t)
+
+	// Nothing should be reported for a test match.
+	_, ok := arena.buildNexusEventStatus()
+	assert.False(t, ok)
+
+	startTime := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+	for i := 1; i <= 7; i++ {
+		match := model.Match{
+			Type:        model.Qualification,
+			TypeOrder:   i,
+			Time:        startTime.Add(time.Duration(i-1) * 6 * time.Minute),
+			ShortName:   fmt.Sprintf("Q%d", i),
+			LongName:    fmt.Sprintf("Qualification %d", i),
+			TbaMatchKey: model.TbaMatchKey{CompLevel: "qm", MatchNumber: i},
+		}
+		if i == 7 {
+			// Leave a gap before the last match, such as for lunch.
+			match.Time = match.Time.Add(time.Hour)
+		}
+		if i == 1 {
+			match.Status = game.RedWonMatch
+		}
+		assert.Nil(t, arena.Database.CreateMatch(&match))
+	}
+	matches, _ := arena.Database.GetMatchesByType(model.Qualification, false)
+
+	// Load the second match; it is now four minutes late since its scheduled time has passed.
+	assert.Nil(t, arena.LoadMatch(&matches[1]))
+	status, ok := arena.buildNexusEventStatus()
+	assert.True(t, ok)
+	assert.Equal(t, "Qualification 4", status.NowQueuing)
+	assert.Equal(t, 4, status.DelayMinutes)
+	if assert.Equal(t, 5, len(status.Matches)) {
+		assert.Equal(t, "Qualification 2", status.Matches[0].Label)
+		assert.Equal(t, "qm2", status.Matches[0].MatchKey)
+		assert.Equal(t, partner.NexusOnField, status.Matches[0].Status)
+		assert.Equal(t, partner.NexusOnDeck, status.Matches[1].Status)
+		assert.Equal(t, partner.NexusNowQueuing, status.Matches[2].Status)
+		assert.Equal(t, partner.NexusQueuingSoon, status.Matches[3].Status)
+		assert.Equal(t, "Qualification 6", status.Matches[4].Label)
+		assert.Equal(t, matches[2].Time.UnixMilli(), status.Matches[1].ScheduledStartTime)
+		assert.InDelta(t, matches[2].Time.Add(4*time.Minute).UnixMilli(), status.Matches[1].EstimatedStartTime, 1000)
+	}
+
+	// Check that the list stops at a large gap in the schedule.
+	assert.Nil(t, arena.LoadMatch(&matches[4]))
+	status, _ = arena.buildNexusEventStatus()
+	if assert.Equal(t, 2, len(status.Matches)) {
+		assert.Equal(t, "Qualification 5", status.Matches[0].Label)
+		assert.Equal(t, "Qualification 6", status.Matches[1].Label)
+	}
+	assert.Equal(t, "", status.NowQueuing)
+}
+
+func TestNexusStatusPublishing(t *testing.T) {
+	arena := setupTestArena(t)
+	mock := partner.NewMockNexusServer("2025casj", "event_key")
+	defer mock.Close()
+	arena.NexusClient = partner.NewNexusClient("2025casj", "event_key")
+	arena.NexusClient.BaseUrl = mock.URL()
+	go arena.runNexusPublisher()
+
+	match := model.Match{Type: model.Practice, TypeOrder: 1, Time: time.Now(), LongName: "Practice 1"}
+	assert.Nil(t, arena.Database.CreateMatch(&match))
+
+	// Nothing should be pushed while the feature is disabled.
+	assert.Nil(t, arena.LoadMatch(&match))
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, mock.GetStatuses())
+
+	arena.EventSettings.NexusStatusEnabled = true
+	assert.Nil(t, arena.LoadMatch(&match))
+	assert.Eventually(t, func() bool { return len(mock.GetStatuses()) == 1 }, time.Second, 10*time.Millisecond)
+	statuses := mock.GetStatuses()
+	if assert.Equal(t, 1, len(statuses[0].Matches)) {
+		assert.Equal(t, "Practice 1", statuses[0].Matches[0].Label)
+		assert.Equal(t, partner.NexusOnField, statuses[0].Matches[0].Status)
+	}
+
+	// Check that pit notes are pulled and kept until the next successful pull.
+	mock.SetPitNote(254, "Waiting on a replacement motor")
+	arena.updateNexusPitNotes()
+	assert.Equal(t, map[int]string{254: "Waiting on a replacement motor"}, arena.GetNexusPitNotes())
+	mock.Close()
+	arena.updateNexusPitNotes()
+	assert.Equal(t, map[int]string{254: "Waiting on a replacement motor"}, arena.GetNexusPitNotes())
+}
@@ -5,14 +5,38 @@
 
 package field
 
-import "github.com/Team254/cheesy-arena/game"
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"sync"
+)
 
 type RealtimeScore struct {
-	CurrentScore   game.Score
-	Cards          map[string]string
-	FoulsCommitted bool
+	CurrentScore        game.Score
+	Cards               map[string]string
+	FoulsCommitted      bool
+	lastOperationSeqs   map[string]int // The highest sequence number applied so far from each scoring panel client.
+	lastOperationsMutex sync.Mutex
 }
 
 func NewRealtimeScore() *RealtimeScore {
-	return &RealtimeScore{Cards: make(map[string]string)}
+	return &RealtimeScore{
+		CurrentScore:      *game.NewScore(),
+		Cards:             make(map[string]string),
+		lastOperationSeqs: make(map[string]int),
+	}
+}
+
+// Returns true if the operation having the given sequence number has not yet been applied to this score on behalf of
+// the given client, and marks it as applied. Clients number their operations in increasing order and replay any that
+// haven't been acknowledged after reconnecting before sending any new ones, so anything at or below the last number seen
+// is a duplicate.
+func (realtimeScore *RealtimeScore) ClaimOperation(clientId string, seq int) bool {
+	realtimeScore.lastOperationsMutex.Lock()
+	defer realtimeScore.lastOperationsMutex.Unlock()
+
+	if seq <= realtimeScore.lastOperationSeqs[clientId] {
+		return false
+	}
+	realtimeScore.lastOperationSeqs[clientId] = seq
+	return true
 }
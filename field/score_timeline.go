@@ -0,0 +1,92 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Tracking of every change to the realtime scores over the course of a match.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+)
+
+type scoreTimeline struct {
+	events           []model.ScoreEvent
+	lastRedScore     *game.Score
+	lastBlueScore    *game.Score
+	lastMatchTimeSec float64
+}
+
+// Discards any recorded events and takes the current realtime scores as the baseline to compare against.
+func (arena *Arena) resetScoreTimeline() {
+	arena.scoreTimeline = scoreTimeline{
+		lastRedScore:  arena.RedRealtimeScore.CurrentScore.Clone(),
+		lastBlueScore: arena.BlueRealtimeScore.CurrentScore.Clone(),
+	}
+}
+
+// Compares the realtime scores to those from the previous iteration and records an event for each alliance whose
+// score has changed. Changes made before the match starts only move the baseline.
+func (arena *Arena) recordScoreChanges(matchTimeSec float64) {
+	timeline := &arena.scoreTimeline
+	redScore := &arena.RedRealtimeScore.CurrentScore
+	blueScore := &arena.BlueRealtimeScore.CurrentScore
+	redChanged := timeline.lastRedScore == nil || !redScore.Equals(timeline.lastRedScore)
+	blueChanged := timeline.lastBlueScore == nil || !blueScore.Equals(timeline.lastBlueScore)
+	if !redChanged && !blueChanged {
+		return
+	}
+
+	recording := false
+	switch arena.MatchState {
+	case WarmupPeriod, AutoPeriod, PausePeriod, TeleopPeriod:
+		recording = true
+		timeline.lastMatchTimeSec = matchTimeSec
+	case PostMatch:
+		// The match clock reads zero once the match is over, so attribute edits made while finalizing the score to
+		// the moment the match ended.
+		recording = true
+		matchTimeSec = timeline.lastMatchTimeSec
+	}
+
+	if redChanged {
+		timeline.lastRedScore = redScore.Clone()
+	}
+	if blueChanged {
+		timeline.lastBlueScore = blueScore.Clone()
+	}
+	if !recording {
+		return
+	}
+
+	redPoints := arena.RedScoreSummary().Score
+	bluePoints := arena.BlueScoreSummary().Score
+	if redChanged {
+		timeline.events = append(
+			timeline.events,
+			model.ScoreEvent{
+				MatchTimeSec: matchTimeSec,
+				Alliance:     "red",
+				Score:        timeline.lastRedScore.Clone(),
+				RedPoints:    redPoints,
+				BluePoints:   bluePoints,
+			},
+		)
+	}
+	if blueChanged {
+		timeline.events = append(
+			timeline.events,
+			model.ScoreEvent{
+				MatchTimeSec: matchTimeSec,
+				Alliance:     "blue",
+				Score:        timeline.lastBlueScore.Clone(),
+				RedPoints:    redPoints,
+				BluePoints:   bluePoints,
+			},
+		)
+	}
+}
+
+// Returns a copy of the score changes recorded so far during the current match.
+func (arena *Arena) ScoreEvents() []model.ScoreEvent {
+	return append([]model.ScoreEvent{}, arena.scoreTimeline.events...)
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/game/reefscape"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestScoreTimeline(t *testing.T) {
+	arena := setupTestArena(t)
+	assert.Empty(t, arena.ScoreEvents())
+
+	// Check that changes before the match starts are not recorded.
+	arena.BlueRealtimeScore.CurrentScore = *reefscape.TestScore2()
+	arena.recordScoreChanges(0)
+	assert.Empty(t, arena.ScoreEvents())
+
+	// Check that nothing is recorded if the scores haven't changed.
+	arena.MatchState = AutoPeriod
+	arena.recordScoreChanges(1.5)
+	assert.Empty(t, arena.ScoreEvents())
+
+	arena.RedRealtimeScore.CurrentScore = *reefscape.TestScore1()
+	arena.recordScoreChanges(7.25)
+	arena.recordScoreChanges(7.5)
+	events := arena.ScoreEvents()
+	if assert.Equal(t, 1, len(events)) {
+		assert.Equal(t, 7.25, events[0].MatchTimeSec)
+		assert.Equal(t, "red", events[0].Alliance)
+		assert.True(t, reefscape.TestScore1().Equals(events[0].Score))
+		assert.Equal(t, arena.RedScoreSummary().Score, events[0].RedPoints)
+		assert.Equal(t, arena.BlueScoreSummary().Score, events[0].BluePoints)
+	}
+
+	// Check that the recorded score is unaffected by later changes and that removals are recorded too.
+	reefscape.ScoreDetails(&arena.RedRealtimeScore.CurrentScore).BargeAlgae = 0
+	arena.MatchState = TeleopPeriod
+	arena.recordScoreChanges(100)
+	events = arena.ScoreEvents()
+	if assert.Equal(t, 2, len(events)) {
+		assert.Equal(t, 7, reefscape.ScoreDetails(events[0].Score).BargeAlgae)
+		assert.Equal(t, 0, reefscape.ScoreDetails(events[1].Score).BargeAlgae)
+		assert.Equal(t, 100.0, events[1].MatchTimeSec)
+		assert.Equal(t, events[0].RedPoints-28, events[1].RedPoints)
+	}
+
+	// Check that fouls are recorded against the committing alliance while changing both totals.
+	arena.MatchState = PostMatch
+	arena.BlueRealtimeScore.CurrentScore.Fouls = reefscape.TestScore1().Fouls
+	arena.recordScoreChanges(0)
+	events = arena.ScoreEvents()
+	if assert.Equal(t, 3, len(events)) {
+		assert.Equal(t, 100.0, events[2].MatchTimeSec)
+		assert.Equal(t, "blue", events[2].Alliance)
+		assert.Greater(t, events[2].RedPoints, events[1].RedPoints)
+	}
+
+	// Check that loading a new match clears the timeline.
+	arena.MatchState = PreMatch
+	assert.Nil(t, arena.LoadTestMatch())
+	assert.Empty(t, arena.ScoreEvents())
+}
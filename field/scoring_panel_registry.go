@@ -6,17 +6,44 @@
 package field
 
 import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/websocket"
+	"slices"
+	"strings"
 	"sync"
 )
 
 type ScoringPanelRegistry struct {
 	scoringPanels map[string]map[*websocket.Websocket]bool // The score committed state for each panel.
+	scorerViews   map[string][]*scorerView                 // Dual-entry views, in order of first input.
+	plcEnabled    func() bool                              // Whether the game reads some of the score from the PLC.
 	mutex         sync.Mutex
 }
 
-func (registry *ScoringPanelRegistry) initialize() {
+// An individual scorer's independent record of the score for their position, used in dual-entry scoring.
+type scorerView struct {
+	clientId string
+	score    *game.Score
+	fouls    map[string][]game.Foul // The fouls entered by the scorer, keyed by the alliance they were assessed on.
+}
+
+// ScorerDiscrepancy is a difference between the primary scorer's record of a position and that of another scorer.
+type ScorerDiscrepancy struct {
+	// The other scorer, numbered from 1 for the primary scorer in order of first input.
+	Scorer int
+
+	// Identifies the disputed part of the score, for resolving the discrepancy.
+	Key string
+
+	// Human-readable name of the part along with the primary and other scorers' values.
+	Description string
+}
+
+func (registry *ScoringPanelRegistry) initialize(plcEnabled func() bool) {
+	registry.plcEnabled = plcEnabled
 	registry.scoringPanels = map[string]map[*websocket.Websocket]bool{}
+	registry.scorerViews = map[string][]*scorerView{}
 }
 
 // Resets the score committed state for each registered panel to false.
@@ -79,3 +106,170 @@ func (registry *ScoringPanelRegistry) UnregisterPanel(position string, ws *webso
 
 	delete(registry.scoringPanels[position], ws)
 }
+
+// Returns the given scorer's own view of the score for the given position, creating it if this is the scorer's first
+// input of the match, and whether the scorer is the primary one whose input also drives the realtime score.
+func (registry *ScoringPanelRegistry) GetScorerView(position, clientId string) (*game.Score, bool) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	view, isPrimary := registry.getScorerView(position, clientId)
+	return view.score, isPrimary
+}
+
+// Records a foul entered by the given scorer against the given alliance, and returns whether the scorer is the primary
+// one whose fouls also go into the realtime score.
+func (registry *ScoringPanelRegistry) AddScorerFoul(position, clientId, alliance string, foul game.Foul) bool {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	view, isPrimary := registry.getScorerView(position, clientId)
+	view.fouls[alliance] = append(view.fouls[alliance], foul)
+	return isPrimary
+}
+
+// Returns each difference between the primary scorer's view of the given position and that of each other scorer.
+func (registry *ScoringPanelRegistry) GetScorerDiscrepancies(position string) []ScorerDiscrepancy {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	views := registry.scorerViews[position]
+	discrepancies := []ScorerDiscrepancy{}
+	for i := 1; i < len(views); i++ {
+		for _, discrepancy := range game.CurrentGame.ScoreDiscrepancies(
+			views[0].score, views[i].score, registry.plcEnabled(),
+		) {
+			discrepancies = append(
+				discrepancies,
+				ScorerDiscrepancy{Scorer: i + 1, Key: discrepancy.Key, Description: discrepancy.Description},
+			)
+		}
+		for _, alliance := range []string{"red", "blue"} {
+			minorFouls, majorFouls := countFouls(views[0].fouls[alliance])
+			otherMinorFouls, otherMajorFouls := countFouls(views[i].fouls[alliance])
+			if minorFouls != otherMinorFouls || majorFouls != otherMajorFouls {
+				discrepancy := ScorerDiscrepancy{
+					Scorer: i + 1,
+					Key:    alliance + "Fouls",
+					Description: fmt.Sprintf(
+						"%s%s fouls: %d minor, %d major vs. %d minor, %d major",
+						strings.ToUpper(alliance[:1]),
+						alliance[1:],
+						minorFouls,
+						majorFouls,
+						otherMinorFouls,
+						otherMajorFouls,
+					),
+				}
+				discrepancies = append(discrepancies, discrepancy)
+			}
+		}
+	}
+	return discrepancies
+}
+
+// Resolves the discrepancy at the given position identified by the given key in favor of the given scorer (numbered
+// from 1 in order of first input), or every discrepancy at the position if the key is empty. The scorer's record of
+// the disputed part of the score is copied into the views of the other scorers and into the realtime scores.
+func (registry *ScoringPanelRegistry) AcceptScorerValue(
+	position string, scorer int, key string, redScore, blueScore *game.Score,
+) error {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	views := registry.scorerViews[position]
+	if scorer < 1 || scorer > len(views) {
+		return fmt.Errorf("invalid scorer %d for position %s", scorer, position)
+	}
+	acceptedView := views[scorer-1]
+	allianceScore := redScore
+	if strings.HasPrefix(position, "blue") {
+		allianceScore = blueScore
+	}
+
+	keys := []string{key}
+	if key == "" {
+		keys = []string{"redFouls", "blueFouls"}
+		for i := range views {
+			for _, discrepancy := range game.CurrentGame.ScoreDiscrepancies(
+				acceptedView.score, views[i].score, registry.plcEnabled(),
+			) {
+				keys = append(keys, discrepancy.Key)
+			}
+		}
+	}
+	for _, key := range keys {
+		switch key {
+		case "redFouls", "blueFouls":
+			alliance := strings.TrimSuffix(key, "Fouls")
+			foulScore := redScore
+			if alliance == "blue" {
+				foulScore = blueScore
+			}
+			// Only the primary scorer's fouls are in the realtime score, alongside those entered by the referees, so
+			// just make up the difference.
+			minorFouls, majorFouls := countFouls(views[0].fouls[alliance])
+			acceptedMinorFouls, acceptedMajorFouls := countFouls(acceptedView.fouls[alliance])
+			adjustScoringPanelFouls(foulScore, false, acceptedMinorFouls-minorFouls)
+			adjustScoringPanelFouls(foulScore, true, acceptedMajorFouls-majorFouls)
+			for _, view := range views {
+				view.fouls[alliance] = slices.Clone(acceptedView.fouls[alliance])
+			}
+		default:
+			game.CurrentGame.CopyScorePart(allianceScore, acceptedView.score, key)
+			for _, view := range views {
+				game.CurrentGame.CopyScorePart(view.score, acceptedView.score, key)
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the given scorer's view of the given position, creating it if necessary, and whether the scorer is the
+// primary one. The caller must hold the mutex.
+func (registry *ScoringPanelRegistry) getScorerView(position, clientId string) (*scorerView, bool) {
+	for i, view := range registry.scorerViews[position] {
+		if view.clientId == clientId {
+			return view, i == 0
+		}
+	}
+	view := &scorerView{clientId: clientId, score: game.NewScore(), fouls: map[string][]game.Foul{}}
+	registry.scorerViews[position] = append(registry.scorerViews[position], view)
+	return view, len(registry.scorerViews[position]) == 1
+}
+
+// Returns the number of minor and major fouls in the given list.
+func countFouls(fouls []game.Foul) (int, int) {
+	minorFouls, majorFouls := 0, 0
+	for _, foul := range fouls {
+		if foul.IsMajor {
+			majorFouls++
+		} else {
+			minorFouls++
+		}
+	}
+	return minorFouls, majorFouls
+}
+
+// Adds the given number of fouls of the given severity to the score as a scoring panel would, or removes that many of
+// the ones added by a scoring panel if the number is negative. Fouls that the referees have since assigned to a team or
+// rule are left alone.
+func adjustScoringPanelFouls(score *game.Score, isMajor bool, count int) {
+	for ; count > 0; count-- {
+		score.Fouls = append(score.Fouls, game.Foul{IsMajor: isMajor})
+	}
+	for i := len(score.Fouls) - 1; i >= 0 && count < 0; i-- {
+		if score.Fouls[i] == (game.Foul{IsMajor: isMajor}) {
+			score.Fouls = slices.Delete(score.Fouls, i, i+1)
+			count++
+		}
+	}
+}
+
+// Discards the scorer views from the previous match.
+func (registry *ScoringPanelRegistry) resetScorerViews() {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	registry.scorerViews = map[string][]*scorerView{}
+}
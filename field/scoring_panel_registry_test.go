@@ -4,6 +4,8 @@
 package field
 
 import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/game/reefscape"
 	"github.com/Team254/cheesy-arena/websocket"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -11,7 +13,7 @@ import (
 
 func TestScoringPanelRegistry(t *testing.T) {
 	var registry ScoringPanelRegistry
-	registry.initialize()
+	registry.initialize(func() bool { return false })
 	assert.Equal(t, 0, registry.GetNumPanels("red"))
 	assert.Equal(t, 0, registry.GetNumScoreCommitted("red"))
 	assert.Equal(t, 0, registry.GetNumPanels("blue"))
@@ -49,3 +51,92 @@ func TestScoringPanelRegistry(t *testing.T) {
 	assert.Equal(t, 0, registry.GetNumPanels("blue"))
 	assert.Equal(t, 0, registry.GetNumScoreCommitted("blue"))
 }
+
+func TestScoringPanelRegistryScorerViews(t *testing.T) {
+	var registry ScoringPanelRegistry
+	plcEnabled := false
+	registry.initialize(func() bool { return plcEnabled })
+	assert.Empty(t, registry.GetScorerDiscrepancies("red_near"))
+
+	// Check that the first scorer to provide input is the primary one.
+	score1, isPrimary := registry.GetScorerView("red_near", "tablet1")
+	assert.True(t, isPrimary)
+	score2, isPrimary := registry.GetScorerView("red_near", "tablet2")
+	assert.False(t, isPrimary)
+	score, isPrimary := registry.GetScorerView("red_near", "tablet1")
+	assert.True(t, isPrimary)
+	assert.Same(t, score1, score)
+	score3, isPrimary := registry.GetScorerView("red_far", "tablet3")
+	assert.True(t, isPrimary)
+	score4, _ := registry.GetScorerView("red_far", "tablet4")
+
+	reefscape.ScoreDetails(score1).BargeAlgae = 3
+	reefscape.ScoreDetails(score2).BargeAlgae = 2
+	reefscape.ScoreDetails(score3).ProcessorAlgae = 1
+	assert.Equal(
+		t,
+		[]ScorerDiscrepancy{{Scorer: 2, Key: "bargeAlgae", Description: "Barge algae: 3 vs. 2"}},
+		registry.GetScorerDiscrepancies("red_near"),
+	)
+	assert.Equal(
+		t,
+		[]ScorerDiscrepancy{{Scorer: 2, Key: "processorAlgae", Description: "Processor algae: 1 vs. 0"}},
+		registry.GetScorerDiscrepancies("red_far"),
+	)
+
+	// Check that parts of the score counted by the PLC are not compared when it is enabled.
+	plcEnabled = true
+	assert.Empty(t, registry.GetScorerDiscrepancies("red_far"))
+	plcEnabled = false
+	reefscape.ScoreDetails(score4).ProcessorAlgae = 1
+
+	// Check that fouls are compared by severity for each alliance.
+	assert.True(t, registry.AddScorerFoul("red_near", "tablet1", "blue", game.Foul{IsMajor: true}))
+	assert.False(t, registry.AddScorerFoul("red_near", "tablet2", "blue", game.Foul{}))
+	registry.GetScorerView("red_near", "tablet5")
+	assert.Equal(
+		t,
+		[]ScorerDiscrepancy{
+			{Scorer: 2, Key: "bargeAlgae", Description: "Barge algae: 3 vs. 2"},
+			{Scorer: 2, Key: "blueFouls", Description: "Blue fouls: 0 minor, 1 major vs. 1 minor, 0 major"},
+			{Scorer: 3, Key: "bargeAlgae", Description: "Barge algae: 3 vs. 0"},
+			{Scorer: 3, Key: "blueFouls", Description: "Blue fouls: 0 minor, 1 major vs. 0 minor, 0 major"},
+		},
+		registry.GetScorerDiscrepancies("red_near"),
+	)
+
+	// Check that a single discrepancy can be resolved in favor of a scorer other than the primary one, which also
+	// updates the realtime score.
+	redScore := game.NewScore()
+	blueScore := game.NewScore()
+	reefscape.ScoreDetails(redScore).BargeAlgae = 3
+	blueScore.Fouls = []game.Foul{{IsMajor: true, TeamId: 254, RuleId: 1}, {IsMajor: true}}
+	assert.Nil(t, registry.AcceptScorerValue("red_near", 2, "blueFouls", redScore, blueScore))
+	assert.Equal(t, []game.Foul{{IsMajor: true, TeamId: 254, RuleId: 1}, {}}, blueScore.Fouls)
+	assert.Equal(
+		t,
+		[]ScorerDiscrepancy{
+			{Scorer: 2, Key: "bargeAlgae", Description: "Barge algae: 3 vs. 2"},
+			{Scorer: 3, Key: "bargeAlgae", Description: "Barge algae: 3 vs. 0"},
+		},
+		registry.GetScorerDiscrepancies("red_near"),
+	)
+	assert.Nil(t, registry.AcceptScorerValue("red_near", 2, "bargeAlgae", redScore, blueScore))
+	assert.Empty(t, registry.GetScorerDiscrepancies("red_near"))
+	assert.Equal(t, 2, reefscape.ScoreDetails(score1).BargeAlgae)
+	assert.Equal(t, 2, reefscape.ScoreDetails(redScore).BargeAlgae)
+
+	// Check that all discrepancies at a position can be resolved at once.
+	assert.Nil(t, registry.AcceptScorerValue("red_far", 1, "", redScore, blueScore))
+	assert.Empty(t, registry.GetScorerDiscrepancies("red_far"))
+	assert.Equal(t, 1, reefscape.ScoreDetails(score4).ProcessorAlgae)
+	err := registry.AcceptScorerValue("red_far", 3, "", redScore, blueScore)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "invalid scorer 3")
+	}
+
+	registry.resetScorerViews()
+	score, isPrimary = registry.GetScorerView("red_near", "tablet2")
+	assert.True(t, isPrimary)
+	assert.Equal(t, 0, reefscape.ScoreDetails(score).BargeAlgae)
+}
@@ -6,10 +6,14 @@
 package field
 
 import (
+	"encoding/csv"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/Team254/cheesy-arena/model"
@@ -19,24 +23,30 @@ import (
 const logsDir = "static/logs"
 
 type TeamMatchLog struct {
-	logger     *log.Logger
-	logFile    *os.File
-	wifiStatus *network.TeamWifiStatus
+	logger             *log.Logger
+	logFile            *os.File
+	wifiStatus         *network.TeamWifiStatus
+	diagnosticsWriter  *csv.Writer
+	diagnosticsLogFile *os.File
+	diagnosticsMutex   sync.Mutex // Guards the diagnostics writer, which unlike the logger is not safe to share.
 }
 
 // Creates a file to log to for the given match and team.
-func NewTeamMatchLog(teamId int, match *model.Match, wifiStatus *network.TeamWifiStatus) (*TeamMatchLog, error) {
+func NewTeamMatchLog(
+	teamId int, match *model.Match, playNumber int, wifiStatus *network.TeamWifiStatus,
+) (*TeamMatchLog, error) {
 	err := os.MkdirAll(filepath.Join(model.BaseDir, logsDir), 0755)
 	if err != nil {
 		return nil, err
 	}
 
 	filename := fmt.Sprintf(
-		"%s/%s_%s_Match_%s_%d.csv",
+		"%s/%s_%s_Match_%s_Play_%d_%d.csv",
 		filepath.Join(model.BaseDir, logsDir),
 		time.Now().Format("20060102150405"),
 		match.Type.String(),
 		match.ShortName,
+		playNumber,
 		teamId,
 	)
 	logFile, err := os.Create(filename)
@@ -44,16 +54,35 @@ func NewTeamMatchLog(teamId int, match *model.Match, wifiStatus *network.TeamWif
 		return nil, err
 	}
 
-	log := TeamMatchLog{log.New(logFile, "", 0), logFile, wifiStatus}
+	diagnosticsLogFile, err := os.Create(DiagnosticsLogFilename(filename))
+	if err != nil {
+		logFile.Close()
+		return nil, err
+	}
+
+	log := TeamMatchLog{
+		logger:             log.New(logFile, "", 0),
+		logFile:            logFile,
+		wifiStatus:         wifiStatus,
+		diagnosticsWriter:  csv.NewWriter(diagnosticsLogFile),
+		diagnosticsLogFile: diagnosticsLogFile,
+	}
 	log.logger.Println(
 		"matchTimeSec,packetType,teamId,allianceStation,dsLinked,radioLinked,rioLinked,robotLinked,auto,enabled," +
 			"emergencyStop,autonomousStop,batteryVoltage,missedPacketCount,dsRobotTripTimeMs,rxRate,txRate," +
 			"signalNoiseRatio",
 	)
+	log.diagnosticsWriter.Write([]string{"matchTimeSec", "packetType", "category", "message"})
+	log.diagnosticsWriter.Flush()
 
 	return &log, nil
 }
 
+// Returns the name of the file holding the diagnostic messages that accompanies the given packet log file.
+func DiagnosticsLogFilename(filename string) string {
+	return strings.TrimSuffix(filename, ".csv") + "_diagnostics.csv"
+}
+
 // Adds a line to the log when a packet is received.
 func (log *TeamMatchLog) LogDsPacket(matchTimeSec float64, packetType int, dsConn *DriverStationConnection) {
 	log.logger.Printf(
@@ -79,6 +108,24 @@ func (log *TeamMatchLog) LogDsPacket(matchTimeSec float64, packetType int, dsCon
 	)
 }
 
+// Adds a line to the diagnostics log when a version report, error or other message is received.
+func (log *TeamMatchLog) LogDsDiagnostic(matchTimeSec float64, diagnostic DsDiagnostic) {
+	log.diagnosticsMutex.Lock()
+	defer log.diagnosticsMutex.Unlock()
+	log.diagnosticsWriter.Write(
+		[]string{
+			strconv.FormatFloat(matchTimeSec, 'f', 6, 64),
+			strconv.Itoa(diagnostic.PacketType),
+			diagnostic.Category,
+			diagnostic.Message,
+		},
+	)
+	log.diagnosticsWriter.Flush()
+}
+
 func (log *TeamMatchLog) Close() {
+	log.diagnosticsMutex.Lock()
+	defer log.diagnosticsMutex.Unlock()
 	log.logFile.Close()
+	log.diagnosticsLogFile.Close()
 }
@@ -197,36 +197,29 @@ func generateInMatchTeamRearText(arena *Arena, isRed bool, countdown string) str
 		opponentRealtimeScore = arena.RedRealtimeScore
 		formatString = "B%03d-R%03d"
 	}
-	scoreSummary := realtimeScore.CurrentScore.Summarize(&opponentRealtimeScore.CurrentScore)
-	scoreTotal := scoreSummary.Score - scoreSummary.BargePoints
-	opponentScoreSummary := opponentRealtimeScore.CurrentScore.Summarize(&realtimeScore.CurrentScore)
-	opponentScoreTotal := opponentScoreSummary.Score - opponentScoreSummary.BargePoints
-	allianceScores := fmt.Sprintf(formatString, scoreTotal, opponentScoreTotal)
-
-	var coralRankingPointProgress string
-	if arena.CurrentMatch.Type != model.Playoff {
-		coralRankingPointProgress = fmt.Sprintf("%d/%d", scoreSummary.NumCoralLevels, scoreSummary.NumCoralLevelsGoal)
-	}
+	isPlayoff := arena.CurrentMatch.Type == model.Playoff
+	status := game.CurrentGame.TeamSignStatus(
+		&realtimeScore.CurrentScore, &opponentRealtimeScore.CurrentScore, isPlayoff,
+	)
+	opponentStatus := game.CurrentGame.TeamSignStatus(
+		&opponentRealtimeScore.CurrentScore, &realtimeScore.CurrentScore, isPlayoff,
+	)
+	allianceScores := fmt.Sprintf(formatString, status.Score, opponentStatus.Score)
 
-	return fmt.Sprintf("%s %s %s", countdown, allianceScores, coralRankingPointProgress)
+	return fmt.Sprintf("%s %s %s", countdown, allianceScores, status.Progress)
 }
 
 // Returns the in-match rear text for the timer display for the given alliance.
 func generateInMatchTimerRearText(arena *Arena, isRed bool) string {
-	var reef *game.Reef
+	isPlayoff := arena.CurrentMatch.Type == model.Playoff
 	if isRed {
-		reef = &arena.RedRealtimeScore.CurrentScore.Reef
-	} else {
-		reef = &arena.BlueRealtimeScore.CurrentScore.Reef
+		return game.CurrentGame.TeamSignStatus(
+			&arena.RedRealtimeScore.CurrentScore, &arena.BlueRealtimeScore.CurrentScore, isPlayoff,
+		).TimerText
 	}
-
-	return fmt.Sprintf(
-		"1-%02d 2-%02d 3-%02d 4-%02d",
-		reef.CountTotalCoralByLevel(game.Level1),
-		reef.CountTotalCoralByLevel(game.Level2),
-		reef.CountTotalCoralByLevel(game.Level3),
-		reef.CountTotalCoralByLevel(game.Level4),
-	)
+	return game.CurrentGame.TeamSignStatus(
+		&arena.BlueRealtimeScore.CurrentScore, &arena.RedRealtimeScore.CurrentScore, isPlayoff,
+	).TimerText
 }
 
 // Returns the front text, front color, and rear text to display on the timer display.
@@ -4,7 +4,7 @@
 package field
 
 import (
-	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/game/reefscape"
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/stretchr/testify/assert"
 	"image/color"
@@ -13,15 +13,16 @@ import (
 
 func TestTeamSign_GenerateInMatchRearText(t *testing.T) {
 	arena := setupTestArena(t)
-	arena.RedRealtimeScore.CurrentScore = *game.TestScore1()
-	arena.BlueRealtimeScore.CurrentScore = *game.TestScore2()
+	arena.RedRealtimeScore.CurrentScore = *reefscape.TestScore1()
+	arena.BlueRealtimeScore.CurrentScore = *reefscape.TestScore2()
 
 	assert.Equal(t, "01:23 R080-B162 1/4", generateInMatchTeamRearText(arena, true, "01:23"))
 	assert.Equal(t, "01:23 B162-R080 1/4", generateInMatchTeamRearText(arena, false, "01:23"))
 	assert.Equal(t, "1-07 2-02 3-03 4-00", generateInMatchTimerRearText(arena, true))
 	assert.Equal(t, "1-15 2-03 3-05 4-03", generateInMatchTimerRearText(arena, false))
-	arena.BlueRealtimeScore.CurrentScore.Reef.Branches[2] = [12]bool{true, true, true, true, true, true, true, true}
-	arena.BlueRealtimeScore.CurrentScore.ProcessorAlgae = 2
+	reefscape.ScoreDetails(&arena.BlueRealtimeScore.CurrentScore).Reef.Branches[2] =
+		[12]bool{true, true, true, true, true, true, true, true}
+	reefscape.ScoreDetails(&arena.BlueRealtimeScore.CurrentScore).ProcessorAlgae = 2
 	assert.Equal(t, "00:59 R080-B195 1/3", generateInMatchTeamRearText(arena, true, "00:59"))
 	assert.Equal(t, "00:59 B195-R080 2/3", generateInMatchTeamRearText(arena, false, "00:59"))
 	assert.Equal(t, "1-07 2-02 3-03 4-00", generateInMatchTimerRearText(arena, true))
@@ -0,0 +1,29 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Helpers for serializing the season-specific details of a type inline with its common fields.
+
+package game
+
+import "encoding/json"
+
+// Returns the JSON representation of the given struct with the fields of the given details merged into the same
+// object, so that the serialized form is the same as if all the fields had been declared on a single struct.
+func marshalWithDetails(common, details any) ([]byte, error) {
+	commonJson, err := json.Marshal(common)
+	if err != nil || details == nil {
+		return commonJson, err
+	}
+	detailsJson, err := json.Marshal(details)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err = json.Unmarshal(detailsJson, &fields); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(commonJson, &fields); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMarshalWithDetails(t *testing.T) {
+	common := struct {
+		Score  int
+		Fouls  []Foul
+		Hidden int `json:"-"`
+	}{Score: 10, Fouls: []Foul{{IsMajor: true, TeamId: 254, RuleId: 3}}, Hidden: 5}
+	details := &struct {
+		Points int
+		Levels [2]bool
+	}{Points: 7, Levels: [2]bool{true, false}}
+
+	json, err := marshalWithDetails(common, details)
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		"{\"Fouls\":[{\"IsMajor\":true,\"TeamId\":254,\"RuleId\":3}],\"Levels\":[true,false],\"Points\":7,\"Score\":10}",
+		string(json),
+	)
+
+	// Check that the common fields take precedence in case of a name collision.
+	json, err = marshalWithDetails(common, &struct{ Score int }{Score: 20})
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"Fouls\":[{\"IsMajor\":true,\"TeamId\":254,\"RuleId\":3}],\"Score\":10}", string(json))
+
+	// Check that missing details are ignored.
+	json, err = marshalWithDetails(common, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"Score\":10,\"Fouls\":[{\"IsMajor\":true,\"TeamId\":254,\"RuleId\":3}]}", string(json))
+}
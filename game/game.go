@@ -0,0 +1,86 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Interface encapsulating the season-specific scoring logic, which is implemented by a separate package per game.
+
+package game
+
+// Game defines the rules of a particular season's game. The season-agnostic types in this package (Score,
+// ScoreSummary, RankingFields) each carry a Details field holding the game's own representation, which only the Game
+// implementation needs to understand.
+type Game interface {
+	// NewScoreDetails returns the season-specific portion of an empty alliance score.
+	NewScoreDetails() ScoreDetails
+
+	// Summarize calculates the summary fields used for ranking and display for the given alliance score.
+	Summarize(score, opponentScore *Score) *ScoreSummary
+
+	// Rules returns all rules from the game manual that carry point penalties, keyed by ID.
+	Rules() map[int]*Rule
+
+	// FoulPointValue returns the number of points that the given foul adds to the opposing alliance's score.
+	FoulPointValue(foul *Foul) int
+
+	// ComparePlayoffTiebreakers uses the game's scoring breakdowns to determine the winner of a playoff match in which
+	// both alliances have the same score.
+	ComparePlayoffTiebreakers(redScoreSummary, blueScoreSummary *ScoreSummary) MatchStatus
+
+	// NewRankingFieldsDetails returns the season-specific portion of the ranking fields for a team yet to play.
+	NewRankingFieldsDetails() any
+
+	// AddToRankingFields accumulates the ranking points and tiebreaker values from the given match result, given
+	// that the team was not disqualified. Wins, losses and ties will already have been counted.
+	AddToRankingFields(fields *RankingFields, ownScoreSummary, opponentScoreSummary *ScoreSummary)
+
+	// CompareRankingFields returns a positive number if the first team should be ranked ahead of the second, a
+	// negative number if behind, or zero if they are tied on every criterion.
+	CompareRankingFields(fields, otherFields *RankingFields) int
+
+	// TbaScoreBreakdown returns the season-specific score breakdown for one alliance in the format expected by The
+	// Blue Alliance.
+	TbaScoreBreakdown(score *Score, scoreSummary *ScoreSummary) map[string]any
+
+	// TbaRankingBreakdown returns the names and per-match values of the ranking criteria to publish to The Blue
+	// Alliance, in sort order.
+	TbaRankingBreakdown(fields *RankingFields) ([]string, []float32)
+
+	// HandleScoringCommand applies the given command from a scoring panel to the score and returns whether it changed.
+	HandleScoringCommand(score *Score, command string, data any) (bool, error)
+
+	// ScoreDiscrepancies returns each difference between the season-specific portions of the two scores, for
+	// reconciling the entries of redundant scorers. If plcEnabled is true, the parts of the score that UpdateField reads
+	// from the field PLC are left out, since the scorers don't enter them.
+	ScoreDiscrepancies(score, otherScore *Score, plcEnabled bool) []ScoreDiscrepancy
+
+	// CopyScorePart overwrites the part of the season-specific score identified by the given discrepancy key with its
+	// value in the source score.
+	CopyScorePart(score, sourceScore *Score, key string)
+
+	// Settings returns the event-level parameters of the game's rules that can be adjusted, in display order.
+	Settings() []Setting
+
+	// ApplySettings configures the game's rules from the given setting values, keyed by setting key. Any setting
+	// missing from the map takes its default value.
+	ApplySettings(values map[string]int)
+
+	// CoopertitionEnabled returns whether the game's coopertition bonus is in effect, as configured in its settings.
+	CoopertitionEnabled() bool
+
+	// UpdateField reads the game's scoring sensors from the field PLC into the alliance scores and drives the game's
+	// field lights. It is called on every iteration of the arena loop.
+	UpdateField(fieldIo FieldIo, state FieldState, redScore, blueScore *Score)
+
+	// TeamSignStatus returns the game-specific parts of the in-match team sign text for the alliance with the given
+	// score.
+	TeamSignStatus(score, opponentScore *Score, isPlayoff bool) TeamSignStatus
+
+	// RankingReportColumns returns the names and values of the game's ranking criteria to show in the standings
+	// report, in sort order.
+	RankingReportColumns(fields *RankingFields) ([]string, []int)
+
+	// ScoreBreakdown returns the components of the posted score to show the announcer, in display order. The foul
+	// points, final score and ranking point total common to every game are shown separately.
+	ScoreBreakdown(scoreSummary *ScoreSummary) []ScoreBreakdownItem
+}
+
+// The game being played at the event, which is registered by its package at initialization.
+var CurrentGame Game
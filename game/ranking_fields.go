@@ -1,24 +1,24 @@
 // Copyright 2017 Team 254. All Rights Reserved.
 // Author: pat@patfairbank.com (Patrick Fairbank)
 //
-// Game-specific fields by which teams are ranked and the logic for sorting rankings.
+// Fields by which teams are ranked and the logic for sorting rankings.
 
 package game
 
-import "math/rand"
+import (
+	"encoding/json"
+	"math/rand"
+)
 
 type RankingFields struct {
-	RankingPoints      int
-	CoopertitionPoints int
-	MatchPoints        int
-	AutoPoints         int
-	BargePoints        int
-	Random             float64
-	Wins               int
-	Losses             int
-	Ties               int
-	Disqualifications  int
-	Played             int
+	RankingPoints     int
+	Random            float64
+	Wins              int
+	Losses            int
+	Ties              int
+	Disqualifications int
+	Played            int
+	Details           any `json:"-"`
 }
 
 type Ranking struct {
@@ -42,25 +42,40 @@ func (fields *RankingFields) AddScoreSummary(ownScore *ScoreSummary, opponentSco
 		return
 	}
 
-	// Assign ranking points and wins/losses/ties.
+	// Assign wins/losses/ties; the game is responsible for the ranking points and tiebreakers.
 	if ownScore.Score > opponentScore.Score {
-		fields.RankingPoints += 3
 		fields.Wins += 1
 	} else if ownScore.Score == opponentScore.Score {
-		fields.RankingPoints += 1
 		fields.Ties += 1
 	} else {
 		fields.Losses += 1
 	}
-	fields.RankingPoints += ownScore.BonusRankingPoints
+	if fields.Details == nil {
+		fields.Details = CurrentGame.NewRankingFieldsDetails()
+	}
+	CurrentGame.AddToRankingFields(fields, ownScore, opponentScore)
+}
+
+// MarshalJSON serializes the season-specific ranking fields inline with the common ones.
+func (ranking Ranking) MarshalJSON() ([]byte, error) {
+	type rankingAlias Ranking
+	details := ranking.Details
+	if details == nil {
+		details = CurrentGame.NewRankingFieldsDetails()
+	}
+	return marshalWithDetails(rankingAlias(ranking), details)
+}
 
-	// Assign tiebreaker points.
-	if ownScore.CoopertitionBonus {
-		fields.CoopertitionPoints++
+// UnmarshalJSON deserializes the season-specific ranking fields from the same object as the common ones.
+func (ranking *Ranking) UnmarshalJSON(data []byte) error {
+	type rankingAlias Ranking
+	if err := json.Unmarshal(data, (*rankingAlias)(ranking)); err != nil {
+		return err
+	}
+	if ranking.Details == nil {
+		ranking.Details = CurrentGame.NewRankingFieldsDetails()
 	}
-	fields.MatchPoints += ownScore.MatchPoints
-	fields.AutoPoints += ownScore.AutoPoints
-	fields.BargePoints += ownScore.BargePoints
+	return json.Unmarshal(data, ranking.Details)
 }
 
 // Helper function to implement the required interface for Sort.
@@ -73,23 +88,10 @@ func (rankings Rankings) Less(i, j int) bool {
 	a := rankings[i]
 	b := rankings[j]
 
-	// Use cross-multiplication to keep it in integer math.
-	if a.RankingPoints*b.Played == b.RankingPoints*a.Played {
-		if a.CoopertitionPoints*b.Played == b.CoopertitionPoints*a.Played {
-			if a.MatchPoints*b.Played == b.MatchPoints*a.Played {
-				if a.AutoPoints*b.Played == b.AutoPoints*a.Played {
-					if a.BargePoints*b.Played == b.BargePoints*a.Played {
-						return a.Random > b.Random
-					}
-					return a.BargePoints*b.Played > b.BargePoints*a.Played
-				}
-				return a.AutoPoints*b.Played > b.AutoPoints*a.Played
-			}
-			return a.MatchPoints*b.Played > b.MatchPoints*a.Played
-		}
-		return a.CoopertitionPoints*b.Played > b.CoopertitionPoints*a.Played
+	if comparison := CurrentGame.CompareRankingFields(&a.RankingFields, &b.RankingFields); comparison != 0 {
+		return comparison > 0
 	}
-	return a.RankingPoints*b.Played > b.RankingPoints*a.Played
+	return a.Random > b.Random
 }
 
 // Helper function to implement the required interface for Sort.
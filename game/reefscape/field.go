@@ -0,0 +1,124 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// 2025-specific event settings and handling of the field hardware and displays.
+
+package reefscape
+
+import (
+	"fmt"
+
+	"github.com/Team254/cheesy-arena/game"
+)
+
+var settings = []game.Setting{
+	{Key: "autoBonusCoralThreshold", Label: "Auto Bonus RP Coral Threshold", Default: defaultAutoBonusCoralThreshold},
+	{Key: "coralBonusCoopEnabled", Label: "Coopertition Bonus Enabled", IsBool: true, Default: 1},
+	{
+		Key:     "coralBonusPerLevelThreshold",
+		Label:   "Coral Bonus RP Per-Level Threshold",
+		Default: defaultCoralBonusPerLevelThreshold,
+	},
+	{Key: "bargeBonusPointThreshold", Label: "Barge Bonus RP Point Threshold", Default: defaultBargeBonusPointThreshold},
+}
+
+func (Game) Settings() []game.Setting {
+	return settings
+}
+
+func (Game) ApplySettings(values map[string]int) {
+	AutoBonusCoralThreshold = settings[0].Value(values)
+	CoralBonusCoopEnabled = settings[1].Value(values) != 0
+	CoralBonusPerLevelThreshold = settings[2].Value(values)
+	BargeBonusPointThreshold = settings[3].Value(values)
+}
+
+func (Game) CoopertitionEnabled() bool {
+	return CoralBonusCoopEnabled
+}
+
+func (g Game) UpdateField(fieldIo game.FieldIo, state game.FieldState, redScore, blueScore *game.Score) {
+	// Count the algae in each processor until the end of the grace period.
+	if state.InMatch || state.InGracePeriod {
+		ScoreDetails(redScore).ProcessorAlgae = fieldIo.GetRegister("redProcessor")
+		ScoreDetails(blueScore).ProcessorAlgae = fieldIo.GetRegister("blueProcessor")
+	}
+
+	// Handle the truss lights.
+	var redLights, blueLights [3]bool
+	if state.InMatch {
+		warningSequenceActive, lights := trussLightWarningSequence(state.MatchTimeSec)
+		if warningSequenceActive {
+			redLights, blueLights = lights, lights
+		} else if !CoralBonusCoopEnabled || state.IsPlayoff {
+			// Just leave the lights on all match if co-op is not enabled for this match (or event).
+			redLights, blueLights = [3]bool{true, true, true}, [3]bool{true, true, true}
+		} else if ScoreSummaryDetails(g.Summarize(redScore, blueScore)).CoopertitionBonus &&
+			ScoreSummaryDetails(g.Summarize(blueScore, redScore)).CoopertitionBonus {
+			redLights, blueLights = [3]bool{true, true, true}, [3]bool{true, true, true}
+		} else {
+			// Set the lights to reflect co-op status.
+			redProcessorAlgae := ScoreDetails(redScore).ProcessorAlgae
+			blueProcessorAlgae := ScoreDetails(blueScore).ProcessorAlgae
+			redLights = [3]bool{redProcessorAlgae >= 1, redProcessorAlgae >= 2, false}
+			blueLights = [3]bool{blueProcessorAlgae >= 1, blueProcessorAlgae >= 2, false}
+		}
+	} else {
+		inGracePeriod := state.InGracePeriod
+		redLights = [3]bool{inGracePeriod, inGracePeriod, inGracePeriod}
+		blueLights = [3]bool{inGracePeriod, inGracePeriod, inGracePeriod}
+	}
+	setTrussLights(fieldIo, "red", redLights)
+	setTrussLights(fieldIo, "blue", blueLights)
+}
+
+func (g Game) TeamSignStatus(score, opponentScore *game.Score, isPlayoff bool) game.TeamSignStatus {
+	summary := g.Summarize(score, opponentScore)
+	details := ScoreSummaryDetails(summary)
+	reef := &ScoreDetails(score).Reef
+
+	// Leave out the barge points since they aren't final until the robots come to rest after the match.
+	status := game.TeamSignStatus{
+		Score: summary.Score - details.BargePoints,
+		TimerText: fmt.Sprintf(
+			"1-%02d 2-%02d 3-%02d 4-%02d",
+			reef.CountTotalCoralByLevel(Level1),
+			reef.CountTotalCoralByLevel(Level2),
+			reef.CountTotalCoralByLevel(Level3),
+			reef.CountTotalCoralByLevel(Level4),
+		),
+	}
+	if !isPlayoff {
+		status.Progress = fmt.Sprintf("%d/%d", details.NumCoralLevels, details.NumCoralLevelsGoal)
+	}
+	return status
+}
+
+// Sets the outer, middle, and inner truss lights of the given alliance, respectively.
+func setTrussLights(fieldIo game.FieldIo, alliance string, lights [3]bool) {
+	fieldIo.SetCoil(alliance+"TrussLightOuter", lights[0])
+	fieldIo.SetCoil(alliance+"TrussLightMiddle", lights[1])
+	fieldIo.SetCoil(alliance+"TrussLightInner", lights[2])
+}
+
+// trussLightWarningSequence generates the sequence of truss light states during the "sonar ping" warning sound. It
+// returns true if the sequence is active, and an array of booleans indicating the state of each truss light.
+func trussLightWarningSequence(matchTimeSec float64) (bool, [3]bool) {
+	stepTimeSec := 0.2
+	sequence := []int{1, 2, 3, 2, 1, 2, 3, 0, 0, 1, 2, 3, 2, 1, 2, 3, 0, 0}
+	startTime := float64(
+		game.MatchTiming.WarmupDurationSec + game.MatchTiming.AutoDurationSec + game.MatchTiming.PauseDurationSec +
+			game.MatchTiming.TeleopDurationSec - game.MatchTiming.WarningRemainingDurationSec,
+	)
+	lights := [3]bool{false, false, false}
+
+	if matchTimeSec < startTime {
+		// The sequence is not active yet.
+		return false, lights
+	}
+
+	step := int((matchTimeSec - startTime) / stepTimeSec)
+	if step < len(sequence) && sequence[step] > 0 {
+		lights[sequence[step]-1] = true
+	}
+	return step < len(sequence), lights
+}
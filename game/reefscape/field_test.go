@@ -0,0 +1,66 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package reefscape
+
+import (
+	"testing"
+
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplySettings(t *testing.T) {
+	defer Game{}.ApplySettings(game.DefaultSettings())
+
+	Game{}.ApplySettings(
+		map[string]int{"autoBonusCoralThreshold": 3, "coralBonusCoopEnabled": 0, "coralBonusPerLevelThreshold": 5},
+	)
+	assert.Equal(t, 3, AutoBonusCoralThreshold)
+	assert.False(t, CoralBonusCoopEnabled)
+	assert.False(t, Game{}.CoopertitionEnabled())
+	assert.Equal(t, 5, CoralBonusPerLevelThreshold)
+	assert.Equal(t, defaultBargeBonusPointThreshold, BargeBonusPointThreshold)
+
+	Game{}.ApplySettings(nil)
+	assert.Equal(t, defaultAutoBonusCoralThreshold, AutoBonusCoralThreshold)
+	assert.True(t, CoralBonusCoopEnabled)
+	assert.Equal(t, defaultCoralBonusPerLevelThreshold, CoralBonusPerLevelThreshold)
+}
+
+func TestTeamSignStatus(t *testing.T) {
+	redScore := TestScore1()
+	blueScore := TestScore2()
+	assert.Equal(
+		t,
+		game.TeamSignStatus{Score: 80, Progress: "1/4", TimerText: "1-07 2-02 3-03 4-00"},
+		Game{}.TeamSignStatus(redScore, blueScore, false),
+	)
+	assert.Equal(
+		t,
+		game.TeamSignStatus{Score: 162, Progress: "", TimerText: "1-15 2-03 3-05 4-03"},
+		Game{}.TeamSignStatus(blueScore, redScore, true),
+	)
+}
+
+func TestTrussLightWarningSequence(t *testing.T) {
+	startTime := float64(
+		game.MatchTiming.WarmupDurationSec + game.MatchTiming.AutoDurationSec + game.MatchTiming.PauseDurationSec +
+			game.MatchTiming.TeleopDurationSec - game.MatchTiming.WarningRemainingDurationSec,
+	)
+
+	active, lights := trussLightWarningSequence(startTime - 1)
+	assert.False(t, active)
+	assert.Equal(t, [3]bool{false, false, false}, lights)
+	active, lights = trussLightWarningSequence(startTime + 0.1)
+	assert.True(t, active)
+	assert.Equal(t, [3]bool{true, false, false}, lights)
+	active, lights = trussLightWarningSequence(startTime + 0.5)
+	assert.True(t, active)
+	assert.Equal(t, [3]bool{false, false, true}, lights)
+	active, lights = trussLightWarningSequence(startTime + 1.5)
+	assert.True(t, active)
+	assert.Equal(t, [3]bool{false, false, false}, lights)
+	active, lights = trussLightWarningSequence(startTime + 4)
+	assert.False(t, active)
+	assert.Equal(t, [3]bool{false, false, false}, lights)
+}
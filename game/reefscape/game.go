@@ -0,0 +1,169 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Implementation of the scoring rules for the 2025 game, REEFSCAPE.
+
+package reefscape
+
+import "github.com/Team254/cheesy-arena/game"
+
+// Game implements the game.Game interface for REEFSCAPE.
+type Game struct{}
+
+func init() {
+	game.CurrentGame = Game{}
+}
+
+func (Game) NewScoreDetails() game.ScoreDetails {
+	return new(Score)
+}
+
+func (Game) Rules() map[int]*game.Rule {
+	return ruleMap
+}
+
+func (Game) FoulPointValue(foul *game.Foul) int {
+	if foul.IsMajor {
+		return 6
+	} else {
+		if foul.Rule() != nil && foul.Rule().RuleNumber == "G206" {
+			// Special case in 2025 for G206, which is not actually a foul but does make the alliance ineligible for
+			// some bonus RPs.
+			return 0
+		}
+		return 2
+	}
+}
+
+func (Game) Summarize(score, opponentScore *game.Score) *game.ScoreSummary {
+	summary := new(game.ScoreSummary)
+	details := ScoreSummaryDetails(summary)
+
+	// Leave the score at zero if the alliance was disqualified.
+	if score.PlayoffDq {
+		return summary
+	}
+
+	scoreDetails := ScoreDetails(score)
+	opponentScoreDetails := ScoreDetails(opponentScore)
+
+	// Calculate autonomous period points.
+	for _, status := range scoreDetails.LeaveStatuses {
+		if status {
+			details.LeavePoints += 3
+		}
+	}
+	autoCoralPoints := scoreDetails.Reef.AutoCoralPoints()
+	details.AutoPoints = details.LeavePoints + autoCoralPoints
+
+	details.NumCoral = scoreDetails.Reef.AutoCoralCount() + scoreDetails.Reef.TeleopCoralCount()
+	details.CoralPoints = autoCoralPoints + scoreDetails.Reef.TeleopCoralPoints()
+	details.NumAlgae = scoreDetails.BargeAlgae + scoreDetails.ProcessorAlgae
+	details.AlgaePoints = 4*scoreDetails.BargeAlgae + 6*scoreDetails.ProcessorAlgae
+
+	// Calculate endgame points.
+	for _, status := range scoreDetails.EndgameStatuses {
+		switch status {
+		case EndgameParked:
+			details.BargePoints += 2
+		case EndgameShallowCage:
+			details.BargePoints += 6
+		case EndgameDeepCage:
+			details.BargePoints += 12
+		default:
+		}
+	}
+
+	summary.MatchPoints = details.LeavePoints + details.CoralPoints + details.AlgaePoints + details.BargePoints
+
+	// Calculate penalty points.
+	for _, foul := range opponentScore.Fouls {
+		summary.FoulPoints += foul.PointValue()
+		// Store the number of major fouls since it is used to break ties in playoffs.
+		if foul.IsMajor {
+			details.NumOpponentMajorFouls++
+		}
+
+		rule := foul.Rule()
+		if rule != nil {
+			// Check for the opponent fouls that automatically trigger a ranking point.
+			if rule.IsRankingPoint {
+				switch rule.RuleNumber {
+				case "G410":
+					details.CoralBonusRankingPoint = true
+				case "G418":
+					details.BargeBonusRankingPoint = true
+				case "G428":
+					details.BargeBonusRankingPoint = true
+				}
+			}
+		}
+	}
+
+	summary.Score = summary.MatchPoints + summary.FoulPoints
+
+	// Calculate bonus ranking points.
+	// Autonomous bonus ranking point.
+	allRobotsLeft := true
+	for i, left := range scoreDetails.LeaveStatuses {
+		if !left && !score.RobotsBypassed[i] {
+			allRobotsLeft = false
+			break
+		}
+	}
+	if allRobotsLeft && scoreDetails.Reef.isAutoBonusCoralThresholdMet() {
+		details.AutoBonusRankingPoint = true
+	}
+
+	// Coral bonus ranking point.
+	details.NumCoralLevels = scoreDetails.Reef.countCoralBonusSatisfiedLevels()
+	details.NumCoralLevelsGoal = 4
+	if CoralBonusCoopEnabled {
+		details.CoopertitionCriteriaMet = scoreDetails.ProcessorAlgae >= 2
+		details.CoopertitionBonus = details.CoopertitionCriteriaMet && opponentScoreDetails.ProcessorAlgae >= 2
+		if details.CoopertitionBonus {
+			details.NumCoralLevelsGoal = 3
+		}
+	}
+	if details.NumCoralLevels >= details.NumCoralLevelsGoal {
+		details.CoralBonusRankingPoint = true
+	}
+
+	// Barge bonus ranking point.
+	if details.BargePoints >= BargeBonusPointThreshold {
+		details.BargeBonusRankingPoint = true
+	}
+
+	// Check for G206 violation.
+	for _, foul := range score.Fouls {
+		if foul.Rule() != nil && foul.Rule().RuleNumber == "G206" {
+			details.CoralBonusRankingPoint = false
+			details.BargeBonusRankingPoint = false
+			break
+		}
+	}
+
+	// Add up the bonus ranking points.
+	if details.AutoBonusRankingPoint {
+		summary.BonusRankingPoints++
+	}
+	if details.CoralBonusRankingPoint {
+		summary.BonusRankingPoints++
+	}
+	if details.BargeBonusRankingPoint {
+		summary.BonusRankingPoints++
+	}
+
+	return summary
+}
+
+func (Game) ComparePlayoffTiebreakers(redScoreSummary, blueScoreSummary *game.ScoreSummary) game.MatchStatus {
+	red := ScoreSummaryDetails(redScoreSummary)
+	blue := ScoreSummaryDetails(blueScoreSummary)
+	if status := game.ComparePoints(red.NumOpponentMajorFouls, blue.NumOpponentMajorFouls); status != game.TieMatch {
+		return status
+	}
+	if status := game.ComparePoints(red.AutoPoints, blue.AutoPoints); status != game.TieMatch {
+		return status
+	}
+	return game.ComparePoints(red.BargePoints, blue.BargePoints)
+}
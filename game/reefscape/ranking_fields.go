@@ -0,0 +1,86 @@
+// Copyright 2017 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// 2025-specific fields by which teams are ranked and the logic for accumulating and sorting them.
+
+package reefscape
+
+import "github.com/Team254/cheesy-arena/game"
+
+type RankingFields struct {
+	CoopertitionPoints int
+	MatchPoints        int
+	AutoPoints         int
+	BargePoints        int
+}
+
+// RankingFieldsDetails returns the 2025-specific portion of the given ranking fields.
+func RankingFieldsDetails(fields *game.RankingFields) *RankingFields {
+	if fields.Details == nil {
+		fields.Details = new(RankingFields)
+	}
+	return fields.Details.(*RankingFields)
+}
+
+func (Game) NewRankingFieldsDetails() any {
+	return new(RankingFields)
+}
+
+func (Game) AddToRankingFields(fields *game.RankingFields, ownScoreSummary, opponentScoreSummary *game.ScoreSummary) {
+	details := RankingFieldsDetails(fields)
+	ownDetails := ScoreSummaryDetails(ownScoreSummary)
+
+	// Assign ranking points for the match outcome and any bonuses.
+	if ownScoreSummary.Score > opponentScoreSummary.Score {
+		fields.RankingPoints += 3
+	} else if ownScoreSummary.Score == opponentScoreSummary.Score {
+		fields.RankingPoints += 1
+	}
+	fields.RankingPoints += ownScoreSummary.BonusRankingPoints
+
+	// Assign tiebreaker points.
+	if ownDetails.CoopertitionBonus {
+		details.CoopertitionPoints++
+	}
+	details.MatchPoints += ownScoreSummary.MatchPoints
+	details.AutoPoints += ownDetails.AutoPoints
+	details.BargePoints += ownDetails.BargePoints
+}
+
+func (Game) CompareRankingFields(fields, otherFields *game.RankingFields) int {
+	a := RankingFieldsDetails(fields)
+	b := RankingFieldsDetails(otherFields)
+
+	// Use cross-multiplication to keep it in integer math.
+	for _, values := range [][2]int{
+		{fields.RankingPoints, otherFields.RankingPoints},
+		{a.CoopertitionPoints, b.CoopertitionPoints},
+		{a.MatchPoints, b.MatchPoints},
+		{a.AutoPoints, b.AutoPoints},
+		{a.BargePoints, b.BargePoints},
+	} {
+		if difference := values[0]*otherFields.Played - values[1]*fields.Played; difference != 0 {
+			return difference
+		}
+	}
+	return 0
+}
+
+func (Game) TbaRankingBreakdown(fields *game.RankingFields) ([]string, []float32) {
+	details := RankingFieldsDetails(fields)
+	played := float32(fields.Played)
+	return []string{"RP", "Coop", "Match", "Auto", "Barge"},
+		[]float32{
+			float32(fields.RankingPoints) / played,
+			float32(details.CoopertitionPoints) / played,
+			float32(details.MatchPoints) / played,
+			float32(details.AutoPoints) / played,
+			float32(details.BargePoints) / played,
+		}
+}
+
+func (Game) RankingReportColumns(fields *game.RankingFields) ([]string, []int) {
+	details := RankingFieldsDetails(fields)
+	return []string{"Coop", "Match", "Auto", "Barge"},
+		[]int{details.CoopertitionPoints, details.MatchPoints, details.AutoPoints, details.BargePoints}
+}
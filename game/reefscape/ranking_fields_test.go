@@ -0,0 +1,119 @@
+// Copyright 2017 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package reefscape
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestAddScoreSummary(t *testing.T) {
+	rand.Seed(0)
+	redSummary := &game.ScoreSummary{
+		MatchPoints:        67,
+		Score:              67,
+		BonusRankingPoints: 2,
+		Details: &ScoreSummary{
+			LeavePoints:            4,
+			AutoPoints:             30,
+			BargePoints:            19,
+			CoopertitionBonus:      false,
+			AutoBonusRankingPoint:  true,
+			CoralBonusRankingPoint: false,
+			BargeBonusRankingPoint: true,
+		},
+	}
+	blueSummary := &game.ScoreSummary{
+		MatchPoints:        61,
+		Score:              81,
+		BonusRankingPoints: 1,
+		Details: &ScoreSummary{
+			LeavePoints:            2,
+			AutoPoints:             16,
+			BargePoints:            14,
+			CoopertitionBonus:      true,
+			AutoBonusRankingPoint:  false,
+			CoralBonusRankingPoint: true,
+			BargeBonusRankingPoint: false,
+		},
+	}
+	rankingFields := game.RankingFields{}
+
+	// Add a loss.
+	rankingFields.AddScoreSummary(redSummary, blueSummary, false)
+	assert.Equal(t, newRankingFields(2, 0, 67, 30, 19, 0.9451961492941164, 0, 1, 0, 0, 1), rankingFields)
+
+	// Add a win.
+	rankingFields.AddScoreSummary(blueSummary, redSummary, false)
+	assert.Equal(t, newRankingFields(6, 1, 128, 46, 33, 0.24496508529377975, 1, 1, 0, 0, 2), rankingFields)
+
+	// Add a tie.
+	rankingFields.AddScoreSummary(redSummary, redSummary, false)
+	assert.Equal(t, newRankingFields(9, 1, 195, 76, 52, 0.6559562651954052, 1, 1, 1, 0, 3), rankingFields)
+
+	// Add a disqualification.
+	rankingFields.AddScoreSummary(blueSummary, redSummary, true)
+	assert.Equal(t, newRankingFields(9, 1, 195, 76, 52, 0.05434383959970039, 1, 1, 1, 1, 4), rankingFields)
+}
+
+func TestSortRankings(t *testing.T) {
+	// Check tiebreakers.
+	rankings := make(game.Rankings, 12)
+	rankings[0] = game.Ranking{TeamId: 1, RankingFields: newRankingFields(50, 50, 50, 50, 50, 0.49, 3, 2, 1, 0, 10)}
+	rankings[1] = game.Ranking{TeamId: 2, RankingFields: newRankingFields(50, 50, 50, 50, 50, 0.51, 3, 2, 1, 0, 10)}
+	rankings[2] = game.Ranking{TeamId: 3, RankingFields: newRankingFields(50, 50, 50, 50, 49, 0.50, 3, 2, 1, 0, 10)}
+	rankings[3] = game.Ranking{TeamId: 4, RankingFields: newRankingFields(50, 50, 50, 50, 51, 0.50, 3, 2, 1, 0, 10)}
+	rankings[4] = game.Ranking{TeamId: 5, RankingFields: newRankingFields(50, 50, 50, 49, 50, 0.50, 3, 2, 1, 0, 10)}
+	rankings[5] = game.Ranking{TeamId: 6, RankingFields: newRankingFields(50, 50, 50, 51, 50, 0.50, 3, 2, 1, 0, 10)}
+	rankings[6] = game.Ranking{TeamId: 7, RankingFields: newRankingFields(50, 50, 49, 50, 50, 0.50, 3, 2, 1, 0, 10)}
+	rankings[7] = game.Ranking{TeamId: 8, RankingFields: newRankingFields(50, 50, 51, 50, 50, 0.50, 3, 2, 1, 0, 10)}
+	rankings[8] = game.Ranking{TeamId: 9, RankingFields: newRankingFields(50, 49, 50, 50, 50, 0.50, 3, 2, 1, 0, 10)}
+	rankings[9] = game.Ranking{TeamId: 10, RankingFields: newRankingFields(50, 51, 50, 50, 50, 0.50, 3, 2, 1, 0, 10)}
+	rankings[10] = game.Ranking{TeamId: 11, RankingFields: newRankingFields(49, 50, 50, 50, 50, 0.50, 3, 2, 1, 0, 10)}
+	rankings[11] = game.Ranking{TeamId: 12, RankingFields: newRankingFields(51, 50, 50, 50, 50, 0.50, 3, 2, 1, 0, 10)}
+	sort.Sort(rankings)
+	assert.Equal(t, 12, rankings[0].TeamId)
+	assert.Equal(t, 10, rankings[1].TeamId)
+	assert.Equal(t, 8, rankings[2].TeamId)
+	assert.Equal(t, 6, rankings[3].TeamId)
+	assert.Equal(t, 4, rankings[4].TeamId)
+	assert.Equal(t, 2, rankings[5].TeamId)
+	assert.Equal(t, 1, rankings[6].TeamId)
+	assert.Equal(t, 3, rankings[7].TeamId)
+	assert.Equal(t, 5, rankings[8].TeamId)
+	assert.Equal(t, 7, rankings[9].TeamId)
+	assert.Equal(t, 9, rankings[10].TeamId)
+	assert.Equal(t, 11, rankings[11].TeamId)
+
+	// Check with unequal number of matches played.
+	rankings = make(game.Rankings, 3)
+	rankings[0] = game.Ranking{TeamId: 1, RankingFields: newRankingFields(10, 25, 25, 25, 25, 0.49, 3, 2, 1, 0, 5)}
+	rankings[1] = game.Ranking{TeamId: 2, RankingFields: newRankingFields(19, 50, 50, 50, 50, 0.51, 3, 2, 1, 0, 9)}
+	rankings[2] = game.Ranking{TeamId: 3, RankingFields: newRankingFields(20, 50, 50, 50, 50, 0.51, 3, 2, 1, 0, 10)}
+	sort.Sort(rankings)
+	assert.Equal(t, 2, rankings[0].TeamId)
+	assert.Equal(t, 3, rankings[1].TeamId)
+	assert.Equal(t, 1, rankings[2].TeamId)
+}
+
+// Returns ranking fields with the given values, in the order in which they are used for sorting.
+func newRankingFields(
+	rankingPoints, coopertitionPoints, matchPoints, autoPoints, bargePoints int,
+	random float64,
+	wins, losses, ties, disqualifications, played int,
+) game.RankingFields {
+	return game.RankingFields{
+		RankingPoints:     rankingPoints,
+		Random:            random,
+		Wins:              wins,
+		Losses:            losses,
+		Ties:              ties,
+		Disqualifications: disqualifications,
+		Played:            played,
+		Details:           &RankingFields{coopertitionPoints, matchPoints, autoPoints, bargePoints},
+	}
+}
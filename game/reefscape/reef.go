@@ -3,7 +3,7 @@
 //
 // Scoring logic for the 2025 Reef element.
 
-package game
+package reefscape
 
 type Reef struct {
 	AutoBranches   [3][12]bool
@@ -1,7 +1,7 @@
 // Copyright 2025 Team 254. All Rights Reserved.
 // Author: pat@patfairbank.com (Patrick Fairbank)
 
-package game
+package reefscape
 
 import (
 	"github.com/stretchr/testify/assert"
@@ -0,0 +1,25 @@
+// Copyright 2020 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package reefscape
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetRuleById(t *testing.T) {
+	assert.Nil(t, game.GetRuleById(0))
+	assert.Equal(t, (*game.Rule)(rules[0]), game.GetRuleById(1))
+	assert.Equal(t, (*game.Rule)(rules[20]), game.GetRuleById(21))
+	assert.Nil(t, game.GetRuleById(1000))
+}
+
+func TestGetAllRules(t *testing.T) {
+	allRules := game.GetAllRules()
+	assert.Equal(t, len(rules), len(allRules))
+	for _, rule := range rules {
+		assert.Equal(t, (*game.Rule)(rule), allRules[rule.Id])
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model representing the 2025-specific portion of the instantaneous score of a match.
+
+package reefscape
+
+import "github.com/Team254/cheesy-arena/game"
+
+type Score struct {
+	LeaveStatuses   [3]bool
+	Reef            Reef
+	BargeAlgae      int
+	ProcessorAlgae  int
+	EndgameStatuses [3]EndgameStatus
+}
+
+// Game-specific settings that can be changed via the settings.
+var AutoBonusCoralThreshold = defaultAutoBonusCoralThreshold
+var CoralBonusPerLevelThreshold = defaultCoralBonusPerLevelThreshold
+var CoralBonusCoopEnabled = true
+var BargeBonusPointThreshold = defaultBargeBonusPointThreshold
+
+const (
+	defaultAutoBonusCoralThreshold     = 1
+	defaultCoralBonusPerLevelThreshold = 7
+	defaultBargeBonusPointThreshold    = 16
+)
+
+// Represents the state of a robot at the end of the match.
+type EndgameStatus int
+
+const (
+	EndgameNone EndgameStatus = iota
+	EndgameParked
+	EndgameShallowCage
+	EndgameDeepCage
+)
+
+// Clone returns a copy of the score details; they don't contain any references so a shallow copy suffices.
+func (score *Score) Clone() game.ScoreDetails {
+	clone := *score
+	return &clone
+}
+
+// ScoreDetails returns the 2025-specific portion of the given score, initializing it if it is empty.
+func ScoreDetails(score *game.Score) *Score {
+	if score.Details == nil {
+		score.Details = new(Score)
+	}
+	return score.Details.(*Score)
+}
@@ -0,0 +1,57 @@
+// Copyright 2022 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model representing the 2025-specific calculated totals of a match score.
+
+package reefscape
+
+import (
+	"strconv"
+
+	"github.com/Team254/cheesy-arena/game"
+)
+
+type ScoreSummary struct {
+	LeavePoints             int
+	AutoPoints              int
+	NumCoral                int
+	CoralPoints             int
+	NumAlgae                int
+	AlgaePoints             int
+	BargePoints             int
+	CoopertitionCriteriaMet bool
+	CoopertitionBonus       bool
+	NumCoralLevels          int
+	NumCoralLevelsGoal      int
+	AutoBonusRankingPoint   bool
+	CoralBonusRankingPoint  bool
+	BargeBonusRankingPoint  bool
+	NumOpponentMajorFouls   int
+}
+
+// ScoreSummaryDetails returns the 2025-specific portion of the given score summary.
+func ScoreSummaryDetails(summary *game.ScoreSummary) *ScoreSummary {
+	if summary.Details == nil {
+		summary.Details = new(ScoreSummary)
+	}
+	return summary.Details.(*ScoreSummary)
+}
+
+func (Game) ScoreBreakdown(scoreSummary *game.ScoreSummary) []game.ScoreBreakdownItem {
+	details := ScoreSummaryDetails(scoreSummary)
+	yesNo := func(value bool) string {
+		if value {
+			return "Yes"
+		}
+		return "No"
+	}
+	return []game.ScoreBreakdownItem{
+		{Label: "Auto Leave Points", Value: strconv.Itoa(details.LeavePoints)},
+		{Label: "Coral Points", Value: strconv.Itoa(details.CoralPoints)},
+		{Label: "Algae Points", Value: strconv.Itoa(details.AlgaePoints)},
+		{Label: "Barge Points", Value: strconv.Itoa(details.BargePoints)},
+		{Label: "Auto Bonus RP", Value: yesNo(details.AutoBonusRankingPoint), IsRankingPoint: true},
+		{Label: "Coral Bonus RP", Value: yesNo(details.CoralBonusRankingPoint), IsRankingPoint: true},
+		{Label: "Barge Bonus RP", Value: yesNo(details.BargeBonusRankingPoint), IsRankingPoint: true},
+	}
+}
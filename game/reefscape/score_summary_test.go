@@ -0,0 +1,80 @@
+// Copyright 2022 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package reefscape
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestScoreSummaryDetermineMatchStatus(t *testing.T) {
+	redScoreSummary := &game.ScoreSummary{Score: 10, Details: &ScoreSummary{}}
+	blueScoreSummary := &game.ScoreSummary{Score: 10, Details: &ScoreSummary{}}
+	assert.Equal(t, game.TieMatch, game.DetermineMatchStatus(redScoreSummary, blueScoreSummary, false))
+	assert.Equal(t, game.TieMatch, game.DetermineMatchStatus(redScoreSummary, blueScoreSummary, true))
+
+	redScoreSummary.Score = 11
+	assert.Equal(t, game.RedWonMatch, game.DetermineMatchStatus(redScoreSummary, blueScoreSummary, false))
+	assert.Equal(t, game.RedWonMatch, game.DetermineMatchStatus(redScoreSummary, blueScoreSummary, true))
+
+	blueScoreSummary.Score = 12
+	assert.Equal(t, game.BlueWonMatch, game.DetermineMatchStatus(redScoreSummary, blueScoreSummary, false))
+	assert.Equal(t, game.BlueWonMatch, game.DetermineMatchStatus(redScoreSummary, blueScoreSummary, true))
+
+	redScoreSummary.Score = 12
+	ScoreSummaryDetails(redScoreSummary).NumOpponentMajorFouls = 11
+	ScoreSummaryDetails(redScoreSummary).AutoPoints = 11
+	ScoreSummaryDetails(redScoreSummary).BargePoints = 11
+	ScoreSummaryDetails(blueScoreSummary).NumOpponentMajorFouls = 10
+	ScoreSummaryDetails(blueScoreSummary).AutoPoints = 10
+	ScoreSummaryDetails(blueScoreSummary).BargePoints = 10
+	assert.Equal(t, game.TieMatch, game.DetermineMatchStatus(redScoreSummary, blueScoreSummary, false))
+	assert.Equal(t, game.RedWonMatch, game.DetermineMatchStatus(redScoreSummary, blueScoreSummary, true))
+
+	ScoreSummaryDetails(blueScoreSummary).NumOpponentMajorFouls = 12
+	assert.Equal(t, game.TieMatch, game.DetermineMatchStatus(redScoreSummary, blueScoreSummary, false))
+	assert.Equal(t, game.BlueWonMatch, game.DetermineMatchStatus(redScoreSummary, blueScoreSummary, true))
+
+	ScoreSummaryDetails(redScoreSummary).NumOpponentMajorFouls = 12
+	assert.Equal(t, game.TieMatch, game.DetermineMatchStatus(redScoreSummary, blueScoreSummary, false))
+	assert.Equal(t, game.RedWonMatch, game.DetermineMatchStatus(redScoreSummary, blueScoreSummary, true))
+
+	ScoreSummaryDetails(blueScoreSummary).AutoPoints = 12
+	assert.Equal(t, game.TieMatch, game.DetermineMatchStatus(redScoreSummary, blueScoreSummary, false))
+	assert.Equal(t, game.BlueWonMatch, game.DetermineMatchStatus(redScoreSummary, blueScoreSummary, true))
+
+	ScoreSummaryDetails(redScoreSummary).AutoPoints = 12
+	assert.Equal(t, game.TieMatch, game.DetermineMatchStatus(redScoreSummary, blueScoreSummary, false))
+	assert.Equal(t, game.RedWonMatch, game.DetermineMatchStatus(redScoreSummary, blueScoreSummary, true))
+
+	ScoreSummaryDetails(blueScoreSummary).BargePoints = 12
+	assert.Equal(t, game.TieMatch, game.DetermineMatchStatus(redScoreSummary, blueScoreSummary, false))
+	assert.Equal(t, game.BlueWonMatch, game.DetermineMatchStatus(redScoreSummary, blueScoreSummary, true))
+
+	ScoreSummaryDetails(redScoreSummary).BargePoints = 12
+	assert.Equal(t, game.TieMatch, game.DetermineMatchStatus(redScoreSummary, blueScoreSummary, false))
+	assert.Equal(t, game.TieMatch, game.DetermineMatchStatus(redScoreSummary, blueScoreSummary, true))
+}
+
+func TestScoreBreakdown(t *testing.T) {
+	scoreSummary := &game.ScoreSummary{
+		Details: &ScoreSummary{
+			LeavePoints: 6, CoralPoints: 31, AlgaePoints: 12, BargePoints: 14, BargeBonusRankingPoint: true,
+		},
+	}
+	assert.Equal(
+		t,
+		[]game.ScoreBreakdownItem{
+			{Label: "Auto Leave Points", Value: "6"},
+			{Label: "Coral Points", Value: "31"},
+			{Label: "Algae Points", Value: "12"},
+			{Label: "Barge Points", Value: "14"},
+			{Label: "Auto Bonus RP", Value: "No", IsRankingPoint: true},
+			{Label: "Coral Bonus RP", Value: "No", IsRankingPoint: true},
+			{Label: "Barge Bonus RP", Value: "Yes", IsRankingPoint: true},
+		},
+		Game{}.ScoreBreakdown(scoreSummary),
+	)
+}
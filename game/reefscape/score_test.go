@@ -0,0 +1,466 @@
+// Copyright 2017 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package reefscape
+
+import (
+	"encoding/json"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+func TestScoreSummary(t *testing.T) {
+	redScore := TestScore1()
+	blueScore := TestScore2()
+
+	redSummary := redScore.Summarize(blueScore)
+	assert.Equal(t, 6, ScoreSummaryDetails(redSummary).LeavePoints)
+	assert.Equal(t, 13, ScoreSummaryDetails(redSummary).AutoPoints)
+	assert.Equal(t, 12, ScoreSummaryDetails(redSummary).NumCoral)
+	assert.Equal(t, 34, ScoreSummaryDetails(redSummary).CoralPoints)
+	assert.Equal(t, 9, ScoreSummaryDetails(redSummary).NumAlgae)
+	assert.Equal(t, 40, ScoreSummaryDetails(redSummary).AlgaePoints)
+	assert.Equal(t, 14, ScoreSummaryDetails(redSummary).BargePoints)
+	assert.Equal(t, 94, redSummary.MatchPoints)
+	assert.Equal(t, 0, redSummary.FoulPoints)
+	assert.Equal(t, 94, redSummary.Score)
+	assert.Equal(t, true, ScoreSummaryDetails(redSummary).CoopertitionCriteriaMet)
+	assert.Equal(t, false, ScoreSummaryDetails(redSummary).CoopertitionBonus)
+	assert.Equal(t, 1, ScoreSummaryDetails(redSummary).NumCoralLevels)
+	assert.Equal(t, 4, ScoreSummaryDetails(redSummary).NumCoralLevelsGoal)
+	assert.Equal(t, true, ScoreSummaryDetails(redSummary).AutoBonusRankingPoint)
+	assert.Equal(t, false, ScoreSummaryDetails(redSummary).CoralBonusRankingPoint)
+	assert.Equal(t, false, ScoreSummaryDetails(redSummary).BargeBonusRankingPoint)
+	assert.Equal(t, 1, redSummary.BonusRankingPoints)
+	assert.Equal(t, 0, ScoreSummaryDetails(redSummary).NumOpponentMajorFouls)
+
+	blueSummary := blueScore.Summarize(redScore)
+	assert.Equal(t, 3, ScoreSummaryDetails(blueSummary).LeavePoints)
+	assert.Equal(t, 33, ScoreSummaryDetails(blueSummary).AutoPoints)
+	assert.Equal(t, 26, ScoreSummaryDetails(blueSummary).NumCoral)
+	assert.Equal(t, 83, ScoreSummaryDetails(blueSummary).CoralPoints)
+	assert.Equal(t, 10, ScoreSummaryDetails(blueSummary).NumAlgae)
+	assert.Equal(t, 42, ScoreSummaryDetails(blueSummary).AlgaePoints)
+	assert.Equal(t, 24, ScoreSummaryDetails(blueSummary).BargePoints)
+	assert.Equal(t, 152, blueSummary.MatchPoints)
+	assert.Equal(t, 34, blueSummary.FoulPoints)
+	assert.Equal(t, 186, blueSummary.Score)
+	assert.Equal(t, false, ScoreSummaryDetails(blueSummary).CoopertitionCriteriaMet)
+	assert.Equal(t, false, ScoreSummaryDetails(blueSummary).CoopertitionBonus)
+	assert.Equal(t, 1, ScoreSummaryDetails(blueSummary).NumCoralLevels)
+	assert.Equal(t, 4, ScoreSummaryDetails(blueSummary).NumCoralLevelsGoal)
+	assert.Equal(t, false, ScoreSummaryDetails(blueSummary).AutoBonusRankingPoint)
+	assert.Equal(t, false, ScoreSummaryDetails(blueSummary).CoralBonusRankingPoint)
+	assert.Equal(t, true, ScoreSummaryDetails(blueSummary).BargeBonusRankingPoint)
+	assert.Equal(t, 1, blueSummary.BonusRankingPoints)
+	assert.Equal(t, 5, ScoreSummaryDetails(blueSummary).NumOpponentMajorFouls)
+
+	// Test that unsetting the team and rule ID don't invalidate the foul.
+	redScore.Fouls[0].TeamId = 0
+	redScore.Fouls[0].RuleId = 0
+	assert.Equal(t, 34, blueScore.Summarize(redScore).FoulPoints)
+
+	// Test playoff disqualification.
+	redScore.PlayoffDq = true
+	assert.Equal(t, 0, redScore.Summarize(blueScore).Score)
+	assert.NotEqual(t, 0, blueScore.Summarize(blueScore).Score)
+	blueScore.PlayoffDq = true
+	assert.Equal(t, 0, blueScore.Summarize(redScore).Score)
+}
+
+func TestScoreAutoBonusRankingPoint(t *testing.T) {
+	redScore := TestScore1()
+	redScore.RobotsBypassed = [3]bool{false, false, false}
+	ScoreDetails(redScore).LeaveStatuses = [3]bool{false, false, false}
+	blueScore := TestScore2()
+
+	// No robots left; no bonus is awarded.
+	redSummary := redScore.Summarize(blueScore)
+	assert.Equal(t, false, ScoreSummaryDetails(redSummary).AutoBonusRankingPoint)
+
+	// All robots left; the bonus is awarded.
+	ScoreDetails(redScore).LeaveStatuses = [3]bool{true, true, true}
+	redSummary = redScore.Summarize(blueScore)
+	assert.Equal(t, true, ScoreSummaryDetails(redSummary).AutoBonusRankingPoint)
+
+	// One robot failed to leave; no bonus is awarded.
+	for i := 0; i < 3; i++ {
+		ScoreDetails(redScore).LeaveStatuses = [3]bool{true, true, true}
+		ScoreDetails(redScore).LeaveStatuses[i] = false
+		redSummary = redScore.Summarize(blueScore)
+		assert.Equal(t, false, ScoreSummaryDetails(redSummary).AutoBonusRankingPoint)
+	}
+
+	// One bypassed robot failed to leave; the bonus is awarded.
+	for i := 0; i < 3; i++ {
+		redScore.RobotsBypassed = [3]bool{false, false, false}
+		redScore.RobotsBypassed[i] = true
+		ScoreDetails(redScore).LeaveStatuses = [3]bool{true, true, true}
+		ScoreDetails(redScore).LeaveStatuses[i] = false
+		redSummary = redScore.Summarize(blueScore)
+		assert.Equal(t, true, ScoreSummaryDetails(redSummary).AutoBonusRankingPoint)
+	}
+
+	// Only one robot left but the other two were bypassed; the bonus is awarded.
+	redScore.RobotsBypassed = [3]bool{false, true, true}
+	ScoreDetails(redScore).LeaveStatuses = [3]bool{true, false, false}
+	redSummary = redScore.Summarize(blueScore)
+	assert.Equal(t, true, ScoreSummaryDetails(redSummary).AutoBonusRankingPoint)
+
+	// No coral is scored; the bonus is not awarded.
+	ScoreDetails(redScore).Reef = Reef{}
+	redSummary = redScore.Summarize(blueScore)
+	assert.Equal(t, false, ScoreSummaryDetails(redSummary).AutoBonusRankingPoint)
+}
+
+func TestScoreCoralBonusRankingPoint(t *testing.T) {
+	// Save the original threshold value and restore it after the test.
+	originalThreshold := CoralBonusPerLevelThreshold
+	defer func() {
+		CoralBonusPerLevelThreshold = originalThreshold
+		CoralBonusCoopEnabled = true
+	}()
+	CoralBonusPerLevelThreshold = 3
+
+	redScore := TestScore1()
+	blueScore := TestScore2()
+
+	redScoreSummary := redScore.Summarize(blueScore)
+	blueScoreSummary := blueScore.Summarize(redScore)
+	assert.Equal(t, true, ScoreSummaryDetails(redScoreSummary).CoopertitionCriteriaMet)
+	assert.Equal(t, false, ScoreSummaryDetails(redScoreSummary).CoopertitionBonus)
+	assert.Equal(t, 2, ScoreSummaryDetails(redScoreSummary).NumCoralLevels)
+	assert.Equal(t, 4, ScoreSummaryDetails(redScoreSummary).NumCoralLevelsGoal)
+	assert.Equal(t, false, ScoreSummaryDetails(redScoreSummary).CoralBonusRankingPoint)
+	assert.Equal(t, false, ScoreSummaryDetails(blueScoreSummary).CoopertitionCriteriaMet)
+	assert.Equal(t, false, ScoreSummaryDetails(blueScoreSummary).CoopertitionBonus)
+	assert.Equal(t, 4, ScoreSummaryDetails(blueScoreSummary).NumCoralLevels)
+	assert.Equal(t, 4, ScoreSummaryDetails(blueScoreSummary).NumCoralLevelsGoal)
+	assert.Equal(t, true, ScoreSummaryDetails(blueScoreSummary).CoralBonusRankingPoint)
+
+	// Activate coopertition bonus for the blue alliance.
+	ScoreDetails(blueScore).ProcessorAlgae = 2
+	redScoreSummary = redScore.Summarize(blueScore)
+	blueScoreSummary = blueScore.Summarize(redScore)
+	assert.Equal(t, true, ScoreSummaryDetails(redScoreSummary).CoopertitionCriteriaMet)
+	assert.Equal(t, true, ScoreSummaryDetails(redScoreSummary).CoopertitionBonus)
+	assert.Equal(t, 2, ScoreSummaryDetails(redScoreSummary).NumCoralLevels)
+	assert.Equal(t, 3, ScoreSummaryDetails(redScoreSummary).NumCoralLevelsGoal)
+	assert.Equal(t, false, ScoreSummaryDetails(redScoreSummary).CoralBonusRankingPoint)
+	assert.Equal(t, true, ScoreSummaryDetails(blueScoreSummary).CoopertitionCriteriaMet)
+	assert.Equal(t, true, ScoreSummaryDetails(blueScoreSummary).CoopertitionBonus)
+	assert.Equal(t, 4, ScoreSummaryDetails(blueScoreSummary).NumCoralLevels)
+	assert.Equal(t, 3, ScoreSummaryDetails(blueScoreSummary).NumCoralLevelsGoal)
+	assert.Equal(t, true, ScoreSummaryDetails(blueScoreSummary).CoralBonusRankingPoint)
+
+	// Satisfy the Coral bonus requirement for the red alliance.
+	ScoreDetails(redScore).Reef.Branches[0] = [12]bool{true, true, true, true}
+	redScoreSummary = redScore.Summarize(blueScore)
+	blueScoreSummary = blueScore.Summarize(redScore)
+	assert.Equal(t, true, ScoreSummaryDetails(redScoreSummary).CoopertitionCriteriaMet)
+	assert.Equal(t, true, ScoreSummaryDetails(redScoreSummary).CoopertitionBonus)
+	assert.Equal(t, 3, ScoreSummaryDetails(redScoreSummary).NumCoralLevels)
+	assert.Equal(t, 3, ScoreSummaryDetails(redScoreSummary).NumCoralLevelsGoal)
+	assert.Equal(t, true, ScoreSummaryDetails(redScoreSummary).CoralBonusRankingPoint)
+
+	// Disable the coopertition bonus.
+	CoralBonusCoopEnabled = false
+	redScoreSummary = redScore.Summarize(blueScore)
+	blueScoreSummary = blueScore.Summarize(redScore)
+	assert.Equal(t, false, ScoreSummaryDetails(redScoreSummary).CoopertitionCriteriaMet)
+	assert.Equal(t, false, ScoreSummaryDetails(redScoreSummary).CoopertitionBonus)
+	assert.Equal(t, 3, ScoreSummaryDetails(redScoreSummary).NumCoralLevels)
+	assert.Equal(t, 4, ScoreSummaryDetails(redScoreSummary).NumCoralLevelsGoal)
+	assert.Equal(t, false, ScoreSummaryDetails(redScoreSummary).CoralBonusRankingPoint)
+	assert.Equal(t, false, ScoreSummaryDetails(blueScoreSummary).CoopertitionCriteriaMet)
+	assert.Equal(t, false, ScoreSummaryDetails(blueScoreSummary).CoopertitionBonus)
+	assert.Equal(t, 4, ScoreSummaryDetails(blueScoreSummary).NumCoralLevels)
+	assert.Equal(t, 4, ScoreSummaryDetails(blueScoreSummary).NumCoralLevelsGoal)
+	assert.Equal(t, true, ScoreSummaryDetails(blueScoreSummary).CoralBonusRankingPoint)
+
+	// Check that G206 disqualifies the alliance from the Coral bonus.
+	blueScore.Fouls = []game.Foul{{RuleId: 1}}
+	redScoreSummary = redScore.Summarize(blueScore)
+	blueScoreSummary = blueScore.Summarize(redScore)
+	assert.Equal(t, 0, redScoreSummary.FoulPoints)
+	assert.Equal(t, false, ScoreSummaryDetails(blueScoreSummary).CoralBonusRankingPoint)
+	assert.Equal(t, 0, blueScoreSummary.BonusRankingPoints)
+}
+
+func TestScoreBargeBonusRankingPoint(t *testing.T) {
+	// Save the original threshold value and restore it after the test.
+	originalThreshold := BargeBonusPointThreshold
+	defer func() {
+		BargeBonusPointThreshold = originalThreshold
+	}()
+
+	testCases := []struct {
+		endgameStatuses      [3]EndgameStatus
+		fouls                []game.Foul
+		threshold            int
+		expectedBonusAwarded bool
+	}{
+		// 0. No endgame points.
+		{
+			endgameStatuses:      [3]EndgameStatus{EndgameNone, EndgameNone, EndgameNone},
+			fouls:                []game.Foul{},
+			threshold:            14,
+			expectedBonusAwarded: false,
+		},
+
+		// 1. All robots parked.
+		{
+			endgameStatuses:      [3]EndgameStatus{EndgameParked, EndgameParked, EndgameParked},
+			fouls:                []game.Foul{},
+			threshold:            14,
+			expectedBonusAwarded: false,
+		},
+
+		// 2. Meeting the minimum threshold.
+		{
+			endgameStatuses:      [3]EndgameStatus{EndgameParked, EndgameNone, EndgameDeepCage},
+			fouls:                []game.Foul{},
+			threshold:            14,
+			expectedBonusAwarded: true,
+		},
+
+		// 3. Same endgame statuses not meeting a higher threshold.
+		{
+			endgameStatuses:      [3]EndgameStatus{EndgameParked, EndgameNone, EndgameDeepCage},
+			fouls:                []game.Foul{},
+			threshold:            16,
+			expectedBonusAwarded: false,
+		},
+
+		// 4. Meeting the new minimum threshold with a different combination.
+		{
+			endgameStatuses:      [3]EndgameStatus{EndgameDeepCage, EndgameParked, EndgameParked},
+			fouls:                []game.Foul{},
+			threshold:            16,
+			expectedBonusAwarded: true,
+		},
+
+		// 5. One of each endgame status with higher threshold.
+		{
+			endgameStatuses:      [3]EndgameStatus{EndgameShallowCage, EndgameDeepCage, EndgameParked},
+			fouls:                []game.Foul{},
+			threshold:            21,
+			expectedBonusAwarded: false,
+		},
+
+		// 6. All deep climbs.
+		{
+			endgameStatuses:      [3]EndgameStatus{EndgameDeepCage, EndgameDeepCage, EndgameDeepCage},
+			fouls:                []game.Foul{},
+			threshold:            36,
+			expectedBonusAwarded: true,
+		},
+
+		// 7. G206 foul disqualifies the alliance from the Barge bonus.
+		{
+			endgameStatuses:      [3]EndgameStatus{EndgameDeepCage, EndgameDeepCage, EndgameDeepCage},
+			fouls:                []game.Foul{{RuleId: 1}},
+			threshold:            14,
+			expectedBonusAwarded: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(
+			strconv.Itoa(i),
+			func(t *testing.T) {
+				BargeBonusPointThreshold = tc.threshold
+				score := game.Score{Fouls: tc.fouls, Details: &Score{EndgameStatuses: tc.endgameStatuses}}
+				summary := score.Summarize(&game.Score{})
+				assert.Equal(t, tc.expectedBonusAwarded, ScoreSummaryDetails(summary).BargeBonusRankingPoint)
+			},
+		)
+	}
+}
+
+func TestScoreAutoRankingPointFromFouls(t *testing.T) {
+	testCases := []struct {
+		ownFouls           []game.Foul
+		opponentFouls      []game.Foul
+		expectedCoralBonus bool
+		expectedBargeBonus bool
+	}{
+		// 0. No fouls - no automatic ranking points.
+		{
+			ownFouls:           []game.Foul{},
+			opponentFouls:      []game.Foul{},
+			expectedCoralBonus: false,
+			expectedBargeBonus: false,
+		},
+
+		// 1. G410 foul automatically awards coral bonus.
+		{
+			ownFouls:           []game.Foul{},
+			opponentFouls:      []game.Foul{{RuleId: 14}},
+			expectedCoralBonus: true,
+			expectedBargeBonus: false,
+		},
+
+		// 2. G418 foul automatically awards barge bonus.
+		{
+			ownFouls:           []game.Foul{},
+			opponentFouls:      []game.Foul{{RuleId: 21}},
+			expectedCoralBonus: false,
+			expectedBargeBonus: true,
+		},
+
+		// 3. G428 foul automatically awards barge bonus.
+		{
+			ownFouls:           []game.Foul{},
+			opponentFouls:      []game.Foul{{RuleId: 33}},
+			expectedCoralBonus: false,
+			expectedBargeBonus: true,
+		},
+
+		// 4. All fouls together still automatically award both bonuses.
+		{
+			ownFouls:           []game.Foul{},
+			opponentFouls:      []game.Foul{{RuleId: 14}, {RuleId: 21}, {RuleId: 33}},
+			expectedCoralBonus: true,
+			expectedBargeBonus: true,
+		},
+
+		// 5. G206 makes the alliance ineligible for both bonuses.
+		{
+			ownFouls:           []game.Foul{{RuleId: 1}},
+			opponentFouls:      []game.Foul{{RuleId: 14}, {RuleId: 21}, {RuleId: 33}},
+			expectedCoralBonus: false,
+			expectedBargeBonus: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(
+			strconv.Itoa(i),
+			func(t *testing.T) {
+				redScore := game.Score{Fouls: tc.ownFouls}
+				blueScore := game.Score{Fouls: tc.opponentFouls}
+				redSummary := redScore.Summarize(&blueScore)
+				assert.Equal(t, tc.expectedCoralBonus, ScoreSummaryDetails(redSummary).CoralBonusRankingPoint)
+				assert.Equal(t, tc.expectedBargeBonus, ScoreSummaryDetails(redSummary).BargeBonusRankingPoint)
+
+				// Count expected total bonus ranking points.
+				expectedBonusRankingPoints := 0
+				if tc.expectedCoralBonus {
+					expectedBonusRankingPoints++
+				}
+				if tc.expectedBargeBonus {
+					expectedBonusRankingPoints++
+				}
+				assert.Equal(t, expectedBonusRankingPoints, redSummary.BonusRankingPoints)
+			},
+		)
+	}
+}
+
+func TestScoreEquals(t *testing.T) {
+	score1 := TestScore1()
+	score2 := TestScore1()
+	assert.True(t, score1.Equals(score2))
+	assert.True(t, score2.Equals(score1))
+
+	score3 := TestScore2()
+	assert.False(t, score1.Equals(score3))
+	assert.False(t, score3.Equals(score1))
+
+	score2 = TestScore1()
+	score2.RobotsBypassed[0] = true
+	assert.False(t, score1.Equals(score2))
+	assert.False(t, score2.Equals(score1))
+
+	score2 = TestScore1()
+	ScoreDetails(score2).LeaveStatuses[0] = false
+	assert.False(t, score1.Equals(score2))
+	assert.False(t, score2.Equals(score1))
+
+	score2 = TestScore1()
+	ScoreDetails(score2).Reef.TroughFar = 5
+	assert.False(t, score1.Equals(score2))
+	assert.False(t, score2.Equals(score1))
+
+	score2 = TestScore1()
+	ScoreDetails(score2).BargeAlgae = 9
+	assert.False(t, score1.Equals(score2))
+	assert.False(t, score2.Equals(score1))
+
+	score2 = TestScore1()
+	ScoreDetails(score2).ProcessorAlgae = 3
+	assert.False(t, score1.Equals(score2))
+	assert.False(t, score2.Equals(score1))
+
+	score2 = TestScore1()
+	ScoreDetails(score2).EndgameStatuses[1] = EndgameParked
+	assert.False(t, score1.Equals(score2))
+	assert.False(t, score2.Equals(score1))
+
+	score2 = TestScore1()
+	score2.Fouls = []game.Foul{}
+	assert.False(t, score1.Equals(score2))
+	assert.False(t, score2.Equals(score1))
+
+	score2 = TestScore1()
+	score2.Fouls[0].IsMajor = false
+	assert.False(t, score1.Equals(score2))
+	assert.False(t, score2.Equals(score1))
+
+	score2 = TestScore1()
+	score2.Fouls[0].TeamId += 1
+	assert.False(t, score1.Equals(score2))
+	assert.False(t, score2.Equals(score1))
+
+	score2 = TestScore1()
+	score2.Fouls[0].RuleId = 1
+	assert.False(t, score1.Equals(score2))
+	assert.False(t, score2.Equals(score1))
+
+	score2 = TestScore1()
+	score2.PlayoffDq = !score2.PlayoffDq
+	assert.False(t, score1.Equals(score2))
+	assert.False(t, score2.Equals(score1))
+}
+
+func TestScoreJson(t *testing.T) {
+	score := TestScore1()
+	scoreJson, err := json.Marshal(score)
+	assert.Nil(t, err)
+
+	// Check that the game-specific fields are serialized inline with the common ones.
+	var fields map[string]any
+	assert.Nil(t, json.Unmarshal(scoreJson, &fields))
+	assert.Equal(t, 7.0, fields["BargeAlgae"])
+	assert.Equal(t, []any{true, true, false}, fields["LeaveStatuses"])
+	assert.Equal(t, []any{false, false, true}, fields["RobotsBypassed"])
+	assert.NotContains(t, fields, "Details")
+
+	var decodedScore game.Score
+	assert.Nil(t, json.Unmarshal(scoreJson, &decodedScore))
+	assert.Equal(t, score, &decodedScore)
+	assert.True(t, score.Equals(&decodedScore))
+
+	// Check that a score without details serializes the same as an empty one.
+	emptyScoreJson, err := json.Marshal(game.Score{})
+	assert.Nil(t, err)
+	newScoreJson, err := json.Marshal(game.NewScore())
+	assert.Nil(t, err)
+	assert.Equal(t, string(newScoreJson), string(emptyScoreJson))
+	assert.True(t, game.NewScore().Equals(&game.Score{}))
+}
+
+func TestScoreClone(t *testing.T) {
+	score := TestScore1()
+	clone := score.Clone()
+	assert.True(t, score.Equals(clone))
+
+	ScoreDetails(clone).Reef.Branches[Level4][11] = true
+	clone.Fouls[0].IsMajor = false
+	assert.False(t, ScoreDetails(score).Reef.Branches[Level4][11])
+	assert.True(t, score.Fouls[0].IsMajor)
+	assert.False(t, score.Equals(clone))
+}
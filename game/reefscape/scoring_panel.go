@@ -0,0 +1,236 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Handling of the 2025-specific commands sent by the scoring panels.
+
+package reefscape
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/mitchellh/mapstructure"
+	"strconv"
+)
+
+var endgameStatusNames = map[EndgameStatus]string{
+	EndgameNone:        "none",
+	EndgameParked:      "parked",
+	EndgameShallowCage: "shallow cage",
+	EndgameDeepCage:    "deep cage",
+}
+
+func (Game) HandleScoringCommand(score *game.Score, command string, data any) (bool, error) {
+	details := ScoreDetails(score)
+	scoreChanged := false
+
+	if command == "reef" {
+		args := struct {
+			ReefPosition int
+			ReefLevel    int
+			Current      bool
+			Autonomous   bool
+			Scored       *bool // Sets the branch to the given state instead of toggling it, if present.
+		}{}
+		if err := mapstructure.Decode(data, &args); err != nil {
+			return false, err
+		}
+
+		if args.ReefPosition >= 1 && args.ReefPosition <= 12 && args.ReefLevel >= 2 && args.ReefLevel <= 4 {
+			level := Level(args.ReefLevel - 2)
+			reefIndex := args.ReefPosition - 1
+			if args.Current {
+				details.Reef.Branches[level][reefIndex] =
+					toggleOrSet(details.Reef.Branches[level][reefIndex], args.Scored)
+			}
+			if args.Autonomous {
+				details.Reef.AutoBranches[level][reefIndex] =
+					toggleOrSet(details.Reef.AutoBranches[level][reefIndex], args.Scored)
+			}
+			scoreChanged = true
+		}
+	} else if command == "endgame" {
+		args := struct {
+			TeamPosition  int
+			EndgameStatus int
+		}{}
+		if err := mapstructure.Decode(data, &args); err != nil {
+			return false, err
+		}
+
+		if args.TeamPosition >= 1 && args.TeamPosition <= 3 && args.EndgameStatus >= 0 && args.EndgameStatus <= 3 {
+			details.EndgameStatuses[args.TeamPosition-1] = EndgameStatus(args.EndgameStatus)
+			scoreChanged = true
+		}
+	} else if command == "leave" {
+		args := struct {
+			TeamPosition int
+			LeaveStatus  *bool // Sets the status to the given value instead of toggling it, if present.
+		}{}
+		if err := mapstructure.Decode(data, &args); err != nil {
+			return false, err
+		}
+
+		if args.TeamPosition >= 1 && args.TeamPosition <= 3 {
+			details.LeaveStatuses[args.TeamPosition-1] =
+				toggleOrSet(details.LeaveStatuses[args.TeamPosition-1], args.LeaveStatus)
+			scoreChanged = true
+		}
+	} else {
+		args := struct {
+			Adjustment int
+			Current    bool
+			Autonomous bool
+			NearSide   bool
+		}{}
+		if err := mapstructure.Decode(data, &args); err != nil {
+			return false, err
+		}
+
+		switch command {
+		case "barge":
+			details.BargeAlgae = max(0, details.BargeAlgae+args.Adjustment)
+			scoreChanged = true
+		case "processor":
+			details.ProcessorAlgae = max(0, details.ProcessorAlgae+args.Adjustment)
+			scoreChanged = true
+		case "trough":
+			if args.Current {
+				if args.NearSide {
+					details.Reef.TroughNear = max(0, details.Reef.TroughNear+args.Adjustment)
+				} else {
+					details.Reef.TroughFar = max(0, details.Reef.TroughFar+args.Adjustment)
+				}
+				scoreChanged = true
+			}
+			if args.Autonomous {
+				if args.NearSide {
+					details.Reef.AutoTroughNear = max(0, details.Reef.AutoTroughNear+args.Adjustment)
+				} else {
+					details.Reef.AutoTroughFar = max(0, details.Reef.AutoTroughFar+args.Adjustment)
+				}
+				scoreChanged = true
+			}
+		}
+	}
+
+	return scoreChanged, nil
+}
+
+// Returns the given value if present, or else the inverse of the current one. Setting an explicit value makes the
+// operation idempotent so that it can be safely replayed by a panel that lost its connection.
+func toggleOrSet(current bool, value *bool) bool {
+	if value != nil {
+		return *value
+	}
+	return !current
+}
+
+func (Game) ScoreDiscrepancies(score, otherScore *game.Score, plcEnabled bool) []game.ScoreDiscrepancy {
+	otherParts := scoredParts(ScoreDetails(otherScore))
+	var discrepancies []game.ScoreDiscrepancy
+	for i, part := range scoredParts(ScoreDetails(score)) {
+		if part.fromPlc && plcEnabled {
+			continue
+		}
+		if part.text != otherParts[i].text {
+			discrepancies = append(
+				discrepancies,
+				game.ScoreDiscrepancy{
+					Key:         part.key,
+					Description: fmt.Sprintf("%s: %s vs. %s", part.description, part.text, otherParts[i].text),
+				},
+			)
+		}
+	}
+	return discrepancies
+}
+
+func (Game) CopyScorePart(score, sourceScore *game.Score, key string) {
+	sourceParts := scoredParts(ScoreDetails(sourceScore))
+	for i, part := range scoredParts(ScoreDetails(score)) {
+		if part.key != key {
+			continue
+		}
+		switch value := part.value.(type) {
+		case *bool:
+			*value = *sourceParts[i].value.(*bool)
+		case *int:
+			*value = *sourceParts[i].value.(*int)
+		case *EndgameStatus:
+			*value = *sourceParts[i].value.(*EndgameStatus)
+		}
+		return
+	}
+}
+
+// A part of the score that is entered on the scoring panels, for comparing and copying between the records of
+// redundant scorers.
+type scoredPart struct {
+	key         string
+	description string
+	text        string // Human-readable value of the part.
+	value       any    // Pointer to the part within the score details.
+	fromPlc     bool   // Whether the part is read from the field PLC instead of entered when the PLC is enabled.
+}
+
+// Returns each part of the given score details that is entered on the scoring panels, in display order.
+func scoredParts(details *Score) []scoredPart {
+	leaveText := map[bool]string{false: "no", true: "yes"}
+	scoredText := map[bool]string{false: "not scored", true: "scored"}
+	var parts []scoredPart
+
+	for i := 0; i < 3; i++ {
+		parts = append(
+			parts,
+			scoredPart{
+				key:         fmt.Sprintf("leave%d", i+1),
+				description: fmt.Sprintf("Team %d leave", i+1),
+				text:        leaveText[details.LeaveStatuses[i]],
+				value:       &details.LeaveStatuses[i],
+			},
+		)
+	}
+	for level := Level2; level <= Level4; level++ {
+		for i := 0; i < 12; i++ {
+			parts = append(
+				parts,
+				scoredPart{
+					key:         fmt.Sprintf("autoBranch%dLevel%d", i+1, level+2),
+					description: fmt.Sprintf("Reef branch %d level %d (auto)", i+1, level+2),
+					text:        scoredText[details.Reef.AutoBranches[level][i]],
+					value:       &details.Reef.AutoBranches[level][i],
+				},
+				scoredPart{
+					key:         fmt.Sprintf("branch%dLevel%d", i+1, level+2),
+					description: fmt.Sprintf("Reef branch %d level %d", i+1, level+2),
+					text:        scoredText[details.Reef.Branches[level][i]],
+					value:       &details.Reef.Branches[level][i],
+				},
+			)
+		}
+	}
+	addCount := func(key, description string, value *int, fromPlc bool) {
+		parts = append(
+			parts,
+			scoredPart{key: key, description: description, text: strconv.Itoa(*value), value: value, fromPlc: fromPlc},
+		)
+	}
+	addCount("autoTroughNear", "Near trough coral (auto)", &details.Reef.AutoTroughNear, false)
+	addCount("autoTroughFar", "Far trough coral (auto)", &details.Reef.AutoTroughFar, false)
+	addCount("troughNear", "Near trough coral", &details.Reef.TroughNear, false)
+	addCount("troughFar", "Far trough coral", &details.Reef.TroughFar, false)
+	addCount("bargeAlgae", "Barge algae", &details.BargeAlgae, false)
+	addCount("processorAlgae", "Processor algae", &details.ProcessorAlgae, true)
+	for i := 0; i < 3; i++ {
+		parts = append(
+			parts,
+			scoredPart{
+				key:         fmt.Sprintf("endgame%d", i+1),
+				description: fmt.Sprintf("Team %d endgame", i+1),
+				text:        endgameStatusNames[details.EndgameStatuses[i]],
+				value:       &details.EndgameStatuses[i],
+			},
+		)
+	}
+
+	return parts
+}
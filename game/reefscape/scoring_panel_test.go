@@ -0,0 +1,77 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package reefscape
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestScoreDiscrepancies(t *testing.T) {
+	score := game.NewScore()
+	otherScore := game.NewScore()
+	assert.Empty(t, Game{}.ScoreDiscrepancies(score, otherScore, false))
+
+	// Check that fouls and other non-season-specific fields are ignored.
+	otherScore.Fouls = []game.Foul{{IsMajor: true}}
+	otherScore.RobotsBypassed[1] = true
+	assert.Empty(t, Game{}.ScoreDiscrepancies(score, otherScore, false))
+
+	details := ScoreDetails(score)
+	otherDetails := ScoreDetails(otherScore)
+	details.LeaveStatuses[0] = true
+	details.Reef.AutoBranches[Level3][4] = true
+	details.Reef.Branches[Level3][4] = true
+	otherDetails.Reef.Branches[Level3][4] = true
+	otherDetails.Reef.Branches[Level4][11] = true
+	details.Reef.TroughFar = 2
+	otherDetails.ProcessorAlgae = 3
+	otherDetails.EndgameStatuses[2] = EndgameDeepCage
+	assert.Equal(
+		t,
+		[]game.ScoreDiscrepancy{
+			{Key: "leave1", Description: "Team 1 leave: yes vs. no"},
+			{Key: "autoBranch5Level3", Description: "Reef branch 5 level 3 (auto): scored vs. not scored"},
+			{Key: "branch12Level4", Description: "Reef branch 12 level 4: not scored vs. scored"},
+			{Key: "troughFar", Description: "Far trough coral: 2 vs. 0"},
+			{Key: "processorAlgae", Description: "Processor algae: 0 vs. 3"},
+			{Key: "endgame3", Description: "Team 3 endgame: none vs. deep cage"},
+		},
+		Game{}.ScoreDiscrepancies(score, otherScore, false),
+	)
+
+	// Check that the processor count is left out when it comes from the PLC.
+	discrepancies := Game{}.ScoreDiscrepancies(score, otherScore, true)
+	if assert.Equal(t, 5, len(discrepancies)) {
+		assert.Equal(t, "troughFar", discrepancies[3].Key)
+		assert.Equal(t, "endgame3", discrepancies[4].Key)
+	}
+}
+
+func TestCopyScorePart(t *testing.T) {
+	score := game.NewScore()
+	sourceScore := game.NewScore()
+	details := ScoreDetails(score)
+	sourceDetails := ScoreDetails(sourceScore)
+	details.BargeAlgae = 4
+	sourceDetails.LeaveStatuses[0] = true
+	sourceDetails.Reef.Branches[Level3][4] = true
+	sourceDetails.Reef.TroughFar = 2
+	sourceDetails.BargeAlgae = 1
+	sourceDetails.EndgameStatuses[2] = EndgameDeepCage
+
+	for _, key := range []string{"leave1", "branch5Level3", "troughFar", "endgame3", "invalid"} {
+		Game{}.CopyScorePart(score, sourceScore, key)
+	}
+	assert.Equal(t, true, details.LeaveStatuses[0])
+	assert.Equal(t, true, details.Reef.Branches[Level3][4])
+	assert.Equal(t, 2, details.Reef.TroughFar)
+	assert.Equal(t, EndgameDeepCage, details.EndgameStatuses[2])
+	assert.Equal(t, 4, details.BargeAlgae)
+	assert.Equal(
+		t,
+		[]game.ScoreDiscrepancy{{Key: "bargeAlgae", Description: "Barge algae: 4 vs. 1"}},
+		Game{}.ScoreDiscrepancies(score, sourceScore, false),
+	)
+}
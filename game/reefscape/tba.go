@@ -0,0 +1,145 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Conversion of 2025 scores into the format expected by The Blue Alliance.
+
+package reefscape
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/mitchellh/mapstructure"
+)
+
+type TbaScoreBreakdown struct {
+	AutoLineRobot1          string  `mapstructure:"autoLineRobot1"`
+	AutoLineRobot2          string  `mapstructure:"autoLineRobot2"`
+	AutoLineRobot3          string  `mapstructure:"autoLineRobot3"`
+	AutoMobilityPoints      int     `mapstructure:"autoMobilityPoints"`
+	AutoReef                tbaReef `mapstructure:"autoReef"`
+	AutoCoralCount          int     `mapstructure:"autoCoralCount"`
+	AutoCoralPoints         int     `mapstructure:"autoCoralPoints"`
+	AutoPoints              int     `mapstructure:"autoPoints"`
+	TeleopReef              tbaReef `mapstructure:"teleopReef"`
+	TeleopCoralCount        int     `mapstructure:"teleopCoralCount"`
+	TeleopCoralPoints       int     `mapstructure:"teleopCoralPoints"`
+	NetAlgaeCount           int     `mapstructure:"netAlgaeCount"`
+	WallAlgaeCount          int     `mapstructure:"wallAlgaeCount"`
+	AlgaePoints             int     `mapstructure:"algaePoints"`
+	EndGameRobot1           string  `mapstructure:"endGameRobot1"`
+	EndGameRobot2           string  `mapstructure:"endGameRobot2"`
+	EndGameRobot3           string  `mapstructure:"endGameRobot3"`
+	EndGameBargePoints      int     `mapstructure:"endGameBargePoints"`
+	TeleopPoints            int     `mapstructure:"teleopPoints"`
+	CoopertitionCriteriaMet bool    `mapstructure:"coopertitionCriteriaMet"`
+	AutoBonusAchieved       bool    `mapstructure:"autoBonusAchieved"`
+	CoralBonusAchieved      bool    `mapstructure:"coralBonusAchieved"`
+	BargeBonusAchieved      bool    `mapstructure:"bargeBonusAchieved"`
+	FoulCount               int     `mapstructure:"foulCount"`
+	TechFoulCount           int     `mapstructure:"techFoulCount"`
+	G206Penalty             bool    `mapstructure:"g206Penalty"`
+	G410Penalty             bool    `mapstructure:"g410Penalty"`
+	G418Penalty             bool    `mapstructure:"g418Penalty"`
+	G428Penalty             bool    `mapstructure:"g428Penalty"`
+}
+
+type tbaReef struct {
+	BotRow         map[string]bool `mapstructure:"botRow"`
+	MidRow         map[string]bool `mapstructure:"midRow"`
+	TopRow         map[string]bool `mapstructure:"topRow"`
+	TbaBotRowCount int             `mapstructure:"tba_botRowCount"`
+	TbaMidRowCount int             `mapstructure:"tba_midRowCount"`
+	TbaTopRowCount int             `mapstructure:"tba_topRowCount"`
+	Trough         int             `mapstructure:"trough"`
+}
+
+var leaveMapping = map[bool]string{false: "No", true: "Yes"}
+var endGameStatusMapping = map[EndgameStatus]string{
+	EndgameNone:        "None",
+	EndgameParked:      "Parked",
+	EndgameShallowCage: "ShallowCage",
+	EndgameDeepCage:    "DeepCage",
+}
+
+func (Game) TbaScoreBreakdown(score *game.Score, scoreSummary *game.ScoreSummary) map[string]any {
+	var breakdown TbaScoreBreakdown
+	details := ScoreDetails(score)
+	summaryDetails := ScoreSummaryDetails(scoreSummary)
+
+	breakdown.AutoLineRobot1 = leaveMapping[details.LeaveStatuses[0]]
+	breakdown.AutoLineRobot2 = leaveMapping[details.LeaveStatuses[1]]
+	breakdown.AutoLineRobot3 = leaveMapping[details.LeaveStatuses[2]]
+	breakdown.AutoMobilityPoints = summaryDetails.LeavePoints
+	breakdown.AutoReef.BotRow = make(map[string]bool)
+	breakdown.AutoReef.MidRow = make(map[string]bool)
+	breakdown.AutoReef.TopRow = make(map[string]bool)
+	for i := 0; i < 12; i++ {
+		breakdown.AutoReef.BotRow["node"+string(rune('A'+i))] = details.Reef.AutoBranches[Level2][i]
+		breakdown.AutoReef.MidRow["node"+string(rune('A'+i))] = details.Reef.AutoBranches[Level3][i]
+		breakdown.AutoReef.TopRow["node"+string(rune('A'+i))] = details.Reef.AutoBranches[Level4][i]
+	}
+	breakdown.AutoReef.TbaBotRowCount = details.Reef.CountCoralByLevelAndPeriod(Level2, true)
+	breakdown.AutoReef.TbaMidRowCount = details.Reef.CountCoralByLevelAndPeriod(Level3, true)
+	breakdown.AutoReef.TbaTopRowCount = details.Reef.CountCoralByLevelAndPeriod(Level4, true)
+	breakdown.AutoReef.Trough = details.Reef.CountCoralByLevelAndPeriod(Level1, true)
+	breakdown.AutoCoralCount = details.Reef.AutoCoralCount()
+	breakdown.AutoCoralPoints = details.Reef.AutoCoralPoints()
+	breakdown.AutoPoints = summaryDetails.AutoPoints
+	breakdown.TeleopReef.BotRow = make(map[string]bool)
+	breakdown.TeleopReef.MidRow = make(map[string]bool)
+	breakdown.TeleopReef.TopRow = make(map[string]bool)
+	for i := 0; i < 12; i++ {
+		breakdown.TeleopReef.BotRow["node"+string(rune('A'+i))] = details.Reef.Branches[Level2][i]
+		breakdown.TeleopReef.MidRow["node"+string(rune('A'+i))] = details.Reef.Branches[Level3][i]
+		breakdown.TeleopReef.TopRow["node"+string(rune('A'+i))] = details.Reef.Branches[Level4][i]
+	}
+	breakdown.TeleopReef.TbaBotRowCount = breakdown.AutoReef.TbaBotRowCount +
+		details.Reef.CountCoralByLevelAndPeriod(Level2, false)
+	breakdown.TeleopReef.TbaMidRowCount = breakdown.AutoReef.TbaMidRowCount +
+		details.Reef.CountCoralByLevelAndPeriod(Level3, false)
+	breakdown.TeleopReef.TbaTopRowCount = breakdown.AutoReef.TbaTopRowCount +
+		details.Reef.CountCoralByLevelAndPeriod(Level4, false)
+	breakdown.TeleopReef.Trough = details.Reef.CountCoralByLevelAndPeriod(Level1, false)
+	breakdown.TeleopCoralCount = details.Reef.TeleopCoralCount()
+	teleopCoralPoints := details.Reef.TeleopCoralPoints()
+	breakdown.TeleopCoralPoints = teleopCoralPoints
+	breakdown.NetAlgaeCount = details.BargeAlgae
+	breakdown.WallAlgaeCount = details.ProcessorAlgae
+	breakdown.AlgaePoints = summaryDetails.AlgaePoints
+	breakdown.EndGameRobot1 = endGameStatusMapping[details.EndgameStatuses[0]]
+	breakdown.EndGameRobot2 = endGameStatusMapping[details.EndgameStatuses[1]]
+	breakdown.EndGameRobot3 = endGameStatusMapping[details.EndgameStatuses[2]]
+	breakdown.EndGameBargePoints = summaryDetails.BargePoints
+	breakdown.TeleopPoints = teleopCoralPoints + summaryDetails.AlgaePoints + summaryDetails.BargePoints
+	breakdown.CoopertitionCriteriaMet = summaryDetails.CoopertitionCriteriaMet
+	breakdown.AutoBonusAchieved = summaryDetails.AutoBonusRankingPoint
+	breakdown.CoralBonusAchieved = summaryDetails.CoralBonusRankingPoint
+	breakdown.BargeBonusAchieved = summaryDetails.BargeBonusRankingPoint
+	for _, foul := range score.Fouls {
+		if foul.IsMajor {
+			breakdown.TechFoulCount++
+		} else if foul.PointValue() > 0 {
+			breakdown.FoulCount++
+		}
+		if foul.Rule() != nil && foul.Rule().IsRankingPoint {
+			switch foul.Rule().RuleNumber {
+			case "G206":
+				breakdown.G206Penalty = true
+			case "G410":
+				breakdown.G410Penalty = true
+			case "G418":
+				breakdown.G418Penalty = true
+			case "G428":
+				breakdown.G428Penalty = true
+			}
+		}
+	}
+
+	// Turn the breakdown struct into a map in order to be able to remove any fields that are disabled based on the
+	// event settings.
+	breakdownMap := make(map[string]any)
+	_ = mapstructure.Decode(breakdown, &breakdownMap)
+	if !CoralBonusCoopEnabled {
+		delete(breakdownMap, "coopertitionCriteriaMet")
+	}
+
+	return breakdownMap
+}
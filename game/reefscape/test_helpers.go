@@ -0,0 +1,94 @@
+// Copyright 2017 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Helper methods for use in tests in this package and others.
+
+package reefscape
+
+import "github.com/Team254/cheesy-arena/game"
+
+func TestScore1() *game.Score {
+	fouls := []game.Foul{
+		{IsMajor: true, TeamId: 25, RuleId: 16},
+		{IsMajor: false, TeamId: 1868, RuleId: 13},
+		{IsMajor: false, TeamId: 1868, RuleId: 13},
+		{IsMajor: true, TeamId: 25, RuleId: 15},
+		{IsMajor: true, TeamId: 25, RuleId: 15},
+		{IsMajor: true, TeamId: 25, RuleId: 15},
+		{IsMajor: true, TeamId: 25, RuleId: 15},
+	}
+	return &game.Score{
+		RobotsBypassed: [3]bool{false, false, true},
+		Fouls:          fouls,
+		PlayoffDq:      false,
+		Details: &Score{
+			LeaveStatuses: [3]bool{true, true, false},
+			Reef: Reef{
+				AutoBranches:   [3][12]bool{{true}},
+				Branches:       [3][12]bool{{true, true}, {true, true, true}},
+				AutoTroughNear: 0,
+				AutoTroughFar:  1,
+				TroughNear:     3,
+				TroughFar:      4,
+			},
+			BargeAlgae:      7,
+			ProcessorAlgae:  2,
+			EndgameStatuses: [3]EndgameStatus{EndgameParked, EndgameNone, EndgameDeepCage},
+		},
+	}
+}
+
+func TestScore2() *game.Score {
+	return &game.Score{
+		RobotsBypassed: [3]bool{false, false, false},
+		Fouls:          []game.Foul{},
+		PlayoffDq:      false,
+		Details: &Score{
+			LeaveStatuses: [3]bool{false, true, false},
+			Reef: Reef{
+				AutoBranches:   [3][12]bool{{}, {}, {true, true, true, true}},
+				Branches:       [3][12]bool{{true, true, true}, {true, true, true, true, true}, {true, true, true}},
+				AutoTroughNear: 2,
+				AutoTroughFar:  1,
+				TroughNear:     10,
+				TroughFar:      5,
+			},
+			BargeAlgae:      9,
+			ProcessorAlgae:  1,
+			EndgameStatuses: [3]EndgameStatus{EndgameDeepCage, EndgameShallowCage, EndgameShallowCage},
+		},
+	}
+}
+
+func TestRanking1() *game.Ranking {
+	return &game.Ranking{
+		TeamId: 254,
+		Rank:   1,
+		RankingFields: game.RankingFields{
+			RankingPoints: 20,
+			Random:        0.254,
+			Wins:          3,
+			Losses:        2,
+			Ties:          1,
+			Played:        10,
+			Details:       &RankingFields{625, 90, 554, 12},
+		},
+	}
+}
+
+func TestRanking2() *game.Ranking {
+	return &game.Ranking{
+		TeamId:       1114,
+		Rank:         2,
+		PreviousRank: 1,
+		RankingFields: game.RankingFields{
+			RankingPoints: 18,
+			Random:        0.1114,
+			Wins:          1,
+			Losses:        3,
+			Ties:          2,
+			Played:        10,
+			Details:       &RankingFields{700, 625, 90, 23},
+		},
+	}
+}
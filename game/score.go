@@ -5,170 +5,98 @@
 
 package game
 
+import (
+	"encoding/json"
+	"reflect"
+)
+
 type Score struct {
-	RobotsBypassed  [3]bool
-	LeaveStatuses   [3]bool
-	Reef            Reef
-	BargeAlgae      int
-	ProcessorAlgae  int
-	EndgameStatuses [3]EndgameStatus
-	Fouls           []Foul
-	PlayoffDq       bool
+	RobotsBypassed [3]bool
+	Fouls          []Foul
+	PlayoffDq      bool
+	Details        ScoreDetails `json:"-"`
 }
 
-// Game-specific settings that can be changed via the settings.
-var AutoBonusCoralThreshold = 1
-var CoralBonusPerLevelThreshold = 7
-var CoralBonusCoopEnabled = true
-var BargeBonusPointThreshold = 16
+// ScoreDiscrepancy describes a part of the score that two redundant scorers have recorded differently.
+type ScoreDiscrepancy struct {
+	// Identifies the part of the score, for copying it from one score to another.
+	Key string
 
-// Represents the state of a robot at the end of the match.
-type EndgameStatus int
+	// Human-readable name of the part along with the two recorded values.
+	Description string
+}
 
-const (
-	EndgameNone EndgameStatus = iota
-	EndgameParked
-	EndgameShallowCage
-	EndgameDeepCage
-)
+// ScoreDetails is the season-specific portion of a score, as defined by the current game.
+type ScoreDetails interface {
+	// Clone returns a deep copy of the details.
+	Clone() ScoreDetails
+}
+
+// NewScore returns an empty score for the current game.
+func NewScore() *Score {
+	return &Score{Details: CurrentGame.NewScoreDetails()}
+}
 
 // Summarize calculates and returns the summary fields used for ranking and display.
 func (score *Score) Summarize(opponentScore *Score) *ScoreSummary {
-	summary := new(ScoreSummary)
-
-	// Leave the score at zero if the alliance was disqualified.
-	if score.PlayoffDq {
-		return summary
-	}
+	return CurrentGame.Summarize(score, opponentScore)
+}
 
-	// Calculate autonomous period points.
-	for _, status := range score.LeaveStatuses {
-		if status {
-			summary.LeavePoints += 3
-		}
+// Equals returns true if and only if all fields of the two scores are equal.
+func (score *Score) Equals(other *Score) bool {
+	details, otherDetails := score.Details, other.Details
+	if details == nil {
+		details = CurrentGame.NewScoreDetails()
 	}
-	autoCoralPoints := score.Reef.AutoCoralPoints()
-	summary.AutoPoints = summary.LeavePoints + autoCoralPoints
-
-	summary.NumCoral = score.Reef.AutoCoralCount() + score.Reef.TeleopCoralCount()
-	summary.CoralPoints = autoCoralPoints + score.Reef.TeleopCoralPoints()
-	summary.NumAlgae = score.BargeAlgae + score.ProcessorAlgae
-	summary.AlgaePoints = 4*score.BargeAlgae + 6*score.ProcessorAlgae
-
-	// Calculate endgame points.
-	for _, status := range score.EndgameStatuses {
-		switch status {
-		case EndgameParked:
-			summary.BargePoints += 2
-		case EndgameShallowCage:
-			summary.BargePoints += 6
-		case EndgameDeepCage:
-			summary.BargePoints += 12
-		default:
-		}
+	if otherDetails == nil {
+		otherDetails = CurrentGame.NewScoreDetails()
 	}
-
-	summary.MatchPoints = summary.LeavePoints + summary.CoralPoints + summary.AlgaePoints + summary.BargePoints
-
-	// Calculate penalty points.
-	for _, foul := range opponentScore.Fouls {
-		summary.FoulPoints += foul.PointValue()
-		// Store the number of major fouls since it is used to break ties in playoffs.
-		if foul.IsMajor {
-			summary.NumOpponentMajorFouls++
-		}
-
-		rule := foul.Rule()
-		if rule != nil {
-			// Check for the opponent fouls that automatically trigger a ranking point.
-			if rule.IsRankingPoint {
-				switch rule.RuleNumber {
-				case "G410":
-					summary.CoralBonusRankingPoint = true
-				case "G418":
-					summary.BargeBonusRankingPoint = true
-				case "G428":
-					summary.BargeBonusRankingPoint = true
-				}
-			}
-		}
+	if score.RobotsBypassed != other.RobotsBypassed ||
+		score.PlayoffDq != other.PlayoffDq ||
+		len(score.Fouls) != len(other.Fouls) ||
+		!reflect.DeepEqual(details, otherDetails) {
+		return false
 	}
 
-	summary.Score = summary.MatchPoints + summary.FoulPoints
-
-	// Calculate bonus ranking points.
-	// Autonomous bonus ranking point.
-	allRobotsLeft := true
-	for i, left := range score.LeaveStatuses {
-		if !left && !score.RobotsBypassed[i] {
-			allRobotsLeft = false
-			break
+	for i, foul := range score.Fouls {
+		if foul != other.Fouls[i] {
+			return false
 		}
 	}
-	if allRobotsLeft && score.Reef.isAutoBonusCoralThresholdMet() {
-		summary.AutoBonusRankingPoint = true
-	}
 
-	// Coral bonus ranking point.
-	summary.NumCoralLevels = score.Reef.countCoralBonusSatisfiedLevels()
-	summary.NumCoralLevelsGoal = 4
-	if CoralBonusCoopEnabled {
-		summary.CoopertitionCriteriaMet = score.ProcessorAlgae >= 2
-		summary.CoopertitionBonus = summary.CoopertitionCriteriaMet && opponentScore.ProcessorAlgae >= 2
-		if summary.CoopertitionBonus {
-			summary.NumCoralLevelsGoal = 3
-		}
-	}
-	if summary.NumCoralLevels >= summary.NumCoralLevelsGoal {
-		summary.CoralBonusRankingPoint = true
-	}
+	return true
+}
 
-	// Barge bonus ranking point.
-	if summary.BargePoints >= BargeBonusPointThreshold {
-		summary.BargeBonusRankingPoint = true
+// Clone returns a deep copy of the score which can be modified independently of the original.
+func (score *Score) Clone() *Score {
+	clone := *score
+	if score.Fouls != nil {
+		clone.Fouls = append([]Foul{}, score.Fouls...)
 	}
-
-	// Check for G206 violation.
-	for _, foul := range score.Fouls {
-		if foul.Rule() != nil && foul.Rule().RuleNumber == "G206" {
-			summary.CoralBonusRankingPoint = false
-			summary.BargeBonusRankingPoint = false
-			break
-		}
+	if score.Details != nil {
+		clone.Details = score.Details.Clone()
 	}
+	return &clone
+}
 
-	// Add up the bonus ranking points.
-	if summary.AutoBonusRankingPoint {
-		summary.BonusRankingPoints++
-	}
-	if summary.CoralBonusRankingPoint {
-		summary.BonusRankingPoints++
-	}
-	if summary.BargeBonusRankingPoint {
-		summary.BonusRankingPoints++
+// MarshalJSON serializes the season-specific details inline with the common fields.
+func (score Score) MarshalJSON() ([]byte, error) {
+	type scoreAlias Score
+	details := score.Details
+	if details == nil {
+		details = CurrentGame.NewScoreDetails()
 	}
-
-	return summary
+	return marshalWithDetails(scoreAlias(score), details)
 }
 
-// Equals returns true if and only if all fields of the two scores are equal.
-func (score *Score) Equals(other *Score) bool {
-	if score.RobotsBypassed != other.RobotsBypassed ||
-		score.LeaveStatuses != other.LeaveStatuses ||
-		score.Reef != other.Reef ||
-		score.BargeAlgae != other.BargeAlgae ||
-		score.ProcessorAlgae != other.ProcessorAlgae ||
-		score.EndgameStatuses != other.EndgameStatuses ||
-		score.PlayoffDq != other.PlayoffDq ||
-		len(score.Fouls) != len(other.Fouls) {
-		return false
+// UnmarshalJSON deserializes the season-specific details from the same object as the common fields.
+func (score *Score) UnmarshalJSON(data []byte) error {
+	type scoreAlias Score
+	if err := json.Unmarshal(data, (*scoreAlias)(score)); err != nil {
+		return err
 	}
-
-	for i, foul := range score.Fouls {
-		if foul != other.Fouls[i] {
-			return false
-		}
+	if score.Details == nil {
+		score.Details = CurrentGame.NewScoreDetails()
 	}
-
-	return true
+	return json.Unmarshal(data, score.Details)
 }
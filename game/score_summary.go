@@ -6,25 +6,26 @@
 package game
 
 type ScoreSummary struct {
-	LeavePoints             int
-	AutoPoints              int
-	NumCoral                int
-	CoralPoints             int
-	NumAlgae                int
-	AlgaePoints             int
-	BargePoints             int
-	MatchPoints             int
-	FoulPoints              int
-	Score                   int
-	CoopertitionCriteriaMet bool
-	CoopertitionBonus       bool
-	NumCoralLevels          int
-	NumCoralLevelsGoal      int
-	AutoBonusRankingPoint   bool
-	CoralBonusRankingPoint  bool
-	BargeBonusRankingPoint  bool
-	BonusRankingPoints      int
-	NumOpponentMajorFouls   int
+	MatchPoints        int
+	FoulPoints         int
+	Score              int
+	BonusRankingPoints int
+	Details            any `json:"-"`
+}
+
+// MarshalJSON serializes the season-specific details inline with the common fields.
+func (summary ScoreSummary) MarshalJSON() ([]byte, error) {
+	type scoreSummaryAlias ScoreSummary
+	return marshalWithDetails(scoreSummaryAlias(summary), summary.Details)
+}
+
+// ScoreBreakdownItem is a labelled component of an alliance's posted score.
+type ScoreBreakdownItem struct {
+	Label string
+	Value string
+
+	// Whether the item is a bonus ranking point, which is only shown for matches that count towards the rankings.
+	IsRankingPoint bool
 }
 
 type MatchStatus int
@@ -43,30 +44,20 @@ func (t MatchStatus) Get() MatchStatus {
 
 // Determines the winner of the match given the score summaries for both alliances.
 func DetermineMatchStatus(redScoreSummary, blueScoreSummary *ScoreSummary, applyPlayoffTiebreakers bool) MatchStatus {
-	if status := comparePoints(redScoreSummary.Score, blueScoreSummary.Score); status != TieMatch {
+	if status := ComparePoints(redScoreSummary.Score, blueScoreSummary.Score); status != TieMatch {
 		return status
 	}
 
 	if applyPlayoffTiebreakers {
 		// Check scoring breakdowns to resolve playoff ties.
-		if status := comparePoints(
-			redScoreSummary.NumOpponentMajorFouls, blueScoreSummary.NumOpponentMajorFouls,
-		); status != TieMatch {
-			return status
-		}
-		if status := comparePoints(redScoreSummary.AutoPoints, blueScoreSummary.AutoPoints); status != TieMatch {
-			return status
-		}
-		if status := comparePoints(redScoreSummary.BargePoints, blueScoreSummary.BargePoints); status != TieMatch {
-			return status
-		}
+		return CurrentGame.ComparePlayoffTiebreakers(redScoreSummary, blueScoreSummary)
 	}
 
 	return TieMatch
 }
 
-// Helper method to compare the red and blue alliance point totals and return the appropriate MatchStatus.
-func comparePoints(redPoints, bluePoints int) MatchStatus {
+// ComparePoints compares the red and blue alliance point totals and returns the appropriate MatchStatus.
+func ComparePoints(redPoints, bluePoints int) MatchStatus {
 	if redPoints > bluePoints {
 		return RedWonMatch
 	}
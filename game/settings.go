@@ -0,0 +1,66 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Season-agnostic representation of the game's adjustable rule parameters and of the field hardware it controls.
+
+package game
+
+// Setting describes an event-level parameter of the game's rules that can be adjusted on the settings page. Values
+// are stored as integers; boolean settings use 1 for true and 0 for false.
+type Setting struct {
+	Key     string
+	Label   string
+	IsBool  bool
+	Default int
+}
+
+// Value returns the value of the setting from the given map, or its default if it is not present.
+func (setting *Setting) Value(values map[string]int) int {
+	if value, ok := values[setting.Key]; ok {
+		return value
+	}
+	return setting.Default
+}
+
+// DefaultSettings returns the default value of each of the current game's settings, keyed by setting key.
+func DefaultSettings() map[string]int {
+	values := make(map[string]int)
+	for _, setting := range CurrentGame.Settings() {
+		values[setting.Key] = setting.Default
+	}
+	return values
+}
+
+// FieldIo is the view of the field PLC given to the game, which addresses the PLC's built-in and custom signals by the
+// names used in its I/O map.
+type FieldIo interface {
+	GetInput(name string) bool
+	GetRegister(name string) int
+	SetCoil(name string, state bool)
+}
+
+// FieldState describes the progress of the match, for the game to decide what to read from and show on the field.
+type FieldState struct {
+	// Whether the match is in the autonomous, pause or teleoperated period.
+	InMatch bool
+
+	// Whether the match has ended but scoring elements are still allowed to count.
+	InGracePeriod bool
+
+	// The number of seconds since the start of the match, including the warmup period.
+	MatchTimeSec float64
+
+	// Whether the match is a playoff match.
+	IsPlayoff bool
+}
+
+// TeamSignStatus holds the game-specific parts of the in-match text on the rear of an alliance's team signs.
+type TeamSignStatus struct {
+	// The running score to show, which may leave out points that are only settled at the end of the match.
+	Score int
+
+	// The alliance's progress towards a bonus ranking point, or empty if not applicable.
+	Progress string
+
+	// Counts of the scored game elements, for the timer display.
+	TimerText string
+}
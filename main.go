@@ -7,7 +7,11 @@
 package main
 
 import (
+	"flag"
 	"github.com/Team254/cheesy-arena/field"
+	_ "github.com/Team254/cheesy-arena/game/reefscape" // Registers the game being played.
+	"github.com/Team254/cheesy-arena/plc"
+	"github.com/Team254/cheesy-arena/simulator"
 	"github.com/Team254/cheesy-arena/web"
 	"log"
 )
@@ -17,15 +21,75 @@ const httpPort = 8080
 
 // Main entry point for the application.
 func main() {
+	simulateDriverStations := flag.Bool(
+		"simulate-driver-stations", false, "simulate a driver station and robot for each team in the current match",
+	)
+	simConfig := simulator.DefaultConfig()
+	flag.Float64Var(
+		&simConfig.LinkDropsPerMinute, "sim-link-drops", 0, "simulated robot link drops per minute of enabled time",
+	)
+	flag.Float64Var(
+		&simConfig.LinkDropDurationSec, "sim-link-drop-duration", simConfig.LinkDropDurationSec,
+		"duration of each simulated robot link drop in seconds",
+	)
+	flag.Float64Var(
+		&simConfig.BatterySagVoltsPerMinute, "sim-battery-sag", 0,
+		"simulated battery voltage lost per minute of enabled time",
+	)
+	flag.Float64Var(&simConfig.EStopsPerMinute, "sim-estops", 0, "simulated DS E-stops per minute of enabled time")
+	swappedStations := flag.String(
+		"sim-swap-stations", "",
+		"comma-separated pairs of stations (e.g. R1-R2) whose simulated driver stations are plugged into each "+
+			"other's cables; requires the teams' 10.TE.AM.5 addresses to be configured on this host",
+	)
+	plcEmulatorAddress := flag.String(
+		"emulate-plc", "",
+		"address (e.g. 127.0.0.1:5020) on which to serve an emulated field PLC over Modbus TCP; set the PLC address "+
+			"in the settings to the same value to use it",
+	)
+	flag.Parse()
+
 	arena, err := field.NewArena(eventDbPath)
 	if err != nil {
 		log.Fatalln("Error during startup: ", err)
 	}
 
+	if *plcEmulatorAddress != "" {
+		emulator := plc.NewEmulator(arena.Plc.GetIoMap())
+		if err = emulator.Listen(*plcEmulatorAddress); err != nil {
+			log.Fatalln("Error starting PLC emulator: ", err)
+		}
+		log.Printf("Serving emulated field PLC at %s", emulator.Address())
+		go emulator.Serve()
+	}
+
 	// Start the web server in a separate goroutine.
 	web := web.NewWeb(arena)
 	go web.ServeWebInterface(httpPort)
 
+	if *simulateDriverStations {
+		simConfig.SwappedStations, err = simulator.ParseSwappedStations(*swappedStations)
+		if err != nil {
+			log.Fatalln("Error parsing simulated station swaps: ", err)
+		}
+		if arena.EventSettings.UseLiteUdpPort {
+			simConfig.UdpControlPort = 1120
+		}
+		fleet := simulator.NewFleet(
+			simConfig,
+			func() map[string]int {
+				teams := make(map[string]int)
+				for station, allianceStation := range arena.AllianceStations {
+					if allianceStation.Team != nil {
+						teams[station] = allianceStation.Team.Id
+					}
+				}
+				return teams
+			},
+		)
+		go fleet.Run()
+	}
+
 	// Run the arena state machine in the main thread.
 	arena.Run()
 }
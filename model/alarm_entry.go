@@ -0,0 +1,45 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Model and datastore CRUD methods for the history of alarms raised against field components.
+
+package model
+
+import "time"
+
+type AlarmEntry struct {
+	Id             int `db:"id"`
+	Component      string
+	Description    string
+	Critical       bool
+	RaisedAt       time.Time
+	ClearedAt      time.Time
+	AcknowledgedAt time.Time
+	AcknowledgedBy string
+}
+
+func (database *Database) CreateAlarmEntry(alarmEntry *AlarmEntry) error {
+	return database.alarmEntryTable.create(alarmEntry)
+}
+
+func (database *Database) UpdateAlarmEntry(alarmEntry *AlarmEntry) error {
+	return database.alarmEntryTable.update(alarmEntry)
+}
+
+func (database *Database) TruncateAlarmEntries() error {
+	return database.alarmEntryTable.truncate()
+}
+
+// Returns all alarm entries in the order in which they were raised.
+func (database *Database) GetAllAlarmEntries() ([]AlarmEntry, error) {
+	return database.alarmEntryTable.getAll()
+}
+
+// Returns true if the condition that raised the alarm has not yet cleared.
+func (alarmEntry *AlarmEntry) IsActive() bool {
+	return alarmEntry.ClearedAt.IsZero()
+}
+
+// Returns true if someone has acknowledged the alarm.
+func (alarmEntry *AlarmEntry) IsAcknowledged() bool {
+	return !alarmEntry.AcknowledgedAt.IsZero()
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAlarmEntryCrud(t *testing.T) {
+	db := setupTestDb(t)
+
+	alarmEntries, err := db.GetAllAlarmEntries()
+	assert.Nil(t, err)
+	assert.Empty(t, alarmEntries)
+
+	alarmEntry1 := AlarmEntry{Component: "plc", Description: "PLC is not responding", RaisedAt: time.Unix(1000, 0).UTC()}
+	alarmEntry2 := AlarmEntry{
+		Component:   "accessPoint",
+		Description: "Access point status is ERROR",
+		Critical:    true,
+		RaisedAt:    time.Unix(2000, 0).UTC(),
+	}
+	assert.Nil(t, db.CreateAlarmEntry(&alarmEntry1))
+	assert.Nil(t, db.CreateAlarmEntry(&alarmEntry2))
+	assert.True(t, alarmEntry1.IsActive())
+	assert.False(t, alarmEntry1.IsAcknowledged())
+
+	alarmEntry1.ClearedAt = time.Unix(1500, 0).UTC()
+	alarmEntry1.AcknowledgedAt = time.Unix(1200, 0).UTC()
+	alarmEntry1.AcknowledgedBy = "fta"
+	assert.Nil(t, db.UpdateAlarmEntry(&alarmEntry1))
+	alarmEntries, err = db.GetAllAlarmEntries()
+	assert.Nil(t, err)
+	assert.Equal(t, []AlarmEntry{alarmEntry1, alarmEntry2}, alarmEntries)
+	assert.False(t, alarmEntries[0].IsActive())
+	assert.True(t, alarmEntries[0].IsAcknowledged())
+
+	assert.Nil(t, db.TruncateAlarmEntries())
+	alarmEntries, err = db.GetAllAlarmEntries()
+	assert.Nil(t, err)
+	assert.Empty(t, alarmEntries)
+}
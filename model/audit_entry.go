@@ -0,0 +1,70 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Model and datastore CRUD methods for an audit log entry recording a scoring or match control action.
+
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+type AuditEntry struct {
+	Id       int `db:"id"`
+	Time     time.Time
+	Username string
+	Source   string
+	Position string
+	MatchId  int
+	Command  string
+	Before   string
+	After    string
+}
+
+// Criteria for narrowing down the list of audit entries; zero-valued fields are not filtered on.
+type AuditEntryFilter struct {
+	MatchId  int
+	Source   string
+	Username string
+	Command  string
+}
+
+func (database *Database) CreateAuditEntry(auditEntry *AuditEntry) error {
+	return database.auditEntryTable.create(auditEntry)
+}
+
+func (database *Database) TruncateAuditEntries() error {
+	return database.auditEntryTable.truncate()
+}
+
+// Returns all audit entries matching the given filter, in chronological order.
+func (database *Database) GetAuditEntries(filter AuditEntryFilter) ([]AuditEntry, error) {
+	auditEntries, err := database.auditEntryTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var matchingAuditEntries []AuditEntry
+	for _, auditEntry := range auditEntries {
+		if filter.matches(&auditEntry) {
+			matchingAuditEntries = append(matchingAuditEntries, auditEntry)
+		}
+	}
+	return matchingAuditEntries, nil
+}
+
+func (filter *AuditEntryFilter) matches(auditEntry *AuditEntry) bool {
+	if filter.MatchId != 0 && auditEntry.MatchId != filter.MatchId {
+		return false
+	}
+	if filter.Source != "" && auditEntry.Source != filter.Source {
+		return false
+	}
+	if filter.Username != "" && !strings.EqualFold(auditEntry.Username, filter.Username) {
+		return false
+	}
+	if filter.Command != "" && auditEntry.Command != filter.Command {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAuditEntryCrud(t *testing.T) {
+	db := setupTestDb(t)
+
+	auditEntries, err := db.GetAuditEntries(AuditEntryFilter{})
+	assert.Nil(t, err)
+	assert.Empty(t, auditEntries)
+
+	auditEntry1 := AuditEntry{
+		Time:     time.Unix(1000, 0).UTC(),
+		Username: "scorer1",
+		Source:   "scoring_panel",
+		Position: "red_near",
+		MatchId:  12,
+		Command:  "barge",
+		Before:   "{\"BargeAlgae\":2}",
+		After:    "{\"BargeAlgae\":3}",
+	}
+	auditEntry2 := AuditEntry{
+		Time:     time.Unix(2000, 0).UTC(),
+		Username: "hr",
+		Source:   "referee_panel",
+		MatchId:  12,
+		Command:  "addFoul",
+		Before:   "{}",
+		After:    "{}",
+	}
+	auditEntry3 := AuditEntry{
+		Time:     time.Unix(3000, 0).UTC(),
+		Username: "scorer1",
+		Source:   "scoring_panel",
+		Position: "blue_far",
+		MatchId:  13,
+		Command:  "barge",
+		Before:   "{}",
+		After:    "{}",
+	}
+	assert.Nil(t, db.CreateAuditEntry(&auditEntry1))
+	assert.Nil(t, db.CreateAuditEntry(&auditEntry2))
+	assert.Nil(t, db.CreateAuditEntry(&auditEntry3))
+
+	auditEntries, err = db.GetAuditEntries(AuditEntryFilter{})
+	assert.Nil(t, err)
+	assert.Equal(t, []AuditEntry{auditEntry1, auditEntry2, auditEntry3}, auditEntries)
+
+	auditEntries, err = db.GetAuditEntries(AuditEntryFilter{MatchId: 12})
+	assert.Nil(t, err)
+	assert.Equal(t, []AuditEntry{auditEntry1, auditEntry2}, auditEntries)
+	auditEntries, err = db.GetAuditEntries(AuditEntryFilter{Source: "scoring_panel", Username: "Scorer1"})
+	assert.Nil(t, err)
+	assert.Equal(t, []AuditEntry{auditEntry1, auditEntry3}, auditEntries)
+	auditEntries, err = db.GetAuditEntries(AuditEntryFilter{MatchId: 13, Command: "barge"})
+	assert.Nil(t, err)
+	assert.Equal(t, []AuditEntry{auditEntry3}, auditEntries)
+	auditEntries, err = db.GetAuditEntries(AuditEntryFilter{Command: "blorpy"})
+	assert.Nil(t, err)
+	assert.Empty(t, auditEntries)
+
+	assert.Nil(t, db.TruncateAuditEntries())
+	auditEntries, err = db.GetAuditEntries(AuditEntryFilter{})
+	assert.Nil(t, err)
+	assert.Empty(t, auditEntries)
+}
@@ -21,21 +21,28 @@ const backupsDir = "db/backups"
 var BaseDir = "." // Mutable for testing
 
 type Database struct {
-	Path                string
-	bolt                *bbolt.DB
-	allianceTable       *table[Alliance]
-	awardTable          *table[Award]
-	eventSettingsTable  *table[EventSettings]
-	judgingSlotTable    *table[JudgingSlot]
-	lowerThirdTable     *table[LowerThird]
-	matchTable          *table[Match]
-	matchResultTable    *table[MatchResult]
-	rankingTable        *table[game.Ranking]
-	scheduleBlockTable  *table[ScheduleBlock]
-	scheduledBreakTable *table[ScheduledBreak]
-	sponsorSlideTable   *table[SponsorSlide]
-	teamTable           *table[Team]
-	userSessionTable    *table[UserSession]
+	Path                  string
+	bolt                  *bbolt.DB
+	alarmEntryTable       *table[AlarmEntry]
+	allianceTable         *table[Alliance]
+	auditEntryTable       *table[AuditEntry]
+	awardTable            *table[Award]
+	eventSettingsTable    *table[EventSettings]
+	judgingSlotTable      *table[JudgingSlot]
+	lowerThirdTable       *table[LowerThird]
+	matchTable            *table[Match]
+	matchResultTable      *table[MatchResult]
+	rankingTable          *table[game.Ranking]
+	scheduleBlockTable    *table[ScheduleBlock]
+	scheduledBreakTable   *table[ScheduledBreak]
+	scoreTimelineTable    *table[ScoreTimeline]
+	sponsorSlideTable     *table[SponsorSlide]
+	tbaPublicationTable   *table[TbaPublication]
+	teamTable             *table[Team]
+	teamAvailabilityTable *table[TeamAvailability]
+	teamDataTable         *table[TeamData]
+	userTable             *table[User]
+	userSessionTable      *table[UserSession]
 }
 
 // Opens the Bolt database at the given path, creating it if it doesn't exist.
@@ -48,9 +55,15 @@ func OpenDatabase(filename string) (*Database, error) {
 	}
 
 	// Register tables.
+	if database.alarmEntryTable, err = newTable[AlarmEntry](&database); err != nil {
+		return nil, err
+	}
 	if database.allianceTable, err = newTable[Alliance](&database); err != nil {
 		return nil, err
 	}
+	if database.auditEntryTable, err = newTable[AuditEntry](&database); err != nil {
+		return nil, err
+	}
 	if database.awardTable, err = newTable[Award](&database); err != nil {
 		return nil, err
 	}
@@ -78,12 +91,27 @@ func OpenDatabase(filename string) (*Database, error) {
 	if database.scheduledBreakTable, err = newTable[ScheduledBreak](&database); err != nil {
 		return nil, err
 	}
+	if database.scoreTimelineTable, err = newTable[ScoreTimeline](&database); err != nil {
+		return nil, err
+	}
 	if database.sponsorSlideTable, err = newTable[SponsorSlide](&database); err != nil {
 		return nil, err
 	}
+	if database.tbaPublicationTable, err = newTable[TbaPublication](&database); err != nil {
+		return nil, err
+	}
 	if database.teamTable, err = newTable[Team](&database); err != nil {
 		return nil, err
 	}
+	if database.teamAvailabilityTable, err = newTable[TeamAvailability](&database); err != nil {
+		return nil, err
+	}
+	if database.teamDataTable, err = newTable[TeamData](&database); err != nil {
+		return nil, err
+	}
+	if database.userTable, err = newTable[User](&database); err != nil {
+		return nil, err
+	}
 	if database.userSessionTable, err = newTable[UserSession](&database); err != nil {
 		return nil, err
 	}
@@ -4,6 +4,7 @@
 package model
 
 import (
+	"github.com/Team254/cheesy-arena/game/reefscape"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -16,3 +17,12 @@ func TestOpenUnreachableDatabase(t *testing.T) {
 func setupTestDb(t *testing.T) *Database {
 	return SetupTestDb(t)
 }
+
+func buildTestMatchResult(matchId int, playNumber int) *MatchResult {
+	matchResult := &MatchResult{MatchId: matchId, PlayNumber: playNumber, MatchType: Qualification}
+	matchResult.RedScore = reefscape.TestScore1()
+	matchResult.BlueScore = reefscape.TestScore2()
+	matchResult.RedCards = map[string]string{"1868": "yellow"}
+	matchResult.BlueCards = map[string]string{}
+	return matchResult
+}
@@ -36,6 +36,20 @@ var (
 		"exit",
 		"exit",
 	}
+	switchDefaultCommandTemplate = `configure terminal
+{{range .Stations}}interface Vlan{{.Vlan}}
+no ip address
+no ip dhcp pool dhcp{{.Vlan}}
+{{end}}{{range .Stations}}{{if .TeamId}}ip dhcp excluded-address {{.NetworkPrefix}}.1 {{.NetworkPrefix}}.19
+ip dhcp excluded-address {{.NetworkPrefix}}.200 {{.NetworkPrefix}}.254
+ip dhcp pool dhcp{{.Vlan}}
+network {{.NetworkPrefix}}.0 255.255.255.0
+default-router {{.GatewayAddress}}
+lease 7
+interface Vlan{{.Vlan}}
+ip address {{.GatewayAddress}} 255.255.255.0
+{{end}}{{end}}end
+exit`
 )
 
 type EventSettings struct {
@@ -52,12 +66,22 @@ type EventSettings struct {
 	TbaSecretId                 string
 	TbaSecret                   string
 	NexusEnabled                bool
+	NexusStatusEnabled          bool
+	NexusApiKey                 string
+	FrcEventsEnabled            bool
+	FrcEventsUsername           string
+	FrcEventsAuthKey            string
 	NetworkSecurityEnabled      bool
 	ApAddress                   string
 	ApPassword                  string
 	ApChannel                   int
+	SwitchType                  string
 	SwitchAddress               string
+	SwitchUsername              string
 	SwitchPassword              string
+	SwitchTeamVlans             string
+	SwitchTeamGatewayAddress    int
+	SwitchCommandTemplate       string
 	SCCManagementEnabled        bool
 	RedSCCAddress               string
 	BlueSCCAddress              string
@@ -66,6 +90,11 @@ type EventSettings struct {
 	SCCUpCommands               string
 	SCCDownCommands             string
 	PlcAddress                  string
+	PlcSimulated                bool
+	PlcIoMap                    string
+	AlarmCriticalPlc            bool
+	AlarmCriticalAccessPoint    bool
+	AlarmCriticalSwitch         bool
 	AdminPassword               string
 	TeamSignRed1Id              int
 	TeamSignRed2Id              int
@@ -82,10 +111,15 @@ type EventSettings struct {
 	PauseDurationSec            int
 	TeleopDurationSec           int
 	WarningRemainingDurationSec int
-	AutoBonusCoralThreshold     int
-	CoralBonusPerLevelThreshold int
-	CoralBonusCoopEnabled       bool
-	BargeBonusPointThreshold    int
+	GameSettings                map[string]int
+	DualEntryScoringEnabled     bool
+	ScheduleMinMatchGap         int
+
+	// Game settings from before they were stored in GameSettings; only read to migrate older records.
+	AutoBonusCoralThreshold     int  `json:",omitempty"`
+	CoralBonusPerLevelThreshold int  `json:",omitempty"`
+	CoralBonusCoopEnabled       bool `json:",omitempty"`
+	BargeBonusPointThreshold    int  `json:",omitempty"`
 }
 
 func (database *Database) GetEventSettings() (*EventSettings, error) {
@@ -94,7 +128,21 @@ func (database *Database) GetEventSettings() (*EventSettings, error) {
 		return nil, err
 	}
 	if len(allEventSettings) == 1 {
-		return &allEventSettings[0], nil
+		eventSettings := &allEventSettings[0]
+		if eventSettings.GameSettings == nil {
+			eventSettings.migrateLegacyGameSettings()
+		}
+
+		// Fill in the defaults of any game settings that weren't present when the record was saved.
+		for key, value := range game.DefaultSettings() {
+			if _, ok := eventSettings.GameSettings[key]; !ok {
+				if eventSettings.GameSettings == nil {
+					eventSettings.GameSettings = make(map[string]int)
+				}
+				eventSettings.GameSettings[key] = value
+			}
+		}
+		return eventSettings, nil
 	}
 
 	// Database record doesn't exist yet; create it now.
@@ -107,6 +155,9 @@ func (database *Database) GetEventSettings() (*EventSettings, error) {
 		SelectionShowUnpickedTeams:  true,
 		TbaDownloadEnabled:          true,
 		ApChannel:                   36,
+		SwitchType:                  "cisco",
+		SwitchTeamGatewayAddress:    4,
+		SwitchCommandTemplate:       switchDefaultCommandTemplate,
 		SCCUpCommands:               strings.Join(sccDefaultUpCommands, "\n"),
 		SCCDownCommands:             strings.Join(sccDefaultDownCommands, "\n"),
 		WarmupDurationSec:           game.MatchTiming.WarmupDurationSec,
@@ -114,10 +165,7 @@ func (database *Database) GetEventSettings() (*EventSettings, error) {
 		PauseDurationSec:            game.MatchTiming.PauseDurationSec,
 		TeleopDurationSec:           game.MatchTiming.TeleopDurationSec,
 		WarningRemainingDurationSec: game.MatchTiming.WarningRemainingDurationSec,
-		AutoBonusCoralThreshold:     game.AutoBonusCoralThreshold,
-		CoralBonusPerLevelThreshold: game.CoralBonusPerLevelThreshold,
-		CoralBonusCoopEnabled:       game.CoralBonusCoopEnabled,
-		BargeBonusPointThreshold:    game.BargeBonusPointThreshold,
+		GameSettings:                game.DefaultSettings(),
 	}
 
 	if err := database.eventSettingsTable.create(&eventSettings); err != nil {
@@ -126,6 +174,24 @@ func (database *Database) GetEventSettings() (*EventSettings, error) {
 	return &eventSettings, nil
 }
 
+// Copies the game settings of a record saved before GameSettings existed into it, preserving the values that were
+// configured for the event.
+func (eventSettings *EventSettings) migrateLegacyGameSettings() {
+	eventSettings.GameSettings = map[string]int{
+		"autoBonusCoralThreshold":     eventSettings.AutoBonusCoralThreshold,
+		"coralBonusPerLevelThreshold": eventSettings.CoralBonusPerLevelThreshold,
+		"coralBonusCoopEnabled":       0,
+		"bargeBonusPointThreshold":    eventSettings.BargeBonusPointThreshold,
+	}
+	if eventSettings.CoralBonusCoopEnabled {
+		eventSettings.GameSettings["coralBonusCoopEnabled"] = 1
+	}
+	eventSettings.AutoBonusCoralThreshold = 0
+	eventSettings.CoralBonusPerLevelThreshold = 0
+	eventSettings.CoralBonusCoopEnabled = false
+	eventSettings.BargeBonusPointThreshold = 0
+}
+
 func (database *Database) UpdateEventSettings(eventSettings *EventSettings) error {
 	return database.eventSettingsTable.update(eventSettings)
 }
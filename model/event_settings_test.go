@@ -26,6 +26,9 @@ func TestEventSettingsReadWrite(t *testing.T) {
 			SelectionShowUnpickedTeams:  true,
 			TbaDownloadEnabled:          true,
 			ApChannel:                   36,
+			SwitchType:                  "cisco",
+			SwitchTeamGatewayAddress:    4,
+			SwitchCommandTemplate:       switchDefaultCommandTemplate,
 			SCCUpCommands:               "configure terminal\ninterface range gigabitEthernet 1/2-4\nno shutdown\nexit\nexit\nexit",
 			SCCDownCommands:             "configure terminal\ninterface range gigabitEthernet 1/2-4\nshutdown\nexit\nexit\nexit",
 			WarmupDurationSec:           0,
@@ -33,10 +36,12 @@ func TestEventSettingsReadWrite(t *testing.T) {
 			PauseDurationSec:            3,
 			TeleopDurationSec:           135,
 			WarningRemainingDurationSec: 20,
-			AutoBonusCoralThreshold:     1,
-			CoralBonusPerLevelThreshold: 7,
-			CoralBonusCoopEnabled:       true,
-			BargeBonusPointThreshold:    16,
+			GameSettings: map[string]int{
+				"autoBonusCoralThreshold":     1,
+				"coralBonusCoopEnabled":       1,
+				"coralBonusPerLevelThreshold": 7,
+				"bargeBonusPointThreshold":    16,
+			},
 		},
 		*eventSettings,
 	)
@@ -50,4 +55,48 @@ func TestEventSettingsReadWrite(t *testing.T) {
 	eventSettings2, err := db.GetEventSettings()
 	assert.Nil(t, err)
 	assert.Equal(t, eventSettings, eventSettings2)
+
+	// Game settings missing from the saved record should take their defaults.
+	delete(eventSettings.GameSettings, "bargeBonusPointThreshold")
+	eventSettings.GameSettings["coralBonusCoopEnabled"] = 0
+	assert.Nil(t, db.UpdateEventSettings(eventSettings))
+	eventSettings2, err = db.GetEventSettings()
+	assert.Nil(t, err)
+	assert.Equal(t, 16, eventSettings2.GameSettings["bargeBonusPointThreshold"])
+	assert.Equal(t, 0, eventSettings2.GameSettings["coralBonusCoopEnabled"])
+}
+
+func TestEventSettingsMigrateLegacyGameSettings(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	// Simulate a record saved before the game settings were stored in GameSettings.
+	eventSettings, err := db.GetEventSettings()
+	assert.Nil(t, err)
+	eventSettings.GameSettings = nil
+	eventSettings.AutoBonusCoralThreshold = 3
+	eventSettings.CoralBonusPerLevelThreshold = 5
+	eventSettings.CoralBonusCoopEnabled = false
+	eventSettings.BargeBonusPointThreshold = 14
+	assert.Nil(t, db.UpdateEventSettings(eventSettings))
+
+	eventSettings, err = db.GetEventSettings()
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		map[string]int{
+			"autoBonusCoralThreshold":     3,
+			"coralBonusCoopEnabled":       0,
+			"coralBonusPerLevelThreshold": 5,
+			"bargeBonusPointThreshold":    14,
+		},
+		eventSettings.GameSettings,
+	)
+	assert.Equal(t, 0, eventSettings.AutoBonusCoralThreshold)
+
+	// The migrated values should survive the record being saved again.
+	assert.Nil(t, db.UpdateEventSettings(eventSettings))
+	eventSettings2, err := db.GetEventSettings()
+	assert.Nil(t, err)
+	assert.Equal(t, eventSettings, eventSettings2)
 }
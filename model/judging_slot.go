@@ -19,12 +19,17 @@ type JudgingSlot struct {
 	NextMatchNumber     int
 	NextMatchTime       time.Time
 	JudgeNumber         int
+	NeedsReschedule     bool
 }
 
 func (database *Database) CreateJudgingSlot(judgingSlot *JudgingSlot) error {
 	return database.judgingSlotTable.create(judgingSlot)
 }
 
+func (database *Database) UpdateJudgingSlot(judgingSlot *JudgingSlot) error {
+	return database.judgingSlotTable.update(judgingSlot)
+}
+
 func (database *Database) TruncateJudgingSlots() error {
 	return database.judgingSlotTable.truncate()
 }
@@ -39,6 +39,15 @@ func TestJudgingSlotCrud(t *testing.T) {
 	assert.Equal(t, 6, slots[0].NextMatchNumber)
 	assert.Equal(t, nextMatchTime, slots[0].NextMatchTime)
 	assert.Equal(t, 2, slots[0].JudgeNumber)
+	assert.False(t, slots[0].NeedsReschedule)
+
+	// Test updating a judging slot.
+	judgingSlot.NextMatchTime = time.Unix(200, 0).UTC()
+	judgingSlot.NeedsReschedule = true
+	assert.Nil(t, database.UpdateJudgingSlot(&judgingSlot))
+	slots, err = database.GetAllJudgingSlots()
+	assert.Nil(t, err)
+	assert.Equal(t, judgingSlot, slots[0])
 
 	// Test creating additional judging slots.
 	slot1 := JudgingSlot{Time: time.Unix(300, 0), TeamId: 1678, JudgeNumber: 1}
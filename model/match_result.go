@@ -7,24 +7,26 @@ package model
 
 import (
 	"github.com/Team254/cheesy-arena/game"
+	"sort"
 )
 
 type MatchResult struct {
-	Id         int `db:"id"`
-	MatchId    int
-	PlayNumber int
-	MatchType  MatchType
-	RedScore   *game.Score
-	BlueScore  *game.Score
-	RedCards   map[string]string
-	BlueCards  map[string]string
+	Id           int `db:"id"`
+	MatchId      int
+	PlayNumber   int
+	ReplayReason string
+	MatchType    MatchType
+	RedScore     *game.Score
+	BlueScore    *game.Score
+	RedCards     map[string]string
+	BlueCards    map[string]string
 }
 
 // Returns a new match result object with empty slices instead of nil.
 func NewMatchResult() *MatchResult {
 	matchResult := new(MatchResult)
-	matchResult.RedScore = new(game.Score)
-	matchResult.BlueScore = new(game.Score)
+	matchResult.RedScore = game.NewScore()
+	matchResult.BlueScore = game.NewScore()
 	matchResult.RedCards = make(map[string]string)
 	matchResult.BlueCards = make(map[string]string)
 	return matchResult
@@ -50,16 +52,49 @@ func (database *Database) GetMatchResultForMatch(matchId int) (*MatchResult, err
 	return mostRecentMatchResult, nil
 }
 
+// Returns every result recorded for the given match, ordered from the first play to the most recent.
+func (database *Database) GetMatchResultsForMatch(matchId int) ([]MatchResult, error) {
+	matchResults, err := database.matchResultTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []MatchResult
+	for _, matchResult := range matchResults {
+		if matchResult.MatchId == matchId {
+			results = append(results, matchResult)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].PlayNumber < results[j].PlayNumber
+	})
+	return results, nil
+}
+
 func (database *Database) UpdateMatchResult(matchResult *MatchResult) error {
 	return database.matchResultTable.update(matchResult)
 }
 
+// Deletes the match result having the given ID along with its score timeline, if one was recorded.
 func (database *Database) DeleteMatchResult(id int) error {
-	return database.matchResultTable.delete(id)
+	if err := database.matchResultTable.delete(id); err != nil {
+		return err
+	}
+	scoreTimeline, err := database.GetScoreTimelineForMatchResult(id)
+	if err != nil {
+		return err
+	}
+	if scoreTimeline != nil {
+		return database.scoreTimelineTable.delete(id)
+	}
+	return nil
 }
 
 func (database *Database) TruncateMatchResults() error {
-	return database.matchResultTable.truncate()
+	if err := database.matchResultTable.truncate(); err != nil {
+		return err
+	}
+	return database.TruncateScoreTimelines()
 }
 
 // Calculates and returns the summary fields used for ranking and display for the red alliance.
@@ -4,7 +4,7 @@
 package model
 
 import (
-	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/game/reefscape"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -22,14 +22,14 @@ func TestMatchResultCrud(t *testing.T) {
 	db := setupTestDb(t)
 	defer db.Close()
 
-	matchResult := BuildTestMatchResult(254, 5)
+	matchResult := buildTestMatchResult(254, 5)
 	assert.Nil(t, db.CreateMatchResult(matchResult))
 	matchResult2, err := db.GetMatchResultForMatch(254)
 	assert.Nil(t, err)
 	assert.Equal(t, matchResult, matchResult2)
 
-	matchResult.BlueScore.EndgameStatuses =
-		[3]game.EndgameStatus{game.EndgameParked, game.EndgameNone, game.EndgameShallowCage}
+	reefscape.ScoreDetails(matchResult.BlueScore).EndgameStatuses =
+		[3]reefscape.EndgameStatus{reefscape.EndgameParked, reefscape.EndgameNone, reefscape.EndgameShallowCage}
 	assert.Nil(t, db.UpdateMatchResult(matchResult))
 	matchResult2, err = db.GetMatchResultForMatch(254)
 	assert.Nil(t, err)
@@ -45,7 +45,7 @@ func TestTruncateMatchResults(t *testing.T) {
 	db := setupTestDb(t)
 	defer db.Close()
 
-	matchResult := BuildTestMatchResult(254, 1)
+	matchResult := buildTestMatchResult(254, 1)
 	assert.Nil(t, db.CreateMatchResult(matchResult))
 	assert.Nil(t, db.TruncateMatchResults())
 	matchResult2, err := db.GetMatchResultForMatch(254)
@@ -57,11 +57,11 @@ func TestGetMatchResultForMatch(t *testing.T) {
 	db := setupTestDb(t)
 	defer db.Close()
 
-	matchResult := BuildTestMatchResult(254, 2)
+	matchResult := buildTestMatchResult(254, 2)
 	assert.Nil(t, db.CreateMatchResult(matchResult))
-	matchResult2 := BuildTestMatchResult(254, 5)
+	matchResult2 := buildTestMatchResult(254, 5)
 	assert.Nil(t, db.CreateMatchResult(matchResult2))
-	matchResult3 := BuildTestMatchResult(254, 4)
+	matchResult3 := buildTestMatchResult(254, 4)
 	assert.Nil(t, db.CreateMatchResult(matchResult3))
 
 	// Should return the match result with the highest play number (i.e. the most recent).
@@ -69,3 +69,31 @@ func TestGetMatchResultForMatch(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, matchResult2, matchResult4)
 }
+
+func TestGetMatchResultsForMatch(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	matchResults, err := db.GetMatchResultsForMatch(254)
+	assert.Nil(t, err)
+	assert.Empty(t, matchResults)
+
+	matchResult := buildTestMatchResult(254, 2)
+	assert.Nil(t, db.CreateMatchResult(matchResult))
+	matchResult2 := buildTestMatchResult(254, 1)
+	assert.Nil(t, db.CreateMatchResult(matchResult2))
+	matchResult3 := buildTestMatchResult(1114, 1)
+	assert.Nil(t, db.CreateMatchResult(matchResult3))
+	matchResult4 := buildTestMatchResult(254, 3)
+	matchResult4.ReplayReason = "Field fault"
+	assert.Nil(t, db.CreateMatchResult(matchResult4))
+
+	// Should return only the results for the given match, in order of play.
+	matchResults, err = db.GetMatchResultsForMatch(254)
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(matchResults)) {
+		assert.Equal(t, *matchResult2, matchResults[0])
+		assert.Equal(t, *matchResult, matchResults[1])
+		assert.Equal(t, *matchResult4, matchResults[2])
+	}
+}
@@ -5,6 +5,7 @@ package model
 
 import (
 	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/game/reefscape"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -22,7 +23,7 @@ func TestRankingCrud(t *testing.T) {
 	db := setupTestDb(t)
 	defer db.Close()
 
-	ranking := game.TestRanking1()
+	ranking := reefscape.TestRanking1()
 	assert.Nil(t, db.CreateRanking(ranking))
 	ranking2, err := db.GetRankingForTeam(254)
 	assert.Nil(t, err)
@@ -39,7 +40,7 @@ func TestTruncateRankings(t *testing.T) {
 	db := setupTestDb(t)
 	defer db.Close()
 
-	ranking := game.TestRanking1()
+	ranking := reefscape.TestRanking1()
 	db.CreateRanking(ranking)
 	db.TruncateRankings()
 	ranking2, err := db.GetRankingForTeam(254)
@@ -0,0 +1,35 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Model and datastore CRUD methods for the history of realtime score changes that led up to a match result.
+
+package model
+
+import "github.com/Team254/cheesy-arena/game"
+
+type ScoreTimeline struct {
+	Id      int `db:"id,manual"` // Same as the ID of the match result that the timeline belongs to.
+	MatchId int
+	Events  []ScoreEvent
+}
+
+// A single change to one alliance's realtime score, along with the resulting totals for both alliances.
+type ScoreEvent struct {
+	MatchTimeSec float64
+	Alliance     string
+	Score        *game.Score
+	RedPoints    int
+	BluePoints   int
+}
+
+func (database *Database) CreateScoreTimeline(scoreTimeline *ScoreTimeline) error {
+	return database.scoreTimelineTable.create(scoreTimeline)
+}
+
+// Returns the timeline recorded for the given match result, or nil if there isn't one.
+func (database *Database) GetScoreTimelineForMatchResult(matchResultId int) (*ScoreTimeline, error) {
+	return database.scoreTimelineTable.getById(matchResultId)
+}
+
+func (database *Database) TruncateScoreTimelines() error {
+	return database.scoreTimelineTable.truncate()
+}
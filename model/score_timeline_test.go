@@ -0,0 +1,60 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/Team254/cheesy-arena/game/reefscape"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetNonexistentScoreTimeline(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	scoreTimeline, err := db.GetScoreTimelineForMatchResult(1114)
+	assert.Nil(t, err)
+	assert.Nil(t, scoreTimeline)
+}
+
+func TestScoreTimelineCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	matchResult := buildTestMatchResult(254, 1)
+	assert.Nil(t, db.CreateMatchResult(matchResult))
+	scoreTimeline := ScoreTimeline{
+		Id:      matchResult.Id,
+		MatchId: 254,
+		Events: []ScoreEvent{
+			{MatchTimeSec: 5.5, Alliance: "red", Score: reefscape.TestScore1(), RedPoints: 12, BluePoints: 0},
+			{MatchTimeSec: 80.25, Alliance: "blue", Score: reefscape.TestScore2(), RedPoints: 12, BluePoints: 40},
+		},
+	}
+	assert.Nil(t, db.CreateScoreTimeline(&scoreTimeline))
+	scoreTimeline2, err := db.GetScoreTimelineForMatchResult(matchResult.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, scoreTimeline, *scoreTimeline2)
+
+	// Check that a timeline is required to have the ID of its match result.
+	assert.NotNil(t, db.CreateScoreTimeline(&ScoreTimeline{MatchId: 254}))
+
+	// Check that deleting the match result also deletes its timeline.
+	assert.Nil(t, db.DeleteMatchResult(matchResult.Id))
+	scoreTimeline2, err = db.GetScoreTimelineForMatchResult(matchResult.Id)
+	assert.Nil(t, err)
+	assert.Nil(t, scoreTimeline2)
+}
+
+func TestTruncateScoreTimelines(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	matchResult := buildTestMatchResult(254, 1)
+	assert.Nil(t, db.CreateMatchResult(matchResult))
+	assert.Nil(t, db.CreateScoreTimeline(&ScoreTimeline{Id: matchResult.Id, MatchId: 254}))
+	assert.Nil(t, db.TruncateMatchResults())
+	scoreTimeline, err := db.GetScoreTimelineForMatchResult(matchResult.Id)
+	assert.Nil(t, err)
+	assert.Nil(t, scoreTimeline)
+}
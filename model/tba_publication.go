@@ -0,0 +1,37 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Model and datastore CRUD methods for the queue of pending writes to The Blue Alliance.
+
+package model
+
+import "time"
+
+type TbaPublication struct {
+	Id            int `db:"id"`
+	Kind          string
+	QueuedAt      time.Time
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+}
+
+func (database *Database) CreateTbaPublication(publication *TbaPublication) error {
+	return database.tbaPublicationTable.create(publication)
+}
+
+func (database *Database) UpdateTbaPublication(publication *TbaPublication) error {
+	return database.tbaPublicationTable.update(publication)
+}
+
+func (database *Database) DeleteTbaPublication(id int) error {
+	return database.tbaPublicationTable.delete(id)
+}
+
+func (database *Database) TruncateTbaPublications() error {
+	return database.tbaPublicationTable.truncate()
+}
+
+// Returns all pending publications in the order in which they were queued.
+func (database *Database) GetAllTbaPublications() ([]TbaPublication, error) {
+	return database.tbaPublicationTable.getAll()
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTbaPublicationCrud(t *testing.T) {
+	db := setupTestDb(t)
+
+	publications, err := db.GetAllTbaPublications()
+	assert.Nil(t, err)
+	assert.Empty(t, publications)
+
+	publication1 := TbaPublication{Kind: "matches", QueuedAt: time.Unix(1000, 0).UTC()}
+	publication2 := TbaPublication{Kind: "rankings", QueuedAt: time.Unix(1001, 0).UTC()}
+	assert.Nil(t, db.CreateTbaPublication(&publication1))
+	assert.Nil(t, db.CreateTbaPublication(&publication2))
+
+	publication1.Attempts = 2
+	publication1.NextAttemptAt = time.Unix(1010, 0).UTC()
+	publication1.LastError = "connection refused"
+	assert.Nil(t, db.UpdateTbaPublication(&publication1))
+	publications, err = db.GetAllTbaPublications()
+	assert.Nil(t, err)
+	assert.Equal(t, []TbaPublication{publication1, publication2}, publications)
+
+	assert.Nil(t, db.DeleteTbaPublication(publication1.Id))
+	publications, err = db.GetAllTbaPublications()
+	assert.Nil(t, err)
+	assert.Equal(t, []TbaPublication{publication2}, publications)
+
+	assert.Nil(t, db.TruncateTbaPublications())
+	publications, err = db.GetAllTbaPublications()
+	assert.Nil(t, err)
+	assert.Empty(t, publications)
+}
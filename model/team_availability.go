@@ -0,0 +1,60 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Model and datastore CRUD methods for a window of time during which a team is able to play matches.
+
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// TeamAvailability is a window outside of which the team can't be scheduled, for teams that arrive late or leave
+// early. A zero start or end time leaves that side of the window open. A team with no windows is always available, and
+// one with several is available during any of them.
+type TeamAvailability struct {
+	Id        int `db:"id"`
+	TeamId    int
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+func (database *Database) CreateTeamAvailability(availability *TeamAvailability) error {
+	return database.teamAvailabilityTable.create(availability)
+}
+
+func (database *Database) GetTeamAvailabilityById(id int) (*TeamAvailability, error) {
+	return database.teamAvailabilityTable.getById(id)
+}
+
+func (database *Database) DeleteTeamAvailability(id int) error {
+	return database.teamAvailabilityTable.delete(id)
+}
+
+func (database *Database) TruncateTeamAvailabilities() error {
+	return database.teamAvailabilityTable.truncate()
+}
+
+// Returns all availability windows, ordered by team and then by start time.
+func (database *Database) GetAllTeamAvailabilities() ([]TeamAvailability, error) {
+	availabilities, err := database.teamAvailabilityTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(
+		availabilities,
+		func(i, j int) bool {
+			if availabilities[i].TeamId != availabilities[j].TeamId {
+				return availabilities[i].TeamId < availabilities[j].TeamId
+			}
+			return availabilities[i].StartTime.Before(availabilities[j].StartTime)
+		},
+	)
+	return availabilities, nil
+}
+
+// Returns true if the given time falls within the window.
+func (availability *TeamAvailability) Contains(t time.Time) bool {
+	return (availability.StartTime.IsZero() || !t.Before(availability.StartTime)) &&
+		(availability.EndTime.IsZero() || !t.After(availability.EndTime))
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTeamAvailabilityCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	availability1 := TeamAvailability{TeamId: 254, StartTime: time.Unix(2000, 0).UTC()}
+	assert.Nil(t, db.CreateTeamAvailability(&availability1))
+	availability2 := TeamAvailability{TeamId: 1114, EndTime: time.Unix(5000, 0).UTC()}
+	assert.Nil(t, db.CreateTeamAvailability(&availability2))
+	availability3 := TeamAvailability{
+		TeamId: 254, StartTime: time.Unix(1000, 0).UTC(), EndTime: time.Unix(1500, 0).UTC(),
+	}
+	assert.Nil(t, db.CreateTeamAvailability(&availability3))
+
+	availability, err := db.GetTeamAvailabilityById(availability2.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, availability2, *availability)
+
+	availabilities, err := db.GetAllTeamAvailabilities()
+	assert.Nil(t, err)
+	assert.Equal(t, []TeamAvailability{availability3, availability1, availability2}, availabilities)
+
+	assert.Nil(t, db.DeleteTeamAvailability(availability1.Id))
+	availability, err = db.GetTeamAvailabilityById(availability1.Id)
+	assert.Nil(t, err)
+	assert.Nil(t, availability)
+
+	assert.Nil(t, db.TruncateTeamAvailabilities())
+	availabilities, err = db.GetAllTeamAvailabilities()
+	assert.Nil(t, err)
+	assert.Empty(t, availabilities)
+}
+
+func TestTeamAvailabilityContains(t *testing.T) {
+	availability := TeamAvailability{StartTime: time.Unix(1000, 0), EndTime: time.Unix(2000, 0)}
+	assert.False(t, availability.Contains(time.Unix(999, 0)))
+	assert.True(t, availability.Contains(time.Unix(1000, 0)))
+	assert.True(t, availability.Contains(time.Unix(2000, 0)))
+	assert.False(t, availability.Contains(time.Unix(2001, 0)))
+
+	availability = TeamAvailability{StartTime: time.Unix(1000, 0)}
+	assert.False(t, availability.Contains(time.Unix(999, 0)))
+	assert.True(t, availability.Contains(time.Unix(100000, 0)))
+
+	availability = TeamAvailability{EndTime: time.Unix(2000, 0)}
+	assert.True(t, availability.Contains(time.Unix(0, 0)))
+	assert.False(t, availability.Contains(time.Unix(2001, 0)))
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Model and datastore CRUD methods for official team information kept locally so that the team list can be set up
+// without access to The Blue Alliance.
+
+package model
+
+import "sort"
+
+type TeamData struct {
+	Id         int `db:"id,manual"`
+	Name       string
+	Nickname   string
+	City       string
+	StateProv  string
+	Country    string
+	RookieYear int
+	RobotName  string
+	Awards     []TeamDataAward
+	Avatar     []byte
+}
+
+type TeamDataAward struct {
+	Year      int
+	EventName string
+	Name      string
+}
+
+func (database *Database) CreateTeamData(teamData *TeamData) error {
+	return database.teamDataTable.create(teamData)
+}
+
+func (database *Database) GetTeamDataById(id int) (*TeamData, error) {
+	return database.teamDataTable.getById(id)
+}
+
+func (database *Database) TruncateTeamData() error {
+	return database.teamDataTable.truncate()
+}
+
+func (database *Database) GetAllTeamData() ([]TeamData, error) {
+	allTeamData, err := database.teamDataTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(
+		allTeamData, func(i, j int) bool {
+			return allTeamData[i].Id < allTeamData[j].Id
+		},
+	)
+	return allTeamData, nil
+}
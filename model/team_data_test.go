@@ -0,0 +1,40 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTeamDataCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	teamData, err := db.GetTeamDataById(254)
+	assert.Nil(t, err)
+	assert.Nil(t, teamData)
+
+	teamData1 := TeamData{
+		Id:         254,
+		Nickname:   "The Cheesy Poofs",
+		RookieYear: 1999,
+		RobotName:  "Barrage",
+		Awards:     []TeamDataAward{{Year: 2024, EventName: "Silicon Valley Regional", Name: "Regional Winners"}},
+		Avatar:     []byte{1, 2, 3},
+	}
+	teamData2 := TeamData{Id: 1114, Nickname: "Simbotics"}
+	assert.Nil(t, db.CreateTeamData(&teamData2))
+	assert.Nil(t, db.CreateTeamData(&teamData1))
+	teamData, err = db.GetTeamDataById(254)
+	assert.Nil(t, err)
+	assert.Equal(t, teamData1, *teamData)
+	allTeamData, err := db.GetAllTeamData()
+	assert.Nil(t, err)
+	assert.Equal(t, []TeamData{teamData1, teamData2}, allTeamData)
+
+	assert.Nil(t, db.TruncateTeamData())
+	allTeamData, err = db.GetAllTeamData()
+	assert.Nil(t, err)
+	assert.Empty(t, allTeamData)
+}
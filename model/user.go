@@ -0,0 +1,165 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Model and datastore CRUD methods for a named user account and the role it is granted.
+
+package model
+
+import (
+	"fmt"
+	"golang.org/x/crypto/bcrypt"
+	"sort"
+	"strings"
+)
+
+type User struct {
+	Id           int `db:"id"`
+	Username     string
+	PasswordHash string
+	Role         UserRole
+}
+
+// UserRole determines which groups of pages and panels a logged-in user is allowed to access.
+type UserRole int
+
+const (
+	ReadOnlyRole UserRole = iota
+	ScorerRole
+	HeadRefereeRole
+	ScorekeeperRole
+	FtaRole
+	AvRole
+	AdminRole
+)
+
+// AllUserRoles lists every role in the order in which they should be presented in the UI.
+var AllUserRoles = []UserRole{
+	ReadOnlyRole, ScorerRole, HeadRefereeRole, ScorekeeperRole, FtaRole, AvRole, AdminRole,
+}
+
+var userRoleNames = map[UserRole]string{
+	ReadOnlyRole:    "Read-Only",
+	ScorerRole:      "Scorer",
+	HeadRefereeRole: "Head Referee",
+	ScorekeeperRole: "Scorekeeper",
+	FtaRole:         "FTA",
+	AvRole:          "AV",
+	AdminRole:       "Admin",
+}
+
+// The set of roles whose routes are additionally accessible to a user having the given role.
+var impliedUserRoles = map[UserRole][]UserRole{
+	HeadRefereeRole: {ScorerRole},
+}
+
+func (database *Database) CreateUser(user *User) error {
+	if err := database.validateUser(user); err != nil {
+		return err
+	}
+	return database.userTable.create(user)
+}
+
+func (database *Database) GetUserById(id int) (*User, error) {
+	return database.userTable.getById(id)
+}
+
+// Returns the user having the given username (compared case-insensitively), or nil if it doesn't exist.
+func (database *Database) GetUserByUsername(username string) (*User, error) {
+	users, err := database.userTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		if strings.EqualFold(user.Username, username) {
+			return &user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (database *Database) UpdateUser(user *User) error {
+	if err := database.validateUser(user); err != nil {
+		return err
+	}
+	return database.userTable.update(user)
+}
+
+func (database *Database) DeleteUser(id int) error {
+	return database.userTable.delete(id)
+}
+
+func (database *Database) TruncateUsers() error {
+	return database.userTable.truncate()
+}
+
+func (database *Database) GetAllUsers() ([]User, error) {
+	users, err := database.userTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(
+		users,
+		func(i, j int) bool {
+			return users[i].Id < users[j].Id
+		},
+	)
+	return users, nil
+}
+
+// Hashes and stores the given password for the user. The record still needs to be saved to the database afterward.
+func (user *User) SetPassword(password string) error {
+	if password == "" {
+		return fmt.Errorf("password must not be blank")
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = string(hash)
+	return nil
+}
+
+// Returns true if the given password matches the one stored for the user.
+func (user *User) CheckPassword(password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) == nil
+}
+
+// Returns the human-readable name of the role.
+func (role UserRole) Name() string {
+	if name, ok := userRoleNames[role]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unknown (%d)", role)
+}
+
+// Returns true if a user having this role may access a route that requires the given role. Admins may access
+// everything, and every role may access routes that only require read-only access.
+func (role UserRole) Permits(requiredRole UserRole) bool {
+	if role == AdminRole || role == requiredRole || requiredRole == ReadOnlyRole {
+		return true
+	}
+	for _, impliedRole := range impliedUserRoles[role] {
+		if impliedRole == requiredRole {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns an error if the user is missing required fields or clashes with an existing one.
+func (database *Database) validateUser(user *User) error {
+	if user.Username == "" {
+		return fmt.Errorf("username must not be blank")
+	}
+	if _, ok := userRoleNames[user.Role]; !ok {
+		return fmt.Errorf("invalid role %d", user.Role)
+	}
+	existingUser, err := database.GetUserByUsername(user.Username)
+	if err != nil {
+		return err
+	}
+	if existingUser != nil && existingUser.Id != user.Id {
+		return fmt.Errorf("a user named %q already exists", existingUser.Username)
+	}
+	return nil
+}
@@ -39,3 +39,20 @@ func (database *Database) DeleteUserSession(id int) error {
 func (database *Database) TruncateUserSessions() error {
 	return database.userSessionTable.truncate()
 }
+
+// Deletes all sessions belonging to the given user, forcing them to log in again.
+func (database *Database) DeleteUserSessionsByUsername(username string) error {
+	userSessions, err := database.userSessionTable.getAll()
+	if err != nil {
+		return err
+	}
+
+	for _, userSession := range userSessions {
+		if userSession.Username == username {
+			if err = database.userSessionTable.delete(userSession.Id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
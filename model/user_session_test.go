@@ -48,3 +48,22 @@ func TestTruncateUserSessions(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Nil(t, session2)
 }
+
+func TestDeleteUserSessionsByUsername(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	db.CreateUserSession(&UserSession{Token: "token1", Username: "Bertha", CreatedAt: time.Now()})
+	db.CreateUserSession(&UserSession{Token: "token2", Username: "Bertha", CreatedAt: time.Now()})
+	db.CreateUserSession(&UserSession{Token: "token3", Username: "Englebert", CreatedAt: time.Now()})
+	assert.Nil(t, db.DeleteUserSessionsByUsername("Bertha"))
+	session, err := db.GetUserSessionByToken("token1")
+	assert.Nil(t, err)
+	assert.Nil(t, session)
+	session, err = db.GetUserSessionByToken("token2")
+	assert.Nil(t, err)
+	assert.Nil(t, session)
+	session, err = db.GetUserSessionByToken("token3")
+	assert.Nil(t, err)
+	assert.NotNil(t, session)
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetNonexistentUser(t *testing.T) {
+	db := setupTestDb(t)
+
+	user, err := db.GetUserById(1114)
+	assert.Nil(t, err)
+	assert.Nil(t, user)
+	user, err = db.GetUserByUsername("blorpy")
+	assert.Nil(t, err)
+	assert.Nil(t, user)
+}
+
+func TestUserCrud(t *testing.T) {
+	db := setupTestDb(t)
+
+	user := User{Username: "Bertha", Role: ScorerRole}
+	assert.Nil(t, user.SetPassword("hunter2"))
+	assert.Nil(t, db.CreateUser(&user))
+	user2, err := db.GetUserById(1)
+	assert.Nil(t, err)
+	assert.Equal(t, user, *user2)
+	user2, err = db.GetUserByUsername("bertha")
+	assert.Nil(t, err)
+	assert.Equal(t, user, *user2)
+	assert.True(t, user2.CheckPassword("hunter2"))
+	assert.False(t, user2.CheckPassword("Hunter2"))
+
+	user.Role = HeadRefereeRole
+	assert.Nil(t, db.UpdateUser(&user))
+	user2, err = db.GetUserById(1)
+	assert.Nil(t, err)
+	assert.Equal(t, HeadRefereeRole, user2.Role)
+
+	assert.Nil(t, db.DeleteUser(user.Id))
+	user2, err = db.GetUserById(1)
+	assert.Nil(t, err)
+	assert.Nil(t, user2)
+}
+
+func TestUserValidation(t *testing.T) {
+	db := setupTestDb(t)
+
+	err := db.CreateUser(&User{Role: ScorerRole})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "username must not be blank", err.Error())
+	}
+	err = db.CreateUser(&User{Username: "Bertha", Role: UserRole(99)})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "invalid role 99", err.Error())
+	}
+	assert.Nil(t, db.CreateUser(&User{Username: "Bertha", Role: ScorerRole}))
+	err = db.CreateUser(&User{Username: "BERTHA", Role: FtaRole})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "a user named \"Bertha\" already exists", err.Error())
+	}
+	assert.NotNil(t, (&User{}).SetPassword(""))
+}
+
+func TestTruncateUsers(t *testing.T) {
+	db := setupTestDb(t)
+
+	db.CreateUser(&User{Username: "Bertha", Role: ScorerRole})
+	db.TruncateUsers()
+	users, err := db.GetAllUsers()
+	assert.Nil(t, err)
+	assert.Empty(t, users)
+}
+
+func TestUserRolePermits(t *testing.T) {
+	for _, role := range AllUserRoles {
+		assert.True(t, role.Permits(ReadOnlyRole))
+		assert.True(t, role.Permits(role))
+		assert.True(t, AdminRole.Permits(role))
+	}
+	assert.True(t, HeadRefereeRole.Permits(ScorerRole))
+	assert.False(t, ScorerRole.Permits(HeadRefereeRole))
+	assert.False(t, ScorerRole.Permits(AdminRole))
+	assert.False(t, ScorekeeperRole.Permits(FtaRole))
+	assert.False(t, AvRole.Permits(ScorekeeperRole))
+	assert.Equal(t, "Head Referee", HeadRefereeRole.Name())
+}
@@ -0,0 +1,161 @@
+// Copyright 2014 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Methods for configuring a Cisco Switch 3500-series switch for team VLANs.
+
+package network
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	switchConfigPauseDurationSec = 2
+	switchTelnetPort             = 23
+)
+
+// CiscoSwitch is the driver for a Cisco IOS switch (e.g. a Catalyst 3500-series) configured over Telnet.
+type CiscoSwitch struct {
+	address               string
+	port                  int
+	password              string
+	teamVlans             [6]int
+	teamGatewayAddress    int
+	mutex                 sync.Mutex
+	configBackoffDuration time.Duration
+	configPauseDuration   time.Duration
+	Status                string
+}
+
+func NewCiscoSwitch(address, password string, teamVlans [6]int, teamGatewayAddress int) *CiscoSwitch {
+	return &CiscoSwitch{
+		address:               address,
+		port:                  switchTelnetPort,
+		password:              password,
+		teamVlans:             teamVlans,
+		teamGatewayAddress:    teamGatewayAddress,
+		configBackoffDuration: switchConfigBackoffDurationSec * time.Second,
+		configPauseDuration:   switchConfigPauseDurationSec * time.Second,
+		Status:                "UNKNOWN",
+	}
+}
+
+// Returns the status of the most recent configuration attempt.
+func (sw *CiscoSwitch) GetStatus() string {
+	return sw.Status
+}
+
+// Sets up wired networks for the given set of teams.
+func (sw *CiscoSwitch) ConfigureTeamEthernet(teams [6]*model.Team) error {
+	// Make sure multiple configurations aren't being set at the same time.
+	sw.mutex.Lock()
+	defer sw.mutex.Unlock()
+	sw.Status = "CONFIGURING"
+
+	// Remove old team VLANs to reset the switch state.
+	removeTeamVlansCommand := ""
+	for _, vlan := range sw.teamVlans {
+		removeTeamVlansCommand += fmt.Sprintf(
+			"interface Vlan%d\nno ip address\nno ip dhcp pool dhcp%d\n", vlan, vlan,
+		)
+	}
+	_, err := sw.runConfigCommand(removeTeamVlansCommand)
+	if err != nil {
+		sw.Status = "ERROR"
+		return err
+	}
+	time.Sleep(sw.configPauseDuration)
+
+	// Create the new team VLANs.
+	addTeamVlansCommand := ""
+	addTeamVlan := func(team *model.Team, vlan int) {
+		if team == nil {
+			return
+		}
+		teamPartialIp := teamNetworkPrefix(team.Id)
+		addTeamVlansCommand += fmt.Sprintf(
+			"ip dhcp excluded-address %s.1 %s.19\n"+
+				"ip dhcp excluded-address %s.200 %s.254\n"+
+				"ip dhcp pool dhcp%d\n"+
+				"network %s.0 255.255.255.0\n"+
+				"default-router %s.%d\n"+
+				"lease 7\n"+
+				"interface Vlan%d\nip address %s.%d 255.255.255.0\n",
+			teamPartialIp,
+			teamPartialIp,
+			teamPartialIp,
+			teamPartialIp,
+			vlan,
+			teamPartialIp,
+			teamPartialIp,
+			sw.teamGatewayAddress,
+			vlan,
+			teamPartialIp,
+			sw.teamGatewayAddress,
+		)
+	}
+	for i, team := range teams {
+		addTeamVlan(team, sw.teamVlans[i])
+	}
+	if len(addTeamVlansCommand) > 0 {
+		_, err = sw.runConfigCommand(addTeamVlansCommand)
+		if err != nil {
+			sw.Status = "ERROR"
+			return err
+		}
+	}
+
+	// Give some time for the configuration to take before another one can be attempted.
+	time.Sleep(sw.configBackoffDuration)
+
+	sw.Status = "ACTIVE"
+	return nil
+}
+
+// Logs into the switch via Telnet and runs the given command in user exec mode. Reads the output and
+// returns it as a string.
+func (sw *CiscoSwitch) runCommand(command string) (string, error) {
+	// Open a Telnet connection to the switch.
+	conn, err := net.Dial("tcp", net.JoinHostPort(sw.address, strconv.Itoa(sw.port)))
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	// Login to the AP, send the command, and log out all at once.
+	writer := bufio.NewWriter(conn)
+	_, err = writer.WriteString(
+		fmt.Sprintf(
+			"%s\nenable\n%s\nterminal length 0\n%sexit\n", sw.password, sw.password,
+			command,
+		),
+	)
+	if err != nil {
+		return "", err
+	}
+	err = writer.Flush()
+	if err != nil {
+		return "", err
+	}
+
+	// Read the response.
+	var reader bytes.Buffer
+	_, err = reader.ReadFrom(conn)
+	if err != nil {
+		return "", err
+	}
+	return reader.String(), nil
+}
+
+// Logs into the switch via Telnet and runs the given command in global configuration mode. Reads the output
+// and returns it as a string.
+func (sw *CiscoSwitch) runConfigCommand(command string) (string, error) {
+	return sw.runCommand(fmt.Sprintf("config terminal\n%send\n", command))
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// HTTP server mimicking the Vivid-Hosting access point API, for testing the access point driver and the arena against
+// configuration latency, failures and changing link statistics.
+
+package network
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+var accessPointStations = []string{"red1", "red2", "red3", "blue1", "blue2", "blue3"}
+
+// MockAccessPoint is an in-process HTTP server implementing the Vivid-Hosting access point API.
+type MockAccessPoint struct {
+	// How long the access point takes to apply a configuration, during which it reports itself as CONFIGURING.
+	ConfigurationLatency time.Duration
+	// Bearer token that requests must present, if non-empty.
+	Password string
+
+	server                *httptest.Server
+	mutex                 sync.Mutex
+	status                string
+	channel               int
+	stationStatuses       map[string]*stationStatus
+	pendingConfiguration  *configurationRequest
+	pendingApplyTime      time.Time
+	failConfigurations    int
+	failStatusRequests    int
+	unappliedStations     map[string]bool
+	configurationRequests int
+}
+
+// NewMockAccessPoint starts a mock access point server with no stations configured. Call Close when done with it.
+func NewMockAccessPoint() *MockAccessPoint {
+	ap := &MockAccessPoint{
+		status:            "ACTIVE",
+		stationStatuses:   make(map[string]*stationStatus),
+		unappliedStations: make(map[string]bool),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /configuration", ap.configurationHandler)
+	mux.HandleFunc("GET /status", ap.statusHandler)
+	ap.server = httptest.NewServer(mux)
+	return ap
+}
+
+// Address returns the host and port of the mock server, in the form expected by the access point settings.
+func (ap *MockAccessPoint) Address() string {
+	return strings.TrimPrefix(ap.server.URL, "http://")
+}
+
+// Close shuts down the mock server.
+func (ap *MockAccessPoint) Close() {
+	ap.server.Close()
+}
+
+// FailConfigurations causes the next given number of configuration requests to be rejected.
+func (ap *MockAccessPoint) FailConfigurations(count int) {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	ap.failConfigurations = count
+}
+
+// FailStatusRequests causes the next given number of status requests to be rejected.
+func (ap *MockAccessPoint) FailStatusRequests(count int) {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	ap.failStatusRequests = count
+}
+
+// FailStations causes subsequent configurations to leave the given stations (e.g. "red2") with their previous
+// configuration while still reporting success, as happens when the access point only partially applies a change.
+func (ap *MockAccessPoint) FailStations(stations ...string) {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	ap.unappliedStations = make(map[string]bool)
+	for _, station := range stations {
+		ap.unappliedStations[station] = true
+	}
+}
+
+// SetStationLink sets the link statistics reported for the robot radio on the given station, if it is configured.
+func (ap *MockAccessPoint) SetStationLink(
+	station string, isLinked bool, rxRateMbps, txRateMbps float64, signalNoiseRatio int, bandwidthUsedMbps float64,
+	connectionQuality string,
+) {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	ap.applyPendingConfiguration()
+	if status, ok := ap.stationStatuses[station]; ok {
+		status.IsLinked = isLinked
+		status.RxRateMbps = rxRateMbps
+		status.TxRateMbps = txRateMbps
+		status.SignalNoiseRatio = signalNoiseRatio
+		status.BandwidthUsedMbps = bandwidthUsedMbps
+		status.ConnectionQuality = connectionQuality
+	}
+}
+
+// GetConfigurationRequestCount returns the number of configuration requests received, including rejected ones.
+func (ap *MockAccessPoint) GetConfigurationRequestCount() int {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	return ap.configurationRequests
+}
+
+// GetStationSsid returns the SSID that the given station is currently configured with, or "" if it is unconfigured.
+func (ap *MockAccessPoint) GetStationSsid(station string) string {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	ap.applyPendingConfiguration()
+	if status, ok := ap.stationStatuses[station]; ok {
+		return status.Ssid
+	}
+	return ""
+}
+
+func (ap *MockAccessPoint) configurationHandler(w http.ResponseWriter, r *http.Request) {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	ap.configurationRequests++
+	if !ap.isAuthorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if ap.failConfigurations > 0 {
+		ap.failConfigurations--
+		http.Error(w, "failed to apply configuration", http.StatusInternalServerError)
+		return
+	}
+	var request configurationRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ap.status = "CONFIGURING"
+	ap.pendingConfiguration = &request
+	ap.pendingApplyTime = time.Now().Add(ap.ConfigurationLatency)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (ap *MockAccessPoint) statusHandler(w http.ResponseWriter, r *http.Request) {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	if !ap.isAuthorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if ap.failStatusRequests > 0 {
+		ap.failStatusRequests--
+		http.Error(w, "status unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	ap.applyPendingConfiguration()
+
+	status := accessPointStatus{Channel: ap.channel, Status: ap.status, StationStatuses: ap.stationStatuses}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// Applies the last configuration received if its latency has elapsed. Must be called with the mutex held.
+func (ap *MockAccessPoint) applyPendingConfiguration() {
+	if ap.pendingConfiguration == nil || time.Now().Before(ap.pendingApplyTime) {
+		return
+	}
+	ap.channel = ap.pendingConfiguration.Channel
+	for _, station := range accessPointStations {
+		if ap.unappliedStations[station] {
+			continue
+		}
+		configuration, ok := ap.pendingConfiguration.StationConfigurations[station]
+		if !ok {
+			delete(ap.stationStatuses, station)
+			continue
+		}
+		salt := station + "salt"
+		hash := sha256.Sum256([]byte(configuration.WpaKey + salt))
+		ap.stationStatuses[station] = &stationStatus{
+			Ssid:         configuration.Ssid,
+			HashedWpaKey: hex.EncodeToString(hash[:]),
+			WpaKeySalt:   salt,
+		}
+	}
+	ap.pendingConfiguration = nil
+	ap.status = "ACTIVE"
+}
+
+func (ap *MockAccessPoint) isAuthorized(r *http.Request) bool {
+	return ap.Password == "" || r.Header.Get("Authorization") == "Bearer "+ap.Password
+}
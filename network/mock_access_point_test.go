@@ -0,0 +1,137 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTestMockAccessPoint(t *testing.T) (*MockAccessPoint, *VividHostingAccessPoint) {
+	mockAp := NewMockAccessPoint()
+	t.Cleanup(mockAp.Close)
+	ap := new(VividHostingAccessPoint)
+	ap.SetSettings(
+		mockAp.Address(),
+		"",
+		5,
+		true,
+		[6]*TeamWifiStatus{{}, {}, {}, {}, {}, {}},
+	)
+	return mockAp, ap
+}
+
+func TestMockAccessPointConfigurationLatency(t *testing.T) {
+	mockAp, ap := setupTestMockAccessPoint(t)
+	mockAp.ConfigurationLatency = 100 * time.Millisecond
+	teams := [6]*model.Team{{Id: 254, WpaKey: "aaaaaaaa"}, nil, nil, nil, {Id: 1114, WpaKey: "bbbbbbbb"}, nil}
+
+	assert.Nil(t, ap.ConfigureTeamWifi(teams))
+	assert.Equal(t, "CONFIGURING", ap.GetStatus())
+	assert.Nil(t, ap.updateMonitoring())
+	assert.Equal(t, "CONFIGURING", ap.GetStatus())
+	assert.Equal(t, 0, ap.TeamWifiStatuses[0].TeamId)
+	assert.False(t, ap.statusMatchesLastConfiguration())
+
+	time.Sleep(mockAp.ConfigurationLatency)
+	assert.Nil(t, ap.updateMonitoring())
+	assert.Equal(t, "ACTIVE", ap.GetStatus())
+	assert.Equal(t, 254, ap.TeamWifiStatuses[0].TeamId)
+	assert.Equal(t, 0, ap.TeamWifiStatuses[1].TeamId)
+	assert.Equal(t, 1114, ap.TeamWifiStatuses[4].TeamId)
+	assert.True(t, ap.statusMatchesLastConfiguration())
+	assert.Equal(t, "254", mockAp.GetStationSsid("red1"))
+	assert.Equal(t, 1, mockAp.GetConfigurationRequestCount())
+
+	// Removing a team should clear its station.
+	teams[0] = nil
+	assert.Nil(t, ap.ConfigureTeamWifi(teams))
+	time.Sleep(mockAp.ConfigurationLatency)
+	assert.Nil(t, ap.updateMonitoring())
+	assert.Equal(t, 0, ap.TeamWifiStatuses[0].TeamId)
+	assert.Equal(t, "", mockAp.GetStationSsid("red1"))
+	assert.True(t, ap.statusMatchesLastConfiguration())
+}
+
+func TestMockAccessPointPartialFailure(t *testing.T) {
+	mockAp, ap := setupTestMockAccessPoint(t)
+	teams := [6]*model.Team{{Id: 254}, {Id: 1114}, {Id: 2056}, nil, nil, nil}
+	assert.Nil(t, ap.ConfigureTeamWifi(teams))
+	assert.Nil(t, ap.updateMonitoring())
+	assert.True(t, ap.statusMatchesLastConfiguration())
+
+	// A station that fails to apply should leave the access point ACTIVE but mismatched, prompting a retry.
+	mockAp.FailStations("red2")
+	teams[1] = &model.Team{Id: 148}
+	assert.Nil(t, ap.ConfigureTeamWifi(teams))
+	assert.Nil(t, ap.updateMonitoring())
+	assert.Equal(t, "ACTIVE", ap.GetStatus())
+	assert.Equal(t, 1114, ap.TeamWifiStatuses[1].TeamId)
+	assert.False(t, ap.statusMatchesLastConfiguration())
+
+	mockAp.FailStations()
+	assert.Nil(t, ap.ConfigureTeamWifi(ap.lastConfiguredTeams))
+	assert.Nil(t, ap.updateMonitoring())
+	assert.Equal(t, 148, ap.TeamWifiStatuses[1].TeamId)
+	assert.True(t, ap.statusMatchesLastConfiguration())
+}
+
+func TestMockAccessPointErrors(t *testing.T) {
+	mockAp, ap := setupTestMockAccessPoint(t)
+	teams := [6]*model.Team{{Id: 254}, nil, nil, nil, nil, nil}
+
+	mockAp.FailConfigurations(1)
+	err := ap.ConfigureTeamWifi(teams)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "access point returned status 500")
+	}
+	assert.Nil(t, ap.ConfigureTeamWifi(teams))
+	assert.Equal(t, 2, mockAp.GetConfigurationRequestCount())
+
+	mockAp.FailStatusRequests(1)
+	err = ap.updateMonitoring()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "access point returned status 503")
+	}
+	assert.Equal(t, "ERROR", ap.GetStatus())
+	assert.Nil(t, ap.updateMonitoring())
+	assert.Equal(t, "ACTIVE", ap.GetStatus())
+
+	// Requests without the right password should be rejected.
+	mockAp.Password = "secret"
+	err = ap.updateMonitoring()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "access point returned status 401")
+	}
+	ap.SetSettings(mockAp.Address(), "secret", 5, true, ap.TeamWifiStatuses)
+	assert.Nil(t, ap.updateMonitoring())
+	assert.Equal(t, "ACTIVE", ap.GetStatus())
+}
+
+func TestMockAccessPointLinkStatistics(t *testing.T) {
+	mockAp, ap := setupTestMockAccessPoint(t)
+	assert.Nil(t, ap.ConfigureTeamWifi([6]*model.Team{nil, nil, nil, nil, nil, {Id: 254}}))
+	mockAp.SetStationLink("blue3", true, 72.2, 86.7, 41, 4.5, "excellent")
+	assert.Nil(t, ap.updateMonitoring())
+	assert.Equal(
+		t,
+		TeamWifiStatus{
+			TeamId:            254,
+			RadioLinked:       true,
+			MBits:             4.5,
+			RxRate:            72.2,
+			TxRate:            86.7,
+			SignalNoiseRatio:  41,
+			ConnectionQuality: 4,
+		},
+		*ap.TeamWifiStatuses[5],
+	)
+
+	mockAp.SetStationLink("blue3", false, 0, 0, 0, 0, "")
+	assert.Nil(t, ap.updateMonitoring())
+	assert.False(t, ap.TeamWifiStatuses[5].RadioLinked)
+	assert.Equal(t, 0, ap.TeamWifiStatuses[5].ConnectionQuality)
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Helper for running commands on network hardware over SSH.
+
+package network
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Logs into the given switch via SSH and runs the given commands in sequence in an interactive shell.
+// Returns the output of the commands or an error if the operation fails.
+func runSshCommandSequence(
+	address string,
+	port int,
+	username, password string,
+	commands []string,
+	connectTimeout, commandTimeout time.Duration,
+) (string, error) {
+	// Open an SSH connection to the switch.
+	sshConfig := &ssh.ClientConfig{
+		User: username,
+		Auth: []ssh.AuthMethod{
+			ssh.Password(password),
+		},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(), // Allow any host key for simplicity
+		Timeout:         connectTimeout,
+	}
+	client, err := ssh.Dial("tcp", net.JoinHostPort(address, strconv.Itoa(port)), sshConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to SSH: %w", err)
+	}
+	defer client.Close()
+
+	// Create an interactive session to run commands
+	session, err := client.NewSession()
+	if err != nil {
+		return "", fmt.Errorf("failed to create SSH session: %w", err)
+	}
+	defer session.Close()
+
+	// Capture the session output
+	var outputBuffer bytes.Buffer
+	session.Stdout = &outputBuffer
+	session.Stderr = &outputBuffer
+
+	inputPipe, err := session.StdinPipe()
+	if err != nil {
+		return "", fmt.Errorf("failed to create input pipe: %w", err)
+	}
+
+	// Launch the switch's interactive shell
+	err = session.Shell()
+	if err != nil {
+		return "", fmt.Errorf("failed to start shell: %w", err)
+	}
+
+	// Submit the commands to the switch
+	for _, command := range commands {
+		if _, err := fmt.Fprintln(inputPipe, command); err != nil {
+			return "", fmt.Errorf("failed to write command to switch: %w", err)
+		}
+	}
+
+	// Wait for the remote to process the commands and exit the shell
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Wait()
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			return "", fmt.Errorf("failed to run command sequence: %w", err)
+		}
+	case <-time.After(commandTimeout):
+		return "", fmt.Errorf("timed out waiting for command sequence to complete")
+	}
+
+	return outputBuffer.String(), nil
+}
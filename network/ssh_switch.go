@@ -0,0 +1,163 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Methods for configuring an arbitrary SSH-capable managed switch for team VLANs using a user-supplied command template.
+
+package network
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+)
+
+const (
+	sshSwitchConnectTimeoutSec = 5
+	sshSwitchConfigTimeoutSec  = 30
+	sshSwitchPort              = 22
+)
+
+var switchTemplateStations = [6]string{"R1", "R2", "R3", "B1", "B2", "B3"}
+
+// SshSwitch is a generic switch driver that logs in over SSH and runs the commands generated from a Go text/template,
+// so that switches from any vendor can be supported without code changes.
+type SshSwitch struct {
+	address                string
+	port                   int
+	username               string
+	password               string
+	commandTemplate        *template.Template
+	templateErr            error
+	teamVlans              [6]int
+	teamGatewayAddress     int
+	mutex                  sync.Mutex
+	connectTimeoutDuration time.Duration
+	configTimeoutDuration  time.Duration
+	configBackoffDuration  time.Duration
+	Status                 string
+}
+
+// SwitchTemplateData is the data passed to the switch command template when the switch is configured.
+type SwitchTemplateData struct {
+	Stations []SwitchTemplateStation
+}
+
+// SwitchTemplateStation describes the network of a single alliance station. The team-specific fields are empty if
+// there is no team in the station.
+type SwitchTemplateStation struct {
+	Station        string // e.g. "R1"
+	Vlan           int
+	TeamId         int
+	NetworkPrefix  string // First three octets of the team subnet, e.g. "10.2.54"
+	GatewayAddress string // e.g. "10.2.54.4"
+}
+
+func NewSshSwitch(
+	address, username, password, commandTemplate string, teamVlans [6]int, teamGatewayAddress int,
+) *SshSwitch {
+	sw := &SshSwitch{
+		address:                address,
+		port:                   sshSwitchPort,
+		username:               username,
+		password:               password,
+		teamVlans:              teamVlans,
+		teamGatewayAddress:     teamGatewayAddress,
+		connectTimeoutDuration: sshSwitchConnectTimeoutSec * time.Second,
+		configTimeoutDuration:  sshSwitchConfigTimeoutSec * time.Second,
+		configBackoffDuration:  switchConfigBackoffDurationSec * time.Second,
+		Status:                 "UNKNOWN",
+	}
+	sw.commandTemplate, sw.templateErr = ParseSwitchCommandTemplate(commandTemplate)
+	return sw
+}
+
+// ParseSwitchCommandTemplate parses the given switch command template and checks that it can be rendered.
+func ParseSwitchCommandTemplate(commandTemplate string) (*template.Template, error) {
+	if strings.TrimSpace(commandTemplate) == "" {
+		return nil, fmt.Errorf("switch command template is empty")
+	}
+	tmpl, err := template.New("switch").Option("missingkey=error").Parse(commandTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid switch command template: %v", err)
+	}
+	teams := [6]*model.Team{{Id: 254}, nil, nil, nil, nil, nil}
+	if _, err = renderSwitchCommands(tmpl, teams, DefaultSwitchTeamVlans, DefaultSwitchTeamGatewayAddress); err != nil {
+		return nil, fmt.Errorf("invalid switch command template: %v", err)
+	}
+	return tmpl, nil
+}
+
+// Returns the status of the most recent configuration attempt.
+func (sw *SshSwitch) GetStatus() string {
+	return sw.Status
+}
+
+// Sets up wired networks for the given set of teams by running the rendered command template on the switch.
+func (sw *SshSwitch) ConfigureTeamEthernet(teams [6]*model.Team) error {
+	// Make sure multiple configurations aren't being set at the same time.
+	sw.mutex.Lock()
+	defer sw.mutex.Unlock()
+	sw.Status = "CONFIGURING"
+
+	if sw.templateErr != nil {
+		sw.Status = "ERROR"
+		return sw.templateErr
+	}
+	commands, err := renderSwitchCommands(sw.commandTemplate, teams, sw.teamVlans, sw.teamGatewayAddress)
+	if err != nil {
+		sw.Status = "ERROR"
+		return err
+	}
+	_, err = runSshCommandSequence(
+		sw.address,
+		sw.port,
+		sw.username,
+		sw.password,
+		commands,
+		sw.connectTimeoutDuration,
+		sw.configTimeoutDuration,
+	)
+	if err != nil {
+		sw.Status = "ERROR"
+		return fmt.Errorf("failed to configure team ethernet: %w", err)
+	}
+
+	// Give some time for the configuration to take before another one can be attempted.
+	time.Sleep(sw.configBackoffDuration)
+
+	sw.Status = "ACTIVE"
+	return nil
+}
+
+// Renders the command template for the given teams and splits the result into individual commands, omitting blank
+// lines.
+func renderSwitchCommands(
+	tmpl *template.Template, teams [6]*model.Team, teamVlans [6]int, teamGatewayAddress int,
+) ([]string, error) {
+	var data SwitchTemplateData
+	for i, team := range teams {
+		station := SwitchTemplateStation{Station: switchTemplateStations[i], Vlan: teamVlans[i]}
+		if team != nil {
+			station.TeamId = team.Id
+			station.NetworkPrefix = teamNetworkPrefix(team.Id)
+			station.GatewayAddress = fmt.Sprintf("%s.%d", station.NetworkPrefix, teamGatewayAddress)
+		}
+		data.Stations = append(data.Stations, station)
+	}
+
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, data); err != nil {
+		return nil, err
+	}
+	var commands []string
+	for _, line := range strings.Split(buffer.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			commands = append(commands, line)
+		}
+	}
+	return commands, nil
+}
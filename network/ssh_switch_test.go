@@ -0,0 +1,84 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigureSshSwitch(t *testing.T) {
+	commandTemplate := "configure\n" +
+		"{{range .Stations}}vlan {{.Vlan}} name {{.Station}}\n" +
+		"{{if .TeamId}}vlan {{.Vlan}} ip {{.GatewayAddress}}/24 dhcp {{.NetworkPrefix}}.20-{{.NetworkPrefix}}.199\n" +
+		"{{else}}no vlan {{.Vlan}} ip\n{{end}}{{end}}\n" +
+		"exit\n"
+	sw := NewSshSwitch(
+		"127.0.0.1", "admin", "password", commandTemplate, [6]int{11, 12, 13, 21, 22, 23}, DefaultSwitchTeamGatewayAddress,
+	)
+	assert.Equal(t, "UNKNOWN", sw.GetStatus())
+	sw.port = 9250
+	sw.connectTimeoutDuration = 10 * time.Millisecond
+	sw.configTimeoutDuration = 15 * time.Millisecond
+	sw.configBackoffDuration = time.Millisecond
+
+	var commands []string
+	mockSSHSwitch(t, sw.port, "admin", "password", &commands)
+	assert.Nil(t, sw.ConfigureTeamEthernet([6]*model.Team{{Id: 254}, nil, nil, nil, {Id: 1114}, nil}))
+	assert.Equal(
+		t,
+		[]string{
+			"configure",
+			"vlan 11 name R1",
+			"vlan 11 ip 10.2.54.4/24 dhcp 10.2.54.20-10.2.54.199",
+			"vlan 12 name R2",
+			"no vlan 12 ip",
+			"vlan 13 name R3",
+			"no vlan 13 ip",
+			"vlan 21 name B1",
+			"no vlan 21 ip",
+			"vlan 22 name B2",
+			"vlan 22 ip 10.11.14.4/24 dhcp 10.11.14.20-10.11.14.199",
+			"vlan 23 name B3",
+			"no vlan 23 ip",
+			"exit",
+		},
+		commands,
+	)
+	assert.Equal(t, "ACTIVE", sw.GetStatus())
+
+	// A failure to connect should be reported as an error.
+	sw.port += 1
+	assert.NotNil(t, sw.ConfigureTeamEthernet([6]*model.Team{}))
+	assert.Equal(t, "ERROR", sw.GetStatus())
+}
+
+func TestConfigureSshSwitchInvalidTemplate(t *testing.T) {
+	sw := NewSshSwitch("127.0.0.1", "admin", "password", "{{.Bogus}}", DefaultSwitchTeamVlans, 4)
+	err := sw.ConfigureTeamEthernet([6]*model.Team{})
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "invalid switch command template")
+	}
+	assert.Equal(t, "ERROR", sw.GetStatus())
+}
+
+func TestParseSwitchCommandTemplate(t *testing.T) {
+	_, err := ParseSwitchCommandTemplate("{{range .Stations}}vlan {{.Vlan}}\n{{end}}")
+	assert.Nil(t, err)
+
+	_, err = ParseSwitchCommandTemplate("  \n")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "template is empty")
+	}
+	_, err = ParseSwitchCommandTemplate("{{range .Stations}}")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "invalid switch command template")
+	}
+	_, err = ParseSwitchCommandTemplate("{{range .Stations}}{{.Team}}{{end}}")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "can't evaluate field Team")
+	}
+}
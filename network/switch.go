@@ -1,166 +1,73 @@
-// Copyright 2014 Team 254. All Rights Reserved.
+// Copyright 2025 Team 254. All Rights Reserved.
 // Author: pat@patfairbank.com (Patrick Fairbank)
 //
-// Methods for configuring a Cisco Switch 3500-series switch for team VLANs.
+// Interface to the field switch, so that different models can be supported, and the team network numbering shared by
+// all of them.
 
 package network
 
 import (
-	"bufio"
-	"bytes"
 	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/Team254/cheesy-arena/model"
-	"net"
-	"sync"
-	"time"
 )
 
 const (
 	switchConfigBackoffDurationSec = 5
-	switchConfigPauseDurationSec   = 2
-	switchTeamGatewayAddress       = 4
-	switchTelnetPort               = 23
+	maxSwitchVlan                  = 4094
 )
 
+// Names of the supported switch drivers, as stored in the event settings.
 const (
-	red1Vlan  = 10
-	red2Vlan  = 20
-	red3Vlan  = 30
-	blue1Vlan = 40
-	blue2Vlan = 50
-	blue3Vlan = 60
+	CiscoSwitchType = "cisco"
+	SshSwitchType   = "ssh"
 )
 
-type Switch struct {
-	address               string
-	port                  int
-	password              string
-	mutex                 sync.Mutex
-	configBackoffDuration time.Duration
-	configPauseDuration   time.Duration
-	Status                string
-}
+const DefaultSwitchTeamGatewayAddress = 4
+
+// DefaultSwitchTeamVlans are the VLANs used for each team network, in the order R1, R2, R3, B1, B2, B3.
+var DefaultSwitchTeamVlans = [6]int{10, 20, 30, 40, 50, 60}
 
 var ServerIpAddress = "10.0.100.5" // The DS will try to connect to this address only.
 
-func NewSwitch(address, password string) *Switch {
-	return &Switch{
-		address:               address,
-		port:                  switchTelnetPort,
-		password:              password,
-		configBackoffDuration: switchConfigBackoffDurationSec * time.Second,
-		configPauseDuration:   switchConfigPauseDurationSec * time.Second,
-		Status:                "UNKNOWN",
-	}
-}
+// Switch is implemented by each supported model of field switch to isolate each team on its own VLAN and subnet.
+type Switch interface {
+	// Sets up wired networks for the given teams, in the order R1, R2, R3, B1, B2, B3.
+	ConfigureTeamEthernet(teams [6]*model.Team) error
 
-// Sets up wired networks for the given set of teams.
-func (sw *Switch) ConfigureTeamEthernet(teams [6]*model.Team) error {
-	// Make sure multiple configurations aren't being set at the same time.
-	sw.mutex.Lock()
-	defer sw.mutex.Unlock()
-	sw.Status = "CONFIGURING"
+	// Returns the status of the most recent configuration attempt (e.g. "ACTIVE", "CONFIGURING" or "ERROR").
+	GetStatus() string
+}
 
-	// Remove old team VLANs to reset the switch state.
-	removeTeamVlansCommand := ""
-	for vlan := 10; vlan <= 60; vlan += 10 {
-		removeTeamVlansCommand += fmt.Sprintf(
-			"interface Vlan%d\nno ip address\nno ip dhcp pool dhcp%d\n", vlan, vlan,
-		)
+// ParseSwitchTeamVlans parses a comma-separated list of the six team VLANs in station order. An empty string yields
+// the default VLANs.
+func ParseSwitchTeamVlans(vlansString string) ([6]int, error) {
+	var vlans [6]int
+	if strings.TrimSpace(vlansString) == "" {
+		return DefaultSwitchTeamVlans, nil
 	}
-	_, err := sw.runConfigCommand(removeTeamVlansCommand)
-	if err != nil {
-		sw.Status = "ERROR"
-		return err
+	fields := strings.Split(vlansString, ",")
+	if len(fields) != len(vlans) {
+		return vlans, fmt.Errorf("expected %d team VLANs but got %d", len(vlans), len(fields))
 	}
-	time.Sleep(sw.configPauseDuration)
-
-	// Create the new team VLANs.
-	addTeamVlansCommand := ""
-	addTeamVlan := func(team *model.Team, vlan int) {
-		if team == nil {
-			return
+	for i, field := range fields {
+		vlan, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || vlan < 2 || vlan > maxSwitchVlan {
+			return vlans, fmt.Errorf("invalid team VLAN %q; must be between 2 and %d", field, maxSwitchVlan)
 		}
-		teamPartialIp := fmt.Sprintf("%d.%d", team.Id/100, team.Id%100)
-		addTeamVlansCommand += fmt.Sprintf(
-			"ip dhcp excluded-address 10.%s.1 10.%s.19\n"+
-				"ip dhcp excluded-address 10.%s.200 10.%s.254\n"+
-				"ip dhcp pool dhcp%d\n"+
-				"network 10.%s.0 255.255.255.0\n"+
-				"default-router 10.%s.%d\n"+
-				"lease 7\n"+
-				"interface Vlan%d\nip address 10.%s.%d 255.255.255.0\n",
-			teamPartialIp,
-			teamPartialIp,
-			teamPartialIp,
-			teamPartialIp,
-			vlan,
-			teamPartialIp,
-			teamPartialIp,
-			switchTeamGatewayAddress,
-			vlan,
-			teamPartialIp,
-			switchTeamGatewayAddress,
-		)
-	}
-	addTeamVlan(teams[0], red1Vlan)
-	addTeamVlan(teams[1], red2Vlan)
-	addTeamVlan(teams[2], red3Vlan)
-	addTeamVlan(teams[3], blue1Vlan)
-	addTeamVlan(teams[4], blue2Vlan)
-	addTeamVlan(teams[5], blue3Vlan)
-	if len(addTeamVlansCommand) > 0 {
-		_, err = sw.runConfigCommand(addTeamVlansCommand)
-		if err != nil {
-			sw.Status = "ERROR"
-			return err
+		for j := 0; j < i; j++ {
+			if vlans[j] == vlan {
+				return vlans, fmt.Errorf("team VLAN %d is used more than once", vlan)
+			}
 		}
+		vlans[i] = vlan
 	}
-
-	// Give some time for the configuration to take before another one can be attempted.
-	time.Sleep(sw.configBackoffDuration)
-
-	sw.Status = "ACTIVE"
-	return nil
-}
-
-// Logs into the switch via Telnet and runs the given command in user exec mode. Reads the output and
-// returns it as a string.
-func (sw *Switch) runCommand(command string) (string, error) {
-	// Open a Telnet connection to the switch.
-	conn, err := net.Dial("tcp", fmt.Sprintf("%s:%d", sw.address, sw.port))
-	if err != nil {
-		return "", err
-	}
-	defer conn.Close()
-
-	// Login to the AP, send the command, and log out all at once.
-	writer := bufio.NewWriter(conn)
-	_, err = writer.WriteString(
-		fmt.Sprintf(
-			"%s\nenable\n%s\nterminal length 0\n%sexit\n", sw.password, sw.password,
-			command,
-		),
-	)
-	if err != nil {
-		return "", err
-	}
-	err = writer.Flush()
-	if err != nil {
-		return "", err
-	}
-
-	// Read the response.
-	var reader bytes.Buffer
-	_, err = reader.ReadFrom(conn)
-	if err != nil {
-		return "", err
-	}
-	return reader.String(), nil
+	return vlans, nil
 }
 
-// Logs into the switch via Telnet and runs the given command in global configuration mode. Reads the output
-// and returns it as a string.
-func (sw *Switch) runConfigCommand(command string) (string, error) {
-	return sw.runCommand(fmt.Sprintf("config terminal\n%send\n", command))
+// Returns the first three octets of the given team's 10.TE.AM.x subnet.
+func teamNetworkPrefix(teamId int) string {
+	return fmt.Sprintf("10.%d.%d", teamId/100, teamId%100)
 }
@@ -12,8 +12,8 @@ import (
 	"testing"
 )
 
-func TestAccessPoint_ConfigureTeamWifi(t *testing.T) {
-	var ap AccessPoint
+func TestVividHostingAccessPoint_ConfigureTeamWifi(t *testing.T) {
+	var ap VividHostingAccessPoint
 	var request configurationRequest
 	wifiStatuses := [6]*TeamWifiStatus{{}, {}, {}, {}, {}, {}}
 	ap.SetSettings("dummy", "password1", 123, true, wifiStatuses)
@@ -87,8 +87,8 @@ func TestAccessPoint_ConfigureTeamWifi(t *testing.T) {
 	assert.Equal(t, "CONFIGURING", ap.Status)
 }
 
-func TestAccessPoint_updateMonitoring(t *testing.T) {
-	var ap AccessPoint
+func TestVividHostingAccessPoint_updateMonitoring(t *testing.T) {
+	var ap VividHostingAccessPoint
 	wifiStatuses := [6]*TeamWifiStatus{{}, {}, {}, {}, {}, {}}
 	ap.SetSettings("dummy", "password2", 123, true, wifiStatuses)
 
@@ -164,8 +164,8 @@ func TestAccessPoint_updateMonitoring(t *testing.T) {
 	assert.Equal(t, "ERROR", ap.Status)
 }
 
-func TestAccessPoint_statusMatchesLastConfiguration(t *testing.T) {
-	var ap AccessPoint
+func TestVividHostingAccessPoint_statusMatchesLastConfiguration(t *testing.T) {
+	var ap VividHostingAccessPoint
 	wifiStatuses := [6]*TeamWifiStatus{{}, {}, {}, {}, {}, {}}
 	ap.SetSettings("dummy", "", 123, true, wifiStatuses)
 
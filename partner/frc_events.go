@@ -0,0 +1,253 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Methods for retrieving team rosters, schedules and results from the FIRST FRC Events API.
+
+package partner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+)
+
+const frcEventsBaseUrl = "https://frc-api.firstinspires.org"
+
+// Upper bound on each request to the FRC Events API, so that an unresponsive server can't hang an import.
+const frcEventsRequestTimeoutSec = 10
+
+// Format of the event-local times returned by the FRC Events API.
+const frcEventsTimeFormat = "2006-01-02T15:04:05"
+
+// Tournament levels accepted by the FRC Events API.
+const (
+	FrcEventsQualification = "Qualification"
+	FrcEventsPlayoff       = "Playoff"
+)
+
+type FrcEventsClient struct {
+	BaseUrl   string
+	season    int
+	eventCode string
+	username  string
+	authKey   string
+}
+
+type FrcEventsTeam struct {
+	TeamNumber int    `json:"teamNumber"`
+	NameFull   string `json:"nameFull"`
+	NameShort  string `json:"nameShort"`
+	City       string `json:"city"`
+	StateProv  string `json:"stateProv"`
+	Country    string `json:"country"`
+	RookieYear int    `json:"rookieYear"`
+	RobotName  string `json:"robotName"`
+	SchoolName string `json:"schoolName"`
+}
+
+type FrcEventsScheduledMatch struct {
+	Description     string                   `json:"description"`
+	TournamentLevel string                   `json:"tournamentLevel"`
+	MatchNumber     int                      `json:"matchNumber"`
+	StartTime       string                   `json:"startTime"`
+	Teams           []FrcEventsScheduledTeam `json:"teams"`
+}
+
+type FrcEventsScheduledTeam struct {
+	TeamNumber int    `json:"teamNumber"`
+	Station    string `json:"station"`
+	Surrogate  bool   `json:"surrogate"`
+}
+
+type FrcEventsMatchResult struct {
+	Description     string                `json:"description"`
+	TournamentLevel string                `json:"tournamentLevel"`
+	MatchNumber     int                   `json:"matchNumber"`
+	IsReplay        bool                  `json:"isReplay"`
+	ActualStartTime string                `json:"actualStartTime"`
+	PostResultTime  string                `json:"postResultTime"`
+	ScoreRedFinal   int                   `json:"scoreRedFinal"`
+	ScoreRedFoul    int                   `json:"scoreRedFoul"`
+	ScoreRedAuto    int                   `json:"scoreRedAuto"`
+	ScoreBlueFinal  int                   `json:"scoreBlueFinal"`
+	ScoreBlueFoul   int                   `json:"scoreBlueFoul"`
+	ScoreBlueAuto   int                   `json:"scoreBlueAuto"`
+	Teams           []FrcEventsResultTeam `json:"teams"`
+}
+
+type FrcEventsResultTeam struct {
+	TeamNumber int    `json:"teamNumber"`
+	Station    string `json:"station"`
+	Dq         bool   `json:"dq"`
+}
+
+type frcEventsTeamsPage struct {
+	Teams       []FrcEventsTeam `json:"teams"`
+	PageCurrent int             `json:"pageCurrent"`
+	PageTotal   int             `json:"pageTotal"`
+}
+
+type frcEventsSchedule struct {
+	Schedule []FrcEventsScheduledMatch `json:"Schedule"`
+}
+
+type frcEventsMatchResults struct {
+	Matches []FrcEventsMatchResult `json:"Matches"`
+}
+
+// NewFrcEventsClient creates a client for the event with the given TBA-style code (e.g. "2025casj"), from which the
+// season and FIRST event code are derived. The username and authorization key are those issued by FIRST for the API.
+func NewFrcEventsClient(tbaEventCode, username, authKey string) *FrcEventsClient {
+	season := time.Now().Year()
+	eventCode := tbaEventCode
+	if len(tbaEventCode) > 4 {
+		if year, err := strconv.Atoi(tbaEventCode[:4]); err == nil {
+			season = year
+			eventCode = tbaEventCode[4:]
+		}
+	}
+	return &FrcEventsClient{
+		BaseUrl:   frcEventsBaseUrl,
+		season:    season,
+		eventCode: strings.ToUpper(eventCode),
+		username:  username,
+		authKey:   authKey,
+	}
+}
+
+// Gets the list of teams registered for the event, across all pages of results.
+func (client *FrcEventsClient) GetTeams() ([]FrcEventsTeam, error) {
+	var teams []FrcEventsTeam
+	for page := 1; ; page++ {
+		path := fmt.Sprintf(
+			"/v3.0/%d/teams?eventCode=%s&page=%d", client.season, url.QueryEscape(client.eventCode), page,
+		)
+		var teamsPage frcEventsTeamsPage
+		if err := client.getJson(path, "teams", &teamsPage); err != nil {
+			return nil, err
+		}
+		teams = append(teams, teamsPage.Teams...)
+		if page >= teamsPage.PageTotal {
+			return teams, nil
+		}
+	}
+}
+
+// Gets the official schedule for the given tournament level, ordered by match number.
+func (client *FrcEventsClient) GetSchedule(tournamentLevel string) ([]FrcEventsScheduledMatch, error) {
+	path := fmt.Sprintf(
+		"/v3.0/%d/schedule/%s?tournamentLevel=%s",
+		client.season,
+		url.PathEscape(client.eventCode),
+		url.QueryEscape(tournamentLevel),
+	)
+	var schedule frcEventsSchedule
+	if err := client.getJson(path, "schedule", &schedule); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(schedule.Schedule, func(i, j int) bool {
+		return schedule.Schedule[i].MatchNumber < schedule.Schedule[j].MatchNumber
+	})
+	return schedule.Schedule, nil
+}
+
+// Gets the published results of the matches played so far at the given tournament level.
+func (client *FrcEventsClient) GetMatchResults(tournamentLevel string) ([]FrcEventsMatchResult, error) {
+	path := fmt.Sprintf(
+		"/v3.0/%d/matches/%s?tournamentLevel=%s",
+		client.season,
+		url.PathEscape(client.eventCode),
+		url.QueryEscape(tournamentLevel),
+	)
+	var results frcEventsMatchResults
+	if err := client.getJson(path, "match results", &results); err != nil {
+		return nil, err
+	}
+	return results.Matches, nil
+}
+
+// Gets the official qualification schedule and converts it into matches ready to be saved, with their times in the
+// local time zone of the event.
+func (client *FrcEventsClient) GetQualificationMatches() ([]model.Match, error) {
+	schedule, err := client.GetSchedule(FrcEventsQualification)
+	if err != nil {
+		return nil, err
+	}
+	if len(schedule) == 0 {
+		return nil, fmt.Errorf(
+			"The official qualification schedule for %d %s has not been published", client.season, client.eventCode,
+		)
+	}
+
+	matches := make([]model.Match, len(schedule))
+	for i, scheduledMatch := range schedule {
+		match := &matches[i]
+		match.Type = model.Qualification
+		match.TypeOrder = scheduledMatch.MatchNumber
+		match.ShortName = fmt.Sprintf("Q%d", scheduledMatch.MatchNumber)
+		match.LongName = fmt.Sprintf("Qualification %d", scheduledMatch.MatchNumber)
+		match.TbaMatchKey.CompLevel = "qm"
+		match.TbaMatchKey.MatchNumber = scheduledMatch.MatchNumber
+		match.Time, err = time.ParseInLocation(frcEventsTimeFormat, scheduledMatch.StartTime, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid start time for %s: %v", match.LongName, err)
+		}
+		for _, team := range scheduledMatch.Teams {
+			switch team.Station {
+			case "Red1":
+				match.Red1, match.Red1IsSurrogate = team.TeamNumber, team.Surrogate
+			case "Red2":
+				match.Red2, match.Red2IsSurrogate = team.TeamNumber, team.Surrogate
+			case "Red3":
+				match.Red3, match.Red3IsSurrogate = team.TeamNumber, team.Surrogate
+			case "Blue1":
+				match.Blue1, match.Blue1IsSurrogate = team.TeamNumber, team.Surrogate
+			case "Blue2":
+				match.Blue2, match.Blue2IsSurrogate = team.TeamNumber, team.Surrogate
+			case "Blue3":
+				match.Blue3, match.Blue3IsSurrogate = team.TeamNumber, team.Surrogate
+			default:
+				return nil, fmt.Errorf("Invalid station %q for %s", team.Station, match.LongName)
+			}
+		}
+	}
+	return matches, nil
+}
+
+// Sends a GET request to the FRC Events API and decodes the JSON response into the given value.
+func (client *FrcEventsClient) getJson(path, description string, value any) error {
+	resp, err := client.getRequest(path)
+	if err != nil {
+		return err
+	}
+
+	// Get the response and handle errors
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("Error getting %s from FRC Events: %d, %s", description, resp.StatusCode, string(body))
+	}
+	return json.Unmarshal(body, value)
+}
+
+// Sends an authenticated GET request to the FRC Events API.
+func (client *FrcEventsClient) getRequest(path string) (*http.Response, error) {
+	httpClient := &http.Client{Timeout: frcEventsRequestTimeoutSec * time.Second}
+	req, err := http.NewRequest("GET", client.BaseUrl+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(client.username, client.authKey)
+	req.Header.Set("Accept", "application/json")
+	return httpClient.Do(req)
+}
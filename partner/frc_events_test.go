@@ -0,0 +1,155 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package partner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFrcEventsClient(t *testing.T) {
+	client := NewFrcEventsClient("2025casj", "user", "key")
+	assert.Equal(t, 2025, client.season)
+	assert.Equal(t, "CASJ", client.eventCode)
+
+	client = NewFrcEventsClient("casj", "user", "key")
+	assert.Equal(t, time.Now().Year(), client.season)
+	assert.Equal(t, "CASJ", client.eventCode)
+}
+
+func TestFrcEventsGetTeams(t *testing.T) {
+	mock := NewMockFrcEventsServer(2025, "CASJ", "user", "key")
+	defer mock.Close()
+	var teams []FrcEventsTeam
+	for i := 0; i < 5; i++ {
+		teams = append(teams, FrcEventsTeam{TeamNumber: 254 + i, NameShort: "Team"})
+	}
+	teams[0].NameShort = "The Cheesy Poofs"
+	teams[0].RookieYear = 1999
+	mock.SetTeams(teams, 2)
+
+	client := NewFrcEventsClient("2025casj", "user", "key")
+	client.BaseUrl = mock.URL()
+	receivedTeams, err := client.GetTeams()
+	if assert.Nil(t, err) && assert.Equal(t, 5, len(receivedTeams)) {
+		assert.Equal(t, "The Cheesy Poofs", receivedTeams[0].NameShort)
+		assert.Equal(t, 1999, receivedTeams[0].RookieYear)
+		assert.Equal(t, 258, receivedTeams[4].TeamNumber)
+	}
+
+	// Check that bad credentials and unknown events are reported.
+	client = NewFrcEventsClient("2025casj", "user", "wrong")
+	client.BaseUrl = mock.URL()
+	_, err = client.GetTeams()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "Error getting teams from FRC Events: 401")
+	}
+	client = NewFrcEventsClient("2024casj", "user", "key")
+	client.BaseUrl = mock.URL()
+	_, err = client.GetTeams()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "404")
+	}
+}
+
+func TestFrcEventsGetQualificationMatches(t *testing.T) {
+	mock := NewMockFrcEventsServer(2025, "CASJ", "user", "key")
+	defer mock.Close()
+	client := NewFrcEventsClient("2025casj", "user", "key")
+	client.BaseUrl = mock.URL()
+
+	_, err := client.GetQualificationMatches()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "has not been published")
+	}
+
+	mock.SetSchedule(
+		FrcEventsQualification,
+		[]FrcEventsScheduledMatch{
+			{
+				MatchNumber: 2,
+				StartTime:   "2025-03-01T09:07:00",
+				Teams: []FrcEventsScheduledTeam{
+					{TeamNumber: 7, Station: "Red1"},
+					{TeamNumber: 8, Station: "Red2"},
+					{TeamNumber: 9, Station: "Red3"},
+					{TeamNumber: 10, Station: "Blue1"},
+					{TeamNumber: 11, Station: "Blue2"},
+					{TeamNumber: 1, Station: "Blue3", Surrogate: true},
+				},
+			},
+			{
+				MatchNumber: 1,
+				StartTime:   "2025-03-01T09:00:00",
+				Teams: []FrcEventsScheduledTeam{
+					{TeamNumber: 1, Station: "Red1"},
+					{TeamNumber: 2, Station: "Red2"},
+					{TeamNumber: 3, Station: "Red3"},
+					{TeamNumber: 4, Station: "Blue1"},
+					{TeamNumber: 5, Station: "Blue2"},
+					{TeamNumber: 6, Station: "Blue3"},
+				},
+			},
+		},
+	)
+	matches, err := client.GetQualificationMatches()
+	if assert.Nil(t, err) && assert.Equal(t, 2, len(matches)) {
+		assert.Equal(t, model.Qualification, matches[0].Type)
+		assert.Equal(t, 1, matches[0].TypeOrder)
+		assert.Equal(t, "Q1", matches[0].ShortName)
+		assert.Equal(t, "Qualification 1", matches[0].LongName)
+		assert.Equal(t, model.TbaMatchKey{CompLevel: "qm", MatchNumber: 1}, matches[0].TbaMatchKey)
+		assert.True(t, time.Date(2025, 3, 1, 9, 0, 0, 0, time.Local).Equal(matches[0].Time))
+		assert.Equal(t, [6]int{1, 2, 3, 4, 5, 6}, matchTeams(matches[0]))
+		assert.Equal(t, "Q2", matches[1].ShortName)
+		assert.Equal(t, [6]int{7, 8, 9, 10, 11, 1}, matchTeams(matches[1]))
+		assert.False(t, matches[1].Blue2IsSurrogate)
+		assert.True(t, matches[1].Blue3IsSurrogate)
+	}
+
+	mock.SetSchedule(
+		FrcEventsQualification,
+		[]FrcEventsScheduledMatch{
+			{MatchNumber: 1, StartTime: "2025-03-01T09:00:00", Teams: []FrcEventsScheduledTeam{{Station: "Red4"}}},
+		},
+	)
+	_, err = client.GetQualificationMatches()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "Invalid station \"Red4\" for Qualification 1")
+	}
+}
+
+func TestFrcEventsGetMatchResults(t *testing.T) {
+	mock := NewMockFrcEventsServer(2025, "CASJ", "user", "key")
+	defer mock.Close()
+	mock.SetResults(
+		FrcEventsQualification,
+		[]FrcEventsMatchResult{
+			{
+				MatchNumber:    1,
+				ScoreRedFinal:  120,
+				ScoreBlueFinal: 98,
+				Teams:          []FrcEventsResultTeam{{TeamNumber: 254, Station: "Red1", Dq: true}},
+			},
+		},
+	)
+	client := NewFrcEventsClient("2025casj", "user", "key")
+	client.BaseUrl = mock.URL()
+
+	results, err := client.GetMatchResults(FrcEventsQualification)
+	if assert.Nil(t, err) && assert.Equal(t, 1, len(results)) {
+		assert.Equal(t, 120, results[0].ScoreRedFinal)
+		assert.Equal(t, 98, results[0].ScoreBlueFinal)
+		assert.Equal(t, FrcEventsResultTeam{TeamNumber: 254, Station: "Red1", Dq: true}, results[0].Teams[0])
+	}
+	results, err = client.GetMatchResults(FrcEventsPlayoff)
+	assert.Nil(t, err)
+	assert.Empty(t, results)
+}
+
+func matchTeams(match model.Match) [6]int {
+	return [6]int{match.Red1, match.Red2, match.Red3, match.Blue1, match.Blue2, match.Blue3}
+}
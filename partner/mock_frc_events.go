@@ -0,0 +1,147 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// HTTP server mimicking the FIRST FRC Events API for a single event, for testing the client and the schedule import
+// without credentials or internet access.
+
+package partner
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MockFrcEventsServer is an in-process HTTP server implementing the parts of the FRC Events API used by the client.
+type MockFrcEventsServer struct {
+	server    *httptest.Server
+	mutex     sync.Mutex
+	season    int
+	eventCode string
+	username  string
+	authKey   string
+	pageSize  int
+	teams     []FrcEventsTeam
+	schedules map[string][]FrcEventsScheduledMatch
+	results   map[string][]FrcEventsMatchResult
+}
+
+// NewMockFrcEventsServer starts a mock server for the event with the given season and FIRST event code (e.g. "CASJ")
+// that accepts the given credentials. Call Close when done with it.
+func NewMockFrcEventsServer(season int, eventCode, username, authKey string) *MockFrcEventsServer {
+	mock := &MockFrcEventsServer{
+		season:    season,
+		eventCode: strings.ToUpper(eventCode),
+		username:  username,
+		authKey:   authKey,
+		pageSize:  65,
+		schedules: make(map[string][]FrcEventsScheduledMatch),
+		results:   make(map[string][]FrcEventsMatchResult),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v3.0/{season}/teams", mock.teamsHandler)
+	mux.HandleFunc("GET /v3.0/{season}/schedule/{eventCode}", mock.scheduleHandler)
+	mux.HandleFunc("GET /v3.0/{season}/matches/{eventCode}", mock.matchesHandler)
+	mock.server = httptest.NewServer(mux)
+	return mock
+}
+
+// URL returns the base URL of the mock server, for use as the client's BaseUrl.
+func (mock *MockFrcEventsServer) URL() string {
+	return mock.server.URL
+}
+
+// Close shuts down the mock server.
+func (mock *MockFrcEventsServer) Close() {
+	mock.server.Close()
+}
+
+// SetTeams sets the team roster for the event, which is returned in pages of the given size.
+func (mock *MockFrcEventsServer) SetTeams(teams []FrcEventsTeam, pageSize int) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	mock.teams = teams
+	mock.pageSize = pageSize
+}
+
+// SetSchedule sets the published schedule for the given tournament level.
+func (mock *MockFrcEventsServer) SetSchedule(tournamentLevel string, schedule []FrcEventsScheduledMatch) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	mock.schedules[tournamentLevel] = schedule
+}
+
+// SetResults sets the published match results for the given tournament level.
+func (mock *MockFrcEventsServer) SetResults(tournamentLevel string, results []FrcEventsMatchResult) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	mock.results[tournamentLevel] = results
+}
+
+func (mock *MockFrcEventsServer) teamsHandler(w http.ResponseWriter, r *http.Request) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	if !mock.checkRequest(w, r, r.URL.Query().Get("eventCode")) {
+		return
+	}
+
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageTotal := max(1, (len(mock.teams)+mock.pageSize-1)/mock.pageSize)
+	start := min(len(mock.teams), (page-1)*mock.pageSize)
+	end := min(len(mock.teams), start+mock.pageSize)
+	writeMockFrcEventsJson(w, frcEventsTeamsPage{Teams: mock.teams[start:end], PageCurrent: page, PageTotal: pageTotal})
+}
+
+func (mock *MockFrcEventsServer) scheduleHandler(w http.ResponseWriter, r *http.Request) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	if !mock.checkRequest(w, r, r.PathValue("eventCode")) {
+		return
+	}
+	schedule := mock.schedules[r.URL.Query().Get("tournamentLevel")]
+	if schedule == nil {
+		schedule = []FrcEventsScheduledMatch{}
+	}
+	writeMockFrcEventsJson(w, frcEventsSchedule{Schedule: schedule})
+}
+
+func (mock *MockFrcEventsServer) matchesHandler(w http.ResponseWriter, r *http.Request) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	if !mock.checkRequest(w, r, r.PathValue("eventCode")) {
+		return
+	}
+	results := mock.results[r.URL.Query().Get("tournamentLevel")]
+	if results == nil {
+		results = []FrcEventsMatchResult{}
+	}
+	writeMockFrcEventsJson(w, frcEventsMatchResults{Matches: results})
+}
+
+// Checks the credentials and the event being requested, writing an error response and returning false if they are not
+// valid.
+func (mock *MockFrcEventsServer) checkRequest(w http.ResponseWriter, r *http.Request, eventCode string) bool {
+	username, authKey, ok := r.BasicAuth()
+	if !ok || username != mock.username || authKey != mock.authKey {
+		http.Error(w, "Unable to authenticate the request", 401)
+		return false
+	}
+	if r.PathValue("season") != strconv.Itoa(mock.season) || !strings.EqualFold(eventCode, mock.eventCode) {
+		http.Error(w, fmt.Sprintf("No event found for season %s and code %s", r.PathValue("season"), eventCode), 404)
+		return false
+	}
+	return true
+}
+
+func writeMockFrcEventsJson(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		http.Error(w, err.Error(), 500)
+	}
+}
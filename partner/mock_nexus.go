@@ -0,0 +1,164 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// HTTP server mimicking the Nexus API for a single event, for testing lineup retrieval, status pushes and pit notes
+// without internet access.
+
+package partner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+)
+
+// MockNexusServer is an in-process HTTP server implementing the parts of the Nexus API used by the client.
+type MockNexusServer struct {
+	server      *httptest.Server
+	mutex       sync.Mutex
+	eventCode   string
+	eventApiKey string
+	lineups     map[string]nexusLineup
+	pitNotes    map[int]string
+	statuses    []NexusEventStatus
+	failPushes  int
+}
+
+// NewMockNexusServer starts a mock server for the given event that accepts status pushes made with the given event API
+// key. Call Close when done with it.
+func NewMockNexusServer(eventCode, eventApiKey string) *MockNexusServer {
+	mock := &MockNexusServer{
+		eventCode:   eventCode,
+		eventApiKey: eventApiKey,
+		lineups:     make(map[string]nexusLineup),
+		pitNotes:    make(map[int]string),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/event/{eventCode}/match/{matchKey}/lineups", mock.lineupHandler)
+	mux.HandleFunc("GET /api/v1/event/{eventCode}/pitNotes", mock.pitNotesHandler)
+	mux.HandleFunc("PUT /api/v1/event/{eventCode}/status", mock.statusHandler)
+	mock.server = httptest.NewServer(mux)
+	return mock
+}
+
+// URL returns the base URL of the mock server, for use as the client's BaseUrl.
+func (mock *MockNexusServer) URL() string {
+	return mock.server.URL
+}
+
+// Close shuts down the mock server.
+func (mock *MockNexusServer) Close() {
+	mock.server.Close()
+}
+
+// SetLineup sets the lineup submitted for the match with the given TBA key (e.g. "p1").
+func (mock *MockNexusServer) SetLineup(matchKey string, lineup [6]int) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	var nexusLineup nexusLineup
+	for i := 0; i < 3; i++ {
+		nexusLineup.Red[i] = strconv.Itoa(lineup[i])
+		nexusLineup.Blue[i] = strconv.Itoa(lineup[i+3])
+	}
+	mock.lineups[matchKey] = nexusLineup
+}
+
+// SetPitNote sets the pit note for the given team, or removes it if the note is empty.
+func (mock *MockNexusServer) SetPitNote(teamId int, note string) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	if note == "" {
+		delete(mock.pitNotes, teamId)
+	} else {
+		mock.pitNotes[teamId] = note
+	}
+}
+
+// FailPushes causes the next given number of status pushes to be rejected.
+func (mock *MockNexusServer) FailPushes(count int) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	mock.failPushes = count
+}
+
+// GetStatuses returns the status updates that have been successfully pushed, oldest first.
+func (mock *MockNexusServer) GetStatuses() []NexusEventStatus {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	return append([]NexusEventStatus(nil), mock.statuses...)
+}
+
+func (mock *MockNexusServer) lineupHandler(w http.ResponseWriter, r *http.Request) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	if !mock.checkEvent(w, r) {
+		return
+	}
+	lineup, ok := mock.lineups[r.PathValue("matchKey")]
+	if !ok {
+		http.Error(w, "Match not found", 404)
+		return
+	}
+	writeMockNexusJson(w, lineup)
+}
+
+func (mock *MockNexusServer) pitNotesHandler(w http.ResponseWriter, r *http.Request) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	if !mock.checkEvent(w, r) {
+		return
+	}
+	pitNotes := make(map[string]string)
+	for teamId, note := range mock.pitNotes {
+		pitNotes[strconv.Itoa(teamId)] = note
+	}
+	writeMockNexusJson(w, pitNotes)
+}
+
+func (mock *MockNexusServer) statusHandler(w http.ResponseWriter, r *http.Request) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	if !mock.checkEvent(w, r) {
+		return
+	}
+	if r.Header.Get("Nexus-Api-Key") != mock.eventApiKey {
+		http.Error(w, "Invalid API key", 401)
+		return
+	}
+	if mock.failPushes > 0 {
+		mock.failPushes--
+		http.Error(w, "Service unavailable", 503)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+	var status NexusEventStatus
+	if err = json.Unmarshal(body, &status); err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+	mock.statuses = append(mock.statuses, status)
+	writeMockNexusJson(w, map[string]string{"status": "ok"})
+}
+
+// Writes an error response and returns false if the request is for a different event.
+func (mock *MockNexusServer) checkEvent(w http.ResponseWriter, r *http.Request) bool {
+	if r.PathValue("eventCode") != mock.eventCode {
+		http.Error(w, fmt.Sprintf("Event %s not found", r.PathValue("eventCode")), 404)
+		return false
+	}
+	return true
+}
+
+func writeMockNexusJson(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		http.Error(w, err.Error(), 500)
+	}
+}
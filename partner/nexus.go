@@ -1,26 +1,58 @@
 // Copyright 2023 Team 254. All Rights Reserved.
 // Author: pat@patfairbank.com (Patrick Fairbank)
 //
-// Methods for pulling match lineups from Nexus for FRC.
+// Methods for exchanging match lineups, queueing status and pit notes with Nexus for FRC.
 
 package partner
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"github.com/Team254/cheesy-arena/model"
 	"io"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 const nexusBaseUrl = "https://frc.nexus"
 const nexusApiKey = "Vn6D9y80kQcNijDItKOJHg8yYEk"
 
+// Upper bound on each request to Nexus, so that an unresponsive server can't hold up match loading or stall the status
+// publishing goroutine.
+const nexusRequestTimeoutSec = 5
+
+// Queueing states reported to Nexus for each upcoming match.
+const (
+	NexusOnField     = "On field"
+	NexusOnDeck      = "On deck"
+	NexusNowQueuing  = "Now queuing"
+	NexusQueuingSoon = "Queuing soon"
+)
+
 type NexusClient struct {
-	BaseUrl   string
-	apiKey    string
-	eventCode string
+	BaseUrl     string
+	apiKey      string
+	eventApiKey string
+	eventCode   string
+}
+
+// NexusEventStatus is the state of the field pushed to Nexus so that its queueing screens and team notifications
+// follow the actual progress of the event.
+type NexusEventStatus struct {
+	NowQueuing   string             `json:"nowQueuing"`
+	DelayMinutes int                `json:"delayMinutes"`
+	Message      string             `json:"message"`
+	Matches      []NexusMatchStatus `json:"matches"`
+}
+
+type NexusMatchStatus struct {
+	Label              string `json:"label"`
+	MatchKey           string `json:"matchKey"`
+	Status             string `json:"status"`
+	ScheduledStartTime int64  `json:"scheduledStartTime"`
+	EstimatedStartTime int64  `json:"estimatedStartTime"`
 }
 
 type nexusLineup struct {
@@ -28,8 +60,10 @@ type nexusLineup struct {
 	Blue [3]string `json:"blue"`
 }
 
-func NewNexusClient(eventCode string) *NexusClient {
-	return &NexusClient{BaseUrl: nexusBaseUrl, apiKey: nexusApiKey, eventCode: eventCode}
+// NewNexusClient creates a client for the given event. The event API key is issued by Nexus to the event organizers and
+// is only needed to push status updates.
+func NewNexusClient(eventCode, eventApiKey string) *NexusClient {
+	return &NexusClient{BaseUrl: nexusBaseUrl, apiKey: nexusApiKey, eventApiKey: eventApiKey, eventCode: eventCode}
 }
 
 // Gets the team lineup for a given match from the Nexus API. Returns nil and an error if the lineup is not available.
@@ -77,13 +111,81 @@ func (client *NexusClient) GetLineup(tbaMatchKey model.TbaMatchKey) (*[6]int, er
 	return nil, fmt.Errorf("Lineup not yet submitted")
 }
 
+// Sends the current queueing status and match timing to Nexus.
+//
+// This endpoint is not part of the published Nexus API; it follows a contract agreed on ahead of its release: a PUT of
+// the JSON-encoded NexusEventStatus to /api/v1/event/{eventCode}/status, authenticated by the event API key in the
+// Nexus-Api-Key header, answered with a 200 on success. MockNexusServer implements the same contract, and the arena only
+// calls this when the NexusStatusEnabled setting is on.
+func (client *NexusClient) PushEventStatus(status NexusEventStatus) error {
+	if client.eventApiKey == "" {
+		return fmt.Errorf("Nexus event API key is not configured")
+	}
+	body, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	path := fmt.Sprintf("/api/v1/event/%s/status", client.eventCode)
+	request, err := http.NewRequest("PUT", client.BaseUrl+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Nexus-Api-Key", client.eventApiKey)
+	resp, err := newNexusHttpClient().Do(request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		responseBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("Error pushing status to Nexus: %d, %s", resp.StatusCode, string(responseBody))
+	}
+	return nil
+}
+
+// Gets the notes that pit volunteers have entered in Nexus for each team, keyed by team number.
+func (client *NexusClient) GetPitNotes() (map[int]string, error) {
+	path := fmt.Sprintf("/api/v1/event/%s/pitNotes?key=%s", client.eventCode, client.apiKey)
+	resp, err := client.getRequest(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the response and handle errors
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("Error getting pit notes from Nexus: %d, %s", resp.StatusCode, string(body))
+	}
+
+	var nexusPitNotes map[string]string
+	if err = json.Unmarshal(body, &nexusPitNotes); err != nil {
+		return nil, err
+	}
+	pitNotes := make(map[int]string)
+	for team, note := range nexusPitNotes {
+		if teamId, err := strconv.Atoi(team); err == nil && note != "" {
+			pitNotes[teamId] = note
+		}
+	}
+	return pitNotes, nil
+}
+
 // Sends a GET request to the Nexus API.
 func (client *NexusClient) getRequest(path string) (*http.Response, error) {
 	url := client.BaseUrl + path
-	httpClient := &http.Client{}
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
-	return httpClient.Do(req)
+	return newNexusHttpClient().Do(req)
+}
+
+// Returns an HTTP client that gives up on a request to Nexus after the timeout.
+func newNexusHttpClient() *http.Client {
+	return &http.Client{Timeout: nexusRequestTimeoutSec * time.Second}
 }
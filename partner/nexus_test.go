@@ -31,7 +31,7 @@ func TestGetLineup(t *testing.T) {
 		),
 	)
 	defer nexusServer.Close()
-	client := NewNexusClient("my_event_code")
+	client := NewNexusClient("my_event_code", "")
 	client.BaseUrl = nexusServer.URL
 
 	tbaMatchKey := model.TbaMatchKey{CompLevel: "p", SetNumber: 0, MatchNumber: 1}
@@ -60,3 +60,67 @@ func TestGetLineup(t *testing.T) {
 		assert.Contains(t, err.Error(), "Lineup not yet submitted")
 	}
 }
+
+func TestPushEventStatus(t *testing.T) {
+	mock := NewMockNexusServer("my_event_code", "event_key")
+	defer mock.Close()
+	status := NexusEventStatus{
+		NowQueuing:   "Qualification 3",
+		DelayMinutes: 4,
+		Message:      "Event is running 4 minutes late",
+		Matches: []NexusMatchStatus{
+			{Label: "Qualification 1", MatchKey: "qm1", Status: NexusOnField, EstimatedStartTime: 1000},
+			{Label: "Qualification 2", MatchKey: "qm2", Status: NexusOnDeck, EstimatedStartTime: 2000},
+		},
+	}
+
+	client := NewNexusClient("my_event_code", "")
+	client.BaseUrl = mock.URL()
+	err := client.PushEventStatus(status)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "event API key is not configured")
+	}
+
+	client = NewNexusClient("my_event_code", "wrong_key")
+	client.BaseUrl = mock.URL()
+	err = client.PushEventStatus(status)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "Error pushing status to Nexus: 401")
+	}
+
+	client = NewNexusClient("my_event_code", "event_key")
+	client.BaseUrl = mock.URL()
+	mock.FailPushes(1)
+	err = client.PushEventStatus(status)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "503")
+	}
+	assert.Nil(t, client.PushEventStatus(status))
+	assert.Equal(t, []NexusEventStatus{status}, mock.GetStatuses())
+}
+
+func TestGetPitNotes(t *testing.T) {
+	mock := NewMockNexusServer("my_event_code", "event_key")
+	defer mock.Close()
+	client := NewNexusClient("my_event_code", "")
+	client.BaseUrl = mock.URL()
+
+	pitNotes, err := client.GetPitNotes()
+	assert.Nil(t, err)
+	assert.Empty(t, pitNotes)
+
+	mock.SetPitNote(254, "Replacing drivetrain; may be late to queue")
+	mock.SetPitNote(1114, "Inspection pending")
+	pitNotes, err = client.GetPitNotes()
+	assert.Nil(t, err)
+	assert.Equal(
+		t, map[int]string{254: "Replacing drivetrain; may be late to queue", 1114: "Inspection pending"}, pitNotes,
+	)
+
+	client = NewNexusClient("other_event_code", "")
+	client.BaseUrl = mock.URL()
+	_, err = client.GetPitNotes()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "Error getting pit notes from Nexus: 404")
+	}
+}
@@ -13,10 +13,8 @@ import (
 	"fmt"
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
-	"github.com/mitchellh/mapstructure"
 	"io"
 	"net/http"
-	"os"
 	"strconv"
 )
 
@@ -52,64 +50,15 @@ type TbaAlliance struct {
 	Score      *int     `json:"score"`
 }
 
-type TbaScoreBreakdown struct {
-	AutoLineRobot1          string  `mapstructure:"autoLineRobot1"`
-	AutoLineRobot2          string  `mapstructure:"autoLineRobot2"`
-	AutoLineRobot3          string  `mapstructure:"autoLineRobot3"`
-	AutoMobilityPoints      int     `mapstructure:"autoMobilityPoints"`
-	AutoReef                tbaReef `mapstructure:"autoReef"`
-	AutoCoralCount          int     `mapstructure:"autoCoralCount"`
-	AutoCoralPoints         int     `mapstructure:"autoCoralPoints"`
-	AutoPoints              int     `mapstructure:"autoPoints"`
-	TeleopReef              tbaReef `mapstructure:"teleopReef"`
-	TeleopCoralCount        int     `mapstructure:"teleopCoralCount"`
-	TeleopCoralPoints       int     `mapstructure:"teleopCoralPoints"`
-	NetAlgaeCount           int     `mapstructure:"netAlgaeCount"`
-	WallAlgaeCount          int     `mapstructure:"wallAlgaeCount"`
-	AlgaePoints             int     `mapstructure:"algaePoints"`
-	EndGameRobot1           string  `mapstructure:"endGameRobot1"`
-	EndGameRobot2           string  `mapstructure:"endGameRobot2"`
-	EndGameRobot3           string  `mapstructure:"endGameRobot3"`
-	EndGameBargePoints      int     `mapstructure:"endGameBargePoints"`
-	TeleopPoints            int     `mapstructure:"teleopPoints"`
-	CoopertitionCriteriaMet bool    `mapstructure:"coopertitionCriteriaMet"`
-	AutoBonusAchieved       bool    `mapstructure:"autoBonusAchieved"`
-	CoralBonusAchieved      bool    `mapstructure:"coralBonusAchieved"`
-	BargeBonusAchieved      bool    `mapstructure:"bargeBonusAchieved"`
-	FoulCount               int     `mapstructure:"foulCount"`
-	TechFoulCount           int     `mapstructure:"techFoulCount"`
-	G206Penalty             bool    `mapstructure:"g206Penalty"`
-	G410Penalty             bool    `mapstructure:"g410Penalty"`
-	G418Penalty             bool    `mapstructure:"g418Penalty"`
-	G428Penalty             bool    `mapstructure:"g428Penalty"`
-	FoulPoints              int     `mapstructure:"foulPoints"`
-	TotalPoints             int     `mapstructure:"totalPoints"`
-	RP                      int     `mapstructure:"rp"`
-}
-
-type tbaReef struct {
-	BotRow         map[string]bool `mapstructure:"botRow"`
-	MidRow         map[string]bool `mapstructure:"midRow"`
-	TopRow         map[string]bool `mapstructure:"topRow"`
-	TbaBotRowCount int             `mapstructure:"tba_botRowCount"`
-	TbaMidRowCount int             `mapstructure:"tba_midRowCount"`
-	TbaTopRowCount int             `mapstructure:"tba_topRowCount"`
-	Trough         int             `mapstructure:"trough"`
-}
-
 type TbaRanking struct {
-	TeamKey string `json:"team_key"`
-	Rank    int    `json:"rank"`
-	RP      float32
-	Coop    float32
-	Match   float32
-	Auto    float32
-	Barge   float32
-	Wins    int `json:"wins"`
-	Losses  int `json:"losses"`
-	Ties    int `json:"ties"`
-	Dqs     int `json:"dqs"`
-	Played  int `json:"played"`
+	TeamKey    string             `json:"team_key"`
+	Rank       int                `json:"rank"`
+	Breakdowns map[string]float32 `json:"-"`
+	Wins       int                `json:"wins"`
+	Losses     int                `json:"losses"`
+	Ties       int                `json:"ties"`
+	Dqs        int                `json:"dqs"`
+	Played     int                `json:"played"`
 }
 
 type TbaRankings struct {
@@ -154,14 +103,6 @@ type TbaPublishedAward struct {
 	Awardee string `json:"awardee"`
 }
 
-var leaveMapping = map[bool]string{false: "No", true: "Yes"}
-var endGameStatusMapping = map[game.EndgameStatus]string{
-	game.EndgameNone:        "None",
-	game.EndgameParked:      "Parked",
-	game.EndgameShallowCage: "ShallowCage",
-	game.EndgameDeepCage:    "DeepCage",
-}
-
 func NewTbaClient(eventCode, secretId, secret string) *TbaClient {
 	return &TbaClient{
 		BaseUrl:         tbaBaseUrl,
@@ -252,44 +193,76 @@ func (client *TbaClient) GetTeamAwards(teamNumber int) ([]*TbaAward, error) {
 	return awards, nil
 }
 
-func (client *TbaClient) DownloadTeamAvatar(teamNumber, year int) error {
+// Gets the team's avatar for the given year as PNG data, or nil if it doesn't have one.
+func (client *TbaClient) GetTeamAvatar(teamNumber, year int) ([]byte, error) {
 	path := fmt.Sprintf("/api/v3/team/%s/media/%d", getTbaTeam(teamNumber), year)
 	resp, err := client.getRequest(path)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Get the response and handle errors
 	defer resp.Body.Close()
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var mediaItems []*TbaMediaItem
 	err = json.Unmarshal(body, &mediaItems)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for _, item := range mediaItems {
 		if item.Type == "avatar" {
 			base64String, ok := item.Details["base64Image"].(string)
 			if !ok {
-				return fmt.Errorf("Could not interpret avatar response from TBA: %v", item)
-			}
-			avatarBytes, err := base64.StdEncoding.DecodeString(base64String)
-			if err != nil {
-				return err
+				return nil, fmt.Errorf("Could not interpret avatar response from TBA: %v", item)
 			}
-
-			// Store the avatar to disk as a PNG file.
-			avatarPath := fmt.Sprintf("%s/%d.png", AvatarsDir, teamNumber)
-			return os.WriteFile(avatarPath, avatarBytes, 0644)
+			return base64.StdEncoding.DecodeString(base64String)
 		}
 	}
 
-	return nil
+	return nil, nil
+}
+
+// Gets everything that is shown about the team at the event, as of the given year, in the form that it is stored
+// locally. Returns nil if the team doesn't exist.
+func (client *TbaClient) GetTeamData(teamNumber, year int) (*model.TeamData, error) {
+	tbaTeam, err := client.GetTeam(teamNumber)
+	if err != nil {
+		return nil, err
+	}
+	if tbaTeam.TeamNumber == 0 {
+		return nil, nil
+	}
+
+	teamData := model.TeamData{
+		Id:         teamNumber,
+		Name:       tbaTeam.Name,
+		Nickname:   tbaTeam.Nickname,
+		City:       tbaTeam.City,
+		StateProv:  tbaTeam.StateProv,
+		Country:    tbaTeam.Country,
+		RookieYear: tbaTeam.RookieYear,
+	}
+	if teamData.RobotName, err = client.GetRobotName(teamNumber, year); err != nil {
+		return nil, err
+	}
+	awards, err := client.GetTeamAwards(teamNumber)
+	if err != nil {
+		return nil, err
+	}
+	for _, award := range awards {
+		teamData.Awards = append(
+			teamData.Awards, model.TeamDataAward{Year: award.Year, EventName: award.EventName, Name: award.Name},
+		)
+	}
+	if teamData.Avatar, err = client.GetTeamAvatar(teamNumber, year); err != nil {
+		return nil, err
+	}
+	return &teamData, nil
 }
 
 // Uploads the event team list to The Blue Alliance.
@@ -331,10 +304,6 @@ func (client *TbaClient) PublishMatches(database *model.Database) error {
 	if err != nil {
 		return err
 	}
-	eventSettings, err := database.GetEventSettings()
-	if err != nil {
-		return err
-	}
 	matches := append(qualMatches, playoffMatches...)
 	tbaMatches := make([]TbaMatch, len(matches))
 
@@ -344,6 +313,7 @@ func (client *TbaClient) PublishMatches(database *model.Database) error {
 		var scoreBreakdown map[string]map[string]any
 		var redScore, blueScore *int
 		var redCards, blueCards map[string]string
+		var displayName string
 		if match.IsComplete() {
 			matchResult, err := database.GetMatchResultForMatch(match.Id)
 			if err != nil {
@@ -351,14 +321,20 @@ func (client *TbaClient) PublishMatches(database *model.Database) error {
 			}
 			if matchResult != nil {
 				scoreBreakdown = make(map[string]map[string]any)
-				scoreBreakdown["red"] = createTbaScoringBreakdown(eventSettings, &match, matchResult, "red")
-				scoreBreakdown["blue"] = createTbaScoringBreakdown(eventSettings, &match, matchResult, "blue")
+				scoreBreakdown["red"] = createTbaScoringBreakdown(&match, matchResult, "red")
+				scoreBreakdown["blue"] = createTbaScoringBreakdown(&match, matchResult, "blue")
 				redScoreValue := scoreBreakdown["red"]["totalPoints"].(int)
 				blueScoreValue, _ := scoreBreakdown["blue"]["totalPoints"].(int)
 				redScore = &redScoreValue
 				blueScore = &blueScoreValue
 				redCards = matchResult.RedCards
 				blueCards = matchResult.BlueCards
+				if matchResult.PlayNumber > 1 {
+					// TBA match keys have no place for a play number, and TBA expects a replay to overwrite the
+					// original result under the same key, so the key stays fixed and only the display name marks the
+					// results as being from a replay.
+					displayName = fmt.Sprintf("%s (Play %d)", match.LongName, matchResult.PlayNumber)
+				}
 			}
 		}
 		alliances := make(map[string]*TbaAlliance)
@@ -383,6 +359,7 @@ func (client *TbaClient) PublishMatches(database *model.Database) error {
 			ScoreBreakdown: scoreBreakdown,
 			TimeString:     match.Time.Local().Format("3:04 PM"),
 			TimeUtc:        match.Time.UTC().Format("2006-01-02T15:04:05"),
+			DisplayName:    displayName,
 		}
 	}
 	jsonBody, err := json.Marshal(tbaMatches)
@@ -410,22 +387,23 @@ func (client *TbaClient) PublishRankings(database *model.Database) error {
 	}
 
 	// Build a JSON object of TBA-format rankings.
-	breakdowns := []string{"RP", "Coop", "Match", "Auto", "Barge"}
+	var breakdowns []string
 	tbaRankings := make([]TbaRanking, len(rankings))
 	for i, ranking := range rankings {
+		var values []float32
+		breakdowns, values = game.CurrentGame.TbaRankingBreakdown(&ranking.RankingFields)
 		tbaRankings[i] = TbaRanking{
-			TeamKey: getTbaTeam(ranking.TeamId),
-			Rank:    ranking.Rank,
-			RP:      float32(ranking.RankingPoints) / float32(ranking.Played),
-			Coop:    float32(ranking.CoopertitionPoints) / float32(ranking.Played),
-			Match:   float32(ranking.MatchPoints) / float32(ranking.Played),
-			Auto:    float32(ranking.AutoPoints) / float32(ranking.Played),
-			Barge:   float32(ranking.BargePoints) / float32(ranking.Played),
-			Wins:    ranking.Wins,
-			Losses:  ranking.Losses,
-			Ties:    ranking.Ties,
-			Dqs:     ranking.Disqualifications,
-			Played:  ranking.Played,
+			TeamKey:    getTbaTeam(ranking.TeamId),
+			Rank:       ranking.Rank,
+			Breakdowns: make(map[string]float32, len(breakdowns)),
+			Wins:       ranking.Wins,
+			Losses:     ranking.Losses,
+			Ties:       ranking.Ties,
+			Dqs:        ranking.Disqualifications,
+			Played:     ranking.Played,
+		}
+		for j, breakdown := range breakdowns {
+			tbaRankings[i].Breakdowns[breakdown] = values[j]
 		}
 	}
 	jsonBody, err := json.Marshal(TbaRankings{breakdowns, tbaRankings})
@@ -564,6 +542,23 @@ func (client *TbaClient) getEventName(eventCode string) (string, error) {
 	return event.Name, err
 }
 
+// MarshalJSON serializes the game-specific ranking breakdowns inline with the common fields, keyed by name.
+func (ranking TbaRanking) MarshalJSON() ([]byte, error) {
+	type tbaRankingAlias TbaRanking
+	rankingJson, err := json.Marshal(tbaRankingAlias(ranking))
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]any)
+	if err = json.Unmarshal(rankingJson, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range ranking.Breakdowns {
+		fields[name] = value
+	}
+	return json.Marshal(fields)
+}
+
 // Converts an integer team number into the "frcXXXX" format TBA expects.
 func getTbaTeam(team int) string {
 	return fmt.Sprintf("frc%d", team)
@@ -628,12 +623,10 @@ func createTbaAlliance(teamIds [3]int, surrogates [3]bool, score *int, cards map
 }
 
 func createTbaScoringBreakdown(
-	eventSettings *model.EventSettings,
 	match *model.Match,
 	matchResult *model.MatchResult,
 	alliance string,
 ) map[string]any {
-	var breakdown TbaScoreBreakdown
 	var score *game.Score
 	var scoreSummary, opponentScoreSummary *game.ScoreSummary
 	if alliance == "red" {
@@ -646,91 +639,16 @@ func createTbaScoringBreakdown(
 		opponentScoreSummary = matchResult.RedScoreSummary()
 	}
 
-	breakdown.AutoLineRobot1 = leaveMapping[score.LeaveStatuses[0]]
-	breakdown.AutoLineRobot2 = leaveMapping[score.LeaveStatuses[1]]
-	breakdown.AutoLineRobot3 = leaveMapping[score.LeaveStatuses[2]]
-	breakdown.AutoMobilityPoints = scoreSummary.LeavePoints
-	breakdown.AutoReef.BotRow = make(map[string]bool)
-	breakdown.AutoReef.MidRow = make(map[string]bool)
-	breakdown.AutoReef.TopRow = make(map[string]bool)
-	for i := 0; i < 12; i++ {
-		breakdown.AutoReef.BotRow["node"+string(rune('A'+i))] = score.Reef.AutoBranches[game.Level2][i]
-		breakdown.AutoReef.MidRow["node"+string(rune('A'+i))] = score.Reef.AutoBranches[game.Level3][i]
-		breakdown.AutoReef.TopRow["node"+string(rune('A'+i))] = score.Reef.AutoBranches[game.Level4][i]
-	}
-	breakdown.AutoReef.TbaBotRowCount = score.Reef.CountCoralByLevelAndPeriod(game.Level2, true)
-	breakdown.AutoReef.TbaMidRowCount = score.Reef.CountCoralByLevelAndPeriod(game.Level3, true)
-	breakdown.AutoReef.TbaTopRowCount = score.Reef.CountCoralByLevelAndPeriod(game.Level4, true)
-	breakdown.AutoReef.Trough = score.Reef.CountCoralByLevelAndPeriod(game.Level1, true)
-	breakdown.AutoCoralCount = score.Reef.AutoCoralCount()
-	breakdown.AutoCoralPoints = score.Reef.AutoCoralPoints()
-	breakdown.AutoPoints = scoreSummary.AutoPoints
-	breakdown.TeleopReef.BotRow = make(map[string]bool)
-	breakdown.TeleopReef.MidRow = make(map[string]bool)
-	breakdown.TeleopReef.TopRow = make(map[string]bool)
-	for i := 0; i < 12; i++ {
-		breakdown.TeleopReef.BotRow["node"+string(rune('A'+i))] = score.Reef.Branches[game.Level2][i]
-		breakdown.TeleopReef.MidRow["node"+string(rune('A'+i))] = score.Reef.Branches[game.Level3][i]
-		breakdown.TeleopReef.TopRow["node"+string(rune('A'+i))] = score.Reef.Branches[game.Level4][i]
-	}
-	breakdown.TeleopReef.TbaBotRowCount = breakdown.AutoReef.TbaBotRowCount +
-		score.Reef.CountCoralByLevelAndPeriod(game.Level2, false)
-	breakdown.TeleopReef.TbaMidRowCount = breakdown.AutoReef.TbaMidRowCount +
-		score.Reef.CountCoralByLevelAndPeriod(game.Level3, false)
-	breakdown.TeleopReef.TbaTopRowCount = breakdown.AutoReef.TbaTopRowCount +
-		score.Reef.CountCoralByLevelAndPeriod(game.Level4, false)
-	breakdown.TeleopReef.Trough = score.Reef.CountCoralByLevelAndPeriod(game.Level1, false)
-	breakdown.TeleopCoralCount = score.Reef.TeleopCoralCount()
-	teleopCoralPoints := score.Reef.TeleopCoralPoints()
-	breakdown.TeleopCoralPoints = teleopCoralPoints
-	breakdown.NetAlgaeCount = score.BargeAlgae
-	breakdown.WallAlgaeCount = score.ProcessorAlgae
-	breakdown.AlgaePoints = scoreSummary.AlgaePoints
-	breakdown.EndGameRobot1 = endGameStatusMapping[score.EndgameStatuses[0]]
-	breakdown.EndGameRobot2 = endGameStatusMapping[score.EndgameStatuses[1]]
-	breakdown.EndGameRobot3 = endGameStatusMapping[score.EndgameStatuses[2]]
-	breakdown.EndGameBargePoints = scoreSummary.BargePoints
-	breakdown.TeleopPoints = teleopCoralPoints + scoreSummary.AlgaePoints + scoreSummary.BargePoints
-	breakdown.CoopertitionCriteriaMet = scoreSummary.CoopertitionCriteriaMet
-	breakdown.AutoBonusAchieved = scoreSummary.AutoBonusRankingPoint
-	breakdown.CoralBonusAchieved = scoreSummary.CoralBonusRankingPoint
-	breakdown.BargeBonusAchieved = scoreSummary.BargeBonusRankingPoint
-	for _, foul := range score.Fouls {
-		if foul.IsMajor {
-			breakdown.TechFoulCount++
-		} else if foul.PointValue() > 0 {
-			breakdown.FoulCount++
-		}
-		if foul.Rule() != nil && foul.Rule().IsRankingPoint {
-			switch foul.Rule().RuleNumber {
-			case "G206":
-				breakdown.G206Penalty = true
-			case "G410":
-				breakdown.G410Penalty = true
-			case "G418":
-				breakdown.G418Penalty = true
-			case "G428":
-				breakdown.G428Penalty = true
-			}
-		}
-	}
-	breakdown.FoulPoints = scoreSummary.FoulPoints
-	breakdown.TotalPoints = scoreSummary.Score
-
+	breakdown := game.CurrentGame.TbaScoreBreakdown(score, scoreSummary)
+	breakdown["foulPoints"] = scoreSummary.FoulPoints
+	breakdown["totalPoints"] = scoreSummary.Score
+	breakdown["rp"] = 0
 	if match.ShouldUpdateRankings() {
 		// Calculate and set the ranking points for the match.
 		var ranking game.Ranking
 		ranking.AddScoreSummary(scoreSummary, opponentScoreSummary, false)
-		breakdown.RP = ranking.RankingPoints
-	}
-
-	// Turn the breakdown struct into a map in order to be able to remove any fields that are disabled based on the
-	// event settings.
-	breakdownMap := make(map[string]any)
-	_ = mapstructure.Decode(breakdown, &breakdownMap)
-	if !eventSettings.CoralBonusCoopEnabled {
-		delete(breakdownMap, "coopertitionCriteriaMet")
+		breakdown["rp"] = ranking.RankingPoints
 	}
 
-	return breakdownMap
+	return breakdown
 }
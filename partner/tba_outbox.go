@@ -0,0 +1,245 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Persistent queue of writes to The Blue Alliance, retried until they succeed.
+
+package partner
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+)
+
+const (
+	tbaOutboxPollPeriodSec    = 1
+	tbaOutboxMinRetryDelaySec = 5
+	tbaOutboxMaxRetryDelaySec = 300
+)
+
+// Kinds of writes to The Blue Alliance that can be queued in the outbox.
+const (
+	TbaPublishTeams           = "teams"
+	TbaPublishMatches         = "matches"
+	TbaPublishRankings        = "rankings"
+	TbaPublishAlliances       = "alliances"
+	TbaPublishAwards          = "awards"
+	TbaDeletePublishedMatches = "deleteMatches"
+)
+
+// Pairs of kinds whose relative order matters, such that a queued write of one can't absorb a later write of the same
+// kind if a write of the other kind was queued in between.
+var tbaOutboxConflictingKinds = map[string]string{
+	TbaPublishMatches:         TbaDeletePublishedMatches,
+	TbaDeletePublishedMatches: TbaPublishMatches,
+}
+
+// TbaOutbox queues writes to The Blue Alliance in the database and sends them in order, retrying with increasing delay
+// while TBA is unreachable so that nothing is lost when the venue internet connection drops. Each write publishes the
+// state of the database at the time it is sent, so a write that is already waiting absorbs any identical ones queued
+// after it.
+type TbaOutbox struct {
+	database      *model.Database
+	client        *TbaClient
+	enabled       bool
+	mutex         sync.Mutex
+	inFlightId    int
+	lastSuccessAt time.Time
+	wake          chan struct{}
+}
+
+// TbaOutboxStatus summarizes the state of the outbox for display.
+type TbaOutboxStatus struct {
+	Depth         int
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	LastSuccessAt time.Time
+}
+
+func NewTbaOutbox() *TbaOutbox {
+	return &TbaOutbox{wake: make(chan struct{}, 1)}
+}
+
+// Configure sets the database that the queue is kept in, the client that queued writes are sent with and whether they
+// should be sent at all. It must be called before the outbox is used. Any delay before the next retry is cleared, so
+// that corrected credentials take effect right away.
+func (outbox *TbaOutbox) Configure(database *model.Database, client *TbaClient, enabled bool) {
+	outbox.mutex.Lock()
+	defer outbox.mutex.Unlock()
+	outbox.database = database
+	outbox.client = client
+	outbox.enabled = enabled
+
+	publications, err := outbox.database.GetAllTbaPublications()
+	if err != nil {
+		log.Printf("Failed to read TBA outbox: %v", err)
+		return
+	}
+	if len(publications) > 0 && !publications[0].NextAttemptAt.IsZero() {
+		publications[0].NextAttemptAt = time.Time{}
+		if err = outbox.database.UpdateTbaPublication(&publications[0]); err != nil {
+			log.Printf("Failed to update TBA outbox: %v", err)
+		}
+	}
+	outbox.notify()
+}
+
+// Enqueue adds a write of the given kind to the end of the queue, unless an equivalent write is already waiting.
+func (outbox *TbaOutbox) Enqueue(kind string) error {
+	if _, err := tbaOutboxAction(nil, kind); err != nil {
+		return err
+	}
+
+	outbox.mutex.Lock()
+	defer outbox.mutex.Unlock()
+	publications, err := outbox.database.GetAllTbaPublications()
+	if err != nil {
+		return err
+	}
+	for i := len(publications) - 1; i >= 0; i-- {
+		if publications[i].Id == outbox.inFlightId || publications[i].Kind == tbaOutboxConflictingKinds[kind] {
+			// The data already being sent may be stale, and conflicting writes must stay in order.
+			break
+		}
+		if publications[i].Kind == kind {
+			return nil
+		}
+	}
+
+	publication := model.TbaPublication{Kind: kind, QueuedAt: time.Now()}
+	if err = outbox.database.CreateTbaPublication(&publication); err != nil {
+		return err
+	}
+	outbox.notify()
+	return nil
+}
+
+// GetStatus returns the number of queued writes and the state of the one at the head of the queue.
+func (outbox *TbaOutbox) GetStatus() (TbaOutboxStatus, error) {
+	outbox.mutex.Lock()
+	defer outbox.mutex.Unlock()
+	status := TbaOutboxStatus{LastSuccessAt: outbox.lastSuccessAt}
+	publications, err := outbox.database.GetAllTbaPublications()
+	if err != nil {
+		return status, err
+	}
+	status.Depth = len(publications)
+	if len(publications) > 0 {
+		status.Attempts = publications[0].Attempts
+		status.LastError = publications[0].LastError
+		status.NextAttemptAt = publications[0].NextAttemptAt
+	}
+	return status, nil
+}
+
+// Run loops indefinitely, sending queued writes as they are added and retrying failed ones when they come due.
+func (outbox *TbaOutbox) Run() {
+	for {
+		select {
+		case <-outbox.wake:
+		case <-time.After(tbaOutboxPollPeriodSec * time.Second):
+		}
+		outbox.processQueue(time.Now())
+	}
+}
+
+// Sends queued writes in order until the queue is empty or one fails.
+func (outbox *TbaOutbox) processQueue(now time.Time) {
+	for {
+		outbox.mutex.Lock()
+		if !outbox.enabled || outbox.client == nil {
+			outbox.mutex.Unlock()
+			return
+		}
+		publications, err := outbox.database.GetAllTbaPublications()
+		if err != nil || len(publications) == 0 || now.Before(publications[0].NextAttemptAt) {
+			if err != nil {
+				log.Printf("Failed to read TBA outbox: %v", err)
+			}
+			outbox.mutex.Unlock()
+			return
+		}
+		publication := publications[0]
+		client := outbox.client
+		outbox.inFlightId = publication.Id
+		outbox.mutex.Unlock()
+
+		// Send without holding the lock so that writes can continue to be queued while TBA is slow to respond.
+		var sendErr error
+		action, err := tbaOutboxAction(client, publication.Kind)
+		if err == nil {
+			sendErr = action(outbox.database)
+		}
+
+		outbox.mutex.Lock()
+		outbox.inFlightId = 0
+		if err != nil || sendErr == nil {
+			if err != nil {
+				log.Printf("Discarding TBA outbox entry: %v", err)
+			} else {
+				outbox.lastSuccessAt = now
+			}
+			err = outbox.database.DeleteTbaPublication(publication.Id)
+		} else {
+			publication.Attempts++
+			publication.LastError = sendErr.Error()
+			publication.NextAttemptAt = now.Add(tbaOutboxRetryDelay(publication.Attempts))
+			log.Printf(
+				"Failed to publish %s to TBA (attempt %d); retrying at %s: %v",
+				publication.Kind,
+				publication.Attempts,
+				publication.NextAttemptAt.Format("15:04:05"),
+				sendErr,
+			)
+			err = outbox.database.UpdateTbaPublication(&publication)
+		}
+		outbox.mutex.Unlock()
+		if err != nil {
+			log.Printf("Failed to update TBA outbox: %v", err)
+			return
+		}
+		if sendErr != nil {
+			return
+		}
+	}
+}
+
+// Wakes up the run loop without blocking if it is already due to wake.
+func (outbox *TbaOutbox) notify() {
+	select {
+	case outbox.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Returns the function that performs the write of the given kind using the given client.
+func tbaOutboxAction(client *TbaClient, kind string) (func(*model.Database) error, error) {
+	switch kind {
+	case TbaPublishTeams:
+		return client.PublishTeams, nil
+	case TbaPublishMatches:
+		return client.PublishMatches, nil
+	case TbaPublishRankings:
+		return client.PublishRankings, nil
+	case TbaPublishAlliances:
+		return client.PublishAlliances, nil
+	case TbaPublishAwards:
+		return client.PublishAwards, nil
+	case TbaDeletePublishedMatches:
+		return func(*model.Database) error { return client.DeletePublishedMatches() }, nil
+	}
+	return nil, fmt.Errorf("invalid TBA publication kind %q", kind)
+}
+
+// Returns how long to wait before retrying a write that has failed the given number of times, doubling with each
+// attempt up to a limit.
+func tbaOutboxRetryDelay(attempts int) time.Duration {
+	delay := tbaOutboxMinRetryDelaySec * time.Second
+	for i := 1; i < attempts && delay < tbaOutboxMaxRetryDelaySec*time.Second; i++ {
+		delay *= 2
+	}
+	return min(delay, tbaOutboxMaxRetryDelaySec*time.Second)
+}
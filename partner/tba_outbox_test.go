@@ -0,0 +1,167 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package partner
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// Mocks the TBA server, recording the resource and action of each write and failing while the given flag is set.
+func setupTestTbaOutbox(t *testing.T, failing *bool) (*TbaOutbox, *[]string) {
+	var requests []string
+	tbaServer := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if *failing {
+					http.Error(w, "TBA is down", 503)
+					return
+				}
+				requests = append(requests, strings.TrimPrefix(r.URL.Path, "/api/trusted/v1/event/my_event_code/"))
+			},
+		),
+	)
+	t.Cleanup(tbaServer.Close)
+	client := NewTbaClient("my_event_code", "my_secret_id", "my_secret")
+	client.BaseUrl = tbaServer.URL
+	outbox := NewTbaOutbox()
+	outbox.Configure(setupTestDb(t), client, true)
+	return outbox, &requests
+}
+
+func getTbaOutboxKinds(t *testing.T, outbox *TbaOutbox) []string {
+	publications, err := outbox.database.GetAllTbaPublications()
+	assert.Nil(t, err)
+	kinds := []string{}
+	for _, publication := range publications {
+		kinds = append(kinds, publication.Kind)
+	}
+	return kinds
+}
+
+func TestTbaOutboxCoalescing(t *testing.T) {
+	failing := false
+	outbox, requests := setupTestTbaOutbox(t, &failing)
+
+	assert.Nil(t, outbox.Enqueue(TbaPublishMatches))
+	assert.Nil(t, outbox.Enqueue(TbaPublishRankings))
+	assert.Nil(t, outbox.Enqueue(TbaPublishMatches))
+	assert.Nil(t, outbox.Enqueue(TbaPublishRankings))
+	assert.Equal(t, []string{"matches", "rankings"}, getTbaOutboxKinds(t, outbox))
+
+	// Deleting and republishing the matches must happen after the pending publish and in order.
+	assert.Nil(t, outbox.Enqueue(TbaDeletePublishedMatches))
+	assert.Nil(t, outbox.Enqueue(TbaPublishMatches))
+	assert.Nil(t, outbox.Enqueue(TbaDeletePublishedMatches))
+	assert.Equal(
+		t,
+		[]string{"matches", "rankings", "deleteMatches", "matches", "deleteMatches"},
+		getTbaOutboxKinds(t, outbox),
+	)
+
+	err := outbox.Enqueue("bogus")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "invalid TBA publication kind")
+	}
+
+	outbox.processQueue(time.Now())
+	assert.Empty(t, getTbaOutboxKinds(t, outbox))
+	assert.Equal(
+		t,
+		[]string{"matches/update", "rankings/update", "matches/delete_all", "matches/update", "matches/delete_all"},
+		*requests,
+	)
+	status, err := outbox.GetStatus()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, status.Depth)
+	assert.False(t, status.LastSuccessAt.IsZero())
+}
+
+func TestTbaOutboxRetry(t *testing.T) {
+	failing := true
+	outbox, requests := setupTestTbaOutbox(t, &failing)
+	now := time.Unix(1000, 0)
+
+	assert.Nil(t, outbox.Enqueue(TbaPublishMatches))
+	assert.Nil(t, outbox.Enqueue(TbaPublishRankings))
+	outbox.processQueue(now)
+	status, err := outbox.GetStatus()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, status.Depth)
+	assert.Equal(t, 1, status.Attempts)
+	assert.Contains(t, status.LastError, "Got status code 503 from TBA")
+	assert.True(t, now.Add(5*time.Second).Equal(status.NextAttemptAt))
+
+	// Nothing should be attempted until the retry delay has elapsed, which doubles with each failure.
+	outbox.processQueue(now.Add(4 * time.Second))
+	status, _ = outbox.GetStatus()
+	assert.Equal(t, 1, status.Attempts)
+	outbox.processQueue(now.Add(5 * time.Second))
+	status, _ = outbox.GetStatus()
+	assert.Equal(t, 2, status.Attempts)
+	assert.True(t, now.Add(15*time.Second).Equal(status.NextAttemptAt))
+
+	// A write that is waiting to be retried should still absorb identical ones.
+	assert.Nil(t, outbox.Enqueue(TbaPublishMatches))
+	assert.Equal(t, []string{"matches", "rankings"}, getTbaOutboxKinds(t, outbox))
+
+	// Once TBA is reachable again, everything should be sent.
+	failing = false
+	outbox.processQueue(now.Add(15 * time.Second))
+	assert.Equal(t, []string{"matches/update", "rankings/update"}, *requests)
+	status, _ = outbox.GetStatus()
+	assert.Equal(t, TbaOutboxStatus{LastSuccessAt: now.Add(15 * time.Second)}, status)
+}
+
+func TestTbaOutboxDisabled(t *testing.T) {
+	failing := true
+	outbox, requests := setupTestTbaOutbox(t, &failing)
+	client := outbox.client
+	assert.Nil(t, outbox.Enqueue(TbaPublishTeams))
+	outbox.processQueue(time.Now())
+	status, _ := outbox.GetStatus()
+	assert.False(t, status.NextAttemptAt.IsZero())
+
+	// Writes should be held while publishing is disabled.
+	failing = false
+	outbox.Configure(outbox.database, client, false)
+	outbox.processQueue(time.Now())
+	assert.Empty(t, *requests)
+	assert.Equal(t, []string{"teams"}, getTbaOutboxKinds(t, outbox))
+
+	// Re-enabling publishing should retry immediately rather than waiting out the delay.
+	outbox.Configure(outbox.database, client, true)
+	outbox.processQueue(time.Now())
+	assert.Equal(t, []string{"team_list/update"}, *requests)
+	assert.Empty(t, getTbaOutboxKinds(t, outbox))
+}
+
+func TestTbaOutboxRetryDelay(t *testing.T) {
+	assert.Equal(t, 5*time.Second, tbaOutboxRetryDelay(1))
+	assert.Equal(t, 10*time.Second, tbaOutboxRetryDelay(2))
+	assert.Equal(t, 160*time.Second, tbaOutboxRetryDelay(6))
+	assert.Equal(t, 300*time.Second, tbaOutboxRetryDelay(7))
+	assert.Equal(t, 300*time.Second, tbaOutboxRetryDelay(100))
+}
+
+func TestTbaOutboxPersistence(t *testing.T) {
+	database := setupTestDb(t)
+	outbox := NewTbaOutbox()
+	outbox.Configure(database, nil, false)
+	assert.Nil(t, outbox.Enqueue(TbaPublishAwards))
+
+	// A new outbox on the same database should pick up where the old one left off.
+	outbox = NewTbaOutbox()
+	outbox.Configure(database, nil, false)
+	assert.Nil(t, outbox.Enqueue(TbaPublishAwards))
+	assert.Nil(t, outbox.Enqueue(TbaPublishAlliances))
+	assert.Equal(t, []string{"awards", "alliances"}, getTbaOutboxKinds(t, outbox))
+	publications, _ := database.GetAllTbaPublications()
+	assert.Equal(t, model.TbaPublication{Id: 1, Kind: "awards", QueuedAt: publications[0].QueuedAt}, publications[0])
+}
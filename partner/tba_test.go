@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/game/reefscape"
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/stretchr/testify/assert"
 	"io"
@@ -49,6 +50,7 @@ func TestPublishMatches(t *testing.T) {
 	match1 := model.Match{
 		Type:        model.Qualification,
 		ShortName:   "Q2",
+		LongName:    "Qualification 2",
 		Time:        time.Unix(600, 0),
 		Red1:        7,
 		Red2:        8,
@@ -62,8 +64,9 @@ func TestPublishMatches(t *testing.T) {
 	match2 := model.Match{Type: model.Playoff, ShortName: "SF2-2", TbaMatchKey: model.TbaMatchKey{"omg", 5, 29}}
 	database.CreateMatch(&match1)
 	database.CreateMatch(&match2)
-	matchResult1 := model.BuildTestMatchResult(match1.Id, 1)
+	matchResult1 := buildTestMatchResult(match1.Id, 1)
 	database.CreateMatchResult(matchResult1)
+	database.CreateMatchResult(buildTestMatchResult(match1.Id, 2))
 
 	// Mock the TBA server.
 	tbaServer := httptest.NewServer(
@@ -76,6 +79,7 @@ func TestPublishMatches(t *testing.T) {
 				assert.Equal(t, "qm", matches[0].CompLevel)
 				assert.Equal(t, 0, matches[0].SetNumber)
 				assert.Equal(t, 2, matches[0].MatchNumber)
+				assert.Equal(t, "Qualification 2 (Play 2)", matches[0].DisplayName)
 				assert.Equal(t, "omg", matches[1].CompLevel)
 				assert.Equal(t, 5, matches[1].SetNumber)
 				assert.Equal(t, 29, matches[1].MatchNumber)
@@ -92,8 +96,8 @@ func TestPublishMatches(t *testing.T) {
 func TestPublishRankings(t *testing.T) {
 	database := setupTestDb(t)
 
-	database.CreateRanking(game.TestRanking2())
-	database.CreateRanking(game.TestRanking1())
+	database.CreateRanking(reefscape.TestRanking2())
+	database.CreateRanking(reefscape.TestRanking1())
 
 	// Mock the TBA server.
 	tbaServer := httptest.NewServer(
@@ -200,3 +204,60 @@ func TestPublishAwards(t *testing.T) {
 func setupTestDb(t *testing.T) *model.Database {
 	return model.SetupTestDb(t)
 }
+
+func buildTestMatchResult(matchId int, playNumber int) *model.MatchResult {
+	matchResult := &model.MatchResult{MatchId: matchId, PlayNumber: playNumber, MatchType: model.Qualification}
+	matchResult.RedScore = reefscape.TestScore1()
+	matchResult.BlueScore = reefscape.TestScore2()
+	matchResult.RedCards = map[string]string{"1868": "yellow"}
+	matchResult.BlueCards = map[string]string{}
+	return matchResult
+}
+
+func TestGetTeamData(t *testing.T) {
+	// Mock the TBA server.
+	tbaServer := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/v3/team/frc254":
+					w.Write([]byte(`{"team_number": 254, "nickname": "The Cheesy Poofs", "city": "San Jose", ` +
+						`"rookie_year": 1999}`))
+				case "/api/v3/team/frc254/robots":
+					w.Write([]byte(`[{"robot_name": "Barrage", "year": 2025}]`))
+				case "/api/v3/team/frc254/awards":
+					w.Write([]byte(`[{"event_key": "2024cmptx", "name": "Championship Winner", "year": 2024}]`))
+				case "/api/v3/event/2024cmptx":
+					w.Write([]byte(`{"name": "Einstein Field"}`))
+				case "/api/v3/team/frc254/media/2025":
+					w.Write([]byte(`[{"type": "imgur"}, {"type": "avatar", "details": {"base64Image": "AQID"}}]`))
+				case "/api/v3/team/frc9999":
+					w.Write([]byte(`{"Errors": [{"team_id": "frc9999 does not exist"}]}`))
+				default:
+					http.Error(w, "Unexpected request during test", 500)
+				}
+			},
+		),
+	)
+	defer tbaServer.Close()
+	client := NewTbaClient("my_event_code", "my_secret_id", "my_secret")
+	client.BaseUrl = tbaServer.URL
+
+	teamData, err := client.GetTeamData(254, 2025)
+	if assert.Nil(t, err) && assert.NotNil(t, teamData) {
+		assert.Equal(t, 254, teamData.Id)
+		assert.Equal(t, "The Cheesy Poofs", teamData.Nickname)
+		assert.Equal(t, "San Jose", teamData.City)
+		assert.Equal(t, 1999, teamData.RookieYear)
+		assert.Equal(t, "Barrage", teamData.RobotName)
+		assert.Equal(
+			t, []model.TeamDataAward{{Year: 2024, EventName: "Einstein Field", Name: "Championship Winner"}}, teamData.Awards,
+		)
+		assert.Equal(t, []byte{1, 2, 3}, teamData.Avatar)
+	}
+
+	// Check that a nonexistent team is reported as missing rather than as an error.
+	teamData, err = client.GetTeamData(9999, 2025)
+	assert.Nil(t, err)
+	assert.Nil(t, teamData)
+}
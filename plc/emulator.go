@@ -0,0 +1,269 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Minimal Modbus TCP server emulating the field PLC, for exercising the real Modbus client end-to-end in tests and on
+// a laptop without field hardware.
+
+package plc
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"sync"
+)
+
+// Modbus function codes supported by the emulator.
+const (
+	readCoilsFunction              = 0x01
+	readDiscreteInputsFunction     = 0x02
+	readHoldingRegistersFunction   = 0x03
+	writeSingleCoilFunction        = 0x05
+	writeSingleRegisterFunction    = 0x06
+	writeMultipleCoilsFunction     = 0x0f
+	writeMultipleRegistersFunction = 0x10
+)
+
+// Modbus exception codes returned by the emulator.
+const (
+	illegalFunctionException    = 0x01
+	illegalDataAddressException = 0x02
+	illegalDataValueException   = 0x03
+)
+
+const (
+	mbapHeaderLength = 7
+	maxPduLength     = 253
+)
+
+// Emulator is a Modbus TCP server holding the discrete inputs, holding registers and coils of an emulated field PLC.
+// Inputs and registers are set by the test or user driving it, and coils are written by the Modbus client.
+type Emulator struct {
+	ioMap       *IoMap
+	listener    net.Listener
+	mutex       sync.Mutex
+	inputs      [maxModbusInputs]bool
+	registers   [maxModbusRegisters]uint16
+	coils       [maxModbusCoils]bool
+	connections map[net.Conn]struct{}
+	requests    int
+}
+
+// NewEmulator creates an emulator laid out according to the given I/O map, in the state of a field that is ready to
+// run a match: all E-stops and A-stops released, all ArmorBlocks connected and all stations' Ethernet plugged in.
+func NewEmulator(ioMap *IoMap) *Emulator {
+	emulator := &Emulator{ioMap: ioMap, connections: make(map[net.Conn]struct{})}
+	simulatedPlc := NewSimulatedPlc()
+	for i, value := range simulatedPlc.inputs {
+		emulator.inputs[ioMap.Inputs[input(i).String()]] = value
+	}
+	for i, value := range simulatedPlc.registers {
+		emulator.registers[ioMap.Registers[register(i).String()]] = value
+	}
+	return emulator
+}
+
+// Listen opens the server socket on the given address (e.g. "127.0.0.1:0" for any free port).
+func (emulator *Emulator) Listen(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	emulator.listener = listener
+	return nil
+}
+
+// Address returns the address that the emulator is listening on, suitable for passing to PLC.SetAddress.
+func (emulator *Emulator) Address() string {
+	return emulator.listener.Addr().String()
+}
+
+// Serve accepts and services client connections until the emulator is closed.
+func (emulator *Emulator) Serve() {
+	for {
+		conn, err := emulator.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Printf("PLC emulator error accepting connection: %v", err)
+			}
+			return
+		}
+		emulator.mutex.Lock()
+		emulator.connections[conn] = struct{}{}
+		emulator.mutex.Unlock()
+		go emulator.handleConnection(conn)
+	}
+}
+
+// Close stops accepting connections and drops any that are open.
+func (emulator *Emulator) Close() {
+	emulator.listener.Close()
+	emulator.DropConnections()
+}
+
+// DropConnections closes every open client connection, as happens when the PLC is power cycled or a cable is pulled.
+func (emulator *Emulator) DropConnections() {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+	for conn := range emulator.connections {
+		conn.Close()
+	}
+}
+
+// SetInput sets the value of the discrete input for the given signal (e.g. "fieldEStop").
+func (emulator *Emulator) SetInput(name string, value bool) {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+	emulator.inputs[signalAddress(name, emulator.ioMap.Inputs, emulator.ioMap.CustomInputs)] = value
+}
+
+// SetRegister sets the value of the holding register for the given signal (e.g. "redProcessor").
+func (emulator *Emulator) SetRegister(name string, value uint16) {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+	emulator.registers[signalAddress(name, emulator.ioMap.Registers, emulator.ioMap.CustomRegisters)] = value
+}
+
+// GetCoil returns the value last written by the client to the coil for the given signal (e.g. "heartbeat"), or false
+// if the signal is unmapped.
+func (emulator *Emulator) GetCoil(name string) bool {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+	address, ok := emulator.ioMap.Coils[name]
+	if !ok {
+		address, ok = emulator.ioMap.CustomCoils[name]
+	}
+	return ok && emulator.coils[address]
+}
+
+// RequestCount returns the number of requests that the emulator has serviced.
+func (emulator *Emulator) RequestCount() int {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+	return emulator.requests
+}
+
+// Reads and responds to Modbus requests on the given connection until it is closed.
+func (emulator *Emulator) handleConnection(conn net.Conn) {
+	defer func() {
+		emulator.mutex.Lock()
+		delete(emulator.connections, conn)
+		emulator.mutex.Unlock()
+		conn.Close()
+	}()
+
+	header := make([]byte, mbapHeaderLength)
+	for {
+		if _, err := io.ReadFull(conn, header); err != nil {
+			return
+		}
+		// The length field counts the unit identifier byte in the header as well as the PDU.
+		length := int(binary.BigEndian.Uint16(header[4:6])) - 1
+		if length < 1 || length > maxPduLength {
+			log.Printf("PLC emulator received a request with invalid length %d.", length)
+			return
+		}
+		request := make([]byte, length)
+		if _, err := io.ReadFull(conn, request); err != nil {
+			return
+		}
+
+		response := emulator.handleRequest(request)
+		responseHeader := make([]byte, mbapHeaderLength)
+		copy(responseHeader, header[0:4])
+		binary.BigEndian.PutUint16(responseHeader[4:6], uint16(len(response)+1))
+		responseHeader[6] = header[6]
+		if _, err := conn.Write(append(responseHeader, response...)); err != nil {
+			return
+		}
+	}
+}
+
+// Executes the given request PDU against the emulated PLC's memory and returns the response PDU.
+func (emulator *Emulator) handleRequest(request []byte) []byte {
+	emulator.mutex.Lock()
+	defer emulator.mutex.Unlock()
+	emulator.requests++
+
+	functionCode := request[0]
+	data := request[1:]
+	if len(data) < 4 {
+		return exceptionResponse(functionCode, illegalDataValueException)
+	}
+	address := int(binary.BigEndian.Uint16(data[0:2]))
+	value := binary.BigEndian.Uint16(data[2:4])
+	quantity := int(value)
+
+	switch functionCode {
+	case readCoilsFunction, readDiscreteInputsFunction:
+		bits := emulator.coils[:]
+		if functionCode == readDiscreteInputsFunction {
+			bits = emulator.inputs[:]
+		}
+		if address+quantity > len(bits) {
+			return exceptionResponse(functionCode, illegalDataAddressException)
+		}
+		values := boolToByte(bits[address : address+quantity])
+		return append([]byte{functionCode, byte(len(values))}, values...)
+	case readHoldingRegistersFunction:
+		if address+quantity > len(emulator.registers) {
+			return exceptionResponse(functionCode, illegalDataAddressException)
+		}
+		response := []byte{functionCode, byte(2 * quantity)}
+		for _, registerValue := range emulator.registers[address : address+quantity] {
+			response = binary.BigEndian.AppendUint16(response, registerValue)
+		}
+		return response
+	case writeSingleCoilFunction:
+		if address >= len(emulator.coils) {
+			return exceptionResponse(functionCode, illegalDataAddressException)
+		}
+		emulator.writeCoil(address, value == 0xff00)
+		return request
+	case writeSingleRegisterFunction:
+		if address >= len(emulator.registers) {
+			return exceptionResponse(functionCode, illegalDataAddressException)
+		}
+		emulator.registers[address] = value
+		return request
+	case writeMultipleCoilsFunction:
+		if len(data) < 5 || len(data[5:]) < int(data[4]) || int(data[4])*8 < quantity {
+			return exceptionResponse(functionCode, illegalDataValueException)
+		}
+		if address+quantity > len(emulator.coils) {
+			return exceptionResponse(functionCode, illegalDataAddressException)
+		}
+		for i, coilValue := range byteToBool(data[5:], quantity) {
+			emulator.writeCoil(address+i, coilValue)
+		}
+		return request[0:5]
+	case writeMultipleRegistersFunction:
+		if len(data) < 5 || len(data[5:]) < 2*quantity {
+			return exceptionResponse(functionCode, illegalDataValueException)
+		}
+		if address+quantity > len(emulator.registers) {
+			return exceptionResponse(functionCode, illegalDataAddressException)
+		}
+		for i := 0; i < quantity; i++ {
+			emulator.registers[address+i] = binary.BigEndian.Uint16(data[5+2*i:])
+		}
+		return request[0:5]
+	default:
+		return exceptionResponse(functionCode, illegalFunctionException)
+	}
+}
+
+// Sets the given coil, emulating the PLC program's reaction to it. Must be called with the mutex held.
+func (emulator *Emulator) writeCoil(address int, value bool) {
+	emulator.coils[address] = value
+	if matchResetAddress, ok := emulator.ioMap.Coils[matchReset.String()]; ok && address == matchResetAddress && value {
+		// The PLC program clears its internal counters when the match is reset.
+		emulator.registers[emulator.ioMap.Registers[redProcessor.String()]] = 0
+		emulator.registers[emulator.ioMap.Registers[blueProcessor.String()]] = 0
+	}
+}
+
+func exceptionResponse(functionCode, exceptionCode byte) []byte {
+	return []byte{functionCode | 0x80, exceptionCode}
+}
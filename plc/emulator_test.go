@@ -0,0 +1,165 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package plc
+
+import (
+	"testing"
+
+	"github.com/Team254/cheesy-arena/websocket"
+	"github.com/goburrow/modbus"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTestEmulator(t *testing.T, ioMap *IoMap) *Emulator {
+	emulator := NewEmulator(ioMap)
+	if !assert.Nil(t, emulator.Listen("127.0.0.1:0")) {
+		t.FailNow()
+	}
+	go emulator.Serve()
+	t.Cleanup(emulator.Close)
+	return emulator
+}
+
+func newTestModbusPlc(address string) *ModbusPlc {
+	var plc ModbusPlc
+	plc.ioChangeNotifier = &websocket.Notifier{}
+	plc.SetAddress(address)
+	return &plc
+}
+
+func TestEmulatorEndToEnd(t *testing.T) {
+	emulator := setupTestEmulator(t, DefaultIoMap())
+	plc := newTestModbusPlc(emulator.Address())
+	assert.True(t, plc.IsEnabled())
+
+	// Connecting should force an initial write of the coils.
+	assert.Nil(t, plc.connect())
+	assert.True(t, emulator.GetCoil("heartbeat"))
+
+	plc.update()
+	assert.True(t, plc.IsHealthy())
+	assert.False(t, plc.GetFieldEStop())
+	redEStops, blueEStops := plc.GetTeamEStops()
+	assert.Equal(t, [3]bool{false, false, false}, redEStops)
+	assert.Equal(t, [3]bool{false, false, false}, blueEStops)
+	redEthernet, blueEthernet := plc.GetEthernetConnected()
+	assert.Equal(t, [3]bool{true, true, true}, redEthernet)
+	assert.Equal(t, [3]bool{true, true, true}, blueEthernet)
+	assert.Equal(
+		t,
+		map[string]bool{"RedDs": true, "BlueDs": true, "RedIoLink": true, "BlueIoLink": true},
+		plc.GetArmorBlockStatuses(),
+	)
+
+	emulator.SetInput("fieldEStop", false)
+	emulator.SetInput("blue2EStop", false)
+	emulator.SetRegister("redProcessor", 7)
+	emulator.SetRegister("blueProcessor", 9)
+	plc.SetStackLights(true, false, true, false)
+	plc.SetFieldResetLight(true)
+	plc.update()
+	assert.True(t, plc.GetFieldEStop())
+	_, blueEStops = plc.GetTeamEStops()
+	assert.Equal(t, [3]bool{false, true, false}, blueEStops)
+	assert.Equal(t, 7, plc.GetRegister("redProcessor"))
+	assert.Equal(t, 9, plc.GetRegister("blueProcessor"))
+	assert.True(t, emulator.GetCoil("stackLightRed"))
+	assert.False(t, emulator.GetCoil("stackLightBlue"))
+	assert.True(t, emulator.GetCoil("stackLightOrange"))
+	assert.True(t, emulator.GetCoil("fieldResetLight"))
+
+	// Resetting the match should pulse the reset coil, which clears the emulated PLC's counters.
+	plc.ResetMatch()
+	plc.update()
+	assert.True(t, emulator.GetCoil("matchReset"))
+	assert.Equal(t, 0, plc.GetRegister("redProcessor"))
+	assert.Equal(t, 0, plc.GetRegister("blueProcessor"))
+	for i := 0; i < 10; i++ {
+		plc.update()
+	}
+	assert.False(t, emulator.GetCoil("matchReset"))
+}
+
+func TestEmulatorRemappedIo(t *testing.T) {
+	ioMap := DefaultIoMap()
+	ioMap.Inputs["fieldEStop"] = 100
+	ioMap.Registers["redProcessor"] = 10
+	ioMap.Coils["heartbeat"] = 50
+	emulator := setupTestEmulator(t, ioMap)
+	plc := newTestModbusPlc(emulator.Address())
+	assert.Nil(t, plc.SetIoMap(ioMap))
+	assert.Nil(t, plc.connect())
+
+	emulator.SetRegister("redProcessor", 3)
+	plc.update()
+	assert.True(t, plc.IsHealthy())
+	assert.False(t, plc.GetFieldEStop())
+	assert.Equal(t, 3, plc.GetRegister("redProcessor"))
+	assert.True(t, emulator.GetCoil("heartbeat"))
+
+	emulator.SetInput("fieldEStop", false)
+	plc.update()
+	assert.True(t, plc.GetFieldEStop())
+}
+
+func TestEmulatorReconnect(t *testing.T) {
+	emulator := setupTestEmulator(t, DefaultIoMap())
+	address := emulator.Address()
+	plc := newTestModbusPlc(address)
+	assert.Nil(t, plc.connect())
+	plc.update()
+	assert.True(t, plc.IsHealthy())
+
+	// Losing the connection should mark the PLC unhealthy and reset the connection so that it is re-established.
+	emulator.DropConnections()
+	plc.update()
+	assert.False(t, plc.IsHealthy())
+	assert.Nil(t, plc.handler)
+
+	// Reconnecting should fail while the PLC is down.
+	emulator.Close()
+	assert.NotNil(t, plc.connect())
+	assert.Nil(t, plc.handler)
+
+	// Bring the PLC back up on the same address and check that the connection recovers.
+	restartedEmulator := NewEmulator(DefaultIoMap())
+	if !assert.Nil(t, restartedEmulator.Listen(address)) {
+		return
+	}
+	go restartedEmulator.Serve()
+	defer restartedEmulator.Close()
+	assert.Nil(t, plc.connect())
+	plc.update()
+	assert.True(t, plc.IsHealthy())
+	assert.True(t, restartedEmulator.RequestCount() > 0)
+}
+
+func TestEmulatorExceptions(t *testing.T) {
+	emulator := setupTestEmulator(t, DefaultIoMap())
+	handler := modbus.NewTCPClientHandler(emulator.Address())
+	handler.SlaveId = 0xff
+	assert.Nil(t, handler.Connect())
+	defer handler.Close()
+	client := modbus.NewClient(handler)
+
+	_, err := client.ReadHoldingRegisters(120, 10)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "illegal data address")
+	}
+	_, err = client.ReadInputRegisters(0, 1)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "illegal function")
+	}
+
+	_, err = client.WriteSingleRegister(5, 1234)
+	assert.Nil(t, err)
+	registers, err := client.ReadHoldingRegisters(5, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x04, 0xd2}, registers)
+
+	_, err = client.WriteSingleCoil(3, 0xff00)
+	assert.Nil(t, err)
+	coils, err := client.ReadCoils(0, 8)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x08}, coils)
+}
@@ -0,0 +1,245 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Configurable mapping of the PLC's logical signals to Modbus addresses.
+
+package plc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// Maximum number of discrete inputs, registers and coils that can be transferred in a single Modbus request.
+	maxModbusInputs    = 2000
+	maxModbusRegisters = 125
+	maxModbusCoils     = 1968
+	registerBits       = 16
+)
+
+// IoMap defines the Modbus address of each of the PLC's logical discrete inputs, registers and coils, keyed by the
+// signal name (e.g. "fieldEStop"), and which bit of the fieldIoConnection register reports each ArmorBlock's status.
+// Every input and register must be mapped; coils that are left out are not driven.
+//
+// The custom sections define additional signals beyond the built-in ones (e.g. extra field lights or sensors), which
+// the game reads and drives by name.
+type IoMap struct {
+	Inputs          map[string]int `json:"inputs"`
+	Registers       map[string]int `json:"registers"`
+	Coils           map[string]int `json:"coils"`
+	ArmorBlocks     map[string]int `json:"armorBlocks"`
+	CustomInputs    map[string]int `json:"customInputs,omitempty"`
+	CustomRegisters map[string]int `json:"customRegisters,omitempty"`
+	CustomCoils     map[string]int `json:"customCoils,omitempty"`
+}
+
+// The resolved addresses of an IoMap, indexed by signal for quick access in the PLC loop. Unmapped coils are -1.
+type ioAddresses struct {
+	inputs          [inputCount]int
+	registers       [registerCount]int
+	coils           [coilCount]int
+	customInputs    []customSignal
+	customRegisters []customSignal
+	customCoils     []customSignal
+	inputSpan       int
+	registerSpan    int
+	coilSpan        int
+}
+
+// A signal defined in one of the custom sections of an IoMap.
+type customSignal struct {
+	name    string
+	address int
+}
+
+// DefaultIoMap returns the I/O map matching the standard field PLC program, in which signals are laid out
+// contiguously from address zero.
+func DefaultIoMap() *IoMap {
+	ioMap := IoMap{
+		Inputs:      make(map[string]int),
+		Registers:   make(map[string]int),
+		Coils:       make(map[string]int),
+		ArmorBlocks: make(map[string]int),
+	}
+	for i := 0; i < int(inputCount); i++ {
+		ioMap.Inputs[input(i).String()] = i
+	}
+	for i := 0; i < int(registerCount); i++ {
+		ioMap.Registers[register(i).String()] = i
+	}
+	for i := 0; i < int(coilCount); i++ {
+		ioMap.Coils[coil(i).String()] = i
+	}
+	for i := 0; i < int(armorBlockCount); i++ {
+		ioMap.ArmorBlocks[armorBlock(i).String()] = i
+	}
+	return &ioMap
+}
+
+// ParseIoMap parses and validates an I/O map from its JSON representation. An empty string yields the default map.
+func ParseIoMap(ioMapJson string) (*IoMap, error) {
+	if strings.TrimSpace(ioMapJson) == "" {
+		return DefaultIoMap(), nil
+	}
+	var ioMap IoMap
+	decoder := json.NewDecoder(strings.NewReader(ioMapJson))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&ioMap); err != nil {
+		return nil, fmt.Errorf("invalid PLC I/O map: %v", err)
+	}
+	if err := ioMap.Validate(); err != nil {
+		return nil, err
+	}
+	return &ioMap, nil
+}
+
+// Validate checks that the map refers only to known signals, maps every input and register, and doesn't assign two
+// signals of the same kind to the same address.
+func (ioMap *IoMap) Validate() error {
+	if _, err := ioMap.resolve(); err != nil {
+		return fmt.Errorf("invalid PLC I/O map: %v", err)
+	}
+	for name, bit := range ioMap.ArmorBlocks {
+		if bit < 0 || bit >= registerBits {
+			return fmt.Errorf("invalid PLC I/O map: ArmorBlock %q bit %d is out of range", name, bit)
+		}
+	}
+	if err := checkDuplicateAddresses("ArmorBlock bit", ioMap.ArmorBlocks); err != nil {
+		return fmt.Errorf("invalid PLC I/O map: %v", err)
+	}
+	return nil
+}
+
+// String returns the indented JSON representation of the map, suitable for editing.
+func (ioMap *IoMap) String() string {
+	ioMapJson, _ := json.MarshalIndent(ioMap, "", "  ")
+	return string(ioMapJson)
+}
+
+// Resolves the signal names in the map into the addresses used by the PLC loop.
+func (ioMap *IoMap) resolve() (*ioAddresses, error) {
+	var addresses ioAddresses
+	var err error
+	addresses.inputSpan, addresses.customInputs, err = resolveSignals(
+		"input", ioMap.Inputs, ioMap.CustomInputs, addresses.inputs[:], func(i int) string { return input(i).String() },
+		true, maxModbusInputs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	addresses.registerSpan, addresses.customRegisters, err = resolveSignals(
+		"register", ioMap.Registers, ioMap.CustomRegisters, addresses.registers[:],
+		func(i int) string { return register(i).String() }, true, maxModbusRegisters,
+	)
+	if err != nil {
+		return nil, err
+	}
+	addresses.coilSpan, addresses.customCoils, err = resolveSignals(
+		"coil", ioMap.Coils, ioMap.CustomCoils, addresses.coils[:], func(i int) string { return coil(i).String() },
+		false, maxModbusCoils,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &addresses, nil
+}
+
+// Fills in the address of each built-in signal of one kind from the given name-to-address mapping and resolves the
+// custom signals of that kind, sorted by name. Returns the number of addresses that must be transferred to cover all
+// of them.
+func resolveSignals(
+	kind string,
+	mapping map[string]int,
+	customMapping map[string]int,
+	addresses []int,
+	nameFunc func(int) string,
+	required bool,
+	maxAddresses int,
+) (int, []customSignal, error) {
+	indexByName := signalIndexes(len(addresses), nameFunc)
+	for i := range addresses {
+		addresses[i] = -1
+	}
+	for name := range mapping {
+		if _, ok := indexByName[name]; !ok {
+			return 0, nil, fmt.Errorf("unknown %s %q", kind, name)
+		}
+	}
+	allMappings := make(map[string]int, len(mapping)+len(customMapping))
+	for name, address := range mapping {
+		allMappings[name] = address
+	}
+	for name, address := range customMapping {
+		if _, ok := indexByName[name]; ok || name == "" {
+			return 0, nil, fmt.Errorf("invalid custom %s name %q", kind, name)
+		}
+		allMappings[name] = address
+	}
+	if err := checkDuplicateAddresses(kind+" address", allMappings); err != nil {
+		return 0, nil, err
+	}
+	for name, address := range allMappings {
+		if address < 0 || address >= maxAddresses {
+			return 0, nil, fmt.Errorf("%s %q address %d is out of range", kind, name, address)
+		}
+	}
+
+	span := 0
+	for i := range addresses {
+		name := nameFunc(i)
+		address, ok := mapping[name]
+		if !ok {
+			if required {
+				return 0, nil, fmt.Errorf("%s %q is not mapped", kind, name)
+			}
+			continue
+		}
+		addresses[i] = address
+		span = max(span, address+1)
+	}
+
+	var customSignals []customSignal
+	for name, address := range customMapping {
+		customSignals = append(customSignals, customSignal{name, address})
+		span = max(span, address+1)
+	}
+	sort.Slice(customSignals, func(i, j int) bool { return customSignals[i].name < customSignals[j].name })
+	return span, customSignals, nil
+}
+
+// Returns the address of the built-in or custom signal having the given name, or zero if it is not mapped.
+func signalAddress(name string, mapping, customMapping map[string]int) int {
+	if address, ok := mapping[name]; ok {
+		return address
+	}
+	return customMapping[name]
+}
+
+// Returns the index of each of the given number of signals of one kind, keyed by the signal name.
+func signalIndexes(count int, nameFunc func(int) string) map[string]int {
+	indexes := make(map[string]int, count)
+	for i := 0; i < count; i++ {
+		indexes[nameFunc(i)] = i
+	}
+	return indexes
+}
+
+// Returns an error if two names in the given mapping share the same value.
+func checkDuplicateAddresses(kind string, mapping map[string]int) error {
+	names := make([]string, 0, len(mapping))
+	for name := range mapping {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	namesByAddress := make(map[int]string, len(mapping))
+	for _, name := range names {
+		address := mapping[name]
+		if otherName, ok := namesByAddress[address]; ok {
+			return fmt.Errorf("%s %d is assigned to both %q and %q", kind, address, otherName, name)
+		}
+		namesByAddress[address] = name
+	}
+	return nil
+}
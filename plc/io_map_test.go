@@ -0,0 +1,232 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package plc
+
+import (
+	"testing"
+
+	"github.com/Team254/cheesy-arena/websocket"
+	"github.com/goburrow/modbus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultIoMap(t *testing.T) {
+	ioMap := DefaultIoMap()
+	assert.Nil(t, ioMap.Validate())
+	assert.Equal(t, 0, ioMap.Inputs["fieldEStop"])
+	assert.Equal(t, 0, ioMap.Registers["fieldIoConnection"])
+	assert.Equal(t, 0, ioMap.Coils["heartbeat"])
+	assert.Equal(t, 3, ioMap.ArmorBlocks["blueIoLink"])
+
+	// The default map should be laid out contiguously from zero, matching the original hard-coded addresses.
+	addresses, err := ioMap.resolve()
+	assert.Nil(t, err)
+	assert.Equal(t, int(inputCount), addresses.inputSpan)
+	assert.Equal(t, int(registerCount), addresses.registerSpan)
+	assert.Equal(t, int(coilCount), addresses.coilSpan)
+
+	// Round-trip the map through its JSON representation.
+	parsedIoMap, err := ParseIoMap(ioMap.String())
+	assert.Nil(t, err)
+	assert.Equal(t, ioMap, parsedIoMap)
+
+	parsedIoMap, err = ParseIoMap("  ")
+	assert.Nil(t, err)
+	assert.Equal(t, ioMap, parsedIoMap)
+}
+
+func TestParseIoMapErrors(t *testing.T) {
+	_, err := ParseIoMap("{")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "invalid PLC I/O map")
+	}
+	_, err = ParseIoMap(`{"outputs": {}}`)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "unknown field")
+	}
+
+	ioMap := DefaultIoMap()
+	ioMap.Inputs["bogus"] = 50
+	_, err = ParseIoMap(ioMap.String())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "unknown input \"bogus\"")
+	}
+
+	ioMap = DefaultIoMap()
+	delete(ioMap.Registers, "redProcessor")
+	_, err = ParseIoMap(ioMap.String())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "register \"redProcessor\" is not mapped")
+	}
+
+	ioMap = DefaultIoMap()
+	ioMap.Inputs["red1EStop"] = 0
+	_, err = ParseIoMap(ioMap.String())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "input address 0 is assigned to both \"fieldEStop\" and \"red1EStop\"")
+	}
+
+	ioMap = DefaultIoMap()
+	ioMap.Coils["heartbeat"] = 5000
+	_, err = ParseIoMap(ioMap.String())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "coil \"heartbeat\" address 5000 is out of range")
+	}
+
+	ioMap = DefaultIoMap()
+	ioMap.ArmorBlocks["redDs"] = 16
+	_, err = ParseIoMap(ioMap.String())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "ArmorBlock \"redDs\" bit 16 is out of range")
+	}
+
+	// Coils may be left unmapped.
+	ioMap = DefaultIoMap()
+	delete(ioMap.Coils, "fieldResetLight")
+	_, err = ParseIoMap(ioMap.String())
+	assert.Nil(t, err)
+
+	ioMap = DefaultIoMap()
+	ioMap.CustomCoils = map[string]int{"heartbeat": 20}
+	_, err = ParseIoMap(ioMap.String())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "invalid custom coil name \"heartbeat\"")
+	}
+
+	ioMap = DefaultIoMap()
+	ioMap.CustomInputs = map[string]int{"redSensor": 0}
+	_, err = ParseIoMap(ioMap.String())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "input address 0 is assigned to both \"fieldEStop\" and \"redSensor\"")
+	}
+
+	ioMap = DefaultIoMap()
+	ioMap.CustomRegisters = map[string]int{"redCounter": 200}
+	_, err = ParseIoMap(ioMap.String())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "register \"redCounter\" address 200 is out of range")
+	}
+}
+
+func TestPlcRemappedIo(t *testing.T) {
+	var client FakeModbusClient
+	var plc ModbusPlc
+	plc.client = &client
+	plc.handler = modbus.NewTCPClientHandler("dummy")
+	plc.ioChangeNotifier = &websocket.Notifier{}
+
+	ioMap := DefaultIoMap()
+	ioMap.Inputs["fieldEStop"] = 31
+	ioMap.Registers["fieldIoConnection"] = 2
+	ioMap.Registers["blueProcessor"] = 0
+	ioMap.Coils["heartbeat"] = 20
+	delete(ioMap.Coils, "fieldResetLight")
+	ioMap.ArmorBlocks["redDs"] = 4
+	assert.Nil(t, plc.SetIoMap(ioMap))
+	assert.Equal(t, ioMap, plc.GetIoMap())
+
+	client.inputs[0] = true
+	client.inputs[31] = false
+	client.registers[0] = 34
+	client.registers[2] = 16
+	plc.SetFieldResetLight(true)
+	plc.update()
+	assert.Equal(t, true, plc.IsHealthy())
+	assert.Equal(t, true, plc.GetFieldEStop())
+	assert.Equal(t, 34, plc.GetRegister("blueProcessor"))
+	assert.Equal(
+		t,
+		map[string]bool{"RedDs": true, "BlueDs": false, "RedIoLink": false, "BlueIoLink": false},
+		plc.GetArmorBlockStatuses(),
+	)
+	assert.Equal(t, true, client.coils[20])
+	assert.Equal(t, false, client.coils[0])
+	// The field reset light is unmapped and its default address of 7 should not be driven.
+	assert.Equal(t, false, client.coils[7])
+
+	invalidIoMap := DefaultIoMap()
+	delete(invalidIoMap.Inputs, "fieldEStop")
+	assert.NotNil(t, plc.SetIoMap(invalidIoMap))
+	assert.Equal(t, ioMap, plc.GetIoMap())
+}
+
+func TestPlcCustomSignals(t *testing.T) {
+	var client FakeModbusClient
+	var plc ModbusPlc
+	plc.client = &client
+	plc.handler = modbus.NewTCPClientHandler("dummy")
+	plc.ioChangeNotifier = &websocket.Notifier{}
+
+	ioMap := DefaultIoMap()
+	ioMap.CustomInputs = map[string]int{"redSensor": 25}
+	ioMap.CustomRegisters = map[string]int{"redCounter": 10, "blueCounter": 5}
+	ioMap.CustomCoils = map[string]int{"stackLightWhite": 30, "redTrussLight4": 20}
+	parsedIoMap, err := ParseIoMap(ioMap.String())
+	assert.Nil(t, err)
+	assert.Equal(t, ioMap, parsedIoMap)
+	assert.Nil(t, plc.SetIoMap(ioMap))
+
+	// Custom signals should be listed after the built-in ones, sorted by name.
+	inputNames := plc.GetInputNames()
+	assert.Equal(t, []string{"redSensor"}, inputNames[inputCount:])
+	assert.Equal(t, []string{"blueCounter", "redCounter"}, plc.GetRegisterNames()[registerCount:])
+	assert.Equal(t, []string{"redTrussLight4", "stackLightWhite"}, plc.GetCoilNames()[coilCount:])
+
+	client.inputs[25] = true
+	client.registers[10] = 42
+	client.registers[5] = 7
+	plc.SetCoil("stackLightWhite", true)
+	plc.SetCoil("bogus", true)
+	plc.update()
+	assert.True(t, plc.IsHealthy())
+	assert.True(t, plc.GetInput("redSensor"))
+	assert.False(t, plc.GetInput("bogus"))
+	assert.Equal(t, 42, plc.GetRegister("redCounter"))
+	assert.Equal(t, 7, plc.GetRegister("blueCounter"))
+	assert.True(t, client.coils[30])
+	assert.False(t, client.coils[20])
+
+	message := plc.generateIoChangeMessage().(*struct {
+		Inputs    []bool
+		Registers []uint16
+		Coils     []bool
+	})
+	assert.Equal(t, []bool{true}, message.Inputs[inputCount:])
+	assert.Equal(t, []uint16{7, 42}, message.Registers[registerCount:])
+	assert.Equal(t, []bool{false, true}, message.Coils[coilCount:])
+
+	// Replacing the map should clear the custom signals.
+	assert.Nil(t, plc.SetIoMap(DefaultIoMap()))
+	plc.update()
+	assert.False(t, plc.GetInput("redSensor"))
+	assert.Equal(t, 0, plc.GetRegister("redCounter"))
+	assert.Equal(t, int(coilCount), len(plc.GetCoilNames()))
+}
+
+func TestPlcSetIoMapWhileRunning(t *testing.T) {
+	var client FakeModbusClient
+	var plc ModbusPlc
+	plc.client = &client
+	plc.handler = modbus.NewTCPClientHandler("dummy")
+	plc.ioChangeNotifier = &websocket.Notifier{}
+
+	// The map should be safe to replace while the PLC loop is cycling (checked when run with -race).
+	ioMap := DefaultIoMap()
+	ioMap.CustomCoils = map[string]int{"stackLightWhite": 30}
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 100; i++ {
+			plc.update()
+		}
+		close(done)
+	}()
+	for i := 0; i < 100; i++ {
+		if i%2 == 0 {
+			assert.Nil(t, plc.SetIoMap(ioMap))
+		} else {
+			assert.Nil(t, plc.SetIoMap(DefaultIoMap()))
+		}
+		plc.SetCoil("stackLightWhite", true)
+	}
+	<-done
+}
@@ -6,11 +6,14 @@
 package plc
 
 import (
-	"fmt"
 	"github.com/Team254/cheesy-arena/websocket"
 	"github.com/goburrow/modbus"
 	"log"
+	"maps"
+	"net"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -33,8 +36,11 @@ type Plc interface {
 	GetInputNames() []string
 	GetRegisterNames() []string
 	GetCoilNames() []string
-	GetProcessorCounts() (int, int)
-	SetTrussLights(redLights, blueLights [3]bool)
+	GetInput(name string) bool
+	GetRegister(name string) int
+	SetCoil(name string, state bool)
+	SetIoMap(ioMap *IoMap) error
+	GetIoMap() *IoMap
 }
 
 type ModbusPlc struct {
@@ -51,6 +57,18 @@ type ModbusPlc struct {
 	oldCoils         [coilCount]bool
 	cycleCounter     int
 	matchResetCycles int
+
+	// The I/O map and the values of its custom signals are guarded by the mutex since the map can be replaced from
+	// the settings page while the PLC loop is running.
+	mutex              sync.Mutex
+	ioMap              *IoMap
+	addresses          *ioAddresses
+	customInputs       map[string]bool
+	customRegisters    map[string]uint16
+	customCoils        map[string]bool
+	oldCustomInputs    map[string]bool
+	oldCustomRegisters map[string]uint16
+	oldCustomCoils     map[string]bool
 }
 
 const (
@@ -136,6 +154,14 @@ const (
 	armorBlockCount
 )
 
+var (
+	defaultIoMap        = DefaultIoMap()
+	defaultAddresses, _ = defaultIoMap.resolve()
+	inputIndexes        = signalIndexes(int(inputCount), func(i int) string { return input(i).String() })
+	registerIndexes     = signalIndexes(int(registerCount), func(i int) string { return register(i).String() })
+	coilIndexes         = signalIndexes(int(coilCount), func(i int) string { return coil(i).String() })
+)
+
 func (plc *ModbusPlc) SetAddress(address string) {
 	plc.address = address
 	plc.resetConnection()
@@ -187,9 +213,10 @@ func (plc *ModbusPlc) Run() {
 
 // Returns a map of ArmorBlocks I/O module names to whether they are connected properly.
 func (plc *ModbusPlc) GetArmorBlockStatuses() map[string]bool {
-	statuses := make(map[string]bool, armorBlockCount)
-	for i := 0; i < int(armorBlockCount); i++ {
-		statuses[strings.Title(armorBlock(i).String())] = plc.registers[fieldIoConnection]&(1<<i) > 0
+	armorBlocks := plc.GetIoMap().ArmorBlocks
+	statuses := make(map[string]bool, len(armorBlocks))
+	for name, bit := range armorBlocks {
+		statuses[strings.Title(name)] = plc.registers[fieldIoConnection]&(1<<bit) > 0
 	}
 	return statuses
 }
@@ -247,6 +274,11 @@ func (plc *ModbusPlc) ResetMatch() {
 	for i := 1; i < int(registerCount); i++ {
 		plc.registers[i] = 0
 	}
+	plc.mutex.Lock()
+	defer plc.mutex.Unlock()
+	for name := range plc.customRegisters {
+		plc.customRegisters[name] = 0
+	}
 }
 
 // Sets the on/off state of the stack lights on the scoring table.
@@ -271,48 +303,126 @@ func (plc *ModbusPlc) GetCycleState(max, index, duration int) bool {
 	return plc.cycleCounter/duration%max == index
 }
 
+// Returns the names of the built-in inputs followed by those of any custom inputs, in the order of the values in the
+// I/O change message.
 func (plc *ModbusPlc) GetInputNames() []string {
 	inputNames := make([]string, inputCount)
 	for i := range plc.inputs {
 		inputNames[i] = input(i).String()
 	}
-	return inputNames
+	return appendCustomSignalNames(inputNames, plc.getAddresses().customInputs)
 }
 
+// Returns the names of the built-in registers followed by those of any custom registers, in the order of the values
+// in the I/O change message.
 func (plc *ModbusPlc) GetRegisterNames() []string {
 	registerNames := make([]string, registerCount)
 	for i := range plc.registers {
 		registerNames[i] = register(i).String()
 	}
-	return registerNames
+	return appendCustomSignalNames(registerNames, plc.getAddresses().customRegisters)
 }
 
+// Returns the names of the built-in coils followed by those of any custom coils, in the order of the values in the
+// I/O change message.
 func (plc *ModbusPlc) GetCoilNames() []string {
 	coilNames := make([]string, coilCount)
 	for i := range plc.coils {
 		coilNames[i] = coil(i).String()
 	}
-	return coilNames
+	return appendCustomSignalNames(coilNames, plc.getAddresses().customCoils)
+}
+
+// Returns the value of the built-in or custom discrete input having the given name, or false if there is no such
+// input. Used by the game to read its scoring sensors.
+func (plc *ModbusPlc) GetInput(name string) bool {
+	if index, ok := inputIndexes[name]; ok {
+		return plc.inputs[index]
+	}
+	plc.mutex.Lock()
+	defer plc.mutex.Unlock()
+	return plc.customInputs[name]
+}
+
+// Returns the value of the built-in or custom register having the given name, or zero if there is no such register.
+// Used by the game to read its scoring sensors.
+func (plc *ModbusPlc) GetRegister(name string) int {
+	if index, ok := registerIndexes[name]; ok {
+		return int(plc.registers[index])
+	}
+	plc.mutex.Lock()
+	defer plc.mutex.Unlock()
+	return int(plc.customRegisters[name])
 }
 
-// Returns the red and blue processor counts, respectively.
-func (plc *ModbusPlc) GetProcessorCounts() (int, int) {
-	return int(plc.registers[redProcessor]), int(plc.registers[blueProcessor])
+// Sets the state of the built-in or custom coil having the given name, if there is one. Used by the game to drive its
+// field lights.
+func (plc *ModbusPlc) SetCoil(name string, state bool) {
+	if index, ok := coilIndexes[name]; ok {
+		plc.coils[index] = state
+		return
+	}
+	plc.mutex.Lock()
+	defer plc.mutex.Unlock()
+	if _, ok := plc.customCoils[name]; ok {
+		plc.customCoils[name] = state
+	}
+}
+
+// Sets the mapping of logical signals to Modbus addresses, after checking that it is valid. Custom signals start out
+// cleared, and the PLC loop picks up the new map on its next cycle.
+func (plc *ModbusPlc) SetIoMap(ioMap *IoMap) error {
+	if err := ioMap.Validate(); err != nil {
+		return err
+	}
+	addresses, _ := ioMap.resolve()
+
+	plc.mutex.Lock()
+	defer plc.mutex.Unlock()
+	plc.ioMap = ioMap
+	plc.addresses = addresses
+	plc.customInputs = make(map[string]bool, len(addresses.customInputs))
+	for _, signal := range addresses.customInputs {
+		plc.customInputs[signal.name] = false
+	}
+	plc.customRegisters = make(map[string]uint16, len(addresses.customRegisters))
+	for _, signal := range addresses.customRegisters {
+		plc.customRegisters[signal.name] = 0
+	}
+	plc.customCoils = make(map[string]bool, len(addresses.customCoils))
+	for _, signal := range addresses.customCoils {
+		plc.customCoils[signal.name] = false
+	}
+	return nil
+}
+
+// Returns the mapping of logical signals to Modbus addresses currently in use.
+func (plc *ModbusPlc) GetIoMap() *IoMap {
+	plc.mutex.Lock()
+	defer plc.mutex.Unlock()
+	if plc.ioMap == nil {
+		return defaultIoMap
+	}
+	return plc.ioMap
 }
 
-// Sets the state of the red and blue truss lights. Each array represents the outer, middle, and inner lights,
-// respectively.
-func (plc *ModbusPlc) SetTrussLights(redLights, blueLights [3]bool) {
-	plc.coils[redTrussLightOuter] = redLights[0]
-	plc.coils[redTrussLightMiddle] = redLights[1]
-	plc.coils[redTrussLightInner] = redLights[2]
-	plc.coils[blueTrussLightOuter] = blueLights[0]
-	plc.coils[blueTrussLightMiddle] = blueLights[1]
-	plc.coils[blueTrussLightInner] = blueLights[2]
+// Returns the resolved Modbus addresses of each signal currently in use. The returned addresses are never modified, so
+// the caller can keep using them for a whole cycle even if the map is replaced in the meantime.
+func (plc *ModbusPlc) getAddresses() *ioAddresses {
+	plc.mutex.Lock()
+	defer plc.mutex.Unlock()
+	if plc.addresses == nil {
+		return defaultAddresses
+	}
+	return plc.addresses
 }
 
 func (plc *ModbusPlc) connect() error {
-	address := fmt.Sprintf("%s:%d", plc.address, modbusPort)
+	// Use the standard Modbus port unless the address specifies one (e.g. for a local emulator).
+	address := plc.address
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, strconv.Itoa(modbusPort))
+	}
 	handler := modbus.NewTCPClientHandler(address)
 	handler.Timeout = 1 * time.Second
 	handler.SlaveId = 0xFF
@@ -354,7 +464,14 @@ func (plc *ModbusPlc) update() {
 	}
 
 	// Detect any changes in input or output and notify listeners if so.
-	if plc.inputs != plc.oldInputs || plc.registers != plc.oldRegisters || plc.coils != plc.oldCoils {
+	plc.mutex.Lock()
+	customChanged := !maps.Equal(plc.customInputs, plc.oldCustomInputs) ||
+		!maps.Equal(plc.customRegisters, plc.oldCustomRegisters) || !maps.Equal(plc.customCoils, plc.oldCustomCoils)
+	plc.oldCustomInputs = maps.Clone(plc.customInputs)
+	plc.oldCustomRegisters = maps.Clone(plc.customRegisters)
+	plc.oldCustomCoils = maps.Clone(plc.customCoils)
+	plc.mutex.Unlock()
+	if plc.inputs != plc.oldInputs || plc.registers != plc.oldRegisters || plc.coils != plc.oldCoils || customChanged {
 		plc.ioChangeNotifier.Notify()
 		plc.oldInputs = plc.inputs
 		plc.oldRegisters = plc.registers
@@ -363,44 +480,68 @@ func (plc *ModbusPlc) update() {
 }
 
 func (plc *ModbusPlc) readInputs() bool {
-	if len(plc.inputs) == 0 {
+	addresses := plc.getAddresses()
+	if addresses.inputSpan == 0 {
 		return true
 	}
 
-	inputs, err := plc.client.ReadDiscreteInputs(0, uint16(len(plc.inputs)))
+	inputs, err := plc.client.ReadDiscreteInputs(0, uint16(addresses.inputSpan))
 	if err != nil {
 		log.Printf("PLC error reading inputs: %v", err)
 		return false
 	}
-	if len(inputs)*8 < len(plc.inputs) {
-		log.Printf("Insufficient length of PLC inputs: got %d bytes, expected %d bits.", len(inputs), len(plc.inputs))
+	if len(inputs)*8 < addresses.inputSpan {
+		log.Printf(
+			"Insufficient length of PLC inputs: got %d bytes, expected %d bits.", len(inputs), addresses.inputSpan,
+		)
 		return false
 	}
 
-	copy(plc.inputs[:], byteToBool(inputs, len(plc.inputs)))
+	values := byteToBool(inputs, addresses.inputSpan)
+	for i, address := range addresses.inputs {
+		plc.inputs[i] = values[address]
+	}
+	plc.mutex.Lock()
+	defer plc.mutex.Unlock()
+	if addresses == plc.addresses {
+		for _, signal := range addresses.customInputs {
+			plc.customInputs[signal.name] = values[signal.address]
+		}
+	}
 	return true
 }
 
 func (plc *ModbusPlc) readRegisters() bool {
-	if len(plc.registers) == 0 {
+	addresses := plc.getAddresses()
+	if addresses.registerSpan == 0 {
 		return true
 	}
 
-	registers, err := plc.client.ReadHoldingRegisters(0, uint16(len(plc.registers)))
+	registers, err := plc.client.ReadHoldingRegisters(0, uint16(addresses.registerSpan))
 	if err != nil {
 		log.Printf("PLC error reading registers: %v", err)
 		return false
 	}
-	if len(registers)/2 < len(plc.registers) {
+	if len(registers)/2 < addresses.registerSpan {
 		log.Printf(
 			"Insufficient length of PLC registers: got %d bytes, expected %d words.",
 			len(registers),
-			len(plc.registers),
+			addresses.registerSpan,
 		)
 		return false
 	}
 
-	copy(plc.registers[:], byteToUint(registers, len(plc.registers)))
+	values := byteToUint(registers, addresses.registerSpan)
+	for i, address := range addresses.registers {
+		plc.registers[i] = values[address]
+	}
+	plc.mutex.Lock()
+	defer plc.mutex.Unlock()
+	if addresses == plc.addresses {
+		for _, signal := range addresses.customRegisters {
+			plc.customRegisters[signal.name] = values[signal.address]
+		}
+	}
 	return true
 }
 
@@ -408,11 +549,25 @@ func (plc *ModbusPlc) writeCoils() bool {
 	// Send a heartbeat to the PLC so that it can disable outputs if the connection is lost.
 	plc.coils[heartbeat] = true
 
-	coils := boolToByte(plc.coils[:])
-	_, err := plc.client.WriteMultipleCoils(0, uint16(len(plc.coils)), coils)
-	if err != nil {
-		log.Printf("PLC error writing coils: %v", err)
-		return false
+	// Write every coil up to the highest mapped address, leaving any that aren't mapped to a signal off.
+	addresses := plc.getAddresses()
+	if addresses.coilSpan > 0 {
+		values := make([]bool, addresses.coilSpan)
+		for i, address := range addresses.coils {
+			if address >= 0 {
+				values[address] = plc.coils[i]
+			}
+		}
+		plc.mutex.Lock()
+		for _, signal := range addresses.customCoils {
+			values[signal.address] = plc.customCoils[signal.name]
+		}
+		plc.mutex.Unlock()
+		_, err := plc.client.WriteMultipleCoils(0, uint16(addresses.coilSpan), boolToByte(values))
+		if err != nil {
+			log.Printf("PLC error writing coils: %v", err)
+			return false
+		}
 	}
 
 	if plc.matchResetCycles > 5 {
@@ -424,11 +579,36 @@ func (plc *ModbusPlc) writeCoils() bool {
 }
 
 func (plc *ModbusPlc) generateIoChangeMessage() any {
+	// Append the values of any custom signals after the built-in ones, in the order of the signal names.
+	addresses := plc.getAddresses()
+	inputs := append([]bool{}, plc.inputs[:]...)
+	registers := append([]uint16{}, plc.registers[:]...)
+	coils := append([]bool{}, plc.coils[:]...)
+	plc.mutex.Lock()
+	for _, signal := range addresses.customInputs {
+		inputs = append(inputs, plc.customInputs[signal.name])
+	}
+	for _, signal := range addresses.customRegisters {
+		registers = append(registers, plc.customRegisters[signal.name])
+	}
+	for _, signal := range addresses.customCoils {
+		coils = append(coils, plc.customCoils[signal.name])
+	}
+	plc.mutex.Unlock()
+
 	return &struct {
 		Inputs    []bool
 		Registers []uint16
 		Coils     []bool
-	}{plc.inputs[:], plc.registers[:], plc.coils[:]}
+	}{inputs, registers, coils}
+}
+
+// Appends the names of the given custom signals to the given names of the built-in signals.
+func appendCustomSignalNames(names []string, customSignals []customSignal) []string {
+	for _, signal := range customSignals {
+		names = append(names, signal.name)
+	}
+	return names
 }
 
 func byteToBool(bytes []byte, size int) []bool {
@@ -347,19 +347,17 @@ func TestPlcRegistersGameSpecific(t *testing.T) {
 	client.registers[1] = 0
 	client.registers[2] = 0
 	plc.update()
-	redProcessor, blueProcessor := plc.GetProcessorCounts()
-	assert.Equal(t, 0, redProcessor)
-	assert.Equal(t, 0, blueProcessor)
+	assert.Equal(t, 0, plc.GetRegister("redProcessor"))
+	assert.Equal(t, 0, plc.GetRegister("blueProcessor"))
 	client.registers[1] = 12
 	plc.update()
-	redProcessor, blueProcessor = plc.GetProcessorCounts()
-	assert.Equal(t, 12, redProcessor)
-	assert.Equal(t, 0, blueProcessor)
+	assert.Equal(t, 12, plc.GetRegister("redProcessor"))
+	assert.Equal(t, 0, plc.GetRegister("blueProcessor"))
 	client.registers[2] = 34
 	plc.update()
-	redProcessor, blueProcessor = plc.GetProcessorCounts()
-	assert.Equal(t, 12, redProcessor)
-	assert.Equal(t, 34, blueProcessor)
+	assert.Equal(t, 12, plc.GetRegister("redProcessor"))
+	assert.Equal(t, 34, plc.GetRegister("blueProcessor"))
+	assert.Equal(t, 0, plc.GetRegister("bogus"))
 }
 
 func TestPlcCoils(t *testing.T) {
@@ -438,27 +436,30 @@ func TestPlcCoilsGameSpecific(t *testing.T) {
 	plc.handler = modbus.NewTCPClientHandler("dummy")
 	plc.ioChangeNotifier = &websocket.Notifier{}
 
-	plc.SetTrussLights([3]bool{false, false, false}, [3]bool{false, false, false})
 	plc.update()
 	assert.Equal(t, []bool{false, false, false, false, false, false}, client.coils[8:14])
-	plc.SetTrussLights([3]bool{true, false, false}, [3]bool{false, false, false})
+	plc.SetCoil("redTrussLightOuter", true)
 	plc.update()
 	assert.Equal(t, []bool{true, false, false, false, false, false}, client.coils[8:14])
-	plc.SetTrussLights([3]bool{true, true, false}, [3]bool{false, false, false})
+	plc.SetCoil("redTrussLightMiddle", true)
 	plc.update()
 	assert.Equal(t, []bool{true, true, false, false, false, false}, client.coils[8:14])
-	plc.SetTrussLights([3]bool{true, true, true}, [3]bool{false, false, false})
+	plc.SetCoil("redTrussLightInner", true)
 	plc.update()
 	assert.Equal(t, []bool{true, true, true, false, false, false}, client.coils[8:14])
-	plc.SetTrussLights([3]bool{true, true, true}, [3]bool{true, false, false})
+	plc.SetCoil("blueTrussLightOuter", true)
 	plc.update()
 	assert.Equal(t, []bool{true, true, true, true, false, false}, client.coils[8:14])
-	plc.SetTrussLights([3]bool{true, true, true}, [3]bool{true, true, false})
+	plc.SetCoil("blueTrussLightMiddle", true)
 	plc.update()
 	assert.Equal(t, []bool{true, true, true, true, true, false}, client.coils[8:14])
-	plc.SetTrussLights([3]bool{true, true, true}, [3]bool{true, true, true})
+	plc.SetCoil("blueTrussLightInner", true)
 	plc.update()
 	assert.Equal(t, []bool{true, true, true, true, true, true}, client.coils[8:14])
+	plc.SetCoil("redTrussLightOuter", false)
+	plc.SetCoil("bogus", true)
+	plc.update()
+	assert.Equal(t, []bool{false, true, true, true, true, true}, client.coils[8:14])
 }
 
 func TestPlcIsHealthy(t *testing.T) {
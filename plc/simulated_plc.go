@@ -0,0 +1,119 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Simulated PLC whose inputs are set from the web interface, for training and demonstrations without field hardware.
+
+package plc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Team254/cheesy-arena/websocket"
+)
+
+// SimulatedPlc behaves like a healthy field PLC but takes its discrete inputs and registers from SetInput and
+// SetRegister instead of from the hardware.
+type SimulatedPlc struct {
+	ModbusPlc
+}
+
+// NewSimulatedPlc creates a simulated PLC in the state of a field that is ready to run a match: all E-stops and
+// A-stops released, all ArmorBlocks connected and all stations' Ethernet plugged in. Its I/O map has no bearing on its
+// behavior other than determining which ArmorBlocks are reported.
+func NewSimulatedPlc() *SimulatedPlc {
+	plc := new(SimulatedPlc)
+	plc.ioChangeNotifier = websocket.NewNotifier("plcIoChange", plc.generateIoChangeMessage)
+
+	// The stop buttons are wired normally closed, so a true input means that the button is not pressed.
+	plc.inputs[fieldEStop] = true
+	for _, stopInput := range []input{
+		red1EStop, red1AStop, red2EStop, red2AStop, red3EStop, red3AStop,
+		blue1EStop, blue1AStop, blue2EStop, blue2AStop, blue3EStop, blue3AStop,
+	} {
+		plc.inputs[stopInput] = true
+	}
+	for _, connectedInput := range []input{
+		redConnected1, redConnected2, redConnected3, blueConnected1, blueConnected2, blueConnected3,
+	} {
+		plc.inputs[connectedInput] = true
+	}
+	plc.registers[fieldIoConnection] = 1<<registerBits - 1
+	return plc
+}
+
+// The simulated PLC has no address to connect to.
+func (plc *SimulatedPlc) SetAddress(address string) {
+}
+
+// The simulated PLC is always enabled.
+func (plc *SimulatedPlc) IsEnabled() bool {
+	return true
+}
+
+// The simulated PLC is always healthy.
+func (plc *SimulatedPlc) IsHealthy() bool {
+	return true
+}
+
+// Loops indefinitely to advance the cycle counter and notify listeners of I/O changes, as the real PLC loop does.
+func (plc *SimulatedPlc) Run() {
+	for {
+		startTime := time.Now()
+		plc.update()
+		time.Sleep(time.Until(startTime.Add(time.Millisecond * plcLoopPeriodMs)))
+	}
+}
+
+// Performs a single iteration of the simulated PLC loop.
+func (plc *SimulatedPlc) update() {
+	// Emulate the short pulse that the real PLC loop sends on the match reset coil.
+	plc.coils[heartbeat] = true
+	if plc.matchResetCycles > 5 {
+		plc.coils[matchReset] = false
+	} else {
+		plc.matchResetCycles++
+	}
+	plc.ModbusPlc.update()
+}
+
+// SetInput sets the value of the discrete input at the given index, where any custom inputs follow the built-in ones
+// as in GetInputNames.
+func (plc *SimulatedPlc) SetInput(index int, value bool) error {
+	if index >= 0 && index < int(inputCount) {
+		plc.inputs[index] = value
+		return nil
+	}
+	customInputs := plc.getAddresses().customInputs
+	if index < 0 || index >= int(inputCount)+len(customInputs) {
+		return fmt.Errorf("invalid PLC input index %d", index)
+	}
+	plc.mutex.Lock()
+	defer plc.mutex.Unlock()
+	// Ignore the value if the map was replaced in the meantime.
+	name := customInputs[index-int(inputCount)].name
+	if _, ok := plc.customInputs[name]; ok {
+		plc.customInputs[name] = value
+	}
+	return nil
+}
+
+// SetRegister sets the value of the register at the given index, where any custom registers follow the built-in ones
+// as in GetRegisterNames.
+func (plc *SimulatedPlc) SetRegister(index int, value uint16) error {
+	if index >= 0 && index < int(registerCount) {
+		plc.registers[index] = value
+		return nil
+	}
+	customRegisters := plc.getAddresses().customRegisters
+	if index < 0 || index >= int(registerCount)+len(customRegisters) {
+		return fmt.Errorf("invalid PLC register index %d", index)
+	}
+	plc.mutex.Lock()
+	defer plc.mutex.Unlock()
+	// Ignore the value if the map was replaced in the meantime.
+	name := customRegisters[index-int(registerCount)].name
+	if _, ok := plc.customRegisters[name]; ok {
+		plc.customRegisters[name] = value
+	}
+	return nil
+}
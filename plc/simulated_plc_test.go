@@ -0,0 +1,90 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package plc
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSimulatedPlcInitialState(t *testing.T) {
+	plc := NewSimulatedPlc()
+
+	assert.True(t, plc.IsEnabled())
+	assert.True(t, plc.IsHealthy())
+	assert.NotNil(t, plc.IoChangeNotifier())
+	assert.False(t, plc.GetFieldEStop())
+	redEStops, blueEStops := plc.GetTeamEStops()
+	assert.Equal(t, [3]bool{false, false, false}, redEStops)
+	assert.Equal(t, [3]bool{false, false, false}, blueEStops)
+	redAStops, blueAStops := plc.GetTeamAStops()
+	assert.Equal(t, [3]bool{false, false, false}, redAStops)
+	assert.Equal(t, [3]bool{false, false, false}, blueAStops)
+	redEthernets, blueEthernets := plc.GetEthernetConnected()
+	assert.Equal(t, [3]bool{true, true, true}, redEthernets)
+	assert.Equal(t, [3]bool{true, true, true}, blueEthernets)
+	for name, status := range plc.GetArmorBlockStatuses() {
+		assert.True(t, status, name)
+	}
+
+	// Setting an address shouldn't turn the simulated PLC into a real one.
+	plc.SetAddress("1.2.3.4")
+	assert.Nil(t, plc.handler)
+	assert.True(t, plc.IsHealthy())
+}
+
+func TestSimulatedPlcSetInputsAndRegisters(t *testing.T) {
+	plc := NewSimulatedPlc()
+
+	assert.Nil(t, plc.SetInput(int(fieldEStop), false))
+	assert.True(t, plc.GetFieldEStop())
+	assert.Nil(t, plc.SetInput(int(blue2EStop), false))
+	_, blueEStops := plc.GetTeamEStops()
+	assert.Equal(t, [3]bool{false, true, false}, blueEStops)
+	assert.Nil(t, plc.SetInput(int(redConnected3), false))
+	redEthernets, _ := plc.GetEthernetConnected()
+	assert.Equal(t, [3]bool{true, true, false}, redEthernets)
+	assert.NotNil(t, plc.SetInput(-1, true))
+	assert.NotNil(t, plc.SetInput(int(inputCount), true))
+
+	assert.Nil(t, plc.SetRegister(int(redProcessor), 3))
+	assert.Nil(t, plc.SetRegister(int(blueProcessor), 5))
+	assert.Equal(t, 3, plc.GetRegister("redProcessor"))
+	assert.Equal(t, 5, plc.GetRegister("blueProcessor"))
+	assert.NotNil(t, plc.SetRegister(int(registerCount), 1))
+
+	assert.Nil(t, plc.SetRegister(int(fieldIoConnection), 0))
+	assert.False(t, plc.GetArmorBlockStatuses()["RedDs"])
+}
+
+func TestSimulatedPlcCustomSignals(t *testing.T) {
+	plc := NewSimulatedPlc()
+	ioMap := DefaultIoMap()
+	ioMap.CustomInputs = map[string]int{"redSensor": 25}
+	ioMap.CustomRegisters = map[string]int{"redCounter": 10}
+	assert.Nil(t, plc.SetIoMap(ioMap))
+
+	// Custom signals are addressed by index after the built-in ones.
+	assert.Nil(t, plc.SetInput(int(inputCount), true))
+	assert.True(t, plc.GetInput("redSensor"))
+	assert.NotNil(t, plc.SetInput(int(inputCount)+1, true))
+	assert.Nil(t, plc.SetRegister(int(registerCount), 12))
+	assert.Equal(t, 12, plc.GetRegister("redCounter"))
+	assert.NotNil(t, plc.SetRegister(int(registerCount)+1, 1))
+}
+
+func TestSimulatedPlcMatchReset(t *testing.T) {
+	plc := NewSimulatedPlc()
+	plc.SetRegister(int(redProcessor), 3)
+
+	plc.ResetMatch()
+	assert.True(t, plc.coils[matchReset])
+	assert.Equal(t, 0, plc.GetRegister("redProcessor"))
+
+	// The reset coil should only be pulsed briefly.
+	for i := 0; i < 7; i++ {
+		plc.update()
+	}
+	assert.False(t, plc.coils[matchReset])
+	assert.True(t, plc.coils[heartbeat])
+}
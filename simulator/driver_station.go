@@ -0,0 +1,389 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Simulated driver station and robot, speaking the same protocol to the arena as a real driver station.
+
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	restingBatteryVoltage      = 12.8
+	minBatteryVoltage          = 4.5
+	batteryRecoveryVoltsPerSec = 1.0
+	baseTripTimeMs             = 4
+	tcpStatusPeriod            = 500 * time.Millisecond
+	reconnectPeriod            = time.Second
+	simulatorVersion           = "Cheesy Arena Simulator"
+)
+
+// Returned when the address that a swapped driver station must connect from isn't configured on this computer.
+var errLocalAddressUnavailable = errors.New("local address unavailable")
+
+// DriverStation simulates a single team's driver station and the robot it is connected to.
+type DriverStation struct {
+	TeamId int
+	// The team whose alliance station cable the driver station is plugged into, which determines its IP address.
+	CableTeamId int
+	// The address to connect from, or nil to use the computer's own.
+	localIp net.IP
+
+	config *Config
+	rand   *rand.Rand
+	done   chan struct{}
+
+	mutex           sync.Mutex
+	stationPosition int
+	tcpConn         net.Conn
+	udpConn         net.Conn
+	packetCount     int
+	auto            bool
+	enabled         bool
+	fieldEStop      bool
+	matchKey        [4]byte
+	batteryVoltage  float64
+	linkDropEnd     time.Time
+	robotEStop      bool
+	missedPackets   int
+	lastUpdateTime  time.Time
+}
+
+func newDriverStation(teamId, cableTeamId int, config *Config, seed int64) *DriverStation {
+	ds := &DriverStation{
+		TeamId:          teamId,
+		CableTeamId:     cableTeamId,
+		config:          config,
+		rand:            rand.New(rand.NewSource(seed)),
+		done:            make(chan struct{}),
+		stationPosition: -1,
+		batteryVoltage:  restingBatteryVoltage,
+	}
+	if cableTeamId != teamId {
+		// Connect from the address that the other team's station would have assigned via DHCP.
+		ds.localIp = teamIpAddress(cableTeamId)
+	}
+	return ds
+}
+
+// Connects to the arena and exchanges packets with it until stopped, reconnecting whenever the connection is lost.
+func (ds *DriverStation) run() {
+	for {
+		if err := ds.connect(); errors.Is(err, errLocalAddressUnavailable) {
+			// Retrying is futile until the computer is reconfigured.
+			log.Printf("Simulated driver station for Team %d unable to connect: %v", ds.TeamId, err)
+			return
+		} else if err != nil {
+			log.Printf("Simulated driver station for Team %d unable to connect: %v", ds.TeamId, err)
+		} else {
+			ds.exchangePackets()
+		}
+
+		select {
+		case <-ds.done:
+			return
+		case <-time.After(reconnectPeriod):
+		}
+	}
+}
+
+// Disconnects the driver station from the arena and stops it from reconnecting.
+func (ds *DriverStation) stop() {
+	close(ds.done)
+	ds.disconnect()
+}
+
+// Opens the TCP connection to the arena and waits for it to assign the driver station to an alliance station.
+func (ds *DriverStation) connect() error {
+	dialer := net.Dialer{Timeout: reconnectPeriod}
+	if ds.localIp != nil {
+		if err := checkLocalAddress(ds.localIp); err != nil {
+			return err
+		}
+		dialer.LocalAddr = &net.TCPAddr{IP: ds.localIp}
+	}
+	tcpAddress := net.JoinHostPort(ds.config.ServerAddress, strconv.Itoa(ds.config.TcpPort))
+	tcpConn, err := dialer.Dial("tcp", tcpAddress)
+	if err != nil {
+		return err
+	}
+
+	_, err = tcpConn.Write([]byte{0, 3, 24, byte(ds.TeamId >> 8), byte(ds.TeamId & 0xff)})
+	if err != nil {
+		tcpConn.Close()
+		return err
+	}
+	var assignmentPacket [5]byte
+	tcpConn.SetReadDeadline(time.Now().Add(2 * reconnectPeriod))
+	if _, err = tcpConn.Read(assignmentPacket[:]); err != nil {
+		tcpConn.Close()
+		return fmt.Errorf("no station assignment received: %v", err)
+	}
+	if assignmentPacket[2] != 25 {
+		tcpConn.Close()
+		return fmt.Errorf("unexpected station assignment packet: %v", assignmentPacket)
+	}
+	if assignmentPacket[4] != 0 {
+		log.Printf("Simulated driver station for Team %d was told it is in the wrong station.", ds.TeamId)
+	}
+
+	udpAddress := net.JoinHostPort(ds.config.ServerAddress, strconv.Itoa(ds.config.UdpStatusPort))
+	udpConn, err := net.Dial("udp4", udpAddress)
+	if err != nil {
+		tcpConn.Close()
+		return err
+	}
+
+	ds.mutex.Lock()
+	ds.tcpConn = tcpConn
+	ds.udpConn = udpConn
+	ds.stationPosition = int(assignmentPacket[3])
+	ds.lastUpdateTime = time.Now()
+	ds.mutex.Unlock()
+
+	// The real driver station reports its software version as soon as it connects.
+	return ds.writeTcpPacket(encodeVersionPacket(2, simulatorVersion))
+}
+
+// Closes any open connections to the arena.
+func (ds *DriverStation) disconnect() {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	if ds.tcpConn != nil {
+		ds.tcpConn.Close()
+		ds.tcpConn = nil
+	}
+	if ds.udpConn != nil {
+		ds.udpConn.Close()
+		ds.udpConn = nil
+	}
+	ds.stationPosition = -1
+}
+
+// Sends status packets to the arena at the configured rate until the connection is closed or the driver station is
+// stopped.
+func (ds *DriverStation) exchangePackets() {
+	defer ds.disconnect()
+
+	// Drain anything the arena sends over TCP (e.g. game data) and watch for the connection being closed.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		ds.mutex.Lock()
+		tcpConn := ds.tcpConn
+		ds.mutex.Unlock()
+		buffer := make([]byte, 1024)
+		for {
+			tcpConn.SetReadDeadline(time.Time{})
+			if _, err := tcpConn.Read(buffer); err != nil {
+				return
+			}
+		}
+	}()
+
+	udpTicker := time.NewTicker(ds.config.StatusPeriod)
+	defer udpTicker.Stop()
+	tcpTicker := time.NewTicker(tcpStatusPeriod)
+	defer tcpTicker.Stop()
+	for {
+		select {
+		case <-ds.done:
+			return
+		case <-closed:
+			log.Printf("Simulated driver station for Team %d was disconnected.", ds.TeamId)
+			return
+		case <-udpTicker.C:
+			ds.updateRobot(time.Now())
+			if err := ds.sendStatusPacket(); err != nil {
+				return
+			}
+		case <-tcpTicker.C:
+			if err := ds.writeTcpPacket([]byte{0, 1, 29}); err != nil {
+				return
+			}
+			if err := ds.writeTcpPacket(ds.encodeTcpStatusPacket()); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Applies a control packet received from the arena.
+func (ds *DriverStation) handleControlPacket(packet []byte) {
+	if len(packet) < 10 {
+		return
+	}
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+
+	var matchKey [4]byte
+	copy(matchKey[:], packet[6:10])
+	if matchKey != ds.matchKey {
+		// A robot that E-stopped itself stays that way until it is power cycled between matches.
+		ds.matchKey = matchKey
+		ds.robotEStop = false
+		ds.missedPackets = 0
+	}
+	ds.auto = packet[3]&0x02 != 0
+	ds.enabled = packet[3]&0x04 != 0
+	ds.fieldEStop = packet[3]&0x80 != 0
+}
+
+// Advances the simulated robot's battery, radio link and E-stop state to the given time.
+func (ds *DriverStation) updateRobot(now time.Time) {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+
+	elapsedMin := now.Sub(ds.lastUpdateTime).Minutes()
+	ds.lastUpdateTime = now
+	if ds.isRobotEnabled() {
+		ds.batteryVoltage -= ds.config.BatterySagVoltsPerMinute * elapsedMin
+		if ds.happens(ds.config.LinkDropsPerMinute, elapsedMin) {
+			log.Printf("Simulating a robot link drop for Team %d.", ds.TeamId)
+			ds.linkDropEnd = now.Add(time.Duration(ds.config.LinkDropDurationSec * float64(time.Second)))
+		}
+		if ds.happens(ds.config.EStopsPerMinute, elapsedMin) {
+			log.Printf("Simulating a driver station E-stop for Team %d.", ds.TeamId)
+			ds.robotEStop = true
+		}
+	} else {
+		ds.batteryVoltage += batteryRecoveryVoltsPerSec * elapsedMin * 60
+	}
+	ds.batteryVoltage = math.Max(math.Min(ds.batteryVoltage, restingBatteryVoltage), minBatteryVoltage)
+	if !ds.isRobotLinked(now) {
+		ds.missedPackets++
+	}
+}
+
+// Returns true with the probability of an event occurring in the given interval at the given rate per minute.
+func (ds *DriverStation) happens(ratePerMinute, elapsedMin float64) bool {
+	return ratePerMinute > 0 && ds.rand.Float64() < 1-math.Exp(-ratePerMinute*elapsedMin)
+}
+
+// Returns true if the robot is currently being commanded to run and is able to.
+func (ds *DriverStation) isRobotEnabled() bool {
+	return ds.enabled && !ds.fieldEStop && !ds.robotEStop
+}
+
+func (ds *DriverStation) isRobotLinked(now time.Time) bool {
+	return now.After(ds.linkDropEnd)
+}
+
+// Sends the UDP status packet describing the state of the radio, roboRIO and robot to the arena.
+func (ds *DriverStation) sendStatusPacket() error {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	if ds.udpConn == nil {
+		return nil
+	}
+	_, err := ds.udpConn.Write(ds.encodeStatusPacket(time.Now()))
+	return err
+}
+
+// Serializes the robot status into a UDP packet in the format expected by the arena.
+func (ds *DriverStation) encodeStatusPacket(now time.Time) []byte {
+	packet := make([]byte, 8)
+
+	// Packet number, stored big-endian in two bytes.
+	packet[0] = byte((ds.packetCount >> 8) & 0xff)
+	packet[1] = byte(ds.packetCount & 0xff)
+	ds.packetCount++
+
+	// Protocol version.
+	packet[2] = 0
+
+	// Status byte; the radio and roboRIO stay reachable during a simulated link drop but robot code does not.
+	packet[3] = 0x08 | 0x10
+	robotLinked := ds.isRobotLinked(now)
+	if robotLinked {
+		packet[3] |= 0x20
+	}
+	if ds.auto {
+		packet[3] |= 0x02
+	}
+	if robotLinked && ds.isRobotEnabled() {
+		packet[3] |= 0x04
+	}
+	if ds.robotEStop {
+		packet[3] |= 0x80
+	}
+
+	// Team number, stored big-endian in two bytes.
+	packet[4] = byte(ds.TeamId >> 8)
+	packet[5] = byte(ds.TeamId & 0xff)
+
+	// Robot battery voltage, stored as volts * 256.
+	packet[6] = byte(ds.batteryVoltage)
+	packet[7] = byte((ds.batteryVoltage - math.Floor(ds.batteryVoltage)) * 256)
+
+	return packet
+}
+
+// Serializes the trip time and missed packet count into a TCP status packet, including its size prefix.
+func (ds *DriverStation) encodeTcpStatusPacket() []byte {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	packet := make([]byte, 38)
+	packet[1] = 36
+	packet[2] = 22
+
+	// Trip time is reported in units of half a millisecond.
+	tripTimeMs := baseTripTimeMs + ds.rand.Intn(3)
+	packet[3] = byte(min(tripTimeMs*2, 255))
+	packet[4] = byte(min(ds.missedPackets, 255))
+	return packet
+}
+
+func (ds *DriverStation) writeTcpPacket(packet []byte) error {
+	ds.mutex.Lock()
+	tcpConn := ds.tcpConn
+	ds.mutex.Unlock()
+	if tcpConn == nil {
+		return fmt.Errorf("not connected")
+	}
+	_, err := tcpConn.Write(packet)
+	return err
+}
+
+// Returns the position (0-5) of the alliance station that the arena assigned the driver station to, or -1 if it is
+// not connected.
+func (ds *DriverStation) getStationPosition() int {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	return ds.stationPosition
+}
+
+// Serializes a software version report of the given tag type, including its size prefix.
+func encodeVersionPacket(tag byte, version string) []byte {
+	data := append([]byte{tag, 0, byte(len(version))}, version...)
+	return append([]byte{byte(len(data) >> 8), byte(len(data) & 0xff)}, data...)
+}
+
+// Returns an error wrapping errLocalAddressUnavailable if the given address can't be connected from because it isn't
+// assigned to this computer.
+func checkLocalAddress(ip net.IP) error {
+	conn, err := net.ListenPacket("udp4", net.JoinHostPort(ip.String(), "0"))
+	if err != nil {
+		return fmt.Errorf(
+			"%w: %s is not assigned to this computer; add it as an alias to simulate swapped stations (e.g. "+
+				"\"ip addr add %s/32 dev lo\" on Linux)",
+			errLocalAddressUnavailable,
+			ip,
+			ip,
+		)
+	}
+	return conn.Close()
+}
+
+// Returns the address that a device belonging to the given team would have on the field network.
+func teamIpAddress(teamId int) net.IP {
+	return net.IPv4(10, byte(teamId/100), byte(teamId%100), 5)
+}
@@ -0,0 +1,187 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package simulator
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestFleetConnectsToArena(t *testing.T) {
+	// Stand in for the arena's driver station listeners.
+	tcpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer tcpListener.Close()
+	udpStatusListener, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	assert.Nil(t, err)
+	defer udpStatusListener.Close()
+	udpControlListener, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	assert.Nil(t, err)
+
+	config := DefaultConfig()
+	config.ServerAddress = "127.0.0.1"
+	config.TcpPort = tcpListener.Addr().(*net.TCPAddr).Port
+	config.UdpStatusPort = udpStatusListener.LocalAddr().(*net.UDPAddr).Port
+	config.StatusPeriod = 5 * time.Millisecond
+	teams := map[string]int{"B2": 1503}
+	fleet := NewFleet(config, func() map[string]int { return teams })
+	go fleet.listenForControlPackets(udpControlListener)
+	fleet.sync()
+
+	tcpConn, err := tcpListener.Accept()
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer tcpConn.Close()
+	var hello [5]byte
+	_, err = tcpConn.Read(hello[:])
+	assert.Nil(t, err)
+	assert.Equal(t, [5]byte{0, 3, 24, 5, 223}, hello)
+	tcpConn.Write([]byte{0, 3, 25, 4, 0})
+
+	// Check that the driver station reports its version.
+	var versionPacket [4]byte
+	_, err = tcpConn.Read(versionPacket[:])
+	assert.Nil(t, err)
+	assert.Equal(t, [4]byte{0, byte(len(simulatorVersion) + 3), 2, 0}, versionPacket)
+
+	// Check that the status packet reports a linked, disabled robot with a full battery.
+	status := readStatusPacket(t, udpStatusListener)
+	assert.Equal(t, byte(0x38), status[3])
+	assert.Equal(t, []byte{5, 223, 12}, status[4:7])
+
+	// Enable the robot from the field and check that it reports being enabled.
+	controlConn, err := net.DialUDP("udp4", nil, udpControlListener.LocalAddr().(*net.UDPAddr))
+	assert.Nil(t, err)
+	defer controlConn.Close()
+	controlPacket := make([]byte, 22)
+	controlPacket[3] = 0x04
+	controlPacket[5] = 4
+	controlConn.Write(controlPacket)
+	assert.Eventually(
+		t,
+		func() bool { return readStatusPacket(t, udpStatusListener)[3]&0x04 != 0 },
+		time.Second,
+		time.Millisecond,
+	)
+
+	// Check that the driver station disconnects once its team leaves the match.
+	teams = map[string]int{}
+	fleet.sync()
+	assert.Empty(t, fleet.driverStations)
+	tcpConn.SetReadDeadline(time.Now().Add(time.Second))
+	buffer := make([]byte, 1024)
+	for err == nil {
+		_, err = tcpConn.Read(buffer)
+	}
+	assert.NotContains(t, err.Error(), "timeout")
+}
+
+func TestDriverStationSwappedStation(t *testing.T) {
+	tcpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer tcpListener.Close()
+	udpStatusListener, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	assert.Nil(t, err)
+	defer udpStatusListener.Close()
+	config := DefaultConfig()
+	config.ServerAddress = "127.0.0.1"
+	config.TcpPort = tcpListener.Addr().(*net.TCPAddr).Port
+	config.UdpStatusPort = udpStatusListener.LocalAddr().(*net.UDPAddr).Port
+
+	// Check that a driver station plugged into another team's cable connects from that team's address.
+	ds := newDriverStation(254, 1114, &config, 0)
+	assert.Equal(t, "10.11.14.5", ds.localIp.String())
+	assert.Nil(t, newDriverStation(254, 254, &config, 0).localIp)
+
+	// Check that it gives up straight away with an explanation if the address isn't configured on the computer.
+	ds.localIp = net.IPv4(192, 0, 2, 5)
+	err = ds.connect()
+	if assert.NotNil(t, err) {
+		assert.ErrorIs(t, err, errLocalAddressUnavailable)
+		assert.Contains(t, err.Error(), "ip addr add 192.0.2.5/32")
+	}
+	stopped := make(chan struct{})
+	go func() {
+		ds.run()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("driver station kept retrying with an unavailable address")
+	}
+
+	// Check that the arena sees the connection coming from the configured address. Linux routes the whole loopback
+	// block to the computer, so another loopback address stands in for the alias.
+	ds = newDriverStation(254, 1114, &config, 0)
+	ds.localIp = net.IPv4(127, 0, 0, 2)
+	if checkLocalAddress(ds.localIp) != nil {
+		t.Skip("loopback alias not available on this platform")
+	}
+	go ds.connect()
+	defer ds.disconnect()
+	tcpConn, err := tcpListener.Accept()
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer tcpConn.Close()
+	assert.Equal(t, "127.0.0.2", tcpConn.RemoteAddr().(*net.TCPAddr).IP.String())
+}
+
+func TestDriverStationFaults(t *testing.T) {
+	config := DefaultConfig()
+	config.BatterySagVoltsPerMinute = 3
+	config.LinkDropsPerMinute = 1000000
+	ds := newDriverStation(254, 254, &config, 0)
+	ds.handleControlPacket([]byte{0, 0, 0, 0x04, 0, 0, 2, 0, 12, 1})
+	now := time.Now()
+	ds.lastUpdateTime = now
+	ds.updateRobot(now.Add(30 * time.Second))
+	assert.InDelta(t, restingBatteryVoltage-1.5, ds.batteryVoltage, 1e-9)
+	status := ds.encodeStatusPacket(now.Add(30 * time.Second))
+	assert.Equal(t, byte(0x18), status[3])
+	assert.Equal(t, 1, ds.missedPackets)
+
+	// Check that the battery recovers and the link comes back once the robot is disabled.
+	config.LinkDropsPerMinute = 0
+	config.EStopsPerMinute = 1000000
+	ds.handleControlPacket([]byte{0, 0, 0, 0, 0, 0, 2, 0, 12, 1})
+	ds.updateRobot(now.Add(time.Minute))
+	assert.Equal(t, restingBatteryVoltage, ds.batteryVoltage)
+	assert.False(t, ds.robotEStop)
+	assert.Equal(t, byte(0x38), ds.encodeStatusPacket(now.Add(time.Minute))[3])
+
+	// Check that a robot E-stops itself while enabled and stays that way until the next match.
+	ds.handleControlPacket([]byte{0, 0, 0, 0x04, 0, 0, 2, 0, 12, 1})
+	ds.updateRobot(now.Add(61 * time.Second))
+	assert.True(t, ds.robotEStop)
+	assert.Equal(t, byte(0xb8), ds.encodeStatusPacket(now.Add(61*time.Second))[3])
+	ds.handleControlPacket([]byte{0, 0, 0, 0, 0, 0, 2, 0, 13, 1})
+	assert.False(t, ds.robotEStop)
+}
+
+func TestParseSwappedStations(t *testing.T) {
+	pairs, err := ParseSwappedStations("R1-R2, b1-B3")
+	assert.Nil(t, err)
+	assert.Equal(t, [][2]string{{"R1", "R2"}, {"B1", "B3"}}, pairs)
+
+	pairs, err = ParseSwappedStations("")
+	assert.Nil(t, err)
+	assert.Empty(t, pairs)
+
+	_, err = ParseSwappedStations("R1")
+	assert.NotNil(t, err)
+	_, err = ParseSwappedStations("R1-R4")
+	assert.NotNil(t, err)
+}
+
+func readStatusPacket(t *testing.T, udpListener *net.UDPConn) []byte {
+	var data [50]byte
+	udpListener.SetReadDeadline(time.Now().Add(time.Second))
+	n, err := udpListener.Read(data[:])
+	assert.Nil(t, err)
+	return data[:n]
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Fleet of simulated driver stations that follows the teams in the arena's current match, for rehearsing match flow
+// and load testing without real robots.
+
+package simulator
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Team254/cheesy-arena/network"
+)
+
+const syncPeriod = time.Second
+
+var stationPositions = map[string]int{"R1": 0, "R2": 1, "R3": 2, "B1": 3, "B2": 4, "B3": 5}
+
+// Config holds the parameters of the simulated driver stations and the faults to inject into them.
+type Config struct {
+	ServerAddress  string
+	TcpPort        int
+	UdpStatusPort  int
+	UdpControlPort int
+	StatusPeriod   time.Duration
+
+	// Average rates at which faults occur, per minute of enabled time.
+	LinkDropsPerMinute float64
+	EStopsPerMinute    float64
+
+	LinkDropDurationSec      float64
+	BatterySagVoltsPerMinute float64
+
+	// Pairs of alliance stations whose driver stations are plugged into each other's cables.
+	SwappedStations [][2]string
+}
+
+// Fleet manages one simulated driver station for each team in the current match.
+type Fleet struct {
+	Config         Config
+	teamsFunc      func() map[string]int
+	mutex          sync.Mutex
+	driverStations map[string]*DriverStation
+	seed           int64
+}
+
+// DefaultConfig returns a configuration that connects to the arena on the standard ports with no faults injected.
+func DefaultConfig() Config {
+	return Config{
+		ServerAddress:       network.ServerIpAddress,
+		TcpPort:             1750,
+		UdpStatusPort:       1160,
+		UdpControlPort:      1121,
+		StatusPeriod:        20 * time.Millisecond,
+		LinkDropDurationSec: 2,
+	}
+}
+
+// NewFleet creates a fleet that simulates the teams returned by the given function, which maps each alliance station
+// to the team assigned to it (or zero if the station is empty).
+func NewFleet(config Config, teamsFunc func() map[string]int) *Fleet {
+	return &Fleet{
+		Config:         config,
+		teamsFunc:      teamsFunc,
+		driverStations: make(map[string]*DriverStation),
+		seed:           time.Now().UnixNano(),
+	}
+}
+
+// Run listens for control packets from the arena and keeps the simulated driver stations in sync with the current
+// match. Does not return.
+func (fleet *Fleet) Run() {
+	udpListener, err := net.ListenUDP("udp4", &net.UDPAddr{Port: fleet.Config.UdpControlPort})
+	if err != nil {
+		log.Printf("Error opening simulated driver station UDP socket: %v", err)
+		return
+	}
+	log.Printf(
+		"Simulating driver stations against %s; listening for control packets on UDP port %d",
+		fleet.Config.ServerAddress,
+		fleet.Config.UdpControlPort,
+	)
+	go fleet.listenForControlPackets(udpListener)
+
+	for {
+		fleet.sync()
+		time.Sleep(syncPeriod)
+	}
+}
+
+// Starts and stops simulated driver stations so that there is one for each team in the current match.
+func (fleet *Fleet) sync() {
+	teams := fleet.teamsFunc()
+	cableTeams := make(map[string]int, len(teams))
+	for station, teamId := range teams {
+		cableTeams[station] = teamId
+	}
+	for _, pair := range fleet.Config.SwappedStations {
+		cableTeams[pair[0]], cableTeams[pair[1]] = teams[pair[1]], teams[pair[0]]
+	}
+
+	fleet.mutex.Lock()
+	defer fleet.mutex.Unlock()
+	for station := range stationPositions {
+		teamId, cableTeamId := teams[station], cableTeams[station]
+		if cableTeamId == 0 {
+			cableTeamId = teamId
+		}
+		ds := fleet.driverStations[station]
+		if ds != nil && (ds.TeamId != teamId || ds.CableTeamId != cableTeamId) {
+			ds.stop()
+			delete(fleet.driverStations, station)
+			ds = nil
+		}
+		if ds == nil && teamId > 0 {
+			fleet.seed++
+			ds = newDriverStation(teamId, cableTeamId, &fleet.Config, fleet.seed)
+			fleet.driverStations[station] = ds
+			go ds.run()
+		}
+	}
+}
+
+// Dispatches each control packet from the arena to the driver station assigned to the station it is addressed to.
+func (fleet *Fleet) listenForControlPackets(udpListener *net.UDPConn) {
+	defer udpListener.Close()
+	var data [100]byte
+	for {
+		n, err := udpListener.Read(data[:])
+		if err != nil {
+			log.Printf("Error reading simulated driver station control packet: %v", err)
+			return
+		}
+		if n < 10 {
+			continue
+		}
+		packet := append([]byte(nil), data[:n]...)
+
+		fleet.mutex.Lock()
+		for _, ds := range fleet.driverStations {
+			if ds.getStationPosition() == int(packet[5]) {
+				ds.handleControlPacket(packet)
+			}
+		}
+		fleet.mutex.Unlock()
+	}
+}
+
+// ParseSwappedStations parses a comma-separated list of alliance station pairs such as "R1-R2,B1-B3".
+func ParseSwappedStations(value string) ([][2]string, error) {
+	var pairs [][2]string
+	if value == "" {
+		return pairs, nil
+	}
+	for _, pairValue := range strings.Split(value, ",") {
+		stations := strings.Split(strings.TrimSpace(pairValue), "-")
+		if len(stations) != 2 {
+			return nil, fmt.Errorf("invalid station pair %q", pairValue)
+		}
+		for i, station := range stations {
+			stations[i] = strings.ToUpper(strings.TrimSpace(station))
+			if _, ok := stationPositions[stations[i]]; !ok {
+				return nil, fmt.Errorf("invalid alliance station %q", station)
+			}
+		}
+		pairs = append(pairs, [2]string{stations[0], stations[1]})
+	}
+	return pairs, nil
+}
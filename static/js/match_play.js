@@ -13,6 +13,14 @@ const loadMatch = function (matchId) {
   websocket.send("loadMatch", {matchId: matchId});
 }
 
+// Prompts for the reason and sends a websocket message to load the specified match to be played again.
+const replayMatch = function (matchId) {
+  const reason = prompt("Reason for replaying this match:");
+  if (reason) {
+    websocket.send("replayMatch", {matchId: matchId, reason: reason});
+  }
+}
+
 // Sends a websocket message to load the results for the specified match into the display buffer.
 const showResult = function (matchId) {
   websocket.send("showResult", {matchId: matchId});
@@ -102,6 +110,19 @@ const startTimeout = function () {
   websocket.send("startTimeout", durationSec);
 };
 
+// Sends a websocket message to push back the times of the remaining matches to reflect how late the event is running.
+const retimeMatches = function () {
+  let compressedCycleTimeSec = 0;
+  const cycleTime = $("#compressedCycleTime").val().split(":");
+  if (cycleTime[0] !== "") {
+    compressedCycleTimeSec = parseFloat(cycleTime[0]);
+    if (cycleTime.length > 1) {
+      compressedCycleTimeSec = compressedCycleTimeSec * 60 + parseFloat(cycleTime[1]);
+    }
+  }
+  websocket.send("retimeMatches", {compressedCycleTimeSec: compressedCycleTimeSec});
+};
+
 const confirmCommit = function () {
   if (isReplay || !scoreIsReady) {
     // Show the appropriate message(s) in the confirmation dialog.
@@ -124,6 +145,37 @@ const getTeamNumber = function (station) {
   return teamId ? parseInt(teamId) : 0;
 }
 
+// Sends a websocket message to acknowledge the alarm raised against the given field component.
+const acknowledgeAlarm = function (component) {
+  websocket.send("acknowledgeAlarm", {component: component});
+};
+
+// Handles a websocket message to update the list of field hardware alarms.
+const handleAlarms = function (data) {
+  const alarms = $("#alarms");
+  alarms.empty();
+  $.each(data, function (i, alarm) {
+    const alarmClass = alarm.Active ? (alarm.Critical ? "alert-danger" : "alert-warning") : "alert-secondary";
+    const alarmElement = $(`<div class="alert ${alarmClass} d-flex align-items-center py-2 mb-1"></div>`);
+    const raisedAt = new Date(alarm.RaisedAt).toLocaleTimeString();
+    let text = `${alarm.Critical ? "CRITICAL: " : ""}${alarm.Description} (since ${raisedAt})`;
+    if (!alarm.Active) {
+      text += " - cleared";
+    }
+    alarmElement.append($("<span class='me-auto'></span>").text(text));
+    if (alarm.Acknowledged) {
+      alarmElement.append($("<span></span>").text(`Acknowledged by ${alarm.AcknowledgedBy || "unknown"}`));
+    } else {
+      const button = $("<button type='button' class='btn btn-sm btn-dark'>Acknowledge</button>");
+      button.on("click", function () {
+        acknowledgeAlarm(alarm.Component);
+      });
+      alarmElement.append(button);
+    }
+    alarms.append(alarmElement);
+  });
+};
+
 // Handles a websocket message to update the team connection status.
 const handleArenaStatus = function (data) {
   // Update the team status view.
@@ -288,7 +340,12 @@ const handleMatchLoad = function (data) {
     .then(response => response.text())
     .then(html => $("#matchListColumn").html(html));
 
-  $("#matchName").text(data.Match.LongName);
+  let matchName = data.Match.LongName;
+  if (data.PlayNumber > 1) {
+    matchName += ` (Play ${data.PlayNumber})`;
+  }
+  $("#matchName").text(matchName);
+  $("#matchName").attr("title", data.ReplayReason);
   $("#testMatchName").val(data.Match.LongName);
   $("#testMatchSettings").toggle(data.Match.Type === matchTypeTest);
   $.each(data.Teams, function (station, team) {
@@ -395,6 +452,9 @@ $(function () {
 
   // Set up the websocket back to the server.
   websocket = new CheesyWebsocket("/match_play/websocket", {
+    alarms: function (event) {
+      handleAlarms(event.data);
+    },
     allianceStationDisplayMode: function (event) {
       handleAllianceStationDisplayMode(event.data);
     },
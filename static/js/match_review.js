@@ -151,3 +151,51 @@ const getSelectElement = function (alliance, name) {
   const selector = `select[name=${alliance}${name}]`;
   return $(selector);
 };
+
+// Fetches the realtime score changes recorded during the match and draws both alliances' scores over time.
+const renderScoreTimeline = function (url) {
+  $.getJSON(url, function (events) {
+    const container = $("#scoreTimeline");
+    if (events.length === 0) {
+      container.text("No score changes were recorded for this match.");
+      return;
+    }
+
+    const width = 1000;
+    const height = 250;
+    const margin = 40;
+    const endTimeSec = Math.max(events[events.length - 1].MatchTimeSec, 1);
+    let maxPoints = 10;
+    $.each(events, function (i, event) {
+      maxPoints = Math.max(maxPoints, event.RedPoints, event.BluePoints);
+    });
+    const x = (timeSec) => margin + timeSec / endTimeSec * (width - 2 * margin);
+    const y = (points) => height - margin - points / maxPoints * (height - 2 * margin);
+
+    // Scores only change at discrete moments, so draw each alliance as a step line.
+    const steps = {red: [`${x(0)},${y(0)}`], blue: [`${x(0)},${y(0)}`]};
+    let lastPoints = {red: 0, blue: 0};
+    $.each(events, function (i, event) {
+      const points = {red: event.RedPoints, blue: event.BluePoints};
+      $.each(steps, function (alliance, step) {
+        step.push(`${x(event.MatchTimeSec)},${y(lastPoints[alliance])}`);
+        step.push(`${x(event.MatchTimeSec)},${y(points[alliance])}`);
+      });
+      lastPoints = points;
+    });
+
+    let svg = `<svg viewBox="0 0 ${width} ${height}" width="100%">`;
+    svg += `<line x1="${x(0)}" y1="${y(0)}" x2="${x(endTimeSec)}" y2="${y(0)}" stroke="#888" />`;
+    svg += `<line x1="${x(0)}" y1="${y(0)}" x2="${x(0)}" y2="${y(maxPoints)}" stroke="#888" />`;
+    for (let timeSec = 0; timeSec <= endTimeSec; timeSec += 15) {
+      svg += `<text x="${x(timeSec)}" y="${height - margin / 2}" fill="#888" font-size="12" text-anchor="middle">` +
+        `${timeSec}s</text>`;
+    }
+    svg += `<text x="${margin - 5}" y="${y(maxPoints)}" fill="#888" font-size="12" text-anchor="end">` +
+      `${maxPoints}</text>`;
+    svg += `<polyline points="${steps.red.join(" ")}" fill="none" stroke="#f44" stroke-width="2" />`;
+    svg += `<polyline points="${steps.blue.join(" ")}" fill="none" stroke="#48f" stroke-width="2" />`;
+    svg += "</svg>";
+    container.html(svg);
+  });
+};
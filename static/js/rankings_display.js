@@ -11,14 +11,32 @@ var staticUpdateIntervalMs = 10000;  // How long between updates if not scrollin
 var standingsTemplate = Handlebars.compile($("#standingsTemplate").html());
 var rankingsData;
 var prevHighestPlayedMatch;
+var showProjection;  // Whether to include each team's projected chance of being an alliance captain.
 
 // Loads the JSON rankings data from the event server.
 var getRankingsData = function (callback) {
   $.getJSON("/api/rankings", function (data) {
-    rankingsData = data;
-    if (callback) {
-      callback(data);
+    if (!showProjection) {
+      rankingsData = data;
+      if (callback) {
+        callback(data);
+      }
+      return;
     }
+
+    $.getJSON("/api/rankings/projection", function (projections) {
+      const captainProbabilities = {};
+      $.each(projections, function (i, projection) {
+        captainProbabilities[projection.TeamId] = projection.CaptainProbability;
+      });
+      $.each(data.Rankings, function (i, ranking) {
+        ranking.CaptainPercent = Math.round(100 * (captainProbabilities[ranking.TeamId] || 0)) + "%";
+      });
+      rankingsData = data;
+      if (callback) {
+        callback(data);
+      }
+    });
   });
 };
 
@@ -88,6 +106,8 @@ $(function () {
   // Read the configuration for this display from the URL query string.
   var urlParams = new URLSearchParams(window.location.search);
   scrollMsPerRow = urlParams.get("scrollMsPerRow");
+  showProjection = urlParams.get("projection") === "true";
+  $("body").attr("data-projection", showProjection);
 
   // Set up the websocket back to the server. Used only for remote forcing of reloads.
   websocket = new CheesyWebsocket("/displays/rankings/websocket", {
@@ -62,6 +62,12 @@ var commitMatch = function () {
   websocket.send("commitMatch");
 };
 
+// Resolves the given difference between the redundant scorers at the given position in favor of the given scorer, or
+// all of them if the key is empty.
+var acceptScorerValue = function (position, scorer, key) {
+  websocket.send("acceptScorerValue", {Position: position, Scorer: scorer, Key: key});
+};
+
 // Handles a websocket message to update the teams for the current match.
 var handleMatchLoad = function (data) {
   $("#matchName").text(data.Match.LongName);
@@ -155,6 +161,40 @@ const handleScoringStatus = function (data) {
   updateScoreStatus(data, "red_far", "#redFarScoreStatus", "Red Far");
   updateScoreStatus(data, "blue_near", "#blueNearScoreStatus", "Blue Near");
   updateScoreStatus(data, "blue_far", "#blueFarScoreStatus", "Blue Far");
+
+  const discrepanciesElement = $("#scorerDiscrepancies");
+  discrepanciesElement.empty();
+  $.each({red_near: "Red Near", red_far: "Red Far", blue_near: "Blue Near", blue_far: "Blue Far"},
+    function (position, displayName) {
+      const discrepancies = data.PositionStatuses[position].Discrepancies;
+      if (discrepancies.length === 0) {
+        return;
+      }
+      const positionElement = $("<div class='scorer-discrepancy'></div>");
+      positionElement.append($("<h4></h4>").text(`${displayName} scorers disagree (Scorer 1 vs. other):`));
+      const list = $("<ul></ul>");
+      $.each(discrepancies, function (i, discrepancy) {
+        const item = $("<li></li>").text(`Scorer ${discrepancy.Scorer}: ${discrepancy.Description}`);
+        $.each([1, discrepancy.Scorer], function (j, scorer) {
+          item.append(
+            $("<span class='control-button' data-enabled='true'></span>").text(`Use Scorer ${scorer}`).click(
+              function () {
+                acceptScorerValue(position, scorer, discrepancy.Key);
+              }
+            )
+          );
+        });
+        list.append(item);
+      });
+      positionElement.append(list);
+      positionElement.append(
+        $("<div class='control-button' data-enabled='true'>Accept Scorer 1 for All</div>").click(function () {
+          acceptScorerValue(position, 1, "");
+        })
+      );
+      discrepanciesElement.append(positionElement);
+    }
+  );
 }
 
 // Helper function to update a badge that shows scoring panel commit status.
@@ -163,6 +203,7 @@ const updateScoreStatus = function (data, position, element, displayName) {
   $(element).text(`${displayName} ${status.NumPanelsReady}/${status.NumPanels}`);
   $(element).attr("data-present", status.NumPanels > 0);
   $(element).attr("data-ready", status.Ready);
+  $(element).attr("data-discrepant", status.Discrepancies.length > 0);
 };
 
 // Populates the red/yellow card button for a given team.
@@ -18,6 +18,20 @@ let inTeleop = false;
 // True when post-auto and in edit auto mode
 let editingAuto = false;
 
+// Scoring operations that have not yet been acknowledged by the server, persisted so that taps made while the tablet
+// is disconnected are replayed once it reconnects.
+let clientId;
+let operationSeq;
+let operationQueue;
+let currentMatchId;
+let currentLoadId;
+// The connection over which the queue was last replayed; new operations are held back on any other connection so that
+// they can't overtake older queued ones, which the server would then discard as already applied.
+let replayedConnection;
+// Descriptions of queued operations that the server rejected because they were made during a different match or an
+// earlier load of this one.
+let staleOperations = [];
+
 let localFoulCounts = {
   "red-minor": 0,
   "blue-minor": 0,
@@ -54,6 +68,8 @@ const closeFoulsDialogIfOutside = function (event) {
 
 // Handles a websocket message to update the teams for the current match.
 const handleMatchLoad = function (data) {
+  currentMatchId = data.Match.Id;
+  currentLoadId = data.LoadId;
   $("#matchName").text(data.Match.LongName);
   if (alliance === "red") {
     $(".team-1 .team-num").text(data.Match.Red1);
@@ -84,7 +100,7 @@ const resetFoulCounts = function () {
 const addFoul = function (alliance, isMajor) {
   const foulType = `${alliance}-${isMajor ? "major" : "minor"}`;
   localFoulCounts[foulType] += 1;
-  websocket.send("addFoul", {Alliance: alliance, IsMajor: isMajor});
+  sendOperation("addFoul", {Alliance: alliance, IsMajor: isMajor});
   renderLocalFoulCounts();
 }
 
@@ -208,7 +224,7 @@ const handleRealtimeScore = function (data) {
 
 // Websocket message senders for various buttons
 const handleCounterClick = function (command, adjustment) {
-  websocket.send(command, {
+  sendOperation(command, {
     Adjustment: adjustment,
     Current: true,
     Autonomous: !inTeleop || editingAuto,
@@ -216,24 +232,112 @@ const handleCounterClick = function (command, adjustment) {
   });
 }
 const handleLeaveClick = function (teamPosition) {
-  websocket.send("leave", {TeamPosition: teamPosition});
+  // Send the desired state rather than a toggle so that the operation is safe to replay, and show it right away in
+  // case the server is unreachable.
+  const leaveStatus = $(`#auto-status-${teamPosition}`).attr("data-selected") !== "true";
+  $(`#auto-status-${teamPosition} > .team-text`).text(leaveStatus ? "Leave" : "None");
+  $(`#auto-status-${teamPosition}`).attr("data-selected", leaveStatus);
+  sendOperation("leave", {TeamPosition: teamPosition, LeaveStatus: leaveStatus});
 }
 const handleEndgameClick = function (teamPosition, endgameStatus) {
-  websocket.send("endgame", {TeamPosition: teamPosition, EndgameStatus: endgameStatus});
+  sendOperation("endgame", {TeamPosition: teamPosition, EndgameStatus: endgameStatus});
 }
 const handleReefClick = function (reefPosition, reefLevel) {
-  websocket.send("reef", {
+  const current = !editingAuto;
+  const autonomous = !inTeleop || editingAuto;
+  const column = $(`#reef-column-${reefPosition}`);
+  const scoredAttribute = current ? `data-l${reefLevel}-scored` : `data-l${reefLevel}-auto-scored`;
+  const scored = column.attr(scoredAttribute) !== "true";
+  if (current) {
+    column.attr(`data-l${reefLevel}-scored`, scored);
+  }
+  if (autonomous) {
+    column.attr(`data-l${reefLevel}-auto-scored`, scored);
+  }
+  sendOperation("reef", {
     ReefPosition: reefPosition,
     ReefLevel: reefLevel,
-    Current: !editingAuto,
-    Autonomous: !inTeleop || editingAuto,
-    NearSide: nearSide
+    Current: current,
+    Autonomous: autonomous,
+    NearSide: nearSide,
+    Scored: scored,
   });
 }
 
+// Assigns the next sequence number to the given command and queues it until the server acknowledges it.
+const sendOperation = function (command, data) {
+  operationSeq++;
+  localStorage.setItem(`scoringPanelSeq-${clientId}`, operationSeq);
+  const operation = {
+    command: command,
+    data: {...data, ClientId: clientId, Seq: operationSeq, MatchId: currentMatchId, LoadId: currentLoadId},
+  };
+  operationQueue.push(operation);
+  saveOperationQueue();
+  if (websocket.websocket === replayedConnection && websocket.websocket.readyState === WebSocket.OPEN) {
+    websocket.send(operation.command, operation.data);
+  }
+}
+
+// Resends every unacknowledged operation in order; the server discards any that it had already applied.
+const replayOperationQueue = function () {
+  for (const operation of operationQueue) {
+    websocket.send(operation.command, operation.data);
+  }
+  replayedConnection = websocket.websocket;
+}
+
+// Handles a websocket message indicating that the queued operation having the given number was not applied because it
+// was made during a different match or play, so that the scorer knows to re-enter it if it still matters.
+const handleStaleOperation = function (seq) {
+  const operation = operationQueue.find(operation => operation.data.Seq === seq);
+  if (operation !== undefined) {
+    const details = Object.entries(operation.data)
+      .filter(([key]) => !["ClientId", "Seq", "MatchId", "LoadId"].includes(key))
+      .map(([key, value]) => `${key}: ${value}`)
+      .join(", ");
+    staleOperations.push(`${operation.command} (${details})`);
+    $("#stale-operations").text(`(${staleOperations.length} not applied)`);
+  }
+}
+
+// Shows the scorer the operations that weren't applied and clears the indicator.
+const showStaleOperations = function () {
+  if (staleOperations.length > 0) {
+    alert(
+      "These actions were made during a previous match or play and were not applied:\n\n" + staleOperations.join("\n")
+    );
+    staleOperations = [];
+    $("#stale-operations").text("");
+  }
+}
+
+// Handles a websocket message confirming that the server has processed the operation having the given number.
+const handleOperationAck = function (seq) {
+  operationQueue = operationQueue.filter(operation => operation.data.Seq > seq);
+  saveOperationQueue();
+}
+
+const saveOperationQueue = function () {
+  localStorage.setItem(`scoringPanelQueue-${clientId}`, JSON.stringify(operationQueue));
+  $("#queued-operations").text(operationQueue.length > 0 ? `(${operationQueue.length} queued)` : "");
+}
+
+// Restores the identity and any unsent operations of this panel from a previous session in the same browser.
+const loadOperationQueue = function (position) {
+  clientId = localStorage.getItem(`scoringPanelClientId-${position}`);
+  if (clientId === null) {
+    clientId = `${position}-${Date.now().toString(36)}-${Math.random().toString(36).substring(2)}`;
+    localStorage.setItem(`scoringPanelClientId-${position}`, clientId);
+  }
+  operationSeq = parseInt(localStorage.getItem(`scoringPanelSeq-${clientId}`)) || 0;
+  operationQueue = JSON.parse(localStorage.getItem(`scoringPanelQueue-${clientId}`)) || [];
+  saveOperationQueue();
+}
+
 // Sends a websocket message to indicate that the score for this alliance is ready.
 const commitMatchScore = function () {
-  websocket.send("commitMatch");
+  sendOperation("commitMatch", {});
 
   committed = true;
   scoringAvailable = false;
@@ -249,20 +353,41 @@ $(function () {
   $(".container").attr("data-alliance", alliance);
   nearSide = side === "near";
   resetLocalState();
+  loadOperationQueue(position);
 
   // Set up the websocket back to the server.
   websocket = new CheesyWebsocket("/panels/scoring/" + position + "/websocket", {
+    error: function (event) {
+      console.log(event.data);
+      alert(event.data);
+      if (committed) {
+        // The commit was rejected (e.g. due to discrepancies between dual-entry scorers), so allow further edits.
+        committed = false;
+        scoringAvailable = true;
+        commitAvailable = true;
+        inTeleop = true;
+        updateUIMode();
+      }
+    },
     matchLoad: function (event) {
       handleMatchLoad(event.data);
     },
     matchTime: function (event) {
       handleMatchTime(event.data);
     },
+    operationAck: function (event) {
+      handleOperationAck(event.data);
+    },
+    staleOperation: function (event) {
+      handleStaleOperation(event.data);
+    },
     realtimeScore: function (event) {
       handleRealtimeScore(event.data);
     },
     resetLocalState: function (event) {
+      // This is sent upon every connection, so take the opportunity to catch the server up on anything it missed.
       resetLocalState();
+      replayOperationQueue();
     },
   });
 });
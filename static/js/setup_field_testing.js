@@ -10,6 +10,17 @@ var playSound = function (sound) {
   websocket.send("playSound", sound);
 };
 
+// Sends a websocket message to flip the value of the given simulated PLC input.
+var togglePlcInput = function (index) {
+  var value = $("#input" + index).attr("data-plc-value") === "true";
+  websocket.send("setPlcInput", {index: index, value: !value});
+};
+
+// Sends a websocket message to set the value of the given simulated PLC register.
+var setPlcRegister = function (index, value) {
+  websocket.send("setPlcRegister", {index: index, value: parseInt(value)});
+};
+
 // Handles a websocket message to update the PLC IO status.
 var handlePlcIoChange = function (data) {
   $.each(data.Inputs, function (index, input) {
@@ -18,7 +29,15 @@ var handlePlcIoChange = function (data) {
   });
 
   $.each(data.Registers, function (index, register) {
-    $("#register" + index).text(register)
+    var registerElement = $("#register" + index);
+    if (registerElement.is("input")) {
+      // Don't clobber a value that the user is in the middle of editing.
+      if (!registerElement.is(":focus")) {
+        registerElement.val(register);
+      }
+    } else {
+      registerElement.text(register);
+    }
   });
 
   $.each(data.Coils, function (index, coil) {
@@ -6,6 +6,7 @@ package tournament
 import (
 	"fmt"
 	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/game/reefscape"
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/stretchr/testify/assert"
 	"math/rand"
@@ -45,7 +46,7 @@ func TestCalculateRankings(t *testing.T) {
 	fmt.Println()
 
 	// Test after changing a match result.
-	matchResult3 := model.BuildTestMatchResult(3, 3)
+	matchResult3 := buildTestMatchResult(3, 3)
 	matchResult3.RedScore, matchResult3.BlueScore = matchResult3.BlueScore, matchResult3.RedScore
 	err = database.CreateMatchResult(matchResult3)
 	assert.Nil(t, err)
@@ -74,7 +75,7 @@ func TestCalculateRankings(t *testing.T) {
 	}
 	fmt.Println()
 
-	matchResult3 = model.BuildTestMatchResult(3, 4)
+	matchResult3 = buildTestMatchResult(3, 4)
 	err = database.CreateMatchResult(matchResult3)
 	assert.Nil(t, err)
 	updatedRankings, err = CalculateRankings(database, true)
@@ -106,7 +107,7 @@ func TestCalculateRankings(t *testing.T) {
 
 func TestAddMatchResultToRankingsHandleCards(t *testing.T) {
 	rankings := map[int]*game.Ranking{}
-	matchResult := model.BuildTestMatchResult(1, 1)
+	matchResult := buildTestMatchResult(1, 1)
 	matchResult.RedCards = map[string]string{"1": "yellow", "2": "red", "3": "dq"}
 	matchResult.BlueCards = map[string]string{"4": "red", "5": "dq", "6": "yellow"}
 	addMatchResultToRankings(rankings, 1, matchResult, true)
@@ -137,7 +138,7 @@ func setupMatchResultsForRankings(database *model.Database) {
 		Status:    game.RedWonMatch,
 	}
 	database.CreateMatch(&match1)
-	matchResult1 := model.BuildTestMatchResult(match1.Id, 1)
+	matchResult1 := buildTestMatchResult(match1.Id, 1)
 	matchResult1.RedCards = map[string]string{"2": "red"}
 	database.CreateMatchResult(matchResult1)
 
@@ -155,7 +156,7 @@ func setupMatchResultsForRankings(database *model.Database) {
 		Blue3IsSurrogate: true,
 	}
 	database.CreateMatch(&match2)
-	matchResult2 := model.BuildTestMatchResult(match2.Id, 1)
+	matchResult2 := buildTestMatchResult(match2.Id, 1)
 	matchResult2.BlueScore = matchResult2.RedScore
 	database.CreateMatchResult(matchResult2)
 
@@ -172,7 +173,7 @@ func setupMatchResultsForRankings(database *model.Database) {
 		Red3IsSurrogate: true,
 	}
 	database.CreateMatch(&match3)
-	matchResult3 := model.BuildTestMatchResult(match3.Id, 1)
+	matchResult3 := buildTestMatchResult(match3.Id, 1)
 	database.CreateMatchResult(matchResult3)
 	matchResult3 = model.NewMatchResult()
 	matchResult3.MatchId = match3.Id
@@ -191,7 +192,7 @@ func setupMatchResultsForRankings(database *model.Database) {
 		Status:    game.RedWonMatch,
 	}
 	database.CreateMatch(&match4)
-	matchResult4 := model.BuildTestMatchResult(match4.Id, 1)
+	matchResult4 := buildTestMatchResult(match4.Id, 1)
 	database.CreateMatchResult(matchResult4)
 
 	match5 := model.Match{
@@ -206,7 +207,7 @@ func setupMatchResultsForRankings(database *model.Database) {
 		Status:    game.BlueWonMatch,
 	}
 	database.CreateMatch(&match5)
-	matchResult5 := model.BuildTestMatchResult(match5.Id, 1)
+	matchResult5 := buildTestMatchResult(match5.Id, 1)
 	database.CreateMatchResult(matchResult5)
 
 	match6 := model.Match{
@@ -221,6 +222,15 @@ func setupMatchResultsForRankings(database *model.Database) {
 		Status:    game.MatchScheduled,
 	}
 	database.CreateMatch(&match6)
-	matchResult6 := model.BuildTestMatchResult(match6.Id, 1)
+	matchResult6 := buildTestMatchResult(match6.Id, 1)
 	database.CreateMatchResult(matchResult6)
 }
+
+func buildTestMatchResult(matchId int, playNumber int) *model.MatchResult {
+	matchResult := &model.MatchResult{MatchId: matchId, PlayNumber: playNumber, MatchType: model.Qualification}
+	matchResult.RedScore = reefscape.TestScore1()
+	matchResult.BlueScore = reefscape.TestScore2()
+	matchResult.RedCards = map[string]string{"1868": "yellow"}
+	matchResult.BlueCards = map[string]string{}
+	return matchResult
+}
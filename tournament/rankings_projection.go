@@ -0,0 +1,235 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Monte Carlo projection of the final qualification rankings from the matches remaining to be played.
+
+package tournament
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"math/rand"
+	"sort"
+	"strconv"
+)
+
+const defaultProjectionIterations = 1000
+
+// RankingProjection holds the simulated distribution of final ranks for a single team.
+type RankingProjection struct {
+	TeamId             int
+	CurrentRank        int
+	AverageRank        float64
+	CaptainProbability float64
+	// The probability of the team finishing at each rank, where index 0 corresponds to first place.
+	RankProbabilities []float64
+}
+
+// RankingsProjector simulates the remaining qualification matches by sampling from the historical alliance scores of
+// the teams in each match and tallies the resulting final rankings.
+type RankingsProjector struct {
+	Iterations int
+	rand       *rand.Rand
+}
+
+// A single team's appearance in a completed match, retained so that it can be replayed into each simulation.
+type projectionResult struct {
+	teamId        int
+	ownSummary    *game.ScoreSummary
+	opponentScore *game.ScoreSummary
+	disqualified  bool
+}
+
+// The teams playing in a match yet to be played, and which of them are surrogates whose rankings are unaffected.
+type projectionMatch struct {
+	teamIds     [6]int
+	isSurrogate [6]bool
+}
+
+func NewRankingsProjector(seed int64) *RankingsProjector {
+	return &RankingsProjector{Iterations: defaultProjectionIterations, rand: rand.New(rand.NewSource(seed))}
+}
+
+// ProjectRankings simulates the remainder of the qualification schedule and returns the projected rank distribution
+// for every team in it, ordered by current rank. The given number of top-ranked teams are considered captains.
+func (projector *RankingsProjector) ProjectRankings(
+	database *model.Database, numCaptains int,
+) ([]RankingProjection, error) {
+	matches, err := database.GetMatchesByType(model.Qualification, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []projectionResult
+	var remainingMatches []projectionMatch
+	contributions := make(map[int][]*game.ScoreSummary)
+	var allContributions []*game.ScoreSummary
+	teamIdSet := make(map[int]struct{})
+	for _, match := range matches {
+		teamIds := [6]int{match.Red1, match.Red2, match.Red3, match.Blue1, match.Blue2, match.Blue3}
+		isSurrogate := [6]bool{
+			match.Red1IsSurrogate,
+			match.Red2IsSurrogate,
+			match.Red3IsSurrogate,
+			match.Blue1IsSurrogate,
+			match.Blue2IsSurrogate,
+			match.Blue3IsSurrogate,
+		}
+		for _, teamId := range teamIds {
+			if teamId > 0 {
+				teamIdSet[teamId] = struct{}{}
+			}
+		}
+
+		if !match.IsComplete() {
+			remainingMatches = append(remainingMatches, projectionMatch{teamIds: teamIds, isSurrogate: isSurrogate})
+			continue
+		}
+
+		matchResult, err := database.GetMatchResultForMatch(match.Id)
+		if err != nil {
+			return nil, err
+		}
+		if matchResult == nil {
+			continue
+		}
+		redSummary, blueSummary := matchResult.RedScoreSummary(), matchResult.BlueScoreSummary()
+		for i, teamId := range teamIds {
+			if teamId == 0 || isSurrogate[i] {
+				continue
+			}
+			result := projectionResult{teamId: teamId, ownSummary: redSummary, opponentScore: blueSummary}
+			cards := matchResult.RedCards
+			if i >= 3 {
+				result.ownSummary, result.opponentScore = blueSummary, redSummary
+				cards = matchResult.BlueCards
+			}
+			if card, ok := cards[strconv.Itoa(teamId)]; ok && (card == "red" || card == "dq") {
+				result.disqualified = true
+			}
+			results = append(results, result)
+			contributions[teamId] = append(contributions[teamId], result.ownSummary)
+			allContributions = append(allContributions, result.ownSummary)
+		}
+	}
+
+	// Take the current ranks from the stored rankings rather than recalculating them, so that they agree with the
+	// published standings on the random final tiebreaker.
+	rankings, err := database.GetAllRankings()
+	if err != nil {
+		return nil, err
+	}
+	currentRanks := make(map[int]int)
+	for _, ranking := range rankings {
+		currentRanks[ranking.TeamId] = ranking.Rank
+	}
+	teamIds := make([]int, 0, len(teamIdSet))
+	for teamId := range teamIdSet {
+		teamIds = append(teamIds, teamId)
+	}
+	sort.Slice(teamIds, func(i, j int) bool {
+		rankI, okI := currentRanks[teamIds[i]]
+		rankJ, okJ := currentRanks[teamIds[j]]
+		if okI != okJ {
+			return okI
+		}
+		if rankI != rankJ {
+			return rankI < rankJ
+		}
+		return teamIds[i] < teamIds[j]
+	})
+
+	rankCounts := make(map[int][]int, len(teamIds))
+	for _, teamId := range teamIds {
+		rankCounts[teamId] = make([]int, len(teamIds))
+	}
+	iterations := max(projector.Iterations, 1)
+	if len(remainingMatches) == 0 {
+		// The outcome is already decided, apart from the random final tiebreaker.
+		iterations = 1
+	}
+	for i := 0; i < iterations; i++ {
+		simulatedResults := append([]projectionResult(nil), results...)
+		for _, match := range remainingMatches {
+			redSummary := projector.sampleAllianceSummary(match.teamIds[:3], contributions, allContributions)
+			blueSummary := projector.sampleAllianceSummary(match.teamIds[3:], contributions, allContributions)
+			for j, teamId := range match.teamIds {
+				if teamId == 0 || match.isSurrogate[j] {
+					continue
+				}
+				result := projectionResult{teamId: teamId, ownSummary: redSummary, opponentScore: blueSummary}
+				if j >= 3 {
+					result.ownSummary, result.opponentScore = blueSummary, redSummary
+				}
+				simulatedResults = append(simulatedResults, result)
+			}
+		}
+		for rank, ranking := range projectRankings(simulatedResults, teamIds) {
+			rankCounts[ranking.TeamId][rank]++
+		}
+	}
+
+	projections := make([]RankingProjection, len(teamIds))
+	for i, teamId := range teamIds {
+		projection := RankingProjection{
+			TeamId:            teamId,
+			CurrentRank:       currentRanks[teamId],
+			RankProbabilities: make([]float64, len(teamIds)),
+		}
+		for rank, count := range rankCounts[teamId] {
+			probability := float64(count) / float64(iterations)
+			projection.RankProbabilities[rank] = probability
+			projection.AverageRank += float64(rank+1) * probability
+			if rank < numCaptains {
+				projection.CaptainProbability += probability
+			}
+		}
+		projections[i] = projection
+	}
+	return projections, nil
+}
+
+// Returns a plausible score summary for an alliance made up of the given teams, by picking one of the teams at random
+// and drawing one of its past alliance score summaries at random. Real summaries are used whole rather than blended so
+// that the game-specific details behind the ranking tiebreakers stay consistent with the score. Teams that have yet to
+// play are drawn from the pool of every team's results.
+func (projector *RankingsProjector) sampleAllianceSummary(
+	teamIds []int, contributions map[int][]*game.ScoreSummary, allContributions []*game.ScoreSummary,
+) *game.ScoreSummary {
+	if len(allContributions) == 0 {
+		return new(game.ScoreSummary)
+	}
+	var presentTeamIds []int
+	for _, teamId := range teamIds {
+		if teamId > 0 {
+			presentTeamIds = append(presentTeamIds, teamId)
+		}
+	}
+	pool := allContributions
+	if len(presentTeamIds) > 0 {
+		if teamPool := contributions[presentTeamIds[projector.rand.Intn(len(presentTeamIds))]]; len(teamPool) > 0 {
+			pool = teamPool
+		}
+	}
+	return pool[projector.rand.Intn(len(pool))]
+}
+
+// Builds the sorted rankings from the given results, including any of the given teams that have yet to play at the
+// bottom of the list.
+func projectRankings(results []projectionResult, teamIds []int) game.Rankings {
+	rankings := make(map[int]*game.Ranking)
+	for _, result := range results {
+		ranking := rankings[result.teamId]
+		if ranking == nil {
+			ranking = &game.Ranking{TeamId: result.teamId}
+			rankings[result.teamId] = ranking
+		}
+		ranking.AddScoreSummary(result.ownSummary, result.opponentScore, result.disqualified)
+	}
+	sortedRankings := sortRankings(rankings)
+	for _, teamId := range teamIds {
+		if _, ok := rankings[teamId]; !ok {
+			sortedRankings = append(sortedRankings, game.Ranking{TeamId: teamId})
+		}
+	}
+	return sortedRankings
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package tournament
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestProjectRankings(t *testing.T) {
+	database := setupTestDb(t)
+	setupMatchResultsForRankings(database)
+	_, err := CalculateRankings(database, false)
+	assert.Nil(t, err)
+	rankings, err := database.GetAllRankings()
+	assert.Nil(t, err)
+
+	projector := NewRankingsProjector(0)
+	projector.Iterations = 200
+	projections, err := projector.ProjectRankings(database, 4)
+	assert.Nil(t, err)
+	if assert.Equal(t, 12, len(projections)) {
+		// Teams that have played are listed by their stored current rank, followed by those yet to play.
+		for i := 0; i < 6; i++ {
+			assert.Equal(t, rankings[i].TeamId, projections[i].TeamId)
+			assert.Equal(t, i+1, projections[i].CurrentRank)
+		}
+		for i := 6; i < 12; i++ {
+			assert.Equal(t, i+1, projections[i].TeamId)
+			assert.Equal(t, 0, projections[i].CurrentRank)
+		}
+	}
+
+	totalCaptainProbability := 0.0
+	for _, projection := range projections {
+		assert.Equal(t, 12, len(projection.RankProbabilities))
+		totalProbability := 0.0
+		for _, probability := range projection.RankProbabilities {
+			totalProbability += probability
+		}
+		assert.InDelta(t, 1, totalProbability, 1e-9)
+		assert.True(t, projection.AverageRank >= 1 && projection.AverageRank <= 12)
+		totalCaptainProbability += projection.CaptainProbability
+	}
+	assert.InDelta(t, 4, totalCaptainProbability, 1e-9)
+
+	// Check that the projection is reproducible for a given seed.
+	otherProjector := NewRankingsProjector(0)
+	otherProjector.Iterations = 200
+	otherProjections, err := otherProjector.ProjectRankings(database, 4)
+	assert.Nil(t, err)
+	for i := range projections {
+		assert.Equal(t, projections[i].TeamId, otherProjections[i].TeamId)
+	}
+
+	// Check that the current ranks follow the stored rankings, as they would after a random tiebreaker.
+	rankings[0].Rank, rankings[1].Rank = 2, 1
+	assert.Nil(t, database.UpdateRanking(&rankings[0]))
+	assert.Nil(t, database.UpdateRanking(&rankings[1]))
+	projections, err = projector.ProjectRankings(database, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, rankings[1].TeamId, projections[0].TeamId)
+	assert.Equal(t, 1, projections[0].CurrentRank)
+	assert.Equal(t, rankings[0].TeamId, projections[1].TeamId)
+	assert.Equal(t, 2, projections[1].CurrentRank)
+}
+
+func TestProjectRankingsFavorsStrongerTeam(t *testing.T) {
+	database := setupTestDb(t)
+
+	// Have team 4 win its only match by a wide margin and team 1 lose it, with a second round still to play.
+	match1 := model.Match{
+		Type: model.Qualification, TypeOrder: 1, Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5, Blue3: 6,
+		Status: game.BlueWonMatch,
+	}
+	assert.Nil(t, database.CreateMatch(&match1))
+	matchResult := buildTestMatchResult(match1.Id, 1)
+	assert.Nil(t, database.CreateMatchResult(matchResult))
+	match2 := model.Match{
+		Type: model.Qualification, TypeOrder: 2, Red1: 1, Red2: 2, Red3: 5, Blue1: 4, Blue2: 3, Blue3: 6,
+		Status: game.MatchScheduled,
+	}
+	assert.Nil(t, database.CreateMatch(&match2))
+
+	projections, err := NewRankingsProjector(0).ProjectRankings(database, 2)
+	assert.Nil(t, err)
+	captainProbabilities := make(map[int]float64)
+	for _, projection := range projections {
+		captainProbabilities[projection.TeamId] = projection.CaptainProbability
+	}
+	assert.Greater(t, matchResult.BlueScoreSummary().Score, matchResult.RedScoreSummary().Score)
+	assert.Greater(t, captainProbabilities[4], captainProbabilities[1])
+}
+
+func TestProjectRankingsNoMatches(t *testing.T) {
+	database := setupTestDb(t)
+
+	projections, err := NewRankingsProjector(0).ProjectRankings(database, 8)
+	assert.Nil(t, err)
+	assert.Empty(t, projections)
+}
+
+func TestSampleAllianceSummaryKeepsDetails(t *testing.T) {
+	projector := NewRankingsProjector(0)
+	summary254 := &game.ScoreSummary{Score: 100, MatchPoints: 90, Details: "254"}
+	summary1114 := &game.ScoreSummary{Score: 50, MatchPoints: 45, Details: "1114"}
+	contributions := map[int][]*game.ScoreSummary{254: {summary254}, 1114: {summary1114}}
+	allContributions := []*game.ScoreSummary{summary254, summary1114}
+
+	// Check that each sample is one of the real summaries, details and all, drawn from the alliance's teams.
+	for i := 0; i < 20; i++ {
+		summary := projector.sampleAllianceSummary([]int{254, 0, 1114}, contributions, allContributions)
+		assert.Contains(t, allContributions, summary)
+		assert.Same(t, summary254, projector.sampleAllianceSummary([]int{254, 0, 0}, contributions, allContributions))
+	}
+
+	// Check that a team yet to play draws from every team's results.
+	summary := projector.sampleAllianceSummary([]int{9999}, contributions, allContributions)
+	assert.Contains(t, allContributions, summary)
+	assert.Equal(t, game.ScoreSummary{}, *projector.sampleAllianceSummary([]int{254}, contributions, nil))
+}
@@ -6,64 +6,95 @@
 package tournament
 
 import (
-	"encoding/csv"
+	"errors"
 	"fmt"
 	"github.com/Team254/cheesy-arena/model"
-	"math"
 	"math/rand"
-	"os"
-	"path/filepath"
-	"strconv"
+	"sync"
 	"time"
 )
 
 const (
-	schedulesDir  = "schedules"
-	TeamsPerMatch = 6
+	schedulesDir    = "schedules"
+	TeamsPerMatch   = 6
+	maxTeamShuffles = 1000
 )
 
-// Creates a random schedule for the given parameters and returns it as a list of matches.
+// Creates a random schedule for the given parameters and returns it as a list of matches. A pre-built template is
+// used if one exists for the given number of teams and matches per team; otherwise one is generated.
 func BuildRandomSchedule(
 	teams []model.Team, scheduleBlocks []model.ScheduleBlock, matchType model.MatchType,
 ) ([]model.Match, error) {
-	// Load the anonymized, pre-randomized match schedule for the given number of teams and matches per team.
+	matches, err := BuildSchedule(teams, scheduleBlocks, matchType, TemplateScheduleGenerator{}, ScheduleConstraints{})
+	if errors.Is(err, errNoScheduleTemplate) {
+		return BuildSchedule(
+			teams, scheduleBlocks, matchType, NewAnnealingScheduleGenerator(rand.Int63()), ScheduleConstraints{},
+		)
+	}
+	return matches, err
+}
+
+// Creates a schedule for the given parameters using the given generator and returns it as a list of matches. The
+// schedule is not guaranteed to satisfy the given constraints; use CheckScheduleConstraints to find any violations.
+func BuildSchedule(
+	teams []model.Team,
+	scheduleBlocks []model.ScheduleBlock,
+	matchType model.MatchType,
+	generator ScheduleGenerator,
+	constraints ScheduleConstraints,
+) ([]model.Match, error) {
+	// Get the anonymized, pre-randomized match schedule for the given number of teams and matches per team.
 	numTeams := len(teams)
 	numMatches := countMatches(scheduleBlocks)
 	matchesPerTeam := int(float32(numMatches*TeamsPerMatch) / float32(numTeams))
 
 	// Adjust the number of matches to remove any excess from non-perfect block scheduling.
-	numMatches = int(math.Ceil(float64(numTeams) * float64(matchesPerTeam) / TeamsPerMatch))
+	numMatches = countScheduleMatches(numTeams, matchesPerTeam)
+	matchTimes := getMatchTimes(scheduleBlocks, numMatches)
 
-	file, err := os.Open(
-		fmt.Sprintf("%s/%d_%d.csv", filepath.Join(model.BaseDir, schedulesDir), numTeams, matchesPerTeam),
+	// Generate a random permutation of the team ordering to fill into the pre-randomized schedule.
+	teamShuffle := rand.Perm(numTeams)
+
+	anonSchedule, err := generator.GenerateSchedule(
+		numTeams, matchesPerTeam, constraints.forGenerator(teams, teamShuffle, matchTimes),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("No schedule template exists for %d teams and %d matches", numTeams, matchesPerTeam)
+		return nil, err
 	}
-	defer file.Close()
-	reader := csv.NewReader(file)
-	csvLines, err := reader.ReadAll()
+	if len(anonSchedule) != numMatches {
+		return nil, fmt.Errorf("Generated schedule contains %d matches, expected %d", len(anonSchedule), numMatches)
+	}
+	matches, err := fillSchedule(anonSchedule, teams, teamShuffle, matchType, matchTimes)
 	if err != nil {
 		return nil, err
 	}
-	if len(csvLines) != numMatches {
-		return nil, fmt.Errorf("Schedule file contains %d matches, expected %d", len(csvLines), numMatches)
-	}
 
-	// Convert string fields from schedule to integers.
-	anonSchedule := make([][12]int, numMatches)
-	for i := 0; i < numMatches; i++ {
-		for j := 0; j < 12; j++ {
-			anonSchedule[i][j], err = strconv.Atoi(csvLines[i][j])
-			if err != nil {
-				return nil, err
+	if len(constraints.TeamAvailabilities) > 0 {
+		// Templates don't take team availability into account, so look for an ordering of the teams that fits better.
+		numViolations := len(CheckScheduleConstraints(matches, constraints))
+		for i := 0; i < maxTeamShuffles && numViolations > 0; i++ {
+			shuffledMatches, _ := fillSchedule(anonSchedule, teams, rand.Perm(numTeams), matchType, matchTimes)
+			shuffledViolations := len(CheckScheduleConstraints(shuffledMatches, constraints))
+			if shuffledViolations < numViolations {
+				matches = shuffledMatches
+				numViolations = shuffledViolations
 			}
 		}
 	}
 
-	// Generate a random permutation of the team ordering to fill into the pre-randomized schedule.
-	teamShuffle := rand.Perm(numTeams)
-	matches := make([]model.Match, numMatches)
+	return matches, nil
+}
+
+// Fills in the given anonymized schedule with the teams in the order given by the shuffle, and returns it as a list of
+// matches.
+func fillSchedule(
+	anonSchedule [][12]int,
+	teams []model.Team,
+	teamShuffle []int,
+	matchType model.MatchType,
+	matchTimes []time.Time,
+) ([]model.Match, error) {
+	matches := make([]model.Match, len(anonSchedule))
 	for i, anonMatch := range anonSchedule {
 		matches[i].Type = matchType
 		matches[i].TypeOrder = i + 1
@@ -91,18 +122,77 @@ func BuildRandomSchedule(
 		matches[i].Blue3 = teams[teamShuffle[anonMatch[10]-1]].Id
 		matches[i].Blue3IsSurrogate = anonMatch[11] == 1
 		matches[i].TbaMatchKey.MatchNumber = i + 1
+		matches[i].Time = matchTimes[i]
 	}
+	return matches, nil
+}
 
-	// Fill in the match times.
+// Returns the start time of each of the given number of matches, as laid out by the schedule blocks.
+func getMatchTimes(scheduleBlocks []model.ScheduleBlock, numMatches int) []time.Time {
+	matchTimes := make([]time.Time, numMatches)
 	matchIndex := 0
 	for _, block := range scheduleBlocks {
 		for i := 0; i < block.NumMatches && matchIndex < numMatches; i++ {
-			matches[matchIndex].Time = block.StartTime.Add(time.Duration(i*block.MatchSpacingSec) * time.Second)
+			matchTimes[matchIndex] = block.StartTime.Add(time.Duration(i*block.MatchSpacingSec) * time.Second)
 			matchIndex++
 		}
 	}
+	return matchTimes
+}
 
-	return matches, nil
+// ScheduleCandidate is one of several alternative schedules that can be compared before choosing one to save.
+type ScheduleCandidate struct {
+	Source  string
+	Matches []model.Match
+	Quality ScheduleQuality
+}
+
+// Creates alternative schedules for the given parameters: one from the pre-built template if one exists, plus the
+// given number of independently generated ones.
+func BuildScheduleCandidates(
+	teams []model.Team,
+	scheduleBlocks []model.ScheduleBlock,
+	matchType model.MatchType,
+	constraints ScheduleConstraints,
+	numGenerated int,
+) ([]ScheduleCandidate, error) {
+	var candidates []ScheduleCandidate
+	matches, err := BuildSchedule(teams, scheduleBlocks, matchType, TemplateScheduleGenerator{}, constraints)
+	if err == nil {
+		candidates = append(
+			candidates,
+			ScheduleCandidate{Source: "Template", Matches: matches, Quality: CalculateScheduleQuality(matches)},
+		)
+	} else if !errors.Is(err, errNoScheduleTemplate) {
+		return nil, err
+	}
+
+	// Generation is CPU-bound, so build the generated candidates in parallel.
+	generated := make([]ScheduleCandidate, numGenerated)
+	errs := make([]error, numGenerated)
+	var waitGroup sync.WaitGroup
+	for i := 0; i < numGenerated; i++ {
+		generator := NewAnnealingScheduleGenerator(rand.Int63())
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			matches, err := BuildSchedule(teams, scheduleBlocks, matchType, generator, constraints)
+			generated[i] = ScheduleCandidate{
+				Source:  fmt.Sprintf("Generated %d", i+1),
+				Matches: matches,
+				Quality: CalculateScheduleQuality(matches),
+			}
+			errs[i] = err
+		}()
+	}
+	waitGroup.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, generated[i])
+	}
+	return candidates, nil
 }
 
 // Returns the total number of matches that can be run within the given schedule blocks.
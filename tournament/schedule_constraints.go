@@ -0,0 +1,97 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Restrictions on when teams may be scheduled to play, and checking of schedules against them.
+
+package tournament
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"time"
+)
+
+// ScheduleConstraints are the restrictions on when teams may play that a schedule should satisfy.
+type ScheduleConstraints struct {
+	MinMatchGap        int // Minimum number of matches a team must sit out between two of its own.
+	TeamAvailabilities []model.TeamAvailability
+}
+
+// GeneratorConstraints are the schedule constraints in terms of an anonymized schedule, for use by a generator.
+type GeneratorConstraints struct {
+	MinMatchGap int
+	// Whether each anonymized team, numbered from zero, is unable to play in each match; nil if there are no
+	// restrictions.
+	Unavailable [][]bool
+}
+
+// Returns true if the given team is able to play at the given time.
+func (constraints *ScheduleConstraints) isTeamAvailable(teamId int, t time.Time) bool {
+	hasWindow := false
+	for _, availability := range constraints.TeamAvailabilities {
+		if availability.TeamId == teamId {
+			if availability.Contains(t) {
+				return true
+			}
+			hasWindow = true
+		}
+	}
+	return !hasWindow
+}
+
+// Converts the constraints into the terms of an anonymized schedule whose team numbers will be mapped to the given
+// teams using the given shuffle.
+func (constraints *ScheduleConstraints) forGenerator(
+	teams []model.Team, teamShuffle []int, matchTimes []time.Time,
+) GeneratorConstraints {
+	generatorConstraints := GeneratorConstraints{MinMatchGap: constraints.MinMatchGap}
+	if len(constraints.TeamAvailabilities) == 0 {
+		return generatorConstraints
+	}
+	generatorConstraints.Unavailable = make([][]bool, len(teams))
+	for i, teamIndex := range teamShuffle {
+		generatorConstraints.Unavailable[i] = make([]bool, len(matchTimes))
+		for j, matchTime := range matchTimes {
+			generatorConstraints.Unavailable[i][j] = !constraints.isTeamAvailable(teams[teamIndex].Id, matchTime)
+		}
+	}
+	return generatorConstraints
+}
+
+// Checks the given schedule against the constraints and returns a description of each violation found.
+func CheckScheduleConstraints(matches []model.Match, constraints ScheduleConstraints) []string {
+	var violations []string
+	lastMatchIndices := make(map[int]int)
+	for i, match := range matches {
+		for _, team := range []int{match.Red1, match.Red2, match.Red3, match.Blue1, match.Blue2, match.Blue3} {
+			if team == 0 {
+				continue
+			}
+			if !constraints.isTeamAvailable(team, match.Time) {
+				violations = append(
+					violations,
+					fmt.Sprintf(
+						"Team %d is scheduled in %s at %s but is unavailable then.",
+						team,
+						match.ShortName,
+						match.Time.Format("Mon 3:04 PM"),
+					),
+				)
+			}
+			if lastIndex, ok := lastMatchIndices[team]; ok && i-lastIndex-1 < constraints.MinMatchGap {
+				violations = append(
+					violations,
+					fmt.Sprintf(
+						"Team %d has only %d matches between %s and %s; the minimum is %d.",
+						team,
+						i-lastIndex-1,
+						matches[lastIndex].ShortName,
+						match.ShortName,
+						constraints.MinMatchGap,
+					),
+				)
+			}
+			lastMatchIndices[team] = i
+		}
+	}
+	return violations
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package tournament
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestCheckScheduleConstraints(t *testing.T) {
+	matches := []model.Match{
+		{ShortName: "Q1", Time: time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC), Red1: 1, Red2: 2, Blue1: 3},
+		{ShortName: "Q2", Time: time.Date(2025, 4, 1, 9, 10, 0, 0, time.UTC), Red1: 4, Red2: 5, Blue1: 6},
+		{ShortName: "Q3", Time: time.Date(2025, 4, 1, 9, 20, 0, 0, time.UTC), Red1: 1, Red2: 4, Blue1: 7},
+	}
+	constraints := ScheduleConstraints{}
+	assert.Empty(t, CheckScheduleConstraints(matches, constraints))
+
+	constraints.MinMatchGap = 1
+	assert.Equal(
+		t,
+		[]string{"Team 4 has only 0 matches between Q2 and Q3; the minimum is 1."},
+		CheckScheduleConstraints(matches, constraints),
+	)
+
+	constraints.MinMatchGap = 0
+	constraints.TeamAvailabilities = []model.TeamAvailability{
+		{TeamId: 2, StartTime: time.Date(2025, 4, 1, 9, 5, 0, 0, time.UTC)},
+		{TeamId: 7, EndTime: time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)},
+		{TeamId: 7, StartTime: time.Date(2025, 4, 1, 9, 15, 0, 0, time.UTC)},
+		{TeamId: 1, EndTime: time.Date(2025, 4, 1, 9, 10, 0, 0, time.UTC)},
+	}
+	assert.Equal(
+		t,
+		[]string{
+			"Team 2 is scheduled in Q1 at Tue 9:00 AM but is unavailable then.",
+			"Team 1 is scheduled in Q3 at Tue 9:20 AM but is unavailable then.",
+		},
+		CheckScheduleConstraints(matches, constraints),
+	)
+}
+
+func TestBuildScheduleWithConstraints(t *testing.T) {
+	model.BaseDir = ".."
+	teams := make([]model.Team, 24)
+	for i := 0; i < 24; i++ {
+		teams[i].Id = i + 101
+	}
+	startTime := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	scheduleBlocks := []model.ScheduleBlock{
+		{MatchType: model.Qualification, StartTime: startTime, NumMatches: 32, MatchSpacingSec: 600},
+	}
+	constraints := ScheduleConstraints{
+		MinMatchGap: 2,
+		TeamAvailabilities: []model.TeamAvailability{
+			{TeamId: 101, StartTime: startTime.Add(time.Hour)},
+			{TeamId: 102, EndTime: startTime.Add(270 * time.Minute)},
+			{TeamId: 103, EndTime: startTime.Add(2 * time.Hour)},
+			{TeamId: 103, StartTime: startTime.Add(3 * time.Hour)},
+		},
+	}
+
+	// Check that the generator satisfies the constraints.
+	rand.Seed(0)
+	generator := NewAnnealingScheduleGenerator(0)
+	matches, err := BuildSchedule(teams, scheduleBlocks, model.Qualification, generator, constraints)
+	assert.Nil(t, err)
+	assert.Equal(t, 32, len(matches))
+	assert.Empty(t, CheckScheduleConstraints(matches, constraints))
+
+	// Check that the teams are arranged within a template schedule to satisfy the availability constraints.
+	constraints = ScheduleConstraints{
+		TeamAvailabilities: []model.TeamAvailability{
+			{TeamId: 101, StartTime: startTime.Add(20 * time.Minute)},
+			{TeamId: 102, EndTime: startTime.Add(290 * time.Minute)},
+		},
+	}
+	matches, err = BuildSchedule(teams, scheduleBlocks, model.Qualification, TemplateScheduleGenerator{}, constraints)
+	assert.Nil(t, err)
+	assert.Empty(t, CheckScheduleConstraints(matches, constraints))
+
+	// Check that a schedule is still produced when the constraints can't be satisfied.
+	constraints.MinMatchGap = 10
+	candidates, err := BuildScheduleCandidates(teams, scheduleBlocks, model.Qualification, constraints, 1)
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(candidates)) {
+		assert.Equal(t, "Generated 1", candidates[1].Source)
+		violations := CheckScheduleConstraints(candidates[1].Matches, constraints)
+		if assert.NotEmpty(t, violations) {
+			assert.Contains(t, violations[0], "the minimum is 10.")
+		}
+	}
+}
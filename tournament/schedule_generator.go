@@ -0,0 +1,367 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Generators for the anonymized match schedules that are filled in with teams to create a practice or qualification
+// schedule.
+
+package tournament
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+const (
+	maxGeneratedMatchesPerTeam = 20
+	defaultIterationsPerSlot   = 500
+	annealingStartTemperature  = 5000.0
+	annealingEndTemperature    = 0.5
+
+	// Relative costs of the undesirable properties that the annealing generator tries to minimize.
+	duplicateTeamCost       = 1000000000.0
+	constraintViolationCost = 100000.0
+	matchGapCost            = 500.0
+	partnerRepeatCost       = 100.0
+	opponentRepeatCost      = 30.0
+
+	// Index of the match, in each team's own list of matches, that is played as a surrogate if the team has one.
+	surrogateMatchIndex = 2
+)
+
+var errNoScheduleTemplate = errors.New("No schedule template exists")
+
+// ScheduleGenerator produces an anonymized schedule for the given number of teams and matches per team, satisfying the
+// given constraints as far as it is able to. Each row represents a match and holds, for each of the six positions in
+// order, a team number from 1 to numTeams followed by a 1 if that team is playing the match as a surrogate or a 0
+// otherwise.
+type ScheduleGenerator interface {
+	GenerateSchedule(numTeams, matchesPerTeam int, constraints GeneratorConstraints) ([][12]int, error)
+}
+
+// TemplateScheduleGenerator loads pre-built schedules from the CSV files in the schedules directory. It ignores the
+// constraints.
+type TemplateScheduleGenerator struct{}
+
+// AnnealingScheduleGenerator builds schedules for arbitrary team counts by using simulated annealing to minimize
+// partner and opponent repetition and matches too close together, while avoiding constraint violations. Any
+// surrogates are placed in a team's third match.
+type AnnealingScheduleGenerator struct {
+	IterationsPerSlot int
+	rand              *rand.Rand
+}
+
+func (generator TemplateScheduleGenerator) GenerateSchedule(
+	numTeams, matchesPerTeam int, constraints GeneratorConstraints,
+) ([][12]int, error) {
+	numMatches := countScheduleMatches(numTeams, matchesPerTeam)
+	file, err := os.Open(
+		fmt.Sprintf("%s/%d_%d.csv", filepath.Join(model.BaseDir, schedulesDir), numTeams, matchesPerTeam),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w for %d teams and %d matches", errNoScheduleTemplate, numTeams, matchesPerTeam)
+	}
+	defer file.Close()
+	reader := csv.NewReader(file)
+	csvLines, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(csvLines) != numMatches {
+		return nil, fmt.Errorf("Schedule file contains %d matches, expected %d", len(csvLines), numMatches)
+	}
+
+	// Convert string fields from schedule to integers.
+	anonSchedule := make([][12]int, numMatches)
+	for i := 0; i < numMatches; i++ {
+		for j := 0; j < 12; j++ {
+			anonSchedule[i][j], err = strconv.Atoi(csvLines[i][j])
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return anonSchedule, nil
+}
+
+// NewAnnealingScheduleGenerator creates a generator whose output is determined by the given random seed.
+func NewAnnealingScheduleGenerator(seed int64) *AnnealingScheduleGenerator {
+	return &AnnealingScheduleGenerator{IterationsPerSlot: defaultIterationsPerSlot, rand: rand.New(rand.NewSource(seed))}
+}
+
+func (generator *AnnealingScheduleGenerator) GenerateSchedule(
+	numTeams, matchesPerTeam int, constraints GeneratorConstraints,
+) ([][12]int, error) {
+	if numTeams < TeamsPerMatch {
+		return nil, fmt.Errorf("Can't generate a schedule for fewer than %d teams", TeamsPerMatch)
+	}
+	if matchesPerTeam < 1 || matchesPerTeam > maxGeneratedMatchesPerTeam {
+		return nil, fmt.Errorf(
+			"Can't generate a schedule for %d matches per team; must be between 1 and %d",
+			matchesPerTeam,
+			maxGeneratedMatchesPerTeam,
+		)
+	}
+
+	state := generator.newInitialState(numTeams, matchesPerTeam, constraints)
+	cost := state.totalCost()
+	bestMatches := state.copyMatches()
+	bestCost := cost
+
+	numMatches := len(state.matches)
+	iterations := generator.IterationsPerSlot * numMatches * TeamsPerMatch
+	for i := 0; i < iterations; i++ {
+		temperature := annealingStartTemperature *
+			math.Pow(annealingEndTemperature/annealingStartTemperature, float64(i)/float64(iterations))
+		match1, slot1 := generator.rand.Intn(numMatches), generator.rand.Intn(TeamsPerMatch)
+		match2, slot2 := generator.rand.Intn(numMatches), generator.rand.Intn(TeamsPerMatch)
+		if match1 == match2 && slot1/3 == slot2/3 ||
+			state.matches[match1][slot1] == state.matches[match2][slot2] {
+			// Swapping teams on the same alliance or swapping a team with itself changes nothing.
+			continue
+		}
+
+		delta := state.swap(match1, slot1, match2, slot2)
+		if delta <= 0 || generator.rand.Float64() < math.Exp(-delta/temperature) {
+			cost += delta
+			if cost < bestCost {
+				bestCost = cost
+				bestMatches = state.copyMatches()
+			}
+		} else {
+			// Reject the change by swapping the teams back.
+			state.swap(match1, slot1, match2, slot2)
+		}
+	}
+	state.matches = bestMatches
+	for i := range state.matches {
+		if state.matchCost(i) > 0 {
+			return nil, fmt.Errorf(
+				"Failed to generate a valid schedule for %d teams and %d matches", numTeams, matchesPerTeam,
+			)
+		}
+	}
+
+	// Mark the extra appearance of each team that plays more than the given number of matches as a surrogate one.
+	teamMatches := state.buildTeamMatches()
+	surrogateIndex := min(surrogateMatchIndex, matchesPerTeam)
+	anonSchedule := make([][12]int, numMatches)
+	for i, match := range state.matches {
+		for j, team := range match {
+			anonSchedule[i][2*j] = team + 1
+			if len(teamMatches[team]) > matchesPerTeam && teamMatches[team][surrogateIndex] == i {
+				anonSchedule[i][2*j+1] = 1
+			}
+		}
+	}
+	return anonSchedule, nil
+}
+
+// Returns the number of matches needed for each team to play the given number of matches.
+func countScheduleMatches(numTeams, matchesPerTeam int) int {
+	return int(math.Ceil(float64(numTeams) * float64(matchesPerTeam) / TeamsPerMatch))
+}
+
+// The working state of the annealing generator, with bookkeeping that allows the cost of a swap to be calculated
+// without re-evaluating the whole schedule. Teams are numbered from zero and -1 represents an empty slot.
+type annealingState struct {
+	matches        [][TeamsPerMatch]int
+	teamMatches    [][]int
+	partnerCounts  [][]int
+	opponentCounts [][]int
+	targetGap      int
+	constraints    GeneratorConstraints
+}
+
+// Lays out the teams in rounds of random permutations, with the extra appearances needed to fill the last match
+// inserted before the third round so that they will tend to become surrogate matches.
+func (generator *AnnealingScheduleGenerator) newInitialState(
+	numTeams, matchesPerTeam int, constraints GeneratorConstraints,
+) *annealingState {
+	numMatches := countScheduleMatches(numTeams, matchesPerTeam)
+	numSurrogates := numMatches*TeamsPerMatch - numTeams*matchesPerTeam
+	surrogateRound := min(surrogateMatchIndex, matchesPerTeam)
+
+	var teams []int
+	for round := 0; round <= matchesPerTeam; round++ {
+		if round == surrogateRound {
+			teams = append(teams, generator.rand.Perm(numTeams)[:numSurrogates]...)
+		}
+		if round < matchesPerTeam {
+			teams = append(teams, generator.rand.Perm(numTeams)...)
+		}
+	}
+
+	state := &annealingState{
+		matches:        make([][TeamsPerMatch]int, numMatches),
+		partnerCounts:  make([][]int, numTeams),
+		opponentCounts: make([][]int, numTeams),
+		targetGap:      max(1, 2*numMatches/(3*matchesPerTeam), constraints.MinMatchGap+1),
+		constraints:    constraints,
+	}
+	for i := 0; i < numTeams; i++ {
+		state.partnerCounts[i] = make([]int, numTeams)
+		state.opponentCounts[i] = make([]int, numTeams)
+	}
+	for i := range state.matches {
+		state.matches[i] = [TeamsPerMatch]int{-1, -1, -1, -1, -1, -1}
+	}
+	for i, team := range teams {
+		state.place(i/TeamsPerMatch, i%TeamsPerMatch, team)
+	}
+	state.teamMatches = state.buildTeamMatches()
+	return state
+}
+
+// Returns the ordered list of match indices that each team appears in.
+func (state *annealingState) buildTeamMatches() [][]int {
+	teamMatches := make([][]int, len(state.partnerCounts))
+	for i, match := range state.matches {
+		for _, team := range match {
+			teamMatches[team] = append(teamMatches[team], i)
+		}
+	}
+	return teamMatches
+}
+
+// Returns the cost of the whole schedule.
+func (state *annealingState) totalCost() float64 {
+	cost := 0.0
+	for i := range state.matches {
+		cost += state.matchCost(i)
+	}
+	for team := range state.teamMatches {
+		cost += state.teamCost(team)
+	}
+	for i := range state.partnerCounts {
+		for j := i + 1; j < len(state.partnerCounts); j++ {
+			cost += repeatCost(state.partnerCounts[i][j], partnerRepeatCost)
+			cost += repeatCost(state.opponentCounts[i][j], opponentRepeatCost)
+		}
+	}
+	return cost
+}
+
+// Swaps the teams in the two given slots and returns the resulting change in the schedule's cost.
+func (state *annealingState) swap(match1, slot1, match2, slot2 int) float64 {
+	team1, team2 := state.matches[match1][slot1], state.matches[match2][slot2]
+	costBefore := state.teamCost(team1) + state.teamCost(team2) + state.matchCost(match1)
+	if match2 != match1 {
+		costBefore += state.matchCost(match2)
+	}
+
+	delta := state.remove(match1, slot1)
+	delta += state.remove(match2, slot2)
+	delta += state.place(match2, slot2, team1)
+	delta += state.place(match1, slot1, team2)
+	state.moveTeamMatch(team1, match1, match2)
+	state.moveTeamMatch(team2, match2, match1)
+
+	costAfter := state.teamCost(team1) + state.teamCost(team2) + state.matchCost(match1)
+	if match2 != match1 {
+		costAfter += state.matchCost(match2)
+	}
+	return delta + costAfter - costBefore
+}
+
+// Puts the given team into the given empty slot and returns the resulting change in partner and opponent costs.
+func (state *annealingState) place(match, slot, team int) float64 {
+	state.matches[match][slot] = team
+	return state.updatePairCounts(match, slot, 1)
+}
+
+// Empties the given slot and returns the resulting change in partner and opponent costs.
+func (state *annealingState) remove(match, slot int) float64 {
+	delta := state.updatePairCounts(match, slot, -1)
+	state.matches[match][slot] = -1
+	return delta
+}
+
+// Adjusts the partner and opponent counts between the team in the given slot and the rest of the teams in the match.
+func (state *annealingState) updatePairCounts(match, slot, increment int) float64 {
+	team := state.matches[match][slot]
+	delta := 0.0
+	for otherSlot, otherTeam := range state.matches[match] {
+		if otherSlot == slot || otherTeam == -1 || otherTeam == team {
+			continue
+		}
+		counts, repeatWeight := state.opponentCounts, opponentRepeatCost
+		if otherSlot/3 == slot/3 {
+			counts, repeatWeight = state.partnerCounts, partnerRepeatCost
+		}
+		delta -= repeatCost(counts[team][otherTeam], repeatWeight)
+		counts[team][otherTeam] += increment
+		counts[otherTeam][team] += increment
+		delta += repeatCost(counts[team][otherTeam], repeatWeight)
+	}
+	return delta
+}
+
+// Updates the given team's list of matches to reflect it having been moved from one match to another, keeping the
+// list in order.
+func (state *annealingState) moveTeamMatch(team, fromMatch, toMatch int) {
+	teamMatches := state.teamMatches[team]
+	i := 0
+	for teamMatches[i] != fromMatch {
+		i++
+	}
+	teamMatches[i] = toMatch
+	for ; i > 0 && teamMatches[i-1] > toMatch; i-- {
+		teamMatches[i-1], teamMatches[i] = teamMatches[i], teamMatches[i-1]
+	}
+	for ; i < len(teamMatches)-1 && teamMatches[i+1] < toMatch; i++ {
+		teamMatches[i+1], teamMatches[i] = teamMatches[i], teamMatches[i+1]
+	}
+}
+
+// Returns the cost of a team appearing more than once in the given match.
+func (state *annealingState) matchCost(match int) float64 {
+	cost := 0.0
+	for i, team := range state.matches[match] {
+		for j := i + 1; j < TeamsPerMatch; j++ {
+			if state.matches[match][j] == team {
+				cost += duplicateTeamCost
+			}
+		}
+	}
+	return cost
+}
+
+// Returns the cost of the given team's matches being too close together or at times when it is unavailable.
+func (state *annealingState) teamCost(team int) float64 {
+	cost := 0.0
+	teamMatches := state.teamMatches[team]
+	for i, match := range teamMatches {
+		if state.constraints.Unavailable != nil && state.constraints.Unavailable[team][match] {
+			cost += constraintViolationCost
+		}
+		if i == 0 {
+			continue
+		}
+		gap := match - teamMatches[i-1]
+		if gap > 0 && gap < state.targetGap {
+			cost += matchGapCost * float64((state.targetGap-gap)*(state.targetGap-gap))
+		}
+		if gap > 0 && gap <= state.constraints.MinMatchGap {
+			cost += constraintViolationCost * float64(state.constraints.MinMatchGap-gap+1)
+		}
+	}
+	return cost
+}
+
+func (state *annealingState) copyMatches() [][TeamsPerMatch]int {
+	return append([][TeamsPerMatch]int{}, state.matches...)
+}
+
+// Returns the cost of two teams having been paired together the given number of times.
+func repeatCost(count int, weight float64) float64 {
+	if count <= 1 {
+		return 0
+	}
+	return weight * float64((count-1)*(count-1))
+}
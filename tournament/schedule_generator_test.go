@@ -0,0 +1,60 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package tournament
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAnnealingScheduleGenerator(t *testing.T) {
+	for _, params := range []struct{ numTeams, matchesPerTeam int }{{6, 3}, {7, 1}, {13, 7}, {38, 10}, {110, 4}} {
+		generator := NewAnnealingScheduleGenerator(0)
+		generator.IterationsPerSlot = 200
+		anonSchedule, err := generator.GenerateSchedule(params.numTeams, params.matchesPerTeam, GeneratorConstraints{})
+		assert.Nil(t, err)
+		numMatches := countScheduleMatches(params.numTeams, params.matchesPerTeam)
+		assert.Equal(t, numMatches, len(anonSchedule))
+
+		// Check that every team plays the right number of matches, that no team appears twice in the same match, and
+		// that surrogate matches are in each team's third match.
+		matchCounts := make(map[int]int)
+		numSurrogates := 0
+		for _, match := range anonSchedule {
+			teamsInMatch := make(map[int]bool)
+			for i := 0; i < 12; i += 2 {
+				team := match[i]
+				assert.True(t, team >= 1 && team <= params.numTeams)
+				assert.False(t, teamsInMatch[team], "Team %d appears twice in the same match", team)
+				teamsInMatch[team] = true
+				if match[i+1] == 1 {
+					assert.Equal(t, min(2, params.matchesPerTeam), matchCounts[team], "%v", params)
+					numSurrogates++
+				} else {
+					matchCounts[team]++
+				}
+			}
+		}
+		assert.Equal(t, numMatches*TeamsPerMatch-params.numTeams*params.matchesPerTeam, numSurrogates)
+		for team := 1; team <= params.numTeams; team++ {
+			assert.Equal(t, params.matchesPerTeam, matchCounts[team])
+		}
+	}
+
+	// Check that the same seed produces the same schedule.
+	anonSchedule1, _ := NewAnnealingScheduleGenerator(254).GenerateSchedule(20, 5, GeneratorConstraints{})
+	anonSchedule2, _ := NewAnnealingScheduleGenerator(254).GenerateSchedule(20, 5, GeneratorConstraints{})
+	assert.Equal(t, anonSchedule1, anonSchedule2)
+}
+
+func TestAnnealingScheduleGeneratorErrors(t *testing.T) {
+	generator := NewAnnealingScheduleGenerator(0)
+	_, err := generator.GenerateSchedule(5, 10, GeneratorConstraints{})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Can't generate a schedule for fewer than 6 teams", err.Error())
+	}
+	_, err = generator.GenerateSchedule(30, 21, GeneratorConstraints{})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Can't generate a schedule for 21 matches per team; must be between 1 and 20", err.Error())
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Metrics for comparing the fairness of candidate match schedules.
+
+package tournament
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"math"
+)
+
+// ScheduleQuality summarizes the properties of a schedule that affect how fair it is to the teams.
+type ScheduleQuality struct {
+	MinMatchGap           int     // Fewest matches any team sits out between two of its own.
+	MeanMatchGap          float64 // Average number of matches teams sit out between two of their own.
+	BackToBackMatches     int     // Number of times a team plays two matches in a row.
+	MaxPartnerRepeats     int     // Most times any two teams are on the same alliance.
+	RepeatedPartnerPairs  int     // Number of pairs of teams that are on the same alliance more than once.
+	MaxOpponentRepeats    int     // Most times any two teams are on opposing alliances.
+	RepeatedOpponentPairs int     // Number of pairs of teams that are on opposing alliances more than once.
+	NumSurrogates         int
+}
+
+// Calculates the quality metrics for the given schedule.
+func CalculateScheduleQuality(matches []model.Match) ScheduleQuality {
+	var quality ScheduleQuality
+	lastMatchIndices := make(map[int]int)
+	partnerCounts := make(map[[2]int]int)
+	opponentCounts := make(map[[2]int]int)
+	totalGap, numGaps := 0, 0
+	quality.MinMatchGap = math.MaxInt
+
+	for i, match := range matches {
+		alliances := [2][3]int{{match.Red1, match.Red2, match.Red3}, {match.Blue1, match.Blue2, match.Blue3}}
+		for _, alliance := range alliances {
+			for _, team := range alliance {
+				if lastIndex, ok := lastMatchIndices[team]; ok {
+					gap := i - lastIndex - 1
+					quality.MinMatchGap = min(quality.MinMatchGap, gap)
+					totalGap += gap
+					numGaps++
+					if gap == 0 {
+						quality.BackToBackMatches++
+					}
+				}
+				lastMatchIndices[team] = i
+			}
+			for j := 0; j < 3; j++ {
+				for k := j + 1; k < 3; k++ {
+					partnerCounts[pairKey(alliance[j], alliance[k])]++
+				}
+			}
+		}
+		for _, redTeam := range alliances[0] {
+			for _, blueTeam := range alliances[1] {
+				opponentCounts[pairKey(redTeam, blueTeam)]++
+			}
+		}
+
+		for _, isSurrogate := range []bool{
+			match.Red1IsSurrogate,
+			match.Red2IsSurrogate,
+			match.Red3IsSurrogate,
+			match.Blue1IsSurrogate,
+			match.Blue2IsSurrogate,
+			match.Blue3IsSurrogate,
+		} {
+			if isSurrogate {
+				quality.NumSurrogates++
+			}
+		}
+	}
+
+	if numGaps > 0 {
+		quality.MeanMatchGap = float64(totalGap) / float64(numGaps)
+	} else {
+		quality.MinMatchGap = 0
+	}
+	quality.MaxPartnerRepeats, quality.RepeatedPartnerPairs = summarizePairCounts(partnerCounts)
+	quality.MaxOpponentRepeats, quality.RepeatedOpponentPairs = summarizePairCounts(opponentCounts)
+	return quality
+}
+
+// Returns a key that identifies the given pair of teams regardless of their order.
+func pairKey(team1, team2 int) [2]int {
+	if team1 > team2 {
+		return [2]int{team2, team1}
+	}
+	return [2]int{team1, team2}
+}
+
+// Returns the highest pair count and the number of pairs that occur more than once.
+func summarizePairCounts(pairCounts map[[2]int]int) (int, int) {
+	maxCount, numRepeated := 0, 0
+	for _, count := range pairCounts {
+		maxCount = max(maxCount, count)
+		if count > 1 {
+			numRepeated++
+		}
+	}
+	return maxCount, numRepeated
+}
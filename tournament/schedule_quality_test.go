@@ -0,0 +1,28 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package tournament
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCalculateScheduleQuality(t *testing.T) {
+	matches := []model.Match{
+		{Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5, Blue3: 6},
+		{Red1: 7, Red2: 8, Red3: 1, Blue1: 9, Blue2: 10, Blue3: 11},
+		{Red1: 1, Red2: 2, Red3: 12, Blue1: 4, Blue2: 7, Blue3: 3, Blue3IsSurrogate: true},
+	}
+	quality := CalculateScheduleQuality(matches)
+	assert.Equal(t, 0, quality.MinMatchGap)
+	assert.Equal(t, 0.5, quality.MeanMatchGap)
+	assert.Equal(t, 3, quality.BackToBackMatches)
+	assert.Equal(t, 2, quality.MaxPartnerRepeats)
+	assert.Equal(t, 1, quality.RepeatedPartnerPairs)
+	assert.Equal(t, 2, quality.MaxOpponentRepeats)
+	assert.Equal(t, 2, quality.RepeatedOpponentPairs)
+	assert.Equal(t, 1, quality.NumSurrogates)
+
+	assert.Equal(t, ScheduleQuality{}, CalculateScheduleQuality([]model.Match{}))
+}
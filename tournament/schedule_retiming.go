@@ -0,0 +1,168 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Logic for adjusting the times of the remaining matches when an event is running behind schedule.
+
+package tournament
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"time"
+)
+
+// RetimeMatches pushes back the scheduled times of the matches of the given type that haven't yet started so that none
+// is scheduled before the given time or sooner than one cycle after the match before it. The cycle time is the match
+// spacing of the schedule block that the match belongs to, or the given compressed cycle time if it is positive and
+// shorter. Matches are never moved earlier than their original times, so the delay is absorbed by any idle time between
+// schedule blocks and recovered by a compressed cycle time. Scheduled breaks are moved along with the match that they
+// precede. Returns the matches whose times changed and the judging slots that no longer fit between their team's
+// matches as a result.
+func RetimeMatches(
+	database *model.Database,
+	matchType model.MatchType,
+	currentTime time.Time,
+	compressedCycleTimeSec int,
+	judgingParams JudgingScheduleParams,
+) ([]model.Match, []model.JudgingSlot, error) {
+	matches, err := database.GetMatchesByType(matchType, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	scheduleBlocks, err := database.GetScheduleBlocksByMatchType(matchType)
+	if err != nil {
+		return nil, nil, err
+	}
+	scheduledBreaks, err := database.GetScheduledBreaksByMatchType(matchType)
+	if err != nil {
+		return nil, nil, err
+	}
+	breaksByTypeOrder := make(map[int]model.ScheduledBreak)
+	for _, scheduledBreak := range scheduledBreaks {
+		breaksByTypeOrder[scheduledBreak.TypeOrderBefore] = scheduledBreak
+	}
+
+	// Round up to the next whole minute so that the new times are tidy.
+	earliestTime := currentTime.Truncate(time.Minute)
+	if earliestTime.Before(currentTime) {
+		earliestTime = earliestTime.Add(time.Minute)
+	}
+
+	var retimedMatches []model.Match
+	var previousTime, previousOriginalTime time.Time
+	for _, match := range matches {
+		if match.IsComplete() || !match.StartedAt.IsZero() {
+			// The match has already been played, so the next one can't be scheduled any sooner than a cycle after it.
+			previousTime = match.StartedAt
+			if previousTime.IsZero() {
+				previousTime = match.Time
+			}
+			previousOriginalTime = match.Time
+			continue
+		}
+
+		var breakDuration time.Duration
+		scheduledBreak, hasBreak := breaksByTypeOrder[match.TypeOrder]
+		if hasBreak {
+			breakDuration = time.Duration(scheduledBreak.DurationSec) * time.Second
+		}
+
+		newTime := match.Time
+		if newTime.Before(earliestTime) {
+			newTime = earliestTime
+		}
+		if !previousTime.IsZero() {
+			cycleTime := getCycleTime(scheduleBlocks, match.Time, previousOriginalTime, breakDuration)
+			if compressedCycleTime := time.Duration(compressedCycleTimeSec) * time.Second; compressedCycleTime > 0 &&
+				compressedCycleTime < cycleTime {
+				cycleTime = compressedCycleTime
+			}
+			if earliestCycleTime := previousTime.Add(cycleTime + breakDuration); newTime.Before(earliestCycleTime) {
+				newTime = earliestCycleTime
+			}
+		}
+
+		if hasBreak && newTime.Add(-breakDuration).After(scheduledBreak.Time) {
+			scheduledBreak.Time = newTime.Add(-breakDuration)
+			if err = database.UpdateScheduledBreak(&scheduledBreak); err != nil {
+				return nil, nil, err
+			}
+		}
+		previousTime = newTime
+		previousOriginalTime = match.Time
+		if !newTime.Equal(match.Time) {
+			match.Time = newTime
+			if err = database.UpdateMatch(&match); err != nil {
+				return nil, nil, err
+			}
+			retimedMatches = append(retimedMatches, match)
+		}
+	}
+
+	if matchType != model.Qualification || len(retimedMatches) == 0 {
+		return retimedMatches, nil, nil
+	}
+	conflictingSlots, err := updateJudgingSlotMatchTimes(database, retimedMatches, judgingParams)
+	if err != nil {
+		return nil, nil, err
+	}
+	return retimedMatches, conflictingSlots, nil
+}
+
+// Returns the normal time between the start of the previous match and the given one, excluding any break between them.
+func getCycleTime(
+	scheduleBlocks []model.ScheduleBlock, matchTime, previousMatchTime time.Time, breakDuration time.Duration,
+) time.Duration {
+	for _, block := range scheduleBlocks {
+		blockEndTime := block.StartTime.Add(time.Duration(block.NumMatches*block.MatchSpacingSec) * time.Second)
+		if !matchTime.Before(block.StartTime) && matchTime.Before(blockEndTime) {
+			return time.Duration(block.MatchSpacingSec) * time.Second
+		}
+	}
+
+	// The match isn't part of a schedule block (e.g. it is a playoff match), so infer the cycle time from the original
+	// schedule.
+	return max(matchTime.Sub(previousMatchTime)-breakDuration, 0)
+}
+
+// Updates the judging slots to reflect the new times of the given qualification matches, flagging those that no longer
+// leave the required spacing around the team's matches. Returns the newly flagged slots.
+func updateJudgingSlotMatchTimes(
+	database *model.Database, retimedMatches []model.Match, judgingParams JudgingScheduleParams,
+) ([]model.JudgingSlot, error) {
+	slots, err := database.GetAllJudgingSlots()
+	if err != nil {
+		return nil, err
+	}
+	matchTimes := make(map[int]time.Time)
+	for _, match := range retimedMatches {
+		matchTimes[match.TypeOrder] = match.Time
+	}
+
+	var conflictingSlots []model.JudgingSlot
+	for _, slot := range slots {
+		previousMatchTime, previousChanged := matchTimes[slot.PreviousMatchNumber]
+		nextMatchTime, nextChanged := matchTimes[slot.NextMatchNumber]
+		if !previousChanged && !nextChanged {
+			continue
+		}
+		if previousChanged {
+			slot.PreviousMatchTime = previousMatchTime
+		}
+		if nextChanged {
+			slot.NextMatchTime = nextMatchTime
+		}
+
+		slotEndTime := slot.Time.Add(time.Duration(judgingParams.DurationMinutes) * time.Minute)
+		previousSpacing := time.Duration(judgingParams.PreviousSpacingMinutes) * time.Minute
+		nextSpacing := time.Duration(judgingParams.NextSpacingMinutes) * time.Minute
+		hasConflict := slot.PreviousMatchNumber > 0 && slot.Time.Before(slot.PreviousMatchTime.Add(previousSpacing)) ||
+			slot.NextMatchNumber > 0 && slot.NextMatchTime.Before(slotEndTime.Add(nextSpacing))
+		if hasConflict && !slot.NeedsReschedule {
+			slot.NeedsReschedule = true
+			conflictingSlots = append(conflictingSlots, slot)
+		}
+		if err = database.UpdateJudgingSlot(&slot); err != nil {
+			return nil, err
+		}
+	}
+	return conflictingSlots, nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package tournament
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRetimeMatches(t *testing.T) {
+	database := setupTestDb(t)
+	startTime := time.Date(2025, 4, 16, 9, 0, 0, 0, time.UTC)
+	createQualificationScheduleForRetiming(t, database, startTime)
+	judgingParams := JudgingScheduleParams{DurationMinutes: 10, PreviousSpacingMinutes: 10, NextSpacingMinutes: 10}
+	slot1 := model.JudgingSlot{
+		Time:                startTime.Add(22 * time.Minute),
+		TeamId:              101,
+		PreviousMatchNumber: 3,
+		PreviousMatchTime:   startTime.Add(12 * time.Minute),
+		NextMatchNumber:     7,
+		NextMatchTime:       startTime.Add(60 * time.Minute),
+	}
+	slot2 := model.JudgingSlot{
+		Time:                startTime.Add(10 * time.Minute),
+		TeamId:              102,
+		PreviousMatchNumber: 1,
+		PreviousMatchTime:   startTime,
+		NextMatchNumber:     6,
+		NextMatchTime:       startTime.Add(30 * time.Minute),
+	}
+	slot3 := model.JudgingSlot{
+		Time:                startTime.Add(40 * time.Minute),
+		TeamId:              103,
+		PreviousMatchNumber: 2,
+		PreviousMatchTime:   startTime.Add(6 * time.Minute),
+		NextMatchNumber:     7,
+		NextMatchTime:       startTime.Add(60 * time.Minute),
+	}
+	assert.Nil(t, database.CreateJudgingSlot(&slot1))
+	assert.Nil(t, database.CreateJudgingSlot(&slot2))
+	assert.Nil(t, database.CreateJudgingSlot(&slot3))
+
+	// The second match started four minutes late and the third has yet to start even later than that.
+	retimedMatches, conflictingSlots, err := RetimeMatches(
+		database, model.Qualification, startTime.Add(20*time.Minute+30*time.Second), 0, judgingParams,
+	)
+	assert.Nil(t, err)
+	if assert.Equal(t, 4, len(retimedMatches)) {
+		assert.Equal(t, 3, retimedMatches[0].TypeOrder)
+	}
+	matches, _ := database.GetMatchesByType(model.Qualification, false)
+	expectedMinutes := []int{0, 6, 21, 27, 33, 39, 60, 66, 72, 78}
+	for i, match := range matches {
+		assert.Equal(t, startTime.Add(time.Duration(expectedMinutes[i])*time.Minute).Unix(), match.Time.Unix())
+	}
+	if assert.Equal(t, 1, len(conflictingSlots)) {
+		assert.Equal(t, 101, conflictingSlots[0].TeamId)
+	}
+	slots, _ := database.GetAllJudgingSlots()
+	if assert.Equal(t, 3, len(slots)) {
+		assert.True(t, slots[0].NeedsReschedule)
+		assert.Equal(t, startTime.Add(21*time.Minute).Unix(), slots[0].PreviousMatchTime.Unix())
+		assert.False(t, slots[1].NeedsReschedule)
+		assert.Equal(t, startTime.Add(39*time.Minute).Unix(), slots[1].NextMatchTime.Unix())
+		assert.False(t, slots[2].NeedsReschedule)
+		assert.Equal(t, startTime.Add(60*time.Minute).Unix(), slots[2].NextMatchTime.Unix())
+	}
+
+	// Re-timing again without any further delay should be a no-op.
+	retimedMatches, conflictingSlots, err = RetimeMatches(
+		database, model.Qualification, startTime.Add(20*time.Minute+30*time.Second), 0, judgingParams,
+	)
+	assert.Nil(t, err)
+	assert.Empty(t, retimedMatches)
+	assert.Empty(t, conflictingSlots)
+}
+
+func TestRetimeMatchesWithCompressedCycleTime(t *testing.T) {
+	startTime := time.Date(2025, 4, 16, 9, 0, 0, 0, time.UTC)
+	for cycleTimeSec, expectedMinutes := range map[int][]int{
+		300: {0, 6, 21, 26, 31, 36, 60, 66, 72, 78},
+		// A compressed cycle time longer than the normal spacing shouldn't have any effect.
+		600: {0, 6, 21, 27, 33, 39, 60, 66, 72, 78},
+	} {
+		database := setupTestDb(t)
+		createQualificationScheduleForRetiming(t, database, startTime)
+
+		retimedMatches, _, err := RetimeMatches(
+			database, model.Qualification, startTime.Add(21*time.Minute), cycleTimeSec, JudgingScheduleParams{},
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, 4, len(retimedMatches))
+		matches, _ := database.GetMatchesByType(model.Qualification, false)
+		for i, match := range matches {
+			assert.Equal(t, startTime.Add(time.Duration(expectedMinutes[i])*time.Minute).Unix(), match.Time.Unix())
+		}
+	}
+}
+
+func TestRetimeMatchesWithScheduledBreak(t *testing.T) {
+	database := setupTestDb(t)
+	startTime := time.Date(2025, 4, 18, 13, 0, 0, 0, time.UTC)
+	for i, minutes := range []int{0, 10, 30} {
+		match := model.Match{
+			Type: model.Playoff, TypeOrder: i + 1, Time: startTime.Add(time.Duration(minutes) * time.Minute),
+		}
+		if i == 0 {
+			match.StartedAt = startTime.Add(5 * time.Minute)
+		}
+		assert.Nil(t, database.CreateMatch(&match))
+	}
+	scheduledBreak := model.ScheduledBreak{
+		MatchType: model.Playoff, TypeOrderBefore: 3, Time: startTime.Add(20 * time.Minute), DurationSec: 600,
+	}
+	assert.Nil(t, database.CreateScheduledBreak(&scheduledBreak))
+
+	retimedMatches, conflictingSlots, err := RetimeMatches(
+		database, model.Playoff, startTime.Add(5*time.Minute), 0, JudgingScheduleParams{},
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, conflictingSlots)
+	if assert.Equal(t, 2, len(retimedMatches)) {
+		assert.Equal(t, startTime.Add(15*time.Minute).Unix(), retimedMatches[0].Time.Unix())
+		assert.Equal(t, startTime.Add(35*time.Minute).Unix(), retimedMatches[1].Time.Unix())
+	}
+	scheduledBreaks, _ := database.GetScheduledBreaksByMatchType(model.Playoff)
+	if assert.Equal(t, 1, len(scheduledBreaks)) {
+		assert.Equal(t, startTime.Add(25*time.Minute).Unix(), scheduledBreaks[0].Time.Unix())
+	}
+}
+
+// Creates two blocks of qualification matches, with a break between them, of which the first two have been played.
+func createQualificationScheduleForRetiming(t *testing.T, database *model.Database, startTime time.Time) {
+	blocks := []model.ScheduleBlock{
+		{MatchType: model.Qualification, StartTime: startTime, NumMatches: 6, MatchSpacingSec: 360},
+		{MatchType: model.Qualification, StartTime: startTime.Add(time.Hour), NumMatches: 4, MatchSpacingSec: 360},
+	}
+	typeOrder := 1
+	for _, block := range blocks {
+		assert.Nil(t, database.CreateScheduleBlock(&block))
+		for i := 0; i < block.NumMatches; i++ {
+			match := model.Match{
+				Type:      model.Qualification,
+				TypeOrder: typeOrder,
+				Time:      block.StartTime.Add(time.Duration(i*block.MatchSpacingSec) * time.Second),
+			}
+			if typeOrder == 1 {
+				match.StartedAt = startTime
+				match.Status = game.RedWonMatch
+			} else if typeOrder == 2 {
+				match.StartedAt = startTime.Add(10 * time.Minute)
+				match.Status = game.BlueWonMatch
+			}
+			assert.Nil(t, database.CreateMatch(&match))
+			typeOrder++
+		}
+	}
+}
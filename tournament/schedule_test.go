@@ -22,10 +22,25 @@ func TestNonExistentSchedule(t *testing.T) {
 	teams := make([]model.Team, 5)
 	scheduleBlocks := []model.ScheduleBlock{{0, model.Test, time.Unix(0, 0).UTC(), 2, 60}}
 	_, err := BuildRandomSchedule(teams, scheduleBlocks, model.Test)
-	expectedErr := "No schedule template exists for 5 teams and 2 matches"
+	expectedErr := "Can't generate a schedule for fewer than 6 teams"
 	if assert.NotNil(t, err) {
 		assert.Equal(t, expectedErr, err.Error())
 	}
+
+	// Check that a schedule is generated when there is no template for the given parameters.
+	teams = make([]model.Team, 30)
+	for i := 0; i < 30; i++ {
+		teams[i].Id = i + 101
+	}
+	scheduleBlocks = []model.ScheduleBlock{
+		{MatchType: model.Qualification, StartTime: time.Unix(0, 0).UTC(), NumMatches: 80, MatchSpacingSec: 60},
+	}
+	matches, err := BuildRandomSchedule(teams, scheduleBlocks, model.Qualification)
+	assert.Nil(t, err)
+	assert.Equal(t, 80, len(matches))
+	assert.Equal(t, "Q80", matches[79].ShortName)
+	assert.Equal(t, time.Unix(4740, 0).UTC(), matches[79].Time)
+	assert.Equal(t, 0, CalculateScheduleQuality(matches).BackToBackMatches)
 }
 
 func TestMalformedSchedule(t *testing.T) {
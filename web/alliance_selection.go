@@ -8,6 +8,7 @@ package web
 import (
 	"fmt"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
 	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/Team254/cheesy-arena/websocket"
 	"io"
@@ -25,19 +26,11 @@ var allianceSelectionTicker *time.Ticker
 
 // Shows the alliance selection page.
 func (web *Web) allianceSelectionGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	web.renderAllianceSelection(w, r, "")
 }
 
 // Updates the cache with the latest input from the client.
 func (web *Web) allianceSelectionPostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	if !web.canModifyAllianceSelection() {
 		web.renderAllianceSelection(w, r, "Alliance selection has already been finalized.")
 		return
@@ -101,10 +94,6 @@ func (web *Web) allianceSelectionPostHandler(w http.ResponseWriter, r *http.Requ
 
 // Sets up the empty alliances and populates the ranked team list.
 func (web *Web) allianceSelectionStartHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	if len(web.arena.AllianceSelectionAlliances) != 0 {
 		web.renderAllianceSelection(w, r, "Can't start alliance selection when it is already in progress.")
 		return
@@ -146,10 +135,6 @@ func (web *Web) allianceSelectionStartHandler(w http.ResponseWriter, r *http.Req
 
 // Resets the alliance selection process back to the starting point.
 func (web *Web) allianceSelectionResetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	if !web.canResetAllianceSelection() {
 		web.renderAllianceSelection(w, r, "Cannot reset alliance selection; playoff matches have already started.")
 		return
@@ -176,10 +161,6 @@ func (web *Web) allianceSelectionResetHandler(w http.ResponseWriter, r *http.Req
 
 // Saves the selected alliances to the database and generates the first round of playoff matches.
 func (web *Web) allianceSelectionFinalizeHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	if !web.canModifyAllianceSelection() {
 		web.renderAllianceSelection(w, r, "Alliance selection has already been finalized.")
 		return
@@ -238,15 +219,15 @@ func (web *Web) allianceSelectionFinalizeHandler(w http.ResponseWriter, r *http.
 	}
 
 	if web.arena.EventSettings.TbaPublishingEnabled {
-		// Publish alliances and schedule to The Blue Alliance.
-		err = web.arena.TbaClient.PublishAlliances(web.arena.Database)
+		// Queue the alliances and schedule to be published to The Blue Alliance.
+		err = web.arena.TbaOutbox.Enqueue(partner.TbaPublishAlliances)
 		if err != nil {
-			web.renderAllianceSelection(w, r, fmt.Sprintf("Failed to publish alliances: %s", err.Error()))
+			web.renderAllianceSelection(w, r, fmt.Sprintf("Failed to queue alliances for publishing: %s", err.Error()))
 			return
 		}
-		err = web.arena.TbaClient.PublishMatches(web.arena.Database)
+		err = web.arena.TbaOutbox.Enqueue(partner.TbaPublishMatches)
 		if err != nil {
-			web.renderAllianceSelection(w, r, fmt.Sprintf("Failed to publish matches: %s", err.Error()))
+			web.renderAllianceSelection(w, r, fmt.Sprintf("Failed to queue matches for publishing: %s", err.Error()))
 			return
 		}
 	}
@@ -265,10 +246,6 @@ func (web *Web) allianceSelectionFinalizeHandler(w http.ResponseWriter, r *http.
 
 // The websocket endpoint for the alliance selection client to send control commands and receive status updates.
 func (web *Web) allianceSelectionWebsocketHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	ws, err := websocket.NewWebsocket(w, r)
 	if err != nil {
 		handleWebErr(w, err)
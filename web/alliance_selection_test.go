@@ -148,8 +148,13 @@ func TestAllianceSelectionErrors(t *testing.T) {
 	web.arena.TbaClient.BaseUrl = "fakeurl"
 	web.arena.EventSettings.TbaPublishingEnabled = true
 	recorder = web.postHttpResponse("/alliance_selection/finalize", "startTime=2014-01-01 01:00:00 PM")
-	assert.Equal(t, 200, recorder.Code)
-	assert.Contains(t, recorder.Body.String(), "Failed to publish alliances")
+	assert.Equal(t, 303, recorder.Code)
+	publications, err := web.arena.Database.GetAllTbaPublications()
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(publications)) {
+		assert.Equal(t, "alliances", publications[0].Kind)
+		assert.Equal(t, "matches", publications[1].Kind)
+	}
 
 	// Do other things after finalization.
 	recorder = web.postHttpResponse("/alliance_selection/finalize", "startTime=2014-01-01 01:00:00 PM")
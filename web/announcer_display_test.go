@@ -39,6 +39,15 @@ func TestAnnouncerDisplayScorePosted(t *testing.T) {
 	recorder := web.getHttpResponse("/displays/announcer/score_posted")
 	assert.Equal(t, 200, recorder.Code)
 	assert.Contains(t, recorder.Body.String(), "Qual 17")
+	assert.Contains(t, recorder.Body.String(), "Coral Points")
+	assert.Contains(t, recorder.Body.String(), "Coral Bonus RP")
+
+	// Bonus ranking points shouldn't be shown for playoff matches.
+	match.Type = model.Playoff
+	recorder = web.getHttpResponse("/displays/announcer/score_posted")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Coral Points")
+	assert.NotContains(t, recorder.Body.String(), "Coral Bonus RP")
 }
 
 func TestAnnouncerDisplayWebsocket(t *testing.T) {
@@ -12,13 +12,18 @@ import (
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/Team254/cheesy-arena/partner"
 	"github.com/Team254/cheesy-arena/playoff"
+	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/Team254/cheesy-arena/websocket"
 	"io"
 	"net/http"
 	"os"
 	"strconv"
+	"time"
 )
 
+// The upper limit on the number of simulations a client may request of the rankings projection.
+const maxProjectionIterations = 10000
+
 type MatchResultWithSummary struct {
 	model.MatchResult
 	RedSummary  *game.ScoreSummary
@@ -35,6 +40,36 @@ type RankingWithNickname struct {
 	Nickname string
 }
 
+// MarshalJSON adds the nickname to the serialized ranking, which would otherwise be omitted because the embedded
+// ranking's own marshaler gets promoted.
+func (ranking RankingWithNickname) MarshalJSON() ([]byte, error) {
+	rankingJson, err := json.Marshal(ranking.Ranking)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]json.RawMessage)
+	if err = json.Unmarshal(rankingJson, &fields); err != nil {
+		return nil, err
+	}
+	if fields["Nickname"], err = json.Marshal(ranking.Nickname); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON is the counterpart to MarshalJSON, for the same reason.
+func (ranking *RankingWithNickname) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &ranking.Ranking); err != nil {
+		return err
+	}
+	var nickname struct{ Nickname string }
+	if err := json.Unmarshal(data, &nickname); err != nil {
+		return err
+	}
+	ranking.Nickname = nickname.Nickname
+	return nil
+}
+
 type allianceMatchup struct {
 	Id                 string
 	RedAllianceSource  string
@@ -93,6 +128,50 @@ func (web *Web) matchesApiHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Generates a JSON dump of the realtime score changes recorded during the most recent play of the given match, or
+// of those recorded so far if the match is the one currently in progress.
+func (web *Web) matchTimelineApiHandler(w http.ResponseWriter, r *http.Request) {
+	events := make([]model.ScoreEvent, 0)
+	if r.PathValue("id") == "current" {
+		events = append(events, web.arena.ScoreEvents()...)
+	} else {
+		matchId, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		matchResult, err := web.arena.Database.GetMatchResultForMatch(matchId)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if matchResult != nil {
+			scoreTimeline, err := web.arena.Database.GetScoreTimelineForMatchResult(matchResult.Id)
+			if err != nil {
+				handleWebErr(w, err)
+				return
+			}
+			if scoreTimeline != nil {
+				events = append(events, scoreTimeline.Events...)
+			}
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.Header().Add("Access-Control-Allow-Origin", "*")
+	_, err = w.Write(jsonData)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
 // Generates a JSON dump of the sponsor slides for use by the audience display.
 func (web *Web) sponsorSlidesApiHandler(w http.ResponseWriter, r *http.Request) {
 	sponsors, err := web.arena.Database.GetAllSponsorSlides()
@@ -181,6 +260,38 @@ func (web *Web) rankingsApiHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Generates a JSON dump of each team's simulated chances of finishing at each rank and of being an alliance captain,
+// based on the qualification matches remaining to be played.
+func (web *Web) rankingsProjectionApiHandler(w http.ResponseWriter, r *http.Request) {
+	projector := tournament.NewRankingsProjector(time.Now().UnixNano())
+	if iterations, err := strconv.Atoi(r.URL.Query().Get("iterations")); err == nil && iterations > 0 {
+		projector.Iterations = min(iterations, maxProjectionIterations)
+	}
+	projections, err := projector.ProjectRankings(web.arena.Database, web.arena.EventSettings.NumPlayoffAlliances)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if projections == nil {
+		// Go marshals an empty slice to null, so explicitly create it so that it appears as an empty JSON array.
+		projections = make([]tournament.RankingProjection, 0)
+	}
+
+	jsonData, err := json.MarshalIndent(projections, "", "  ")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.Header().Add("Access-Control-Allow-Origin", "*")
+	_, err = w.Write(jsonData)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
 // Generates a JSON dump of the alliances.
 func (web *Web) alliancesApiHandler(w http.ResponseWriter, r *http.Request) {
 	alliances, err := web.arena.Database.GetAllAlliances()
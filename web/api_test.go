@@ -5,7 +5,10 @@ package web
 
 import (
 	"encoding/json"
+	"fmt"
+	"github.com/Team254/cheesy-arena/field"
 	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/game/reefscape"
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/Team254/cheesy-arena/websocket"
@@ -60,7 +63,7 @@ func TestMatchesApi(t *testing.T) {
 	web.arena.Database.CreateMatch(&match1)
 	web.arena.Database.CreateMatch(&match2)
 	web.arena.Database.CreateMatch(&match3)
-	matchResult1 := model.BuildTestMatchResult(match1.Id, 1)
+	matchResult1 := buildTestMatchResult(match1.Id, 1)
 	web.arena.Database.CreateMatchResult(matchResult1)
 
 	recorder := web.getHttpResponse("/api/matches/qualification")
@@ -77,6 +80,47 @@ func TestMatchesApi(t *testing.T) {
 	}
 }
 
+func TestMatchTimelineApi(t *testing.T) {
+	web := setupTestWeb(t)
+
+	// Check that a match without a recorded timeline produces an empty array.
+	recorder := web.getHttpResponse("/api/matches/1/timeline")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header()["Content-Type"][0])
+	assert.Equal(t, "[]", recorder.Body.String())
+
+	// Record a score change during the match in progress.
+	match := model.Match{Type: model.Practice, ShortName: "P1"}
+	assert.Nil(t, web.arena.Database.CreateMatch(&match))
+	assert.Nil(t, web.arena.LoadMatch(&match))
+	web.arena.MatchState = field.AutoPeriod
+	web.arena.MatchStartTime = time.Now()
+	reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).LeaveStatuses[0] = true
+	web.arena.Update()
+	recorder = web.getHttpResponse("/api/matches/current/timeline")
+	assert.Equal(t, 200, recorder.Code)
+	var events []model.ScoreEvent
+	assert.Nil(t, json.Unmarshal([]byte(recorder.Body.String()), &events))
+	if assert.Equal(t, 1, len(events)) {
+		assert.Equal(t, "red", events[0].Alliance)
+		assert.Equal(t, 3, events[0].RedPoints)
+		assert.Equal(t, 0, events[0].BluePoints)
+	}
+
+	// Check that committing the match saves the timeline alongside the match result.
+	web.arena.MatchState = field.PostMatch
+	assert.Nil(t, web.commitCurrentMatchScore())
+	recorder = web.getHttpResponse(fmt.Sprintf("/api/matches/%d/timeline", match.Id))
+	assert.Equal(t, 200, recorder.Code)
+	var savedEvents []model.ScoreEvent
+	assert.Nil(t, json.Unmarshal([]byte(recorder.Body.String()), &savedEvents))
+	assert.Equal(t, events, savedEvents)
+
+	// Check that an invalid match ID produces an error.
+	recorder = web.getHttpResponse("/api/matches/blorpy/timeline")
+	assert.Equal(t, 500, recorder.Code)
+}
+
 func TestRankingsApi(t *testing.T) {
 	web := setupTestWeb(t)
 
@@ -94,8 +138,8 @@ func TestRankingsApi(t *testing.T) {
 	assert.Equal(t, 0, len(rankingsData.Rankings))
 	assert.Equal(t, "", rankingsData.HighestPlayedMatch)
 
-	ranking1 := RankingWithNickname{*game.TestRanking2(), "Simbots"}
-	ranking2 := RankingWithNickname{*game.TestRanking1(), "ChezyPof"}
+	ranking1 := RankingWithNickname{*reefscape.TestRanking2(), "Simbots"}
+	ranking2 := RankingWithNickname{*reefscape.TestRanking1(), "ChezyPof"}
 	web.arena.Database.CreateRanking(&ranking1.Ranking)
 	web.arena.Database.CreateRanking(&ranking2.Ranking)
 	web.arena.Database.CreateMatch(&model.Match{Type: model.Qualification, ShortName: "Q29", Status: game.RedWonMatch})
@@ -115,6 +159,36 @@ func TestRankingsApi(t *testing.T) {
 	assert.Equal(t, "Q29", rankingsData.HighestPlayedMatch)
 }
 
+func TestRankingsProjectionApi(t *testing.T) {
+	web := setupTestWeb(t)
+
+	// Test that an empty schedule produces an empty array.
+	recorder := web.getHttpResponse("/api/rankings/projection")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header()["Content-Type"][0])
+	var projections []tournament.RankingProjection
+	assert.Nil(t, json.Unmarshal([]byte(recorder.Body.String()), &projections))
+	assert.Equal(t, 0, len(projections))
+
+	match := model.Match{
+		Type: model.Qualification, ShortName: "Q1", Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5, Blue3: 6,
+	}
+	web.arena.Database.CreateMatch(&match)
+	web.arena.EventSettings.NumPlayoffAlliances = 2
+
+	recorder = web.getHttpResponse("/api/rankings/projection?iterations=50")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Nil(t, json.Unmarshal([]byte(recorder.Body.String()), &projections))
+	if assert.Equal(t, 6, len(projections)) {
+		totalCaptainProbability := 0.0
+		for _, projection := range projections {
+			assert.Equal(t, 6, len(projection.RankProbabilities))
+			totalCaptainProbability += projection.CaptainProbability
+		}
+		assert.InDelta(t, 2, totalCaptainProbability, 1e-9)
+	}
+}
+
 func TestSponsorSlidesApi(t *testing.T) {
 	web := setupTestWeb(t)
 
@@ -0,0 +1,142 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Helpers for recording scoring and match control actions to the audit log.
+
+package web
+
+import (
+	"encoding/json"
+	"github.com/Team254/cheesy-arena/model"
+	"log"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+// Returns the username of the logged-in user making the given request, or an empty string if there is none (e.g. if
+// authentication is disabled).
+func (web *Web) getAuditUsername(r *http.Request) string {
+	if session := web.getUserSessionFromCookie(r); session != nil {
+		return session.Username
+	}
+	return ""
+}
+
+// Captures a generic point-in-time copy of the realtime scores of both alliances.
+func (web *Web) auditScoreSnapshot() map[string]any {
+	return auditSnapshot(
+		struct {
+			Red  any
+			Blue any
+		}{web.arena.RedRealtimeScore, web.arena.BlueRealtimeScore},
+	)
+}
+
+// Captures a generic point-in-time copy of the match control state that can be changed from the match play page. The
+// match state itself is omitted since the arena loop advances it independently of any commands.
+func (web *Web) auditMatchControlSnapshot() map[string]any {
+	teams := make(map[string]int)
+	bypass := make(map[string]bool)
+	for station, allianceStation := range web.arena.AllianceStations {
+		if allianceStation.Team != nil {
+			teams[station] = allianceStation.Team.Id
+		}
+		bypass[station] = allianceStation.Bypass
+	}
+	savedMatchId := 0
+	if web.arena.SavedMatch != nil {
+		savedMatchId = web.arena.SavedMatch.Id
+	}
+	return auditSnapshot(
+		struct {
+			MatchId                    int
+			MatchName                  string
+			Teams                      map[string]int
+			Bypass                     map[string]bool
+			MuteMatchSounds            bool
+			FieldVolunteers            bool
+			FieldReset                 bool
+			AudienceDisplayMode        string
+			AllianceStationDisplayMode string
+			SavedMatchId               int
+		}{
+			web.arena.CurrentMatch.Id,
+			web.arena.CurrentMatch.LongName,
+			teams,
+			bypass,
+			web.arena.MuteMatchSounds,
+			web.arena.FieldVolunteers,
+			web.arena.FieldReset,
+			web.arena.AudienceDisplayMode,
+			web.arena.AllianceStationDisplayMode,
+			savedMatchId,
+		},
+	)
+}
+
+// Persists an audit entry for the given command. Only the fields that differ between the before and after snapshots
+// are stored, to keep the log compact and make it obvious what each command changed.
+func (web *Web) recordAuditEntry(
+	username, source, position string, matchId int, command string, before, after map[string]any,
+) {
+	beforeDiff, afterDiff := diffAuditSnapshots(before, after)
+	beforeJson, _ := json.Marshal(beforeDiff)
+	afterJson, _ := json.Marshal(afterDiff)
+	auditEntry := model.AuditEntry{
+		Time:     time.Now(),
+		Username: username,
+		Source:   source,
+		Position: position,
+		MatchId:  matchId,
+		Command:  command,
+		Before:   string(beforeJson),
+		After:    string(afterJson),
+	}
+	if err := web.arena.Database.CreateAuditEntry(&auditEntry); err != nil {
+		log.Printf("Failed to record audit entry for command '%s': %v", command, err)
+	}
+}
+
+// Converts the given value to its generic JSON representation, which also serves as a deep copy.
+func auditSnapshot(value any) map[string]any {
+	snapshot := make(map[string]any)
+	valueJson, err := json.Marshal(value)
+	if err != nil {
+		return snapshot
+	}
+	_ = json.Unmarshal(valueJson, &snapshot)
+	return snapshot
+}
+
+// Recursively strips the fields that are identical between the two snapshots, returning only those that changed.
+func diffAuditSnapshots(before, after map[string]any) (map[string]any, map[string]any) {
+	beforeDiff := make(map[string]any)
+	afterDiff := make(map[string]any)
+	for key, beforeValue := range before {
+		afterValue, ok := after[key]
+		if !ok {
+			beforeDiff[key] = beforeValue
+			continue
+		}
+		beforeMap, beforeIsMap := beforeValue.(map[string]any)
+		afterMap, afterIsMap := afterValue.(map[string]any)
+		if beforeIsMap && afterIsMap {
+			nestedBeforeDiff, nestedAfterDiff := diffAuditSnapshots(beforeMap, afterMap)
+			if len(nestedBeforeDiff) > 0 {
+				beforeDiff[key] = nestedBeforeDiff
+			}
+			if len(nestedAfterDiff) > 0 {
+				afterDiff[key] = nestedAfterDiff
+			}
+		} else if !reflect.DeepEqual(beforeValue, afterValue) {
+			beforeDiff[key] = beforeValue
+			afterDiff[key] = afterValue
+		}
+	}
+	for key, afterValue := range after {
+		if _, ok := before[key]; !ok {
+			afterDiff[key] = afterValue
+		}
+	}
+	return beforeDiff, afterDiff
+}
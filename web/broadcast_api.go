@@ -0,0 +1,166 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Web API for the versioned broadcast graphics feed, in JSON and websocket form and as a vMix XML data source.
+
+package web
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/websocket"
+)
+
+// Root element of the vMix data source. vMix treats each element matched by a configured XPath as a row and its child
+// elements as columns, so each section of the feed is flattened into rows of simple values.
+type vmixDataSource struct {
+	XMLName   xml.Name  `xml:"broadcast"`
+	Version   int       `xml:"version,attr"`
+	Data      vmixRow   `xml:"data"`
+	Rankings  []vmixRow `xml:"ranking"`
+	Alliances []vmixRow `xml:"alliance"`
+}
+
+type vmixRow struct {
+	Columns []vmixColumn
+}
+
+type vmixColumn struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+// Generates a JSON snapshot of the whole broadcast feed.
+func (web *Web) broadcastFeedApiHandler(w http.ResponseWriter, r *http.Request) {
+	jsonData, err := json.MarshalIndent(web.arena.GenerateBroadcastFeed(), "", "  ")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.Header().Add("Access-Control-Allow-Origin", "*")
+	_, err = w.Write(jsonData)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Websocket API for receiving each section of the broadcast feed whenever it changes.
+func (web *Web) broadcastWebsocketApiHandler(w http.ResponseWriter, r *http.Request) {
+	ws, err := websocket.NewWebsocket(w, r)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	defer ws.Close()
+
+	// Subscribe the websocket to the notifiers whose messages will be passed on to the client.
+	ws.HandleNotifiers(
+		web.arena.BroadcastMatchNotifier,
+		web.arena.BroadcastScoreNotifier,
+		web.arena.BroadcastLineupNotifier,
+		web.arena.BroadcastRankingsNotifier,
+		web.arena.BroadcastLowerThirdNotifier,
+		web.arena.BroadcastAllianceSelectionNotifier,
+	)
+}
+
+// Generates the broadcast feed in the XML format expected by vMix for a data source.
+func (web *Web) broadcastVmixApiHandler(w http.ResponseWriter, r *http.Request) {
+	xmlData, err := xml.MarshalIndent(newVmixDataSource(web.arena.GenerateBroadcastFeed()), "", "  ")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/xml")
+	w.Header().Add("Access-Control-Allow-Origin", "*")
+	_, err = w.Write(append([]byte(xml.Header), xmlData...))
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Flattens the given broadcast feed into vMix rows.
+func newVmixDataSource(feed field.BroadcastFeed) vmixDataSource {
+	dataSource := vmixDataSource{Version: feed.Version}
+
+	data := &dataSource.Data
+	data.add("MatchId", feed.Match.Id)
+	data.add("MatchType", feed.Match.Type)
+	data.add("MatchShortName", feed.Match.ShortName)
+	data.add("MatchLongName", feed.Match.LongName)
+	data.add("MatchNameDetail", feed.Match.NameDetail)
+	data.add("MatchState", feed.Match.State)
+	data.add("MatchTimeRemainingSec", feed.Match.TimeRemainingSec)
+	data.add(
+		"MatchTimeRemaining", fmt.Sprintf("%d:%02d", feed.Match.TimeRemainingSec/60, feed.Match.TimeRemainingSec%60),
+	)
+	data.add("BreakDescription", feed.Match.BreakDescription)
+	data.add("RedScore", feed.Score.Red.Score)
+	data.add("BlueScore", feed.Score.Blue.Score)
+	data.add("RedFoulPoints", feed.Score.Red.FoulPoints)
+	data.add("BlueFoulPoints", feed.Score.Blue.FoulPoints)
+	for _, alliance := range []struct {
+		color    string
+		alliance field.BroadcastAlliance
+	}{{"Red", feed.Lineup.Red}, {"Blue", feed.Lineup.Blue}} {
+		data.add(alliance.color+"AllianceId", alliance.alliance.AllianceId)
+		data.add(alliance.color+"SeriesWins", alliance.alliance.SeriesWins)
+		for i, team := range alliance.alliance.Teams {
+			prefix := alliance.color + strconv.Itoa(i+1)
+			data.add(prefix+"Team", formatVmixTeamId(team.Id))
+			data.add(prefix+"Nickname", team.Nickname)
+			data.add(prefix+"Rank", team.Rank)
+		}
+	}
+	data.add("RankingsHighestPlayedMatch", feed.Rankings.HighestPlayedMatch)
+	data.add("LowerThirdVisible", feed.LowerThird.Visible)
+	data.add("LowerThirdTopText", feed.LowerThird.TopText)
+	data.add("LowerThirdBottomText", feed.LowerThird.BottomText)
+	data.add("AllianceSelectionShowTimer", feed.AllianceSelection.ShowTimer)
+	data.add("AllianceSelectionTimeRemainingSec", feed.AllianceSelection.TimeRemainingSec)
+
+	for _, ranking := range feed.Rankings.Rankings {
+		var row vmixRow
+		row.add("Rank", ranking.Rank)
+		row.add("Team", ranking.TeamId)
+		row.add("Nickname", ranking.Nickname)
+		row.add("RankingPoints", ranking.RankingPoints)
+		row.add("Record", fmt.Sprintf("%d-%d-%d", ranking.Wins, ranking.Losses, ranking.Ties))
+		row.add("Played", ranking.Played)
+		dataSource.Rankings = append(dataSource.Rankings, row)
+	}
+
+	for _, alliance := range feed.AllianceSelection.Alliances {
+		var row vmixRow
+		row.add("Id", alliance.AllianceId)
+		for i, team := range alliance.Teams {
+			row.add(fmt.Sprintf("Team%d", i+1), formatVmixTeamId(team.Id))
+			row.add(fmt.Sprintf("Team%dNickname", i+1), team.Nickname)
+		}
+		dataSource.Alliances = append(dataSource.Alliances, row)
+	}
+
+	return dataSource
+}
+
+// Appends a column with the given name and value to the row.
+func (row *vmixRow) add(name string, value any) {
+	row.Columns = append(row.Columns, vmixColumn{XMLName: xml.Name{Local: name}, Value: fmt.Sprint(value)})
+}
+
+// Returns the team number as a string, or an empty string for an empty station or pick so that graphics show nothing.
+func formatVmixTeamId(teamId int) string {
+	if teamId == 0 {
+		return ""
+	}
+	return strconv.Itoa(teamId)
+}
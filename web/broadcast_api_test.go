@@ -0,0 +1,102 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package web
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/websocket"
+	gorillawebsocket "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroadcastFeedApi(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateTeam(&model.Team{Id: 254, Nickname: "The Cheesy Poofs"})
+	web.arena.Database.CreateRanking(&game.Ranking{TeamId: 254, Rank: 1})
+	assert.Nil(t, web.arena.RefreshBroadcastRankings())
+	match := model.Match{Type: model.Practice, ShortName: "P1", LongName: "Practice 1", Blue1: 254}
+	web.arena.Database.CreateMatch(&match)
+	assert.Nil(t, web.arena.LoadMatch(&match))
+
+	recorder := web.getHttpResponse("/api/broadcast/v1")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header()["Content-Type"][0])
+	var feed field.BroadcastFeed
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &feed))
+	assert.Equal(t, 1, feed.Version)
+	assert.Equal(t, "practice", feed.Match.Type)
+	assert.Equal(t, "P1", feed.Match.ShortName)
+	assert.Equal(t, "The Cheesy Poofs", feed.Lineup.Blue.Teams[0].Nickname)
+	assert.Equal(t, 254, feed.Rankings.Rankings[0].TeamId)
+
+	// Check that the field names of the schema are stable.
+	var fields struct {
+		Version    int
+		Match      map[string]any
+		Score      map[string]any
+		LowerThird map[string]any
+	}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &fields))
+	assert.Equal(t, "preMatch", fields.Match["state"])
+	assert.Contains(t, fields.Score, "red")
+	assert.Contains(t, fields.LowerThird, "visible")
+}
+
+func TestBroadcastVmixApi(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateTeam(&model.Team{Id: 254, Nickname: "The Cheesy Poofs"})
+	web.arena.Database.CreateRanking(
+		&game.Ranking{TeamId: 254, Rank: 1, RankingFields: game.RankingFields{Wins: 5, Losses: 1, Played: 6}},
+	)
+	assert.Nil(t, web.arena.RefreshBroadcastRankings())
+	match := model.Match{Type: model.Practice, ShortName: "P1", Red3: 254}
+	web.arena.Database.CreateMatch(&match)
+	assert.Nil(t, web.arena.LoadMatch(&match))
+	web.arena.AllianceSelectionAlliances = []model.Alliance{{Id: 1, TeamIds: []int{254, 0}}}
+
+	recorder := web.getHttpResponse("/api/broadcast/v1/vmix")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/xml", recorder.Header()["Content-Type"][0])
+	body := recorder.Body.String()
+	assert.Contains(t, body, "<broadcast version=\"1\">")
+	assert.Contains(t, body, "<MatchShortName>P1</MatchShortName>")
+	assert.Contains(t, body, "<MatchTimeRemaining>0:15</MatchTimeRemaining>")
+	assert.Contains(t, body, "<Red1Team></Red1Team>")
+	assert.Contains(t, body, "<Red3Team>254</Red3Team>")
+	assert.Contains(t, body, "<Red3Nickname>The Cheesy Poofs</Red3Nickname>")
+	assert.Contains(t, body, "<Record>5-1-0</Record>")
+	assert.Contains(t, body, "<Team1>254</Team1>")
+	assert.Contains(t, body, "<Team2></Team2>")
+}
+
+func TestBroadcastWebsocketApi(t *testing.T) {
+	web := setupTestWeb(t)
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/api/broadcast/v1/websocket", nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws := websocket.NewTestWebsocket(conn)
+
+	// Should get every section of the feed right after connection.
+	messages := readWebsocketMultiple(t, ws, 6)
+	assert.Contains(t, messages, "match")
+	assert.Contains(t, messages, "score")
+	assert.Contains(t, messages, "lineup")
+	assert.Contains(t, messages, "rankings")
+	assert.Contains(t, messages, "lowerThird")
+	assert.Contains(t, messages, "allianceSelection")
+
+	// Should get an updated section when the underlying arena notifier fires.
+	web.arena.LowerThird = &model.LowerThird{TopText: "Top", BottomText: "Bottom"}
+	web.arena.ShowLowerThird = true
+	web.arena.LowerThirdNotifier.Notify()
+	message := readWebsocketType(t, ws, "lowerThird")
+	assert.Equal(t, map[string]any{"visible": true, "topText": "Top", "bottomText": "Bottom"}, message)
+}
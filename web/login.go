@@ -21,8 +21,8 @@ func (web *Web) loginHandler(w http.ResponseWriter, r *http.Request) {
 
 // Processes the login request.
 func (web *Web) loginPostHandler(w http.ResponseWriter, r *http.Request) {
-	username := r.PostFormValue("username")
-	if err := web.checkAuthPassword(username, r.PostFormValue("password")); err != nil {
+	username, err := web.checkAuthPassword(r.PostFormValue("username"), r.PostFormValue("password"))
+	if err != nil {
 		web.renderLogin(w, r, err.Error())
 		return
 	}
@@ -58,14 +58,25 @@ func (web *Web) renderLogin(w http.ResponseWriter, r *http.Request, errorMessage
 	}
 }
 
-// Returns true if the given user is authorized for admin operations. Used for HTTP cookie authentication.
-func (web *Web) userIsAdmin(w http.ResponseWriter, r *http.Request) bool {
-	if web.arena.EventSettings.AdminPassword == "" {
-		// Disable auth if there is no password configured.
+// Wraps the given handler such that it is only invoked if the user is logged in with a role that permits access to
+// the route; otherwise, the user is redirected to the login page.
+func (web *Web) requireRole(role model.UserRole, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if web.userHasRole(w, r, role) {
+			handler(w, r)
+		}
+	}
+}
+
+// Returns true if the given user is authorized for operations requiring the given role. Used for HTTP cookie
+// authentication.
+func (web *Web) userHasRole(w http.ResponseWriter, r *http.Request, role model.UserRole) bool {
+	if web.arena.EventSettings.AdminPassword == "" && !web.hasUserAccounts() {
+		// Disable auth if there is no password configured and nobody else has an account to log in with.
 		return true
 	}
-	session := web.getUserSessionFromCookie(r)
-	if session != nil && session.Username == adminUser {
+	userRole, ok := web.getUserRoleFromCookie(r)
+	if ok && userRole.Permits(role) {
 		return true
 	} else {
 		redirect := r.URL.Path
@@ -77,6 +88,12 @@ func (web *Web) userIsAdmin(w http.ResponseWriter, r *http.Request) bool {
 	}
 }
 
+// Returns true if any user accounts have been created, in which case login is required even without an admin password.
+func (web *Web) hasUserAccounts() bool {
+	users, err := web.arena.Database.GetAllUsers()
+	return err != nil || len(users) > 0
+}
+
 func (web *Web) getUserSessionFromCookie(r *http.Request) *model.UserSession {
 	token, err := r.Cookie(sessionTokenCookie)
 	if err != nil {
@@ -86,10 +103,31 @@ func (web *Web) getUserSessionFromCookie(r *http.Request) *model.UserSession {
 	return session
 }
 
-func (web *Web) checkAuthPassword(user, password string) error {
-	if user == adminUser && password == web.arena.EventSettings.AdminPassword {
-		return nil
-	} else {
-		return fmt.Errorf("Invalid login credentials.")
+// Returns the role of the user associated with the session cookie, and false if there is no valid session.
+func (web *Web) getUserRoleFromCookie(r *http.Request) (model.UserRole, bool) {
+	session := web.getUserSessionFromCookie(r)
+	if session == nil {
+		return 0, false
+	}
+	if session.Username == adminUser {
+		return model.AdminRole, true
+	}
+	user, _ := web.arena.Database.GetUserByUsername(session.Username)
+	if user == nil {
+		// The account has been deleted since the session was created.
+		return 0, false
+	}
+	return user.Role, true
+}
+
+// Validates the given credentials and returns the canonical username to store in the session.
+func (web *Web) checkAuthPassword(username, password string) (string, error) {
+	if username == adminUser {
+		if web.arena.EventSettings.AdminPassword != "" && password == web.arena.EventSettings.AdminPassword {
+			return adminUser, nil
+		}
+	} else if user, _ := web.arena.Database.GetUserByUsername(username); user != nil && user.CheckPassword(password) {
+		return user.Username, nil
 	}
+	return "", fmt.Errorf("Invalid login credentials.")
 }
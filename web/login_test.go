@@ -4,6 +4,7 @@
 package web
 
 import (
+	"github.com/Team254/cheesy-arena/model"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -48,3 +49,61 @@ func TestLoginDisplay(t *testing.T) {
 	recorder = web.getHttpResponseWithHeaders("/match_play?p1=v1&p2=v2", map[string]string{"Cookie": cookie})
 	assert.Equal(t, 200, recorder.Code)
 }
+
+func TestLoginWithUserRole(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.AdminPassword = "admin"
+	user := model.User{Username: "scorer1", Role: model.ScorerRole}
+	assert.Nil(t, user.SetPassword("coral"))
+	assert.Nil(t, web.arena.Database.CreateUser(&user))
+
+	recorder := web.postHttpResponse("/login?redirect=%2Fpanels%2Fscoring%2Fred_near", "username=scorer1&password=reef")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid login credentials.")
+
+	recorder = web.postHttpResponse("/login?redirect=%2Fpanels%2Fscoring%2Fred_near", "username=scorer1&password=coral")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, "/panels/scoring/red_near", recorder.Header().Get("Location"))
+	cookie := recorder.Header().Get("Set-Cookie")
+	headers := map[string]string{"Cookie": cookie}
+
+	// Check that the scorer can access the scoring panel but nothing requiring a different role.
+	recorder = web.getHttpResponseWithHeaders("/panels/scoring/red_near", headers)
+	assert.Equal(t, 200, recorder.Code)
+	recorder = web.getHttpResponseWithHeaders("/panels/referee", headers)
+	assert.Equal(t, 307, recorder.Code)
+	recorder = web.getHttpResponseWithHeaders("/match_play", headers)
+	assert.Equal(t, 307, recorder.Code)
+	recorder = web.getHttpResponseWithHeaders("/setup/settings", headers)
+	assert.Equal(t, 307, recorder.Code)
+	recorder = web.postHttpResponseWithHeaders("/setup/db/clear/qualification", "", headers)
+	assert.Equal(t, 307, recorder.Code)
+	assert.Equal(t, "/login?redirect=%2Fsetup%2Fdb%2Fclear%2Fqualification", recorder.Header().Get("Location"))
+
+	// Check that changing the user's role takes effect for the existing session.
+	user.Role = model.HeadRefereeRole
+	assert.Nil(t, web.arena.Database.UpdateUser(&user))
+	recorder = web.getHttpResponseWithHeaders("/panels/referee", headers)
+	assert.Equal(t, 200, recorder.Code)
+	recorder = web.getHttpResponseWithHeaders("/panels/scoring/red_near", headers)
+	assert.Equal(t, 200, recorder.Code)
+
+	// Check that deleting the user invalidates the session.
+	assert.Nil(t, web.arena.Database.DeleteUser(user.Id))
+	recorder = web.getHttpResponseWithHeaders("/panels/scoring/red_near", headers)
+	assert.Equal(t, 307, recorder.Code)
+}
+
+func TestLoginRequiredWhenUserAccountsExist(t *testing.T) {
+	web := setupTestWeb(t)
+	user := model.User{Username: "scorer1", Role: model.ScorerRole}
+	assert.Nil(t, user.SetPassword("coral"))
+	assert.Nil(t, web.arena.Database.CreateUser(&user))
+
+	// Check that the lack of an admin password doesn't bypass authentication nor allow logging in as the admin.
+	recorder := web.getHttpResponse("/match_play")
+	assert.Equal(t, 307, recorder.Code)
+	recorder = web.postHttpResponse("/login", "username=admin&password=")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid login credentials.")
+}
@@ -0,0 +1,283 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Event-wide analysis of the team match logs, for spotting robots and radios that are trending toward failure.
+
+package web
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Team254/cheesy-arena/model"
+)
+
+const (
+	// Battery voltage below which the roboRIO disables its outputs to protect itself.
+	brownoutVoltage = 6.8
+	// Battery voltage below which a robot is considered to be sagging dangerously close to a brownout.
+	lowBatteryVoltage = 8.0
+	// Decline in average signal-to-noise ratio per match, in dB, beyond which a team's radio is flagged.
+	snrTrendWarningDb = -2.0
+)
+
+var matchLogFilenameRe = regexp.MustCompile(`^(\d{14})_([A-Za-z]+)_Match_(.+?)(?:_Play_(\d+))?_(\d+)\.csv$`)
+
+// RobotHealthMatch summarizes a single team's robot and radio health during a single match.
+type RobotHealthMatch struct {
+	StartTime         string
+	MatchType         string
+	MatchName         string
+	PlayNumber        int
+	BrownoutEvents    int
+	LowVoltageEvents  int
+	LinkDrops         int
+	MinBatteryVoltage float64
+	AverageTripTimeMs float64
+	MissedPackets     int
+	AverageSnr        float64
+	tripTimeSamples   int
+	snrSamples        int
+}
+
+// RobotHealthSummary aggregates a team's robot and radio health across every match it has a log for.
+type RobotHealthSummary struct {
+	TeamId            int
+	Matches           []RobotHealthMatch
+	BrownoutEvents    int
+	LowVoltageEvents  int
+	LinkDrops         int
+	MinBatteryVoltage float64
+	AverageTripTimeMs float64
+	MissedPackets     int
+	AverageSnr        float64
+	// Least-squares slope of the team's per-match average signal-to-noise ratio, in dB per match.
+	SnrTrend float64
+}
+
+// Shows the FTA dashboard summarizing robot and radio health across the event.
+func (web *Web) matchLogsHealthHandler(w http.ResponseWriter, r *http.Request) {
+	summaries, err := analyzeMatchLogs(filepath.Join(".", "static", "logs"))
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	template, err := web.parseFiles("templates/match_logs_health.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Summaries         []RobotHealthSummary
+		SnrTrendWarningDb float64
+	}{web.arena.EventSettings, summaries, snrTrendWarningDb}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Generates a CSV-formatted report of the per-match robot and radio health of every team.
+func (web *Web) robotHealthCsvReportHandler(w http.ResponseWriter, r *http.Request) {
+	summaries, err := analyzeMatchLogs(filepath.Join(".", "static", "logs"))
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.Write(
+		[]string{
+			"Team", "Start Time", "Match Type", "Match", "Play", "Brownouts", "Low Voltage Events", "Link Drops",
+			"Min Battery Voltage", "Average Trip Time (ms)", "Missed Packets", "Average SNR",
+		},
+	)
+	for _, summary := range summaries {
+		for _, match := range summary.Matches {
+			writer.Write(
+				[]string{
+					strconv.Itoa(summary.TeamId),
+					match.StartTime,
+					match.MatchType,
+					match.MatchName,
+					strconv.Itoa(match.PlayNumber),
+					strconv.Itoa(match.BrownoutEvents),
+					strconv.Itoa(match.LowVoltageEvents),
+					strconv.Itoa(match.LinkDrops),
+					fmt.Sprintf("%.2f", match.MinBatteryVoltage),
+					fmt.Sprintf("%.1f", match.AverageTripTimeMs),
+					strconv.Itoa(match.MissedPackets),
+					fmt.Sprintf("%.1f", match.AverageSnr),
+				},
+			)
+		}
+	}
+	writer.Flush()
+
+	// Don't set the content type as "text/csv", as that will trigger an automatic download in the browser.
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write(buffer.Bytes())
+}
+
+// Returns true if the team has shown any sign of a robot or radio problem.
+func (summary *RobotHealthSummary) NeedsAttention() bool {
+	return summary.BrownoutEvents > 0 || summary.LinkDrops > 0 || summary.SnrTrend <= snrTrendWarningDb
+}
+
+// Parses every team match log in the given directory and returns the health summary for each team, ordered by team
+// number.
+func analyzeMatchLogs(logsDir string) ([]RobotHealthSummary, error) {
+	filenames, err := filepath.Glob(filepath.Join(logsDir, "*_*_Match_*.csv"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(filenames)
+
+	summariesByTeam := make(map[int]*RobotHealthSummary)
+	for _, filename := range filenames {
+		if strings.HasSuffix(filename, "_diagnostics.csv") {
+			continue
+		}
+		matches := matchLogFilenameRe.FindStringSubmatch(filepath.Base(filename))
+		if matches == nil {
+			continue
+		}
+		teamId, _ := strconv.Atoi(matches[5])
+		matchLog, err := readMatchLog(filename)
+		if err != nil {
+			return nil, err
+		}
+		healthMatch := analyzeMatchLog(matchLog)
+		healthMatch.MatchType = matches[2]
+		healthMatch.MatchName = matches[3]
+
+		summary, ok := summariesByTeam[teamId]
+		if !ok {
+			summary = &RobotHealthSummary{TeamId: teamId}
+			summariesByTeam[teamId] = summary
+		}
+		summary.Matches = append(summary.Matches, healthMatch)
+	}
+
+	summaries := make([]RobotHealthSummary, 0, len(summariesByTeam))
+	for _, summary := range summariesByTeam {
+		summary.aggregate()
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].TeamId < summaries[j].TeamId
+	})
+	return summaries, nil
+}
+
+// Tallies the health events and averages recorded in a single match log.
+func analyzeMatchLog(matchLog *MatchLog) RobotHealthMatch {
+	healthMatch := RobotHealthMatch{StartTime: matchLog.StartTime, PlayNumber: matchLog.PlayNumber}
+	var tripTimeTotal, snrTotal float64
+	wasBrownedOut, wasLowVoltage, wasRobotLinked := false, false, false
+	for _, row := range matchLog.Rows {
+		// Count each dip below a threshold once, rather than once for every packet logged while below it.
+		hasVoltage := row.RobotLinked && row.BatteryVoltage > 0
+		isBrownedOut := hasVoltage && row.BatteryVoltage < brownoutVoltage
+		isLowVoltage := hasVoltage && row.BatteryVoltage < lowBatteryVoltage
+		if isBrownedOut && !wasBrownedOut {
+			healthMatch.BrownoutEvents++
+		}
+		if isLowVoltage && !wasLowVoltage {
+			healthMatch.LowVoltageEvents++
+		}
+		wasBrownedOut, wasLowVoltage = isBrownedOut, isLowVoltage
+		if hasVoltage && (healthMatch.MinBatteryVoltage == 0 || row.BatteryVoltage < healthMatch.MinBatteryVoltage) {
+			healthMatch.MinBatteryVoltage = row.BatteryVoltage
+		}
+
+		if row.Enabled && wasRobotLinked && !row.RobotLinked {
+			healthMatch.LinkDrops++
+		}
+		wasRobotLinked = row.RobotLinked
+
+		if row.RobotLinked {
+			tripTimeTotal += float64(row.DsRobotTripTimeMs)
+			healthMatch.tripTimeSamples++
+		}
+		// The missed packet count is cumulative over the match.
+		healthMatch.MissedPackets = max(healthMatch.MissedPackets, row.MissedPacketCount)
+		if row.RadioLinked && row.SignalNoiseRatio > 0 {
+			snrTotal += float64(row.SignalNoiseRatio)
+			healthMatch.snrSamples++
+		}
+	}
+	if healthMatch.tripTimeSamples > 0 {
+		healthMatch.AverageTripTimeMs = tripTimeTotal / float64(healthMatch.tripTimeSamples)
+	}
+	if healthMatch.snrSamples > 0 {
+		healthMatch.AverageSnr = snrTotal / float64(healthMatch.snrSamples)
+	}
+	return healthMatch
+}
+
+// Rolls up the team's per-match statistics into its event totals and averages.
+func (summary *RobotHealthSummary) aggregate() {
+	sort.Slice(summary.Matches, func(i, j int) bool {
+		return summary.Matches[i].StartTime < summary.Matches[j].StartTime
+	})
+
+	var tripTimeTotal, snrTotal float64
+	var tripTimeSamples, snrSamples int
+	var snrX, snrY []float64
+	for _, match := range summary.Matches {
+		summary.BrownoutEvents += match.BrownoutEvents
+		summary.LowVoltageEvents += match.LowVoltageEvents
+		summary.LinkDrops += match.LinkDrops
+		summary.MissedPackets += match.MissedPackets
+		if match.MinBatteryVoltage > 0 &&
+			(summary.MinBatteryVoltage == 0 || match.MinBatteryVoltage < summary.MinBatteryVoltage) {
+			summary.MinBatteryVoltage = match.MinBatteryVoltage
+		}
+		tripTimeTotal += match.AverageTripTimeMs * float64(match.tripTimeSamples)
+		tripTimeSamples += match.tripTimeSamples
+		snrTotal += match.AverageSnr * float64(match.snrSamples)
+		snrSamples += match.snrSamples
+		if match.snrSamples > 0 {
+			snrX = append(snrX, float64(len(snrX)))
+			snrY = append(snrY, match.AverageSnr)
+		}
+	}
+	if tripTimeSamples > 0 {
+		summary.AverageTripTimeMs = tripTimeTotal / float64(tripTimeSamples)
+	}
+	if snrSamples > 0 {
+		summary.AverageSnr = snrTotal / float64(snrSamples)
+	}
+	summary.SnrTrend = leastSquaresSlope(snrX, snrY)
+}
+
+// Returns the slope of the least-squares line through the given points, or zero if there are too few to fit one.
+func leastSquaresSlope(x, y []float64) float64 {
+	n := float64(len(x))
+	if len(x) < 2 {
+		return 0
+	}
+	var sumX, sumY, sumXY, sumXX float64
+	for i := range x {
+		sumX += x[i]
+		sumY += y[i]
+		sumXY += x[i] * y[i]
+		sumXX += x[i] * x[i]
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package web
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testMatchLogHeader = "matchTimeSec,packetType,teamId,allianceStation,dsLinked,radioLinked,rioLinked," +
+	"robotLinked,auto,enabled,emergencyStop,autonomousStop,batteryVoltage,missedPacketCount,dsRobotTripTimeMs," +
+	"rxRate,txRate,signalNoiseRatio"
+
+func TestAnalyzeMatchLogs(t *testing.T) {
+	logsDir := t.TempDir()
+	writeTestMatchLog(
+		t,
+		logsDir,
+		"20250101100000_Qualification_Match_Q1_254.csv",
+		"0.1,22,254,R1,true,true,true,true,true,true,false,false,12.5,0,10,1,1,40",
+		"0.2,22,254,R1,true,true,true,true,true,true,false,false,7.5,1,14,1,1,40",
+		"0.3,22,254,R1,true,true,true,true,true,true,false,false,6.5,2,12,1,1,40",
+		"0.4,22,254,R1,true,true,true,true,true,true,false,false,6.6,2,12,1,1,40",
+		"0.5,22,254,R1,true,true,true,false,true,true,false,false,0,3,0,1,1,40",
+		"0.6,22,254,R1,true,true,true,true,true,true,false,false,11.5,4,12,1,1,40",
+	)
+	writeTestMatchLog(
+		t,
+		logsDir,
+		"20250101110000_Qualification_Match_Q5_Play_2_254.csv",
+		"0.1,22,254,R2,true,true,true,true,false,false,false,false,12.0,0,10,1,1,34",
+		"0.2,22,254,R2,true,true,true,false,false,false,false,false,0,0,0,1,1,34",
+		"0.3,22,254,R2,true,true,true,true,false,true,false,false,12.0,1,10,1,1,34",
+	)
+	writeTestMatchLog(
+		t,
+		logsDir,
+		"20250101100000_Qualification_Match_Q1_1114.csv",
+		"0.1,22,1114,B1,true,true,true,true,true,true,false,false,12.2,0,8,1,1,45",
+	)
+	writeTestMatchLog(t, logsDir, "20250101100000_Qualification_Match_Q1_1114_diagnostics.csv", "0.1,23,error,Oops")
+
+	summaries, err := analyzeMatchLogs(logsDir)
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(summaries)) {
+		summary := summaries[0]
+		assert.Equal(t, 254, summary.TeamId)
+		if assert.Equal(t, 2, len(summary.Matches)) {
+			match := summary.Matches[0]
+			assert.Equal(t, "Q1", match.MatchName)
+			assert.Equal(t, "Qualification", match.MatchType)
+			assert.Equal(t, 1, match.PlayNumber)
+			assert.Equal(t, 1, match.BrownoutEvents)
+			assert.Equal(t, 1, match.LowVoltageEvents)
+			assert.Equal(t, 1, match.LinkDrops)
+			assert.Equal(t, 6.5, match.MinBatteryVoltage)
+			assert.Equal(t, 12.0, match.AverageTripTimeMs)
+			assert.Equal(t, 4, match.MissedPackets)
+			assert.Equal(t, 40.0, match.AverageSnr)
+
+			// Link drops while disabled shouldn't count against the team.
+			match = summary.Matches[1]
+			assert.Equal(t, "Q5", match.MatchName)
+			assert.Equal(t, 2, match.PlayNumber)
+			assert.Equal(t, 0, match.LinkDrops)
+			assert.Equal(t, 0, match.BrownoutEvents)
+		}
+		assert.Equal(t, 1, summary.BrownoutEvents)
+		assert.Equal(t, 1, summary.LinkDrops)
+		assert.Equal(t, 5, summary.MissedPackets)
+		assert.Equal(t, 6.5, summary.MinBatteryVoltage)
+		assert.Equal(t, -6.0, summary.SnrTrend)
+		assert.True(t, summary.NeedsAttention())
+
+		summary = summaries[1]
+		assert.Equal(t, 1114, summary.TeamId)
+		assert.Equal(t, 1, len(summary.Matches))
+		assert.Equal(t, 0.0, summary.SnrTrend)
+		assert.False(t, summary.NeedsAttention())
+	}
+}
+
+func TestMatchLogsHealth(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/match_logs/health")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Robot Health")
+
+	recorder = web.getHttpResponse("/reports/csv/robot_health")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "text/plain", recorder.Header()["Content-Type"][0])
+	assert.True(t, strings.HasPrefix(recorder.Body.String(), "Team,Start Time,Match Type,Match,Play,"))
+}
+
+func writeTestMatchLog(t *testing.T, logsDir, filename string, rows ...string) {
+	header := testMatchLogHeader
+	if strings.HasSuffix(filename, "_diagnostics.csv") {
+		header = "matchTimeSec,packetType,category,message"
+	}
+	contents := header + "\n" + strings.Join(rows, "\n") + "\n"
+	assert.Nil(t, os.WriteFile(filepath.Join(logsDir, filename), []byte(contents), 0644))
+}
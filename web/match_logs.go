@@ -11,12 +11,17 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
 
+	"github.com/Team254/cheesy-arena/field"
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
 )
 
+var playNumberRe = regexp.MustCompile(`_Play_(\d+)_\d+\.csv$`)
+
 type MatchLogsListItem struct {
 	Id         int
 	ShortName  string
@@ -46,12 +51,23 @@ type MatchLogRow struct {
 	TxRate            float64
 	RxRate            float64
 	SignalNoiseRatio  int
+	Diagnostics       []MatchLogDiagnostic
+}
+
+type MatchLogDiagnostic struct {
+	MatchTimeSec float64
+	PacketType   int
+	Category     string
+	Message      string
 }
 
 type MatchLog struct {
-	Filename  string
-	StartTime string
-	Rows      []MatchLogRow
+	Filename            string
+	DiagnosticsFilename string
+	StartTime           string
+	PlayNumber          int
+	Rows                []MatchLogRow
+	Versions            []MatchLogDiagnostic
 }
 
 type MatchLogs struct {
@@ -164,76 +180,144 @@ func (web *Web) getMatchLogFromRequest(r *http.Request) (*model.Match, *MatchLog
 	case "B3":
 		logs.TeamId = match.Blue3
 	}
-	headerMap := make(map[string]int)
-	// rows []MatchLogRow
 	// Load a csv file.
 	if logs.TeamId == 0 {
 		return nil, nil, false, nil
 	}
+	// Logs from before play numbers were recorded in the filename are treated as being from the first play.
 	var files []string
-	files, _ = filepath.Glob(
-		filepath.Join(".", "static", "logs", "*_*_Match_"+match.ShortName+"_"+strconv.Itoa(logs.TeamId)+".csv"),
+	teamSuffix := "_" + strconv.Itoa(logs.TeamId) + ".csv"
+	files, _ = filepath.Glob(filepath.Join(".", "static", "logs", "*_*_Match_"+match.ShortName+teamSuffix))
+	replayFiles, _ := filepath.Glob(
+		filepath.Join(".", "static", "logs", "*_*_Match_"+match.ShortName+"_Play_*"+teamSuffix),
 	)
+	files = append(files, replayFiles...)
+	sort.Strings(files)
 	if len(files) == 0 {
 		return match, &logs, false, nil
 	}
 
 	for _, filename := range files {
-		f, _ := os.Open(filename)
-		defer f.Close()
-		// Create a new reader.
-		reader := csv.NewReader(f)
+		curlog, err := readMatchLog(filename)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		logs.Logs = append(logs.Logs, *curlog)
+	}
+	return match, &logs, false, nil
+}
 
-		// Read row
-		header, _ := reader.Read()
+// Loads the packet log in the given file along with the diagnostic messages recorded alongside it.
+func readMatchLog(filename string) (*MatchLog, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	// Create a new reader.
+	reader := csv.NewReader(f)
 
-		// Add mapping: Column/property name --> record index
-		for i, v := range header {
-			headerMap[v] = i
-		}
-		records, _ := reader.ReadAll()
+	// Read row
+	header, _ := reader.Read()
 
-		var curlog = MatchLog{
-			Filename:  filename,
-			StartTime: filename[12:26],
-			Rows:      make([]MatchLogRow, len(records)),
+	// Add mapping: Column/property name --> record index
+	headerMap := make(map[string]int)
+	for i, v := range header {
+		headerMap[v] = i
+	}
+	records, _ := reader.ReadAll()
+
+	var curlog = MatchLog{
+		Filename:   filename,
+		StartTime:  filepath.Base(filename)[0:14],
+		PlayNumber: 1,
+		Rows:       make([]MatchLogRow, len(records)),
+	}
+	if matches := playNumberRe.FindStringSubmatch(filename); matches != nil {
+		curlog.PlayNumber, _ = strconv.Atoi(matches[1])
+	}
+	for i, record := range records {
+		var curRow MatchLogRow
+		curRow.MatchTimeSec, _ = strconv.ParseFloat(record[headerMap["matchTimeSec"]], 64)
+		curRow.PacketType, _ = strconv.Atoi(record[headerMap["packetType"]])
+		curRow.TeamId, _ = strconv.Atoi(record[headerMap["teamId"]])
+		curRow.AllianceStation = record[headerMap["allianceStation"]]
+		curRow.DsLinked, _ = strconv.ParseBool(record[headerMap["dsLinked"]])
+		curRow.RadioLinked, _ = strconv.ParseBool(record[headerMap["radioLinked"]])
+		curRow.RioLinked, _ = strconv.ParseBool(record[headerMap["rioLinked"]])
+		curRow.RobotLinked, _ = strconv.ParseBool(record[headerMap["robotLinked"]])
+		curRow.Auto, _ = strconv.ParseBool(record[headerMap["auto"]])
+		curRow.Enabled, _ = strconv.ParseBool(record[headerMap["enabled"]])
+		curRow.EmergencyStop, _ = strconv.ParseBool(record[headerMap["emergencyStop"]])
+		curRow.AutonomousStop, _ = strconv.ParseBool(record[headerMap["autonomousStop"]])
+		curRow.BatteryVoltage, _ = strconv.ParseFloat(record[headerMap["batteryVoltage"]], 64)
+		curRow.MissedPacketCount, _ = strconv.Atoi(record[headerMap["missedPacketCount"]])
+		curRow.DsRobotTripTimeMs, _ = strconv.Atoi(record[headerMap["dsRobotTripTimeMs"]])
+		if len(headerMap) > 13 {
+
+			curRow.TxRate, _ = strconv.ParseFloat(record[headerMap["txRate"]], 64)
+			curRow.RxRate, _ = strconv.ParseFloat(record[headerMap["rxRate"]], 64)
+			curRow.SignalNoiseRatio, _ = strconv.Atoi(record[headerMap["signalNoiseRatio"]])
+		} else {
+			curRow.TxRate = -1
+			curRow.RxRate = -1
+			curRow.SignalNoiseRatio = -1
 		}
-		for i, record := range records {
-			var curRow MatchLogRow
-			curRow.MatchTimeSec, _ = strconv.ParseFloat(record[headerMap["matchTimeSec"]], 64)
-			curRow.PacketType, _ = strconv.Atoi(record[headerMap["packetType"]])
-			curRow.TeamId, _ = strconv.Atoi(record[headerMap["teamId"]])
-			curRow.AllianceStation = record[headerMap["allianceStation"]]
-			curRow.DsLinked, _ = strconv.ParseBool(record[headerMap["dsLinked"]])
-			curRow.RadioLinked, _ = strconv.ParseBool(record[headerMap["radioLinked"]])
-			curRow.RioLinked, _ = strconv.ParseBool(record[headerMap["rioLinked"]])
-			curRow.RobotLinked, _ = strconv.ParseBool(record[headerMap["robotLinked"]])
-			curRow.Auto, _ = strconv.ParseBool(record[headerMap["auto"]])
-			curRow.Enabled, _ = strconv.ParseBool(record[headerMap["enabled"]])
-			curRow.EmergencyStop, _ = strconv.ParseBool(record[headerMap["emergencyStop"]])
-			curRow.AutonomousStop, _ = strconv.ParseBool(record[headerMap["autonomousStop"]])
-			curRow.BatteryVoltage, _ = strconv.ParseFloat(record[headerMap["batteryVoltage"]], 64)
-			curRow.MissedPacketCount, _ = strconv.Atoi(record[headerMap["missedPacketCount"]])
-			curRow.DsRobotTripTimeMs, _ = strconv.Atoi(record[headerMap["dsRobotTripTimeMs"]])
-			if len(headerMap) > 13 {
 
-				curRow.TxRate, _ = strconv.ParseFloat(record[headerMap["txRate"]], 64)
-				curRow.RxRate, _ = strconv.ParseFloat(record[headerMap["rxRate"]], 64)
-				curRow.SignalNoiseRatio, _ = strconv.Atoi(record[headerMap["signalNoiseRatio"]])
-			} else {
-				curRow.TxRate = -1
-				curRow.RxRate = -1
-				curRow.SignalNoiseRatio = -1
-			}
+		curlog.Rows[i] = curRow
+	}
 
-			// Create new person and add to persons array
-			curlog.Rows[i] = curRow
+	// Attach the diagnostic messages, if any, to the packet row that was logged at or just after their arrival.
+	diagnosticsFilename := field.DiagnosticsLogFilename(filename)
+	if diagnostics, err := readMatchLogDiagnostics(diagnosticsFilename); err == nil {
+		curlog.DiagnosticsFilename = diagnosticsFilename
+		for _, diagnostic := range diagnostics {
+			if diagnostic.Category == "version" {
+				curlog.Versions = append(curlog.Versions, diagnostic)
+				continue
+			}
+			if len(curlog.Rows) == 0 {
+				continue
+			}
+			rowIndex := len(curlog.Rows) - 1
+			for i, row := range curlog.Rows {
+				if row.MatchTimeSec >= diagnostic.MatchTimeSec {
+					rowIndex = i
+					break
+				}
+			}
+			curlog.Rows[rowIndex].Diagnostics = append(curlog.Rows[rowIndex].Diagnostics, diagnostic)
 		}
+	}
+	return &curlog, nil
+}
 
-		logs.Logs = append(logs.Logs, curlog)
+// Loads the version reports, errors and other messages recorded alongside a team match log.
+func readMatchLogDiagnostics(filename string) ([]MatchLogDiagnostic, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
 
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, err
 	}
-	return match, &logs, false, nil
+	var diagnostics []MatchLogDiagnostic
+	for i, record := range records {
+		if i == 0 || len(record) < 4 {
+			// Skip the header row and any malformed lines.
+			continue
+		}
+		var diagnostic MatchLogDiagnostic
+		diagnostic.MatchTimeSec, _ = strconv.ParseFloat(record[0], 64)
+		diagnostic.PacketType, _ = strconv.Atoi(record[1])
+		diagnostic.Category = record[2]
+		diagnostic.Message = record[3]
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	return diagnostics, nil
 }
 
 // Constructs the list of matches to display in the match Logs interface.
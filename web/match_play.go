@@ -16,6 +16,7 @@ import (
 	"github.com/Team254/cheesy-arena/field"
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
 	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/Team254/cheesy-arena/websocket"
 	"github.com/mitchellh/mapstructure"
@@ -33,10 +34,6 @@ type MatchPlayList []MatchPlayListItem
 
 // Shows the match play control interface.
 func (web *Web) matchPlayHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	template, err := web.parseFiles(
 		"templates/match_play.html", "templates/audience_display_radio_buttons.html", "templates/base.html",
 	)
@@ -62,10 +59,6 @@ func (web *Web) matchPlayHandler(w http.ResponseWriter, r *http.Request) {
 
 // Renders a partial template containing the list of matches.
 func (web *Web) matchPlayMatchLoadHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	practiceMatches, err := web.buildMatchPlayList(model.Practice)
 	if err != nil {
 		handleWebErr(w, err)
@@ -100,9 +93,11 @@ func (web *Web) matchPlayMatchLoadHandler(w http.ResponseWriter, r *http.Request
 	data := struct {
 		MatchesByType    map[model.MatchType]MatchPlayList
 		CurrentMatchType model.MatchType
+		NexusPitNotes    map[int]string
 	}{
 		matchesByType,
 		currentMatchType,
+		web.arena.GetNexusPitNotes(),
 	}
 	err = template.ExecuteTemplate(w, "match_play_match_load.html", data)
 	if err != nil {
@@ -113,19 +108,17 @@ func (web *Web) matchPlayMatchLoadHandler(w http.ResponseWriter, r *http.Request
 
 // The websocket endpoint for the match play client to send control commands and receive status updates.
 func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	ws, err := websocket.NewWebsocket(w, r)
 	if err != nil {
 		handleWebErr(w, err)
 		return
 	}
 	defer ws.Close()
+	username := web.getAuditUsername(r)
 
 	// Subscribe the websocket to the notifiers whose messages will be passed on to the client, in a separate goroutine.
 	go ws.HandleNotifiers(
+		web.arena.AlarmsNotifier,
 		web.arena.MatchTimingNotifier,
 		web.arena.AllianceStationDisplayModeNotifier,
 		web.arena.ArenaStatusNotifier,
@@ -149,6 +142,8 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 			log.Println(err)
 			return
 		}
+		matchId := web.arena.CurrentMatch.Id
+		stateBefore := web.auditMatchControlSnapshot()
 
 		switch messageType {
 		case "loadMatch":
@@ -183,6 +178,30 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 				ws.WriteError(err.Error())
 				continue
 			}
+		case "replayMatch":
+			args := struct {
+				MatchId int
+				Reason  string
+			}{}
+			err = mapstructure.Decode(data, &args)
+			if err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+			match, err := web.arena.Database.GetMatchById(args.MatchId)
+			if err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+			if match == nil {
+				ws.WriteError(fmt.Sprintf("invalid match ID %d", args.MatchId))
+				continue
+			}
+			err = web.arena.ReplayMatch(match, args.Reason)
+			if err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
 		case "showResult":
 			args := struct {
 				MatchId int
@@ -277,6 +296,22 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 				ws.WriteError(err.Error())
 				continue
 			}
+		case "acknowledgeAlarm":
+			args := struct {
+				Component string
+			}{}
+			err = mapstructure.Decode(data, &args)
+			if err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+			if err = web.arena.AcknowledgeAlarm(args.Component, username); err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+			if err = ws.WriteNotifier(web.arena.ArenaStatusNotifier); err != nil {
+				log.Println(err)
+			}
 		case "abortMatch":
 			err = web.arena.AbortMatch()
 			if err != nil {
@@ -356,6 +391,19 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 				ws.WriteError(err.Error())
 				continue
 			}
+		case "retimeMatches":
+			args := struct {
+				CompressedCycleTimeSec int
+			}{}
+			err = mapstructure.Decode(data, &args)
+			if err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+			if err = web.retimeRemainingMatches(args.CompressedCycleTimeSec); err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
 		case "setTestMatchName":
 			if web.arena.CurrentMatch.Type != model.Test {
 				// Don't allow changing the name of a non-test match.
@@ -370,7 +418,11 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 			web.arena.MatchLoadNotifier.Notify()
 		default:
 			ws.WriteError(fmt.Sprintf("Invalid message type '%s'.", messageType))
+			continue
 		}
+		web.recordAuditEntry(
+			username, "match_play", "", matchId, messageType, stateBefore, web.auditMatchControlSnapshot(),
+		)
 	}
 }
 
@@ -407,6 +459,16 @@ func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchRes
 			if err != nil {
 				return err
 			}
+
+			if !isMatchReviewEdit {
+				// Save the history of realtime score changes that led up to this result.
+				scoreTimeline := model.ScoreTimeline{
+					Id: matchResult.Id, MatchId: match.Id, Events: web.arena.ScoreEvents(),
+				}
+				if err = web.arena.Database.CreateScoreTimeline(&scoreTimeline); err != nil {
+					return err
+				}
+			}
 		} else {
 			// We are updating a match result record that already exists.
 			err := web.arena.Database.UpdateMatchResult(matchResult)
@@ -434,6 +496,9 @@ func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchRes
 				return err
 			}
 			updatedRankings = rankings
+			if err = web.arena.RefreshBroadcastRankings(); err != nil {
+				return err
+			}
 		}
 
 		if match.ShouldUpdatePlayoffMatches() {
@@ -466,17 +531,15 @@ func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchRes
 		}
 
 		if web.arena.EventSettings.TbaPublishingEnabled && match.Type != model.Practice {
-			// Publish asynchronously to The Blue Alliance.
-			go func() {
-				if err = web.arena.TbaClient.PublishMatches(web.arena.Database); err != nil {
-					log.Printf("Failed to publish matches: %s", err.Error())
-				}
-				if match.ShouldUpdateRankings() {
-					if err = web.arena.TbaClient.PublishRankings(web.arena.Database); err != nil {
-						log.Printf("Failed to publish rankings: %s", err.Error())
-					}
+			// Queue the results to be published to The Blue Alliance in the background.
+			if err := web.arena.TbaOutbox.Enqueue(partner.TbaPublishMatches); err != nil {
+				log.Printf("Failed to queue matches for publishing: %s", err.Error())
+			}
+			if match.ShouldUpdateRankings() {
+				if err := web.arena.TbaOutbox.Enqueue(partner.TbaPublishRankings); err != nil {
+					log.Printf("Failed to queue rankings for publishing: %s", err.Error())
 				}
-			}()
+			}
 		}
 
 		// Back up the database, but don't error out if it fails.
@@ -501,12 +564,13 @@ func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchRes
 
 func (web *Web) getCurrentMatchResult() *model.MatchResult {
 	return &model.MatchResult{
-		MatchId:   web.arena.CurrentMatch.Id,
-		MatchType: web.arena.CurrentMatch.Type,
-		RedScore:  &web.arena.RedRealtimeScore.CurrentScore,
-		BlueScore: &web.arena.BlueRealtimeScore.CurrentScore,
-		RedCards:  web.arena.RedRealtimeScore.Cards,
-		BlueCards: web.arena.BlueRealtimeScore.Cards,
+		MatchId:      web.arena.CurrentMatch.Id,
+		ReplayReason: web.arena.CurrentReplayReason,
+		MatchType:    web.arena.CurrentMatch.Type,
+		RedScore:     &web.arena.RedRealtimeScore.CurrentScore,
+		BlueScore:    &web.arena.BlueRealtimeScore.CurrentScore,
+		RedCards:     web.arena.RedRealtimeScore.Cards,
+		BlueCards:    web.arena.BlueRealtimeScore.Cards,
 	}
 }
 
@@ -515,6 +579,41 @@ func (web *Web) commitCurrentMatchScore() error {
 	return web.commitMatchScore(web.arena.CurrentMatch, web.getCurrentMatchResult(), false)
 }
 
+// Pushes back the times of the remaining matches of the current type to reflect how late the event is running, and
+// publishes the new times to the displays and The Blue Alliance.
+func (web *Web) retimeRemainingMatches(compressedCycleTimeSec int) error {
+	matchType := web.arena.CurrentMatch.Type
+	if matchType == model.Test {
+		return fmt.Errorf("cannot re-time matches while a test match is loaded")
+	}
+	retimedMatches, conflictingSlots, err := tournament.RetimeMatches(
+		web.arena.Database, matchType, time.Now(), compressedCycleTimeSec, judgingScheduleParams,
+	)
+	if err != nil {
+		return err
+	}
+	if len(retimedMatches) == 0 {
+		return nil
+	}
+	for _, match := range retimedMatches {
+		if match.Id == web.arena.CurrentMatch.Id {
+			web.arena.CurrentMatch.Time = match.Time
+		}
+	}
+	if len(conflictingSlots) > 0 {
+		log.Printf("Re-timing matches created conflicts in %d judging slots.", len(conflictingSlots))
+	}
+	web.arena.MatchLoadNotifier.Notify()
+
+	if web.arena.EventSettings.TbaPublishingEnabled && matchType != model.Practice {
+		// Queue the new schedule to be published to The Blue Alliance in the background.
+		if err := web.arena.TbaOutbox.Enqueue(partner.TbaPublishMatches); err != nil {
+			log.Printf("Failed to queue matches for publishing: %s", err.Error())
+		}
+	}
+	return nil
+}
+
 // Helper function to implement the required interface for Sort.
 func (list MatchPlayList) Len() int {
 	return len(list)
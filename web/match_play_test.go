@@ -4,16 +4,15 @@
 package web
 
 import (
-	"bytes"
 	"github.com/Team254/cheesy-arena/field"
 	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/game/reefscape"
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/Team254/cheesy-arena/websocket"
 	gorillawebsocket "github.com/gorilla/websocket"
 	"github.com/mitchellh/mapstructure"
 	"github.com/stretchr/testify/assert"
-	"log"
 	"testing"
 	"time"
 )
@@ -57,7 +56,7 @@ func TestCommitMatch(t *testing.T) {
 	// Committing test match should update the stored saved match but not persist anything.
 	match := &model.Match{Id: 0, Type: model.Test, Red1: 101, Red2: 102, Red3: 103, Blue1: 104, Blue2: 105, Blue3: 106}
 	matchResult := &model.MatchResult{MatchId: match.Id, RedScore: &game.Score{}, BlueScore: &game.Score{}}
-	matchResult.BlueScore.LeaveStatuses[2] = true
+	reefscape.ScoreDetails(matchResult.BlueScore).LeaveStatuses[2] = true
 	err := web.commitMatchScore(match, matchResult, false)
 	assert.Nil(t, err)
 	matchResult, err = web.arena.Database.GetMatchResultForMatch(match.Id)
@@ -71,16 +70,17 @@ func TestCommitMatch(t *testing.T) {
 	assert.Nil(t, web.arena.Database.CreateMatch(match))
 	matchResult = model.NewMatchResult()
 	matchResult.MatchId = match.Id
-	matchResult.BlueScore = &game.Score{LeaveStatuses: [3]bool{true, false, false}}
+	matchResult.BlueScore = &game.Score{Details: &reefscape.Score{LeaveStatuses: [3]bool{true, false, false}}}
 	err = web.commitMatchScore(match, matchResult, true)
 	assert.Nil(t, err)
 	assert.Equal(t, 1, matchResult.PlayNumber)
 	match, _ = web.arena.Database.GetMatchById(1)
 	assert.Equal(t, game.BlueWonMatch, match.Status)
+	assert.Equal(t, 6, len(web.arena.GenerateBroadcastFeed().Rankings.Rankings))
 
 	matchResult = model.NewMatchResult()
 	matchResult.MatchId = match.Id
-	matchResult.RedScore = &game.Score{LeaveStatuses: [3]bool{true, false, true}}
+	matchResult.RedScore = &game.Score{Details: &reefscape.Score{LeaveStatuses: [3]bool{true, false, true}}}
 	err = web.commitMatchScore(match, matchResult, true)
 	assert.Nil(t, err)
 	assert.Equal(t, 2, matchResult.PlayNumber)
@@ -95,16 +95,17 @@ func TestCommitMatch(t *testing.T) {
 	match, _ = web.arena.Database.GetMatchById(1)
 	assert.Equal(t, game.TieMatch, match.Status)
 
-	// Verify TBA publishing by checking the log for the expected failure messages.
+	// Verify TBA publishing by checking that the results were queued.
 	web.arena.TbaClient.BaseUrl = "fakeUrl"
 	web.arena.EventSettings.TbaPublishingEnabled = true
-	var writer bytes.Buffer
-	log.SetOutput(&writer)
 	err = web.commitMatchScore(match, matchResult, true)
 	assert.Nil(t, err)
-	time.Sleep(time.Millisecond * 100) // Allow some time for the asynchronous publishing to happen.
-	assert.Contains(t, writer.String(), "Failed to publish matches")
-	assert.Contains(t, writer.String(), "Failed to publish rankings")
+	publications, err := web.arena.Database.GetAllTbaPublications()
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(publications)) {
+		assert.Equal(t, "matches", publications[0].Kind)
+		assert.Equal(t, "rankings", publications[1].Kind)
+	}
 }
 
 func TestCommitTiebreak(t *testing.T) {
@@ -126,8 +127,8 @@ func TestCommitTiebreak(t *testing.T) {
 		MatchId: match.Id,
 		// These should all be fields that aren't part of the tiebreaker.
 		RedScore: &game.Score{
-			Reef:  game.Reef{TroughFar: 1},
-			Fouls: []game.Foul{{IsMajor: false}, {IsMajor: false}},
+			Fouls:   []game.Foul{{IsMajor: false}, {IsMajor: false}},
+			Details: &reefscape.Score{Reef: reefscape.Reef{TroughFar: 1}},
 		},
 		BlueScore: &game.Score{
 			Fouls: []game.Foul{{IsMajor: false}},
@@ -155,7 +156,7 @@ func TestCommitTiebreak(t *testing.T) {
 	assert.Equal(t, game.TieMatch, match.Status)
 
 	// Change the score to still be equal nominally but trigger the tiebreaker criteria.
-	matchResult.BlueScore.ProcessorAlgae = 1
+	reefscape.ScoreDetails(matchResult.BlueScore).ProcessorAlgae = 1
 	matchResult.BlueScore.Fouls = []game.Foul{{IsMajor: false}, {IsMajor: true}}
 
 	// Sanity check that the test scores are equal; they will need to be updated accordingly for each new game.
@@ -251,7 +252,7 @@ func TestCommitCards(t *testing.T) {
 	match.PlayoffRedAlliance = 1
 	match.PlayoffBlueAlliance = 2
 	web.arena.Database.UpdateMatch(match)
-	matchResult = model.BuildTestMatchResult(match.Id, 0)
+	matchResult = buildTestMatchResult(match.Id, 0)
 	matchResult.MatchType = match.Type
 	matchResult.RedCards = map[string]string{"1": "red"}
 	assert.Nil(t, web.commitMatchScore(match, matchResult, true))
@@ -278,6 +279,7 @@ func TestMatchPlayWebsocketCommands(t *testing.T) {
 	ws := websocket.NewTestWebsocket(conn)
 
 	// Should get a few status updates right after connection.
+	readWebsocketType(t, ws, "alarms")
 	readWebsocketType(t, ws, "matchTiming")
 	readWebsocketType(t, ws, "allianceStationDisplayMode")
 	readWebsocketType(t, ws, "arenaStatus")
@@ -334,12 +336,16 @@ func TestMatchPlayWebsocketCommands(t *testing.T) {
 	ws.Write("abortMatch", nil)
 	readWebsocketType(t, ws, "audienceDisplayMode")
 	assert.Equal(t, field.PostMatch, web.arena.MatchState)
-	web.arena.RedRealtimeScore.CurrentScore.BargeAlgae = 6
-	web.arena.BlueRealtimeScore.CurrentScore.LeaveStatuses = [3]bool{true, false, true}
+	reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).BargeAlgae = 6
+	reefscape.ScoreDetails(&web.arena.BlueRealtimeScore.CurrentScore).LeaveStatuses = [3]bool{true, false, true}
 	ws.Write("commitResults", nil)
 	readWebsocketMultiple(t, ws, 5) // scorePosted, matchLoad, realtimeScore, allianceStationDisplayMode, scoringStatus
-	assert.Equal(t, 6, web.arena.SavedMatchResult.RedScore.BargeAlgae)
-	assert.Equal(t, [3]bool{true, false, true}, web.arena.SavedMatchResult.BlueScore.LeaveStatuses)
+	assert.Equal(t, 6, reefscape.ScoreDetails(web.arena.SavedMatchResult.RedScore).BargeAlgae)
+	assert.Equal(
+		t,
+		[3]bool{true, false, true},
+		reefscape.ScoreDetails(web.arena.SavedMatchResult.BlueScore).LeaveStatuses,
+	)
 	assert.Equal(t, field.PreMatch, web.arena.MatchState)
 	ws.Write("discardResults", nil)
 	readWebsocketMultiple(t, ws, 4) // matchLoad, realtimeScore, allianceStationDisplayMode, scoringStatus
@@ -367,7 +373,7 @@ func TestMatchPlayWebsocketLoadMatch(t *testing.T) {
 	ws := websocket.NewTestWebsocket(conn)
 
 	// Should get a few status updates right after connection.
-	readWebsocketMultiple(t, ws, 10)
+	readWebsocketMultiple(t, ws, 11)
 
 	web.arena.Database.CreateTeam(&model.Team{Id: 101})
 	web.arena.Database.CreateTeam(&model.Team{Id: 102})
@@ -428,7 +434,7 @@ func TestMatchPlayWebsocketShowAndClearResult(t *testing.T) {
 	ws := websocket.NewTestWebsocket(conn)
 
 	// Should get a few status updates right after connection.
-	readWebsocketMultiple(t, ws, 10)
+	readWebsocketMultiple(t, ws, 11)
 
 	matchIdMessage := struct{ MatchId int }{1}
 	ws.Write("showResult", matchIdMessage)
@@ -439,7 +445,7 @@ func TestMatchPlayWebsocketShowAndClearResult(t *testing.T) {
 	ws.Write("showResult", matchIdMessage)
 	assert.Contains(t, readWebsocketError(t, ws), "No result found")
 
-	web.arena.Database.CreateMatchResult(model.BuildTestMatchResult(match.Id, 1))
+	web.arena.Database.CreateMatchResult(buildTestMatchResult(match.Id, 1))
 	ws.Write("showResult", matchIdMessage)
 	readWebsocketType(t, ws, "scorePosted")
 	assert.Equal(t, match.Id, web.arena.SavedMatch.Id)
@@ -452,6 +458,91 @@ func TestMatchPlayWebsocketShowAndClearResult(t *testing.T) {
 	assert.Equal(t, *model.NewMatchResult(), *web.arena.SavedMatchResult)
 }
 
+func TestMatchPlayWebsocketReplayMatch(t *testing.T) {
+	web := setupTestWeb(t)
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/match_play/websocket", nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws := websocket.NewTestWebsocket(conn)
+
+	// Should get a few status updates right after connection.
+	readWebsocketMultiple(t, ws, 11)
+
+	ws.Write("replayMatch", map[string]any{"matchId": 1, "reason": "Field fault"})
+	assert.Contains(t, readWebsocketError(t, ws), "invalid match ID 1")
+
+	match := model.Match{Type: model.Practice, ShortName: "P1", LongName: "Practice 1"}
+	web.arena.Database.CreateMatch(&match)
+	ws.Write("replayMatch", map[string]any{"matchId": match.Id, "reason": "Field fault"})
+	assert.Contains(t, readWebsocketError(t, ws), "has not yet been played")
+
+	match.Status = game.RedWonMatch
+	web.arena.Database.UpdateMatch(&match)
+	web.arena.Database.CreateMatchResult(buildTestMatchResult(match.Id, 1))
+	ws.Write("replayMatch", map[string]any{"matchId": match.Id, "reason": "Field fault"})
+	messages := readWebsocketMultiple(t, ws, 4)
+	assert.Contains(t, messages, "matchLoad")
+	assert.Equal(t, match.Id, web.arena.CurrentMatch.Id)
+	assert.Equal(t, 2, web.arena.CurrentPlayNumber)
+
+	// Check that committing the replay keeps the original result and records the reason on the new one.
+	web.arena.MatchState = field.PostMatch
+	assert.Nil(t, web.commitCurrentMatchScore())
+	matchResults, err := web.arena.Database.GetMatchResultsForMatch(match.Id)
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(matchResults)) {
+		assert.Equal(t, 1, matchResults[0].PlayNumber)
+		assert.Equal(t, "", matchResults[0].ReplayReason)
+		assert.Equal(t, 2, matchResults[1].PlayNumber)
+		assert.Equal(t, "Field fault", matchResults[1].ReplayReason)
+	}
+
+	recorder := web.getHttpResponse("/match_review")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "P1 (Play 1)")
+	assert.Contains(t, recorder.Body.String(), "Replayed: Field fault")
+}
+
+func TestMatchPlayWebsocketRetimeMatches(t *testing.T) {
+	web := setupTestWeb(t)
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/match_play/websocket", nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws := websocket.NewTestWebsocket(conn)
+
+	// Should get a few status updates right after connection.
+	readWebsocketMultiple(t, ws, 11)
+
+	ws.Write("retimeMatches", nil)
+	assert.Contains(t, readWebsocketError(t, ws), "cannot re-time matches while a test match is loaded")
+
+	startTime := time.Now().Add(-time.Hour).Truncate(time.Minute)
+	for i := 0; i < 3; i++ {
+		match := model.Match{
+			Type: model.Qualification, TypeOrder: i + 1, Time: startTime.Add(time.Duration(i*6) * time.Minute),
+		}
+		web.arena.Database.CreateMatch(&match)
+	}
+	match, _ := web.arena.Database.GetMatchByTypeOrder(model.Qualification, 1)
+	assert.Nil(t, web.arena.LoadMatch(match))
+	readWebsocketType(t, ws, "matchLoad")
+	readWebsocketMultiple(t, ws, 3)
+
+	ws.Write("retimeMatches", struct{ CompressedCycleTimeSec int }{300})
+	readWebsocketType(t, ws, "matchLoad")
+	matches, _ := web.arena.Database.GetMatchesByType(model.Qualification, false)
+	assert.True(t, matches[0].Time.After(time.Now()))
+	assert.Equal(t, matches[0].Time.Unix(), web.arena.CurrentMatch.Time.Unix())
+	assert.Equal(t, 5*time.Minute, matches[1].Time.Sub(matches[0].Time))
+	assert.Equal(t, 5*time.Minute, matches[2].Time.Sub(matches[1].Time))
+}
+
 func TestMatchPlayWebsocketNotifications(t *testing.T) {
 	web := setupTestWeb(t)
 
@@ -465,7 +556,7 @@ func TestMatchPlayWebsocketNotifications(t *testing.T) {
 	ws := websocket.NewTestWebsocket(conn)
 
 	// Should get a few status updates right after connection.
-	readWebsocketMultiple(t, ws, 10)
+	readWebsocketMultiple(t, ws, 11)
 
 	web.arena.AllianceStations["R1"].Bypass = true
 	web.arena.AllianceStations["R2"].Bypass = true
@@ -536,3 +627,22 @@ func getStatusMatchTime(t *testing.T, messages map[string]any) (bool, field.Matc
 	}
 	return statusReceived, matchTime
 }
+
+func TestMatchPlayWebsocketAcknowledgeAlarm(t *testing.T) {
+	web := setupTestWeb(t)
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/match_play/websocket", nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws := websocket.NewTestWebsocket(conn)
+
+	// Should get a few status updates right after connection.
+	readWebsocketMultiple(t, ws, 11)
+
+	ws.Write("acknowledgeAlarm", map[string]any{"component": field.AccessPointAlarm})
+	assert.Contains(t, readWebsocketError(t, ws), "no alarm is raised")
+	ws.Write("acknowledgeAlarm", map[string]any{"component": "blah"})
+	assert.Contains(t, readWebsocketError(t, ws), "invalid alarm component")
+}
@@ -24,6 +24,16 @@ type MatchReviewListItem struct {
 	BlueScore  int
 	ColorClass string
 	IsComplete bool
+	// Results from earlier plays of the match that were superseded by a replay, in order of play.
+	PriorPlays []MatchReviewPlay
+}
+
+type MatchReviewPlay struct {
+	PlayNumber int
+	RedScore   int
+	BlueScore  int
+	// The reason recorded when this play was superseded by the next one.
+	ReplayReason string
 }
 
 // Shows the match review interface.
@@ -72,10 +82,6 @@ func (web *Web) matchReviewHandler(w http.ResponseWriter, r *http.Request) {
 
 // Shows the page to edit the results for a match.
 func (web *Web) matchReviewEditGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	match, matchResult, isCurrent, err := web.getMatchResultFromRequest(r)
 	if err != nil {
 		handleWebErr(w, err)
@@ -108,15 +114,13 @@ func (web *Web) matchReviewEditGetHandler(w http.ResponseWriter, r *http.Request
 
 // Updates the results for a match.
 func (web *Web) matchReviewEditPostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
-	match, _, isCurrent, err := web.getMatchResultFromRequest(r)
+	match, previousMatchResult, isCurrent, err := web.getMatchResultFromRequest(r)
 	if err != nil {
 		handleWebErr(w, err)
 		return
 	}
+	username := web.getAuditUsername(r)
+	resultBefore := auditSnapshot(previousMatchResult)
 
 	var matchResult model.MatchResult
 	if err = json.Unmarshal([]byte(r.PostFormValue("matchResultJson")), &matchResult); err != nil {
@@ -136,6 +140,8 @@ func (web *Web) matchReviewEditPostHandler(w http.ResponseWriter, r *http.Reques
 		web.arena.BlueRealtimeScore.Cards = matchResult.BlueCards
 
 		web.arena.RealtimeScoreNotifier.Notify()
+		resultAfter := auditSnapshot(matchResult)
+		web.recordAuditEntry(username, "match_review", "", match.Id, "editResult", resultBefore, resultAfter)
 
 		http.Redirect(w, r, "/match_play", 303)
 	} else {
@@ -144,6 +150,8 @@ func (web *Web) matchReviewEditPostHandler(w http.ResponseWriter, r *http.Reques
 			handleWebErr(w, err)
 			return
 		}
+		resultAfter := auditSnapshot(matchResult)
+		web.recordAuditEntry(username, "match_review", "", match.Id, "editResult", resultBefore, resultAfter)
 
 		http.Redirect(w, r, "/match_review", 303)
 	}
@@ -192,13 +200,23 @@ func (web *Web) buildMatchReviewList(matchType model.MatchType) ([]MatchReviewLi
 		matchReviewList[i].Time = match.Time.Local().Format("Mon 1/02 03:04 PM")
 		matchReviewList[i].RedTeams = []int{match.Red1, match.Red2, match.Red3}
 		matchReviewList[i].BlueTeams = []int{match.Blue1, match.Blue2, match.Blue3}
-		matchResult, err := web.arena.Database.GetMatchResultForMatch(match.Id)
+		matchResults, err := web.arena.Database.GetMatchResultsForMatch(match.Id)
 		if err != nil {
 			return []MatchReviewListItem{}, err
 		}
-		if matchResult != nil {
-			matchReviewList[i].RedScore = matchResult.RedScoreSummary().Score
-			matchReviewList[i].BlueScore = matchResult.BlueScoreSummary().Score
+		for j, matchResult := range matchResults {
+			play := MatchReviewPlay{
+				PlayNumber: matchResult.PlayNumber,
+				RedScore:   matchResult.RedScoreSummary().Score,
+				BlueScore:  matchResult.BlueScoreSummary().Score,
+			}
+			if j < len(matchResults)-1 {
+				play.ReplayReason = matchResults[j+1].ReplayReason
+				matchReviewList[i].PriorPlays = append(matchReviewList[i].PriorPlays, play)
+			} else {
+				matchReviewList[i].RedScore = play.RedScore
+				matchReviewList[i].BlueScore = play.BlueScore
+			}
 		}
 		switch match.Status {
 		case game.RedWonMatch:
@@ -6,6 +6,7 @@ package web
 import (
 	"fmt"
 	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/game/reefscape"
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/stretchr/testify/assert"
@@ -49,7 +50,7 @@ func TestMatchReviewEditExistingResult(t *testing.T) {
 	match, _ := web.arena.Database.GetMatchByTypeOrder(model.Playoff, 36)
 	match.Status = game.RedWonMatch
 	web.arena.Database.UpdateMatch(match)
-	matchResult := model.BuildTestMatchResult(match.Id, 1)
+	matchResult := buildTestMatchResult(match.Id, 1)
 	matchResult.MatchType = match.Type
 	assert.Nil(t, web.arena.Database.CreateMatchResult(matchResult))
 
@@ -164,10 +165,10 @@ func TestMatchReviewEditCurrentMatch(t *testing.T) {
 	assert.Equal(t, game.MatchScheduled, match2.Status)
 	assert.Equal(
 		t,
-		[3]game.EndgameStatus{game.EndgameNone, game.EndgameShallowCage, game.EndgameParked},
-		web.arena.RedRealtimeScore.CurrentScore.EndgameStatuses,
+		[3]reefscape.EndgameStatus{reefscape.EndgameNone, reefscape.EndgameShallowCage, reefscape.EndgameParked},
+		reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).EndgameStatuses,
 	)
-	assert.Equal(t, 21, web.arena.BlueRealtimeScore.CurrentScore.Reef.TroughFar)
+	assert.Equal(t, 21, reefscape.ScoreDetails(&web.arena.BlueRealtimeScore.CurrentScore).Reef.TroughFar)
 	assert.Equal(t, 0, len(web.arena.RedRealtimeScore.CurrentScore.Fouls))
 	assert.Equal(t, 1, len(web.arena.BlueRealtimeScore.CurrentScore.Fouls))
 	assert.Equal(t, 1, len(web.arena.RedRealtimeScore.Cards))
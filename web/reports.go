@@ -7,6 +7,7 @@ package web
 
 import (
 	"bytes"
+	"encoding/csv"
 	"errors"
 	"fmt"
 	"math"
@@ -17,11 +18,97 @@ import (
 
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
 	"github.com/Team254/cheesy-arena/playoff"
 	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/jung-kurt/gofpdf"
 )
 
+// Generates a CSV-formatted report of the audit log, narrowed down by the filters given in the query string.
+func (web *Web) auditCsvReportHandler(w http.ResponseWriter, r *http.Request) {
+	auditEntries, err := web.arena.Database.GetAuditEntries(parseAuditEntryFilter(r))
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	matchNames, err := web.getAuditMatchNames(auditEntries)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Use a CSV writer rather than a template since the before and after values contain JSON which needs quoting.
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	_ = writer.Write([]string{"Time", "User", "Source", "Position", "Match", "Command", "Before", "After"})
+	for _, auditEntry := range auditEntries {
+		_ = writer.Write(
+			[]string{
+				auditEntry.Time.Local().Format(time.RFC3339),
+				auditEntry.Username,
+				auditEntry.Source,
+				auditEntry.Position,
+				matchNames[auditEntry.MatchId],
+				auditEntry.Command,
+				auditEntry.Before,
+				auditEntry.After,
+			},
+		)
+	}
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Don't set the content type as "text/csv", as that will trigger an automatic download in the browser.
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write(buf.Bytes())
+}
+
+// Generates a CSV-formatted report of every alarm raised against the field hardware over the course of the event.
+func (web *Web) alarmsCsvReportHandler(w http.ResponseWriter, r *http.Request) {
+	alarmEntries, err := web.arena.Database.GetAllAlarmEntries()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Local().Format(time.RFC3339)
+	}
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	_ = writer.Write(
+		[]string{"Raised", "Cleared", "Component", "Description", "Critical", "Acknowledged", "Acknowledged By"},
+	)
+	for _, alarmEntry := range alarmEntries {
+		_ = writer.Write(
+			[]string{
+				formatTime(alarmEntry.RaisedAt),
+				formatTime(alarmEntry.ClearedAt),
+				alarmEntry.Component,
+				alarmEntry.Description,
+				strconv.FormatBool(alarmEntry.Critical),
+				formatTime(alarmEntry.AcknowledgedAt),
+				alarmEntry.AcknowledgedBy,
+			},
+		)
+	}
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Don't set the content type as "text/csv", as that will trigger an automatic download in the browser.
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write(buf.Bytes())
+}
+
 // Generates a CSV-formatted report of the qualification rankings.
 func (web *Web) rankingsCsvReportHandler(w http.ResponseWriter, r *http.Request) {
 	rankings, err := web.arena.Database.GetAllRankings()
@@ -37,8 +124,24 @@ func (web *Web) rankingsCsvReportHandler(w http.ResponseWriter, r *http.Request)
 		handleWebErr(w, err)
 		return
 	}
+	// The game-specific ranking criteria go between the common columns.
+	type rankingRow struct {
+		game.Ranking
+		GameColumnValues []int
+	}
+	gameColNames, _ := game.CurrentGame.RankingReportColumns(&game.RankingFields{})
+	rows := make([]rankingRow, len(rankings))
+	for i, ranking := range rankings {
+		_, gameColValues := game.CurrentGame.RankingReportColumns(&ranking.RankingFields)
+		rows[i] = rankingRow{ranking, gameColValues}
+	}
+	data := struct {
+		GameColumnNames []string
+		Rankings        []rankingRow
+	}{gameColNames, rows}
+
 	var buf bytes.Buffer
-	err = template.ExecuteTemplate(&buf, "rankings.csv", rankings)
+	err = template.ExecuteTemplate(&buf, "rankings.csv", data)
 	if err != nil {
 		handleWebErr(w, err)
 		return
@@ -62,16 +165,16 @@ func (web *Web) rankingsPdfReportHandler(w http.ResponseWriter, r *http.Request)
 		"Rank":   13,
 		"Team":   20,
 		"RP":     20,
-		"Coop":   20,
-		"Match":  20,
-		"Auto":   20,
-		"Barge":  20,
 		"W-L-T":  22,
 		"DQ":     20,
 		"Played": 20,
 	}
 	rowHeight := 6.5
 
+	// Split the remaining width evenly between the game-specific ranking criteria.
+	gameColNames, _ := game.CurrentGame.RankingReportColumns(&game.RankingFields{})
+	gameColWidth := 80.0 / float64(max(len(gameColNames), 1))
+
 	pdf := gofpdf.New("P", "mm", "Letter", "font")
 	pdf.AddPage()
 
@@ -82,10 +185,9 @@ func (web *Web) rankingsPdfReportHandler(w http.ResponseWriter, r *http.Request)
 	pdf.CellFormat(colWidths["Rank"], rowHeight, "Rank", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(colWidths["Team"], rowHeight, "Team", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(colWidths["RP"], rowHeight, "RP", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths["Coop"], rowHeight, "Coop", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths["Match"], rowHeight, "Match", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths["Auto"], rowHeight, "Auto", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths["Barge"], rowHeight, "Barge", "1", 0, "C", true, 0, "")
+	for _, name := range gameColNames {
+		pdf.CellFormat(gameColWidth, rowHeight, name, "1", 0, "C", true, 0, "")
+	}
 	pdf.CellFormat(colWidths["W-L-T"], rowHeight, "W-L-T", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(colWidths["DQ"], rowHeight, "DQ", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(colWidths["Played"], rowHeight, "Played", "1", 1, "C", true, 0, "")
@@ -96,12 +198,10 @@ func (web *Web) rankingsPdfReportHandler(w http.ResponseWriter, r *http.Request)
 		pdf.SetFont("Arial", "", 10)
 		pdf.CellFormat(colWidths["Team"], rowHeight, strconv.Itoa(ranking.TeamId), "1", 0, "C", false, 0, "")
 		pdf.CellFormat(colWidths["RP"], rowHeight, strconv.Itoa(ranking.RankingPoints), "1", 0, "C", false, 0, "")
-		pdf.CellFormat(
-			colWidths["Coop"], rowHeight, strconv.Itoa(ranking.CoopertitionPoints), "1", 0, "C", false, 0, "",
-		)
-		pdf.CellFormat(colWidths["Match"], rowHeight, strconv.Itoa(ranking.MatchPoints), "1", 0, "C", false, 0, "")
-		pdf.CellFormat(colWidths["Auto"], rowHeight, strconv.Itoa(ranking.AutoPoints), "1", 0, "C", false, 0, "")
-		pdf.CellFormat(colWidths["Barge"], rowHeight, strconv.Itoa(ranking.BargePoints), "1", 0, "C", false, 0, "")
+		_, gameColValues := game.CurrentGame.RankingReportColumns(&ranking.RankingFields)
+		for _, value := range gameColValues {
+			pdf.CellFormat(gameColWidth, rowHeight, strconv.Itoa(value), "1", 0, "C", false, 0, "")
+		}
 		record := fmt.Sprintf("%d-%d-%d", ranking.Wins, ranking.Losses, ranking.Ties)
 		pdf.CellFormat(colWidths["W-L-T"], rowHeight, record, "1", 0, "C", false, 0, "")
 		pdf.CellFormat(colWidths["DQ"], rowHeight, strconv.Itoa(ranking.Disqualifications), "1", 0, "C", false, 0, "")
@@ -675,10 +775,6 @@ func (web *Web) teamsPdfReportHandler(w http.ResponseWriter, r *http.Request) {
 
 // Generates a CSV-formatted report of the WPA keys, for import into the radio kiosk.
 func (web *Web) wpaKeysCsvReportHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	teams, err := web.arena.Database.GetAllTeams()
 	if err != nil {
 		handleWebErr(w, err)
@@ -957,6 +1053,102 @@ func (web *Web) cyclePdfReportHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Generates a CSV-formatted report comparing the locally committed qualification scores against the results published
+// on the FRC Events API, to catch any that were entered or uploaded incorrectly.
+func (web *Web) officialResultsCsvReportHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.arena.EventSettings.FrcEventsEnabled {
+		http.Error(w, "FRC Events API access is not enabled", 500)
+		return
+	}
+	officialResults, err := web.arena.FrcEventsClient.GetMatchResults(partner.FrcEventsQualification)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	officialResultsByNumber := make(map[int]partner.FrcEventsMatchResult, len(officialResults))
+	for _, result := range officialResults {
+		officialResultsByNumber[result.MatchNumber] = result
+	}
+	matches, err := web.arena.Database.GetMatchesByType(model.Qualification, false)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	_ = writer.Write(
+		[]string{
+			"Match", "Status", "RedScore", "OfficialRedScore", "RedFoulPoints", "OfficialRedFoulPoints", "BlueScore",
+			"OfficialBlueScore", "BlueFoulPoints", "OfficialBlueFoulPoints",
+		},
+	)
+	for _, match := range matches {
+		localScores := make([]string, 4)
+		officialScores := make([]string, 4)
+		var localValues, officialValues [4]int
+		isPlayed := match.IsComplete()
+		if isPlayed {
+			matchResult, err := web.arena.Database.GetMatchResultForMatch(match.Id)
+			if err != nil {
+				handleWebErr(w, err)
+				return
+			}
+			if matchResult == nil {
+				isPlayed = false
+			} else {
+				redSummary := matchResult.RedScoreSummary()
+				blueSummary := matchResult.BlueScoreSummary()
+				localValues = [4]int{redSummary.Score, redSummary.FoulPoints, blueSummary.Score, blueSummary.FoulPoints}
+				for i, value := range localValues {
+					localScores[i] = strconv.Itoa(value)
+				}
+			}
+		}
+		officialResult, isPublished := officialResultsByNumber[match.TypeOrder]
+		if isPublished {
+			officialValues = [4]int{
+				officialResult.ScoreRedFinal,
+				officialResult.ScoreRedFoul,
+				officialResult.ScoreBlueFinal,
+				officialResult.ScoreBlueFoul,
+			}
+			for i, value := range officialValues {
+				officialScores[i] = strconv.Itoa(value)
+			}
+		}
+
+		var status string
+		switch {
+		case !isPlayed && !isPublished:
+			continue
+		case !isPlayed:
+			status = "not played locally"
+		case !isPublished:
+			status = "not published"
+		case localValues != officialValues:
+			status = "mismatch"
+		default:
+			status = "match"
+		}
+		_ = writer.Write(
+			[]string{
+				match.ShortName, status, localScores[0], officialScores[0], localScores[1], officialScores[1],
+				localScores[2], officialScores[2], localScores[3], officialScores[3],
+			},
+		)
+	}
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Don't set the content type as "text/csv", as that will trigger an automatic download in the browser.
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write(buf.Bytes())
+}
+
 // Generates a CSV-formatted report of the FTA notes.
 func (web *Web) ftaCsvReportHandler(w http.ResponseWriter, r *http.Request) {
 	teams, err := web.arena.Database.GetAllTeams()
@@ -4,10 +4,14 @@
 package web
 
 import (
+	"fmt"
 	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/game/reefscape"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
 	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 	"time"
 )
@@ -15,24 +19,24 @@ import (
 func TestRankingsCsvReport(t *testing.T) {
 	web := setupTestWeb(t)
 
-	ranking1 := game.TestRanking2()
-	ranking2 := game.TestRanking1()
+	ranking1 := reefscape.TestRanking2()
+	ranking2 := reefscape.TestRanking1()
 	web.arena.Database.CreateRanking(ranking1)
 	web.arena.Database.CreateRanking(ranking2)
 
 	recorder := web.getHttpResponse("/reports/csv/rankings")
 	assert.Equal(t, 200, recorder.Code)
 	assert.Equal(t, "text/plain", recorder.Header()["Content-Type"][0])
-	expectedBody := "Rank,TeamId,RankingPoints,CoopertitionPoints,MatchPoints,AutoPoints,BargePoints,Wins,Losses," +
-		"Ties,Disqualifications,Played\n1,254,20,625,90,554,12,3,2,1,0,10\n2,1114,18,700,625,90,23,1,3,2,0,10\n\n"
+	expectedBody := "Rank,TeamId,RankingPoints,Coop,Match,Auto,Barge,Wins,Losses,Ties,Disqualifications,Played\n" +
+		"1,254,20,625,90,554,12,3,2,1,0,10\n2,1114,18,700,625,90,23,1,3,2,0,10\n\n"
 	assert.Equal(t, expectedBody, recorder.Body.String())
 }
 
 func TestRankingsPdfReport(t *testing.T) {
 	web := setupTestWeb(t)
 
-	ranking1 := game.TestRanking2()
-	ranking2 := game.TestRanking1()
+	ranking1 := reefscape.TestRanking2()
+	ranking2 := reefscape.TestRanking1()
 	web.arena.Database.CreateRanking(ranking1)
 	web.arena.Database.CreateRanking(ranking2)
 
@@ -42,6 +46,84 @@ func TestRankingsPdfReport(t *testing.T) {
 	assert.Equal(t, "application/pdf", recorder.Header()["Content-Type"][0])
 }
 
+func TestOfficialResultsCsvReport(t *testing.T) {
+	web := setupTestWeb(t)
+	mock := partner.NewMockFrcEventsServer(2025, "CASJ", "user", "key")
+	defer mock.Close()
+	web.arena.FrcEventsClient = partner.NewFrcEventsClient("2025casj", "user", "key")
+	web.arena.FrcEventsClient.BaseUrl = mock.URL()
+
+	recorder := web.getHttpResponse("/reports/csv/official_results")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "FRC Events API access is not enabled")
+	web.arena.EventSettings.FrcEventsEnabled = true
+
+	// Q1 agrees with the official result, Q2 doesn't, Q3 hasn't been published and Q4 hasn't been played locally.
+	for i := 1; i <= 5; i++ {
+		match := model.Match{Type: model.Qualification, TypeOrder: i, ShortName: fmt.Sprintf("Q%d", i)}
+		if i <= 3 {
+			match.Status = game.RedWonMatch
+		}
+		assert.Nil(t, web.arena.Database.CreateMatch(&match))
+		if i <= 3 {
+			assert.Nil(t, web.arena.Database.CreateMatchResult(buildTestMatchResult(match.Id, 1)))
+		}
+	}
+	matchResult := buildTestMatchResult(0, 1)
+	redSummary := matchResult.RedScoreSummary()
+	blueSummary := matchResult.BlueScoreSummary()
+	mock.SetResults(
+		partner.FrcEventsQualification,
+		[]partner.FrcEventsMatchResult{
+			{
+				MatchNumber:    1,
+				ScoreRedFinal:  redSummary.Score,
+				ScoreRedFoul:   redSummary.FoulPoints,
+				ScoreBlueFinal: blueSummary.Score,
+				ScoreBlueFoul:  blueSummary.FoulPoints,
+			},
+			{
+				MatchNumber:    2,
+				ScoreRedFinal:  redSummary.Score,
+				ScoreRedFoul:   redSummary.FoulPoints,
+				ScoreBlueFinal: blueSummary.Score + 4,
+				ScoreBlueFoul:  blueSummary.FoulPoints,
+			},
+			{MatchNumber: 4, ScoreRedFinal: 10, ScoreRedFoul: 2, ScoreBlueFinal: 20, ScoreBlueFoul: 0},
+		},
+	)
+
+	recorder = web.getHttpResponse("/reports/csv/official_results")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "text/plain", recorder.Header()["Content-Type"][0])
+	expectedBody := "Match,Status,RedScore,OfficialRedScore,RedFoulPoints,OfficialRedFoulPoints,BlueScore," +
+		"OfficialBlueScore,BlueFoulPoints,OfficialBlueFoulPoints\n" +
+		fmt.Sprintf(
+			"Q1,match,%[1]d,%[1]d,%[2]d,%[2]d,%[3]d,%[3]d,%[4]d,%[4]d\n",
+			redSummary.Score,
+			redSummary.FoulPoints,
+			blueSummary.Score,
+			blueSummary.FoulPoints,
+		) +
+		fmt.Sprintf(
+			"Q2,mismatch,%[1]d,%[1]d,%[2]d,%[2]d,%[3]d,%[4]d,%[5]d,%[5]d\n",
+			redSummary.Score,
+			redSummary.FoulPoints,
+			blueSummary.Score,
+			blueSummary.Score+4,
+			blueSummary.FoulPoints,
+		) +
+		fmt.Sprintf(
+			"Q3,not published,%d,,%d,,%d,,%d,\n",
+			redSummary.Score,
+			redSummary.FoulPoints,
+			blueSummary.Score,
+			blueSummary.FoulPoints,
+		) +
+		"Q4,not played locally,,10,,2,,20,,0\n"
+	assert.Equal(t, expectedBody, recorder.Body.String())
+}
+
 func TestScheduleCsvReport(t *testing.T) {
 	web := setupTestWeb(t)
 
@@ -231,3 +313,35 @@ func TestJudgingSchedulePdfReport(t *testing.T) {
 	assert.Equal(t, 200, recorder.Code)
 	assert.Equal(t, "application/pdf", recorder.Header()["Content-Type"][0])
 }
+
+func TestAlarmsCsvReport(t *testing.T) {
+	web := setupTestWeb(t)
+
+	raisedAt := time.Date(2025, 4, 16, 9, 30, 0, 0, time.Local)
+	alarmEntry := model.AlarmEntry{
+		Component:      "accessPoint",
+		Description:    "Access point status is ERROR",
+		Critical:       true,
+		RaisedAt:       raisedAt,
+		ClearedAt:      raisedAt.Add(time.Minute),
+		AcknowledgedAt: raisedAt.Add(30 * time.Second),
+		AcknowledgedBy: "fta",
+	}
+	assert.Nil(t, web.arena.Database.CreateAlarmEntry(&alarmEntry))
+	assert.Nil(t, web.arena.Database.CreateAlarmEntry(&model.AlarmEntry{Component: "plc", RaisedAt: raisedAt}))
+
+	recorder := web.getHttpResponse("/reports/csv/alarms")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "text/plain", recorder.Header()["Content-Type"][0])
+	lines := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+	if assert.Equal(t, 3, len(lines)) {
+		assert.Equal(t, "Raised,Cleared,Component,Description,Critical,Acknowledged,Acknowledged By", lines[0])
+		assert.Equal(
+			t,
+			raisedAt.Format(time.RFC3339)+","+raisedAt.Add(time.Minute).Format(time.RFC3339)+
+				",accessPoint,Access point status is ERROR,true,"+raisedAt.Add(30*time.Second).Format(time.RFC3339)+",fta",
+			lines[1],
+		)
+		assert.Equal(t, raisedAt.Format(time.RFC3339)+",,plc,,false,,", lines[2])
+	}
+}
@@ -6,6 +6,7 @@ package web
 import (
 	"github.com/Team254/cheesy-arena/field"
 	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/game/reefscape"
 	"github.com/Team254/cheesy-arena/websocket"
 	gorillawebsocket "github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
@@ -61,7 +62,11 @@ func TestScoringPanelWebsocket(t *testing.T) {
 	readWebsocketType(t, blueWs, "realtimeScore")
 
 	// Send some autonomous period scoring commands.
-	assert.Equal(t, [3]bool{false, false, false}, web.arena.RedRealtimeScore.CurrentScore.LeaveStatuses)
+	assert.Equal(
+		t,
+		[3]bool{false, false, false},
+		reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).LeaveStatuses,
+	)
 	leaveData := struct {
 		TeamPosition int
 	}{}
@@ -74,11 +79,19 @@ func TestScoringPanelWebsocket(t *testing.T) {
 		readWebsocketType(t, redWs, "realtimeScore")
 		readWebsocketType(t, blueWs, "realtimeScore")
 	}
-	assert.Equal(t, [3]bool{true, false, true}, web.arena.RedRealtimeScore.CurrentScore.LeaveStatuses)
+	assert.Equal(
+		t,
+		[3]bool{true, false, true},
+		reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).LeaveStatuses,
+	)
 	redWs.Write("leave", leaveData)
 	readWebsocketType(t, redWs, "realtimeScore")
 	readWebsocketType(t, blueWs, "realtimeScore")
-	assert.Equal(t, [3]bool{true, false, false}, web.arena.RedRealtimeScore.CurrentScore.LeaveStatuses)
+	assert.Equal(
+		t,
+		[3]bool{true, false, false},
+		reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).LeaveStatuses,
+	)
 
 	// Send some counter scoring commands
 	counterData := struct {
@@ -87,10 +100,10 @@ func TestScoringPanelWebsocket(t *testing.T) {
 		Autonomous bool
 		NearSide   bool
 	}{}
-	assert.Equal(t, 0, web.arena.RedRealtimeScore.CurrentScore.BargeAlgae)
-	assert.Equal(t, 0, web.arena.BlueRealtimeScore.CurrentScore.BargeAlgae)
-	assert.Equal(t, 0, web.arena.RedRealtimeScore.CurrentScore.ProcessorAlgae)
-	assert.Equal(t, 0, web.arena.BlueRealtimeScore.CurrentScore.ProcessorAlgae)
+	assert.Equal(t, 0, reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).BargeAlgae)
+	assert.Equal(t, 0, reefscape.ScoreDetails(&web.arena.BlueRealtimeScore.CurrentScore).BargeAlgae)
+	assert.Equal(t, 0, reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).ProcessorAlgae)
+	assert.Equal(t, 0, reefscape.ScoreDetails(&web.arena.BlueRealtimeScore.CurrentScore).ProcessorAlgae)
 	counterData.Adjustment = 1
 	blueWs.Write("barge", counterData)
 	blueWs.Write("barge", counterData)
@@ -117,16 +130,16 @@ func TestScoringPanelWebsocket(t *testing.T) {
 		readWebsocketType(t, redWs, "realtimeScore")
 		readWebsocketType(t, blueWs, "realtimeScore")
 	}
-	assert.Equal(t, 0, web.arena.RedRealtimeScore.CurrentScore.BargeAlgae)
-	assert.Equal(t, 2, web.arena.BlueRealtimeScore.CurrentScore.BargeAlgae)
-	assert.Equal(t, 2, web.arena.RedRealtimeScore.CurrentScore.ProcessorAlgae)
-	assert.Equal(t, 0, web.arena.BlueRealtimeScore.CurrentScore.ProcessorAlgae)
+	assert.Equal(t, 0, reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).BargeAlgae)
+	assert.Equal(t, 2, reefscape.ScoreDetails(&web.arena.BlueRealtimeScore.CurrentScore).BargeAlgae)
+	assert.Equal(t, 2, reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).ProcessorAlgae)
+	assert.Equal(t, 0, reefscape.ScoreDetails(&web.arena.BlueRealtimeScore.CurrentScore).ProcessorAlgae)
 
 	// Send some trough scoring commands
-	assert.Equal(t, 0, web.arena.RedRealtimeScore.CurrentScore.Reef.TroughNear)
-	assert.Equal(t, 0, web.arena.RedRealtimeScore.CurrentScore.Reef.TroughFar)
-	assert.Equal(t, 0, web.arena.RedRealtimeScore.CurrentScore.Reef.AutoTroughNear)
-	assert.Equal(t, 0, web.arena.RedRealtimeScore.CurrentScore.Reef.AutoTroughFar)
+	assert.Equal(t, 0, reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).Reef.TroughNear)
+	assert.Equal(t, 0, reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).Reef.TroughFar)
+	assert.Equal(t, 0, reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).Reef.AutoTroughNear)
+	assert.Equal(t, 0, reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).Reef.AutoTroughFar)
 	counterData.Adjustment = 1
 	counterData.Current = true
 	counterData.Autonomous = true
@@ -157,10 +170,10 @@ func TestScoringPanelWebsocket(t *testing.T) {
 		readWebsocketType(t, redWs, "realtimeScore")
 		readWebsocketType(t, blueWs, "realtimeScore")
 	}
-	assert.Equal(t, 4, web.arena.RedRealtimeScore.CurrentScore.Reef.TroughNear)
-	assert.Equal(t, 0, web.arena.RedRealtimeScore.CurrentScore.Reef.TroughFar)
-	assert.Equal(t, 3, web.arena.RedRealtimeScore.CurrentScore.Reef.AutoTroughNear)
-	assert.Equal(t, 1, web.arena.RedRealtimeScore.CurrentScore.Reef.AutoTroughFar)
+	assert.Equal(t, 4, reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).Reef.TroughNear)
+	assert.Equal(t, 0, reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).Reef.TroughFar)
+	assert.Equal(t, 3, reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).Reef.AutoTroughNear)
+	assert.Equal(t, 1, reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).Reef.AutoTroughFar)
 
 	// Send some reef scoring commands
 	reefData := struct {
@@ -199,65 +212,65 @@ func TestScoringPanelWebsocket(t *testing.T) {
 	assert.Equal(
 		t,
 		[12]bool{false, false, false, false, false, false, false, false, false, false, false, false},
-		web.arena.RedRealtimeScore.CurrentScore.Reef.AutoBranches[game.Level4],
+		reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).Reef.AutoBranches[reefscape.Level4],
 	)
 	assert.Equal(
 		t,
 		[12]bool{false, false, false, false, false, false, false, false, false, false, false, false},
-		web.arena.RedRealtimeScore.CurrentScore.Reef.AutoBranches[game.Level3],
+		reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).Reef.AutoBranches[reefscape.Level3],
 	)
 	assert.Equal(
 		t,
 		[12]bool{false, false, false, false, false, false, false, false, false, false, false, false},
-		web.arena.RedRealtimeScore.CurrentScore.Reef.AutoBranches[game.Level2],
+		reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).Reef.AutoBranches[reefscape.Level2],
 	)
 	// Red Current
 	assert.Equal(
 		t,
 		[12]bool{false, false, false, false, false, false, false, false, false, false, false, false},
-		web.arena.RedRealtimeScore.CurrentScore.Reef.Branches[game.Level4],
+		reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).Reef.Branches[reefscape.Level4],
 	)
 	assert.Equal(
 		t,
 		[12]bool{false, false, false, false, false, false, false, false, false, false, false, true},
-		web.arena.RedRealtimeScore.CurrentScore.Reef.Branches[game.Level3],
+		reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).Reef.Branches[reefscape.Level3],
 	)
 	assert.Equal(
 		t,
 		[12]bool{false, false, true, false, false, false, false, false, false, false, false, false},
-		web.arena.RedRealtimeScore.CurrentScore.Reef.Branches[game.Level2],
+		reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).Reef.Branches[reefscape.Level2],
 	)
 	// Blue Auto
 	assert.Equal(
 		t,
 		[12]bool{false, false, false, false, false, false, false, false, false, false, false, false},
-		web.arena.BlueRealtimeScore.CurrentScore.Reef.AutoBranches[game.Level4],
+		reefscape.ScoreDetails(&web.arena.BlueRealtimeScore.CurrentScore).Reef.AutoBranches[reefscape.Level4],
 	)
 	assert.Equal(
 		t,
 		[12]bool{false, false, false, false, false, false, false, false, false, false, false, false},
-		web.arena.BlueRealtimeScore.CurrentScore.Reef.AutoBranches[game.Level3],
+		reefscape.ScoreDetails(&web.arena.BlueRealtimeScore.CurrentScore).Reef.AutoBranches[reefscape.Level3],
 	)
 	assert.Equal(
 		t,
 		[12]bool{false, true, false, false, false, false, false, false, false, false, false, false},
-		web.arena.BlueRealtimeScore.CurrentScore.Reef.AutoBranches[game.Level2],
+		reefscape.ScoreDetails(&web.arena.BlueRealtimeScore.CurrentScore).Reef.AutoBranches[reefscape.Level2],
 	)
 	// Blue Current
 	assert.Equal(
 		t,
 		[12]bool{false, false, false, false, false, false, false, false, false, false, false, false},
-		web.arena.BlueRealtimeScore.CurrentScore.Reef.Branches[game.Level4],
+		reefscape.ScoreDetails(&web.arena.BlueRealtimeScore.CurrentScore).Reef.Branches[reefscape.Level4],
 	)
 	assert.Equal(
 		t,
 		[12]bool{false, false, false, false, false, false, false, false, false, false, false, false},
-		web.arena.BlueRealtimeScore.CurrentScore.Reef.Branches[game.Level3],
+		reefscape.ScoreDetails(&web.arena.BlueRealtimeScore.CurrentScore).Reef.Branches[reefscape.Level3],
 	)
 	assert.Equal(
 		t,
 		[12]bool{false, true, false, false, false, false, false, false, false, false, false, false},
-		web.arena.BlueRealtimeScore.CurrentScore.Reef.Branches[game.Level2],
+		reefscape.ScoreDetails(&web.arena.BlueRealtimeScore.CurrentScore).Reef.Branches[reefscape.Level2],
 	)
 
 	// Send some endgame scoring commands
@@ -267,13 +280,13 @@ func TestScoringPanelWebsocket(t *testing.T) {
 	}{}
 	assert.Equal(
 		t,
-		[3]game.EndgameStatus{game.EndgameNone, game.EndgameNone, game.EndgameNone},
-		web.arena.RedRealtimeScore.CurrentScore.EndgameStatuses,
+		[3]reefscape.EndgameStatus{reefscape.EndgameNone, reefscape.EndgameNone, reefscape.EndgameNone},
+		reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).EndgameStatuses,
 	)
 	assert.Equal(
 		t,
-		[3]game.EndgameStatus{game.EndgameNone, game.EndgameNone, game.EndgameNone},
-		web.arena.BlueRealtimeScore.CurrentScore.EndgameStatuses,
+		[3]reefscape.EndgameStatus{reefscape.EndgameNone, reefscape.EndgameNone, reefscape.EndgameNone},
+		reefscape.ScoreDetails(&web.arena.BlueRealtimeScore.CurrentScore).EndgameStatuses,
 	)
 	endgameData.TeamPosition = 1
 	endgameData.EndgameStatus = 2
@@ -299,13 +312,13 @@ func TestScoringPanelWebsocket(t *testing.T) {
 	}
 	assert.Equal(
 		t,
-		[3]game.EndgameStatus{game.EndgameShallowCage, game.EndgameNone, game.EndgameDeepCage},
-		web.arena.RedRealtimeScore.CurrentScore.EndgameStatuses,
+		[3]reefscape.EndgameStatus{reefscape.EndgameShallowCage, reefscape.EndgameNone, reefscape.EndgameDeepCage},
+		reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).EndgameStatuses,
 	)
 	assert.Equal(
 		t,
-		[3]game.EndgameStatus{game.EndgameNone, game.EndgameDeepCage, game.EndgameParked},
-		web.arena.BlueRealtimeScore.CurrentScore.EndgameStatuses,
+		[3]reefscape.EndgameStatus{reefscape.EndgameNone, reefscape.EndgameDeepCage, reefscape.EndgameParked},
+		reefscape.ScoreDetails(&web.arena.BlueRealtimeScore.CurrentScore).EndgameStatuses,
 	)
 
 	// Test that some invalid commands do nothing and don't result in score change notifications.
@@ -345,3 +358,179 @@ func TestScoringPanelWebsocket(t *testing.T) {
 	assert.Equal(t, 0, web.arena.ScoringPanelRegistry.GetNumScoreCommitted("red_near"))
 	assert.Equal(t, 0, web.arena.ScoringPanelRegistry.GetNumScoreCommitted("blue_near"))
 }
+
+func TestScoringPanelWebsocketQueuedOperations(t *testing.T) {
+	web := setupTestWeb(t)
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/panels/scoring/red_near/websocket", nil)
+	assert.Nil(t, err)
+	ws := websocket.NewTestWebsocket(conn)
+	readWebsocketMultiple(t, ws, 4)
+	web.arena.MatchState = field.AutoPeriod
+
+	scored := true
+	reefData := struct {
+		ClientId     string
+		Seq          int
+		MatchId      int
+		LoadId       int
+		ReefPosition int
+		ReefLevel    int
+		Current      bool
+		Autonomous   bool
+		Scored       *bool
+	}{
+		ClientId:     "tablet1",
+		Seq:          1,
+		LoadId:       web.arena.MatchLoadId,
+		ReefPosition: 5,
+		ReefLevel:    3,
+		Current:      true,
+		Scored:       &scored,
+	}
+	ws.Write("reef", reefData)
+	assert.Equal(t, 1.0, readWebsocketType(t, ws, "operationAck"))
+	readWebsocketType(t, ws, "realtimeScore")
+	assert.True(t, reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).Reef.Branches[reefscape.Level3][4])
+
+	// Simulate the panel reconnecting and replaying its whole queue, including the already-applied operation.
+	conn.Close()
+	conn, _, err = gorillawebsocket.DefaultDialer.Dial(wsUrl+"/panels/scoring/red_near/websocket", nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws = websocket.NewTestWebsocket(conn)
+	readWebsocketMultiple(t, ws, 4)
+	ws.Write("reef", reefData)
+	counterData := struct {
+		ClientId   string
+		Seq        int
+		MatchId    int
+		LoadId     int
+		Adjustment int
+	}{ClientId: "tablet1", Seq: 2, LoadId: web.arena.MatchLoadId, Adjustment: 1}
+	ws.Write("barge", counterData)
+	assert.Equal(t, 1.0, readWebsocketType(t, ws, "operationAck"))
+	assert.Equal(t, 2.0, readWebsocketType(t, ws, "operationAck"))
+	readWebsocketType(t, ws, "realtimeScore")
+	assert.True(t, reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).Reef.Branches[reefscape.Level3][4])
+	assert.Equal(t, 1, reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).BargeAlgae)
+
+	// Check that another panel setting the same branch doesn't undo it, and that its own counts are additive.
+	reefData.ClientId = "tablet2"
+	ws.Write("reef", reefData)
+	counterData.ClientId = "tablet2"
+	ws.Write("barge", counterData)
+	messages := readWebsocketMultiple(t, ws, 4)
+	assert.Equal(t, 2.0, messages["operationAck"])
+	assert.Contains(t, messages, "realtimeScore")
+	assert.True(t, reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).Reef.Branches[reefscape.Level3][4])
+	assert.Equal(t, 2, reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).BargeAlgae)
+
+	// Check that operations queued during a different match are discarded.
+	counterData.Seq = 3
+	counterData.MatchId = 254
+	ws.Write("barge", counterData)
+	assert.Equal(t, 3.0, readWebsocketType(t, ws, "staleOperation"))
+	assert.Equal(t, 3.0, readWebsocketType(t, ws, "operationAck"))
+	time.Sleep(time.Millisecond * 10) // Allow some time for the command to be processed.
+	assert.Equal(t, 2, reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).BargeAlgae)
+
+	// Check that operations queued during an earlier load of the same match are discarded after it is reloaded.
+	counterData.MatchId = web.arena.CurrentMatch.Id
+	web.arena.MatchState = field.PostMatch
+	assert.Nil(t, web.arena.ResetMatch())
+	assert.Nil(t, web.arena.LoadMatch(web.arena.CurrentMatch))
+	readWebsocketMultiple(t, ws, 2)
+	web.arena.MatchState = field.AutoPeriod
+	counterData.Seq = 4
+	ws.Write("barge", counterData)
+	assert.Equal(t, 4.0, readWebsocketType(t, ws, "staleOperation"))
+	assert.Equal(t, 4.0, readWebsocketType(t, ws, "operationAck"))
+	time.Sleep(time.Millisecond * 10) // Allow some time for the command to be processed.
+	assert.Equal(t, 0, reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).BargeAlgae)
+}
+
+func TestScoringPanelWebsocketDualEntry(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.DualEntryScoringEnabled = true
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn1, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/panels/scoring/red_near/websocket", nil)
+	assert.Nil(t, err)
+	defer conn1.Close()
+	ws1 := websocket.NewTestWebsocket(conn1)
+	readWebsocketMultiple(t, ws1, 4)
+	conn2, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/panels/scoring/red_near/websocket", nil)
+	assert.Nil(t, err)
+	defer conn2.Close()
+	ws2 := websocket.NewTestWebsocket(conn2)
+	readWebsocketMultiple(t, ws2, 4)
+	refereeConn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/panels/referee/websocket", nil)
+	assert.Nil(t, err)
+	defer refereeConn.Close()
+	refereeWs := websocket.NewTestWebsocket(refereeConn)
+	readWebsocketMultiple(t, refereeWs, 4)
+
+	// Check that only the primary scorer's input affects the realtime score.
+	web.arena.MatchState = field.AutoPeriod
+	counterData := struct {
+		ClientId   string
+		Seq        int
+		LoadId     int
+		Adjustment int
+	}{ClientId: "tablet1", Seq: 1, LoadId: web.arena.MatchLoadId, Adjustment: 1}
+	ws1.Write("barge", counterData)
+	readWebsocketType(t, ws1, "operationAck")
+	readWebsocketType(t, ws1, "realtimeScore")
+	time.Sleep(time.Millisecond * 10) // Allow some time for the command to be processed.
+	counterData.ClientId = "tablet2"
+	ws2.Write("barge", counterData)
+	counterData.Seq = 2
+	ws2.Write("barge", counterData)
+	time.Sleep(time.Millisecond * 10) // Allow some time for the commands to be processed.
+	assert.Equal(t, 1, reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).BargeAlgae)
+	assert.Equal(
+		t,
+		[]field.ScorerDiscrepancy{{Scorer: 2, Key: "bargeAlgae", Description: "Barge algae: 1 vs. 2"}},
+		web.arena.ScoringPanelRegistry.GetScorerDiscrepancies("red_near"),
+	)
+
+	// Check that fouls from the other scorer are tracked but not added to the realtime score.
+	foulData := struct {
+		ClientId string
+		Seq      int
+		LoadId   int
+		Alliance string
+		IsMajor  bool
+	}{"tablet2", 3, web.arena.MatchLoadId, "blue", true}
+	ws2.Write("addFoul", foulData)
+	time.Sleep(time.Millisecond * 10) // Allow some time for the command to be processed.
+	assert.Empty(t, web.arena.BlueRealtimeScore.CurrentScore.Fouls)
+	assert.Equal(t, 2, len(web.arena.ScoringPanelRegistry.GetScorerDiscrepancies("red_near")))
+
+	// Check that the score can't be committed until the discrepancy is resolved by the head referee.
+	web.arena.MatchState = field.PostMatch
+	commitData := struct {
+		ClientId string
+		Seq      int
+		LoadId   int
+	}{"tablet1", 2, web.arena.MatchLoadId}
+	ws1.Write("commitMatch", commitData)
+	readWebsocketType(t, ws1, "operationAck")
+	assert.Contains(t, readWebsocketError(t, ws1), "Discrepancies between scorers must be resolved")
+	assert.Equal(t, 0, web.arena.ScoringPanelRegistry.GetNumScoreCommitted("red_near"))
+	refereeWs.Write("acceptScorerValue", map[string]any{"Position": "red_near", "Scorer": 2, "Key": "blueFouls"})
+	time.Sleep(time.Millisecond * 10) // Allow some time for the command to be processed.
+	assert.Equal(t, []game.Foul{{IsMajor: true}}, web.arena.BlueRealtimeScore.CurrentScore.Fouls)
+	refereeWs.Write("acceptScorerValue", map[string]any{"Position": "red_near", "Scorer": 1, "Key": ""})
+	time.Sleep(time.Millisecond * 10) // Allow some time for the command to be processed.
+	assert.Empty(t, web.arena.ScoringPanelRegistry.GetScorerDiscrepancies("red_near"))
+	commitData.Seq = 3
+	ws1.Write("commitMatch", commitData)
+	time.Sleep(time.Millisecond * 10) // Allow some time for the command to be processed.
+	assert.Equal(t, 1, web.arena.ScoringPanelRegistry.GetNumScoreCommitted("red_near"))
+	assert.Equal(t, 1, reefscape.ScoreDetails(&web.arena.RedRealtimeScore.CurrentScore).BargeAlgae)
+}
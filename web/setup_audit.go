@@ -0,0 +1,106 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Web routes for viewing the audit log of scoring and match control actions.
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var auditSources = []string{"scoring_panel", "referee_panel", "match_play", "match_review"}
+
+type auditEntryListItem struct {
+	model.AuditEntry
+	MatchName string
+}
+
+// Shows the audit log, narrowed down by the filters given in the query string.
+func (web *Web) auditGetHandler(w http.ResponseWriter, r *http.Request) {
+	filter := parseAuditEntryFilter(r)
+	auditEntries, err := web.arena.Database.GetAuditEntries(filter)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Build the list of matches that appear in the log so that they can be shown by name and used as a filter.
+	allAuditEntries, err := web.arena.Database.GetAuditEntries(model.AuditEntryFilter{})
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	matchNames, err := web.getAuditMatchNames(allAuditEntries)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	var matches []model.Match
+	for _, auditEntry := range allAuditEntries {
+		if !slices.ContainsFunc(matches, func(match model.Match) bool { return match.Id == auditEntry.MatchId }) {
+			matches = append(matches, model.Match{Id: auditEntry.MatchId, ShortName: matchNames[auditEntry.MatchId]})
+		}
+	}
+
+	// Show the most recent entries first.
+	auditEntryListItems := make([]auditEntryListItem, len(auditEntries))
+	for i, auditEntry := range auditEntries {
+		auditEntryListItems[len(auditEntries)-1-i] = auditEntryListItem{auditEntry, matchNames[auditEntry.MatchId]}
+	}
+
+	template, err := web.parseFiles("templates/setup_audit.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Filter       model.AuditEntryFilter
+		Sources      []string
+		Matches      []model.Match
+		AuditEntries []auditEntryListItem
+		QueryString  string
+	}{web.arena.EventSettings, filter, auditSources, matches, auditEntryListItems, r.URL.RawQuery}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Returns a map of match IDs to display names for all the matches referenced by the given audit entries.
+func (web *Web) getAuditMatchNames(auditEntries []model.AuditEntry) (map[int]string, error) {
+	matchNames := make(map[int]string)
+	for _, auditEntry := range auditEntries {
+		if _, ok := matchNames[auditEntry.MatchId]; ok {
+			continue
+		}
+		match, err := web.arena.Database.GetMatchById(auditEntry.MatchId)
+		if err != nil {
+			return nil, err
+		}
+		if match != nil {
+			matchNames[auditEntry.MatchId] = match.ShortName
+		} else if auditEntry.MatchId == 0 {
+			matchNames[auditEntry.MatchId] = "Test"
+		} else {
+			matchNames[auditEntry.MatchId] = strconv.Itoa(auditEntry.MatchId)
+		}
+	}
+	return matchNames, nil
+}
+
+// Constructs an audit log filter from the parameters in the request's query string.
+func parseAuditEntryFilter(r *http.Request) model.AuditEntryFilter {
+	matchId, _ := strconv.Atoi(r.URL.Query().Get("matchId"))
+	return model.AuditEntryFilter{
+		MatchId:  matchId,
+		Source:   r.URL.Query().Get("source"),
+		Username: strings.TrimSpace(r.URL.Query().Get("username")),
+		Command:  strings.TrimSpace(r.URL.Query().Get("command")),
+	}
+}
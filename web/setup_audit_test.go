@@ -0,0 +1,133 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/websocket"
+	gorillawebsocket "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDiffAuditSnapshots(t *testing.T) {
+	before := map[string]any{"A": 1.0, "B": map[string]any{"C": true, "D": "x"}, "E": []any{1.0, 2.0}, "F": 5.0}
+	after := map[string]any{"A": 1.0, "B": map[string]any{"C": false, "D": "x"}, "E": []any{1.0, 3.0}, "G": 6.0}
+	beforeDiff, afterDiff := diffAuditSnapshots(before, after)
+	assert.Equal(t, map[string]any{"B": map[string]any{"C": true}, "E": []any{1.0, 2.0}, "F": 5.0}, beforeDiff)
+	assert.Equal(t, map[string]any{"B": map[string]any{"C": false}, "E": []any{1.0, 3.0}, "G": 6.0}, afterDiff)
+
+	beforeDiff, afterDiff = diffAuditSnapshots(before, before)
+	assert.Empty(t, beforeDiff)
+	assert.Empty(t, afterDiff)
+}
+
+func TestAuditScoringPanel(t *testing.T) {
+	web := setupTestWeb(t)
+	match := model.Match{Type: model.Qualification, ShortName: "Q7", Red1: 1001, Blue1: 1004}
+	assert.Nil(t, web.arena.Database.CreateMatch(&match))
+	assert.Nil(t, web.arena.LoadMatch(&match))
+	assert.Nil(t, web.arena.Database.CreateUserSession(&model.UserSession{Token: "abcd", Username: "scorer1"}))
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(
+		wsUrl+"/panels/scoring/red_near/websocket", http.Header{"Cookie": {"session_token=abcd"}},
+	)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws := websocket.NewTestWebsocket(conn)
+	readWebsocketMultiple(t, ws, 4)
+
+	ws.Write("barge", map[string]any{"Adjustment": 2})
+	readWebsocketType(t, ws, "realtimeScore")
+	time.Sleep(time.Millisecond * 10) // Allow some time for the entry to be recorded.
+
+	auditEntries, err := web.arena.Database.GetAuditEntries(model.AuditEntryFilter{})
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(auditEntries)) {
+		assert.Equal(t, "scorer1", auditEntries[0].Username)
+		assert.Equal(t, "scoring_panel", auditEntries[0].Source)
+		assert.Equal(t, "red_near", auditEntries[0].Position)
+		assert.Equal(t, match.Id, auditEntries[0].MatchId)
+		assert.Equal(t, "barge", auditEntries[0].Command)
+		assert.Equal(t, "{\"Red\":{\"CurrentScore\":{\"BargeAlgae\":0}}}", auditEntries[0].Before)
+		assert.Equal(t, "{\"Red\":{\"CurrentScore\":{\"BargeAlgae\":2}}}", auditEntries[0].After)
+	}
+
+	recorder := web.getHttpResponse("/setup/audit")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "scorer1")
+	assert.Contains(t, recorder.Body.String(), "{&#34;Red&#34;:{&#34;CurrentScore&#34;:{&#34;BargeAlgae&#34;:2}}}")
+	assert.Contains(t, recorder.Body.String(), "<td>Q7</td>")
+	recorder = web.getHttpResponse("/setup/audit?source=referee_panel")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No matching audit entries.")
+
+	recorder = web.getHttpResponse(fmt.Sprintf("/reports/csv/audit?matchId=%d", match.Id))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "text/plain", recorder.Header()["Content-Type"][0])
+	assert.Contains(t, recorder.Body.String(), "Time,User,Source,Position,Match,Command,Before,After\n")
+	assert.Contains(
+		t,
+		recorder.Body.String(),
+		",scorer1,scoring_panel,red_near,Q7,barge,\"{\"\"Red\"\":{\"\"CurrentScore\"\":{\"\"BargeAlgae\"\":0}}}\","+
+			"\"{\"\"Red\"\":{\"\"CurrentScore\"\":{\"\"BargeAlgae\"\":2}}}\"\n",
+	)
+	recorder = web.getHttpResponse("/reports/csv/audit?username=blorpy")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "Time,User,Source,Position,Match,Command,Before,After\n", recorder.Body.String())
+}
+
+func TestAuditMatchReviewEdit(t *testing.T) {
+	web := setupTestWeb(t)
+	match := model.Match{Type: model.Qualification, ShortName: "Q7", Red1: 1001, Blue1: 1004}
+	assert.Nil(t, web.arena.Database.CreateMatch(&match))
+	assert.Nil(t, web.arena.LoadMatch(&match))
+
+	postBody := fmt.Sprintf(
+		"matchResultJson={\"MatchId\":%d,\"RedScore\":{},\"BlueScore\":{\"BargeAlgae\":4}}", match.Id,
+	)
+	recorder := web.postHttpResponse("/match_review/current/edit", postBody)
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+
+	auditEntries, err := web.arena.Database.GetAuditEntries(model.AuditEntryFilter{Source: "match_review"})
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(auditEntries)) {
+		assert.Equal(t, "", auditEntries[0].Username)
+		assert.Equal(t, match.Id, auditEntries[0].MatchId)
+		assert.Equal(t, "editResult", auditEntries[0].Command)
+		assert.Contains(t, auditEntries[0].Before, "\"BargeAlgae\":0")
+		assert.Contains(t, auditEntries[0].After, "\"BargeAlgae\":4")
+	}
+}
+
+func TestAuditMatchPlay(t *testing.T) {
+	web := setupTestWeb(t)
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/match_play/websocket", nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws := websocket.NewTestWebsocket(conn)
+	readWebsocketMultiple(t, ws, 11)
+
+	ws.Write("toggleBypass", "R2")
+	readWebsocketType(t, ws, "arenaStatus")
+	ws.Write("toggleBypass", "X9")
+	readWebsocketError(t, ws)
+	time.Sleep(time.Millisecond * 10) // Allow some time for the entry to be recorded.
+
+	auditEntries, err := web.arena.Database.GetAuditEntries(model.AuditEntryFilter{})
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(auditEntries)) {
+		assert.Equal(t, "match_play", auditEntries[0].Source)
+		assert.Equal(t, "toggleBypass", auditEntries[0].Command)
+		assert.Equal(t, "{\"Bypass\":{\"R2\":false}}", auditEntries[0].Before)
+		assert.Equal(t, "{\"Bypass\":{\"R2\":true}}", auditEntries[0].After)
+	}
+}
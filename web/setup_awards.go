@@ -14,10 +14,6 @@ import (
 
 // Shows the awards configuration page.
 func (web *Web) awardsGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	template, err := web.parseFiles("templates/setup_awards.html", "templates/base.html")
 	if err != nil {
 		handleWebErr(w, err)
@@ -51,10 +47,6 @@ func (web *Web) awardsGetHandler(w http.ResponseWriter, r *http.Request) {
 
 // Saves the new or modified awards to the database.
 func (web *Web) awardsPostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	awardId, _ := strconv.Atoi(r.PostFormValue("id"))
 	if r.PostFormValue("action") == "delete" {
 		if err := tournament.DeleteAward(web.arena.Database, awardId); err != nil {
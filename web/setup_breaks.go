@@ -13,10 +13,6 @@ import (
 
 // Shows the breaks configuration page.
 func (web *Web) breaksGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	template, err := web.parseFiles("templates/setup_breaks.html", "templates/base.html")
 	if err != nil {
 		handleWebErr(w, err)
@@ -40,10 +36,6 @@ func (web *Web) breaksGetHandler(w http.ResponseWriter, r *http.Request) {
 
 // Saves the modified breaks to the database.
 func (web *Web) breaksPostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	scheduledBreakId, _ := strconv.Atoi(r.PostFormValue("id"))
 	scheduledBreak, err := web.arena.Database.GetScheduledBreakById(scheduledBreakId)
 	if err != nil {
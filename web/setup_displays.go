@@ -18,10 +18,6 @@ import (
 
 // Shows the displays configuration page.
 func (web *Web) displaysGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	template, err := web.parseFiles("templates/setup_displays.html", "templates/base.html")
 	if err != nil {
 		handleWebErr(w, err)
@@ -40,10 +36,6 @@ func (web *Web) displaysGetHandler(w http.ResponseWriter, r *http.Request) {
 
 // The websocket endpoint for the display configuration page to send control commands and receive status updates.
 func (web *Web) displaysWebsocketHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	ws, err := websocket.NewWebsocket(w, r)
 	if err != nil {
 		handleWebErr(w, err)
@@ -9,31 +9,49 @@ import (
 	"fmt"
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/plc"
 	"github.com/Team254/cheesy-arena/websocket"
+	"github.com/mitchellh/mapstructure"
 	"io"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
 )
 
 // Shows the Field Testing page.
 func (web *Web) fieldTestingGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	template, err := web.parseFiles("templates/setup_field_testing.html", "templates/base.html")
 	if err != nil {
 		handleWebErr(w, err)
 		return
 	}
-	plc := web.arena.Plc
+	fieldPlc := web.arena.Plc
+	_, isPlcSimulated := fieldPlc.(*plc.SimulatedPlc)
+	ioMap := fieldPlc.GetIoMap()
 	data := struct {
 		*model.EventSettings
-		MatchSounds   []*game.MatchSound
-		InputNames    []string
-		RegisterNames []string
-		CoilNames     []string
-	}{web.arena.EventSettings, game.MatchSounds, plc.GetInputNames(), plc.GetRegisterNames(), plc.GetCoilNames()}
+		MatchSounds       []*game.MatchSound
+		InputNames        []string
+		RegisterNames     []string
+		CoilNames         []string
+		InputAddresses    []string
+		RegisterAddresses []string
+		CoilAddresses     []string
+		ArmorBlocks       map[string]int
+		IsPlcSimulated    bool
+	}{
+		web.arena.EventSettings,
+		game.MatchSounds,
+		fieldPlc.GetInputNames(),
+		fieldPlc.GetRegisterNames(),
+		fieldPlc.GetCoilNames(),
+		plcAddressLabels(fieldPlc.GetInputNames(), ioMap.Inputs, ioMap.CustomInputs),
+		plcAddressLabels(fieldPlc.GetRegisterNames(), ioMap.Registers, ioMap.CustomRegisters),
+		plcAddressLabels(fieldPlc.GetCoilNames(), ioMap.Coils, ioMap.CustomCoils),
+		ioMap.ArmorBlocks,
+		isPlcSimulated,
+	}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
@@ -41,12 +59,23 @@ func (web *Web) fieldTestingGetHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// The websocket endpoint for sending realtime updates to the Field Testing page.
-func (web *Web) fieldTestingWebsocketHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
+// Returns the Modbus address that each of the given built-in or custom PLC signals is mapped to, for display.
+func plcAddressLabels(names []string, addresses, customAddresses map[string]int) []string {
+	labels := make([]string, len(names))
+	for i, name := range names {
+		if address, ok := addresses[name]; ok {
+			labels[i] = strconv.Itoa(address)
+		} else if address, ok = customAddresses[name]; ok {
+			labels[i] = strconv.Itoa(address)
+		} else {
+			labels[i] = "unmapped"
+		}
 	}
+	return labels
+}
 
+// The websocket endpoint for sending realtime updates to the Field Testing page.
+func (web *Web) fieldTestingWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 	ws, err := websocket.NewWebsocket(w, r)
 	if err != nil {
 		handleWebErr(w, err)
@@ -77,6 +106,48 @@ func (web *Web) fieldTestingWebsocketHandler(w http.ResponseWriter, r *http.Requ
 				continue
 			}
 			web.arena.PlaySoundNotifier.NotifyWithMessage(sound)
+		case "setPlcInput":
+			args := struct {
+				Index int
+				Value bool
+			}{}
+			err = mapstructure.Decode(data, &args)
+			if err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+			simulatedPlc, ok := web.arena.Plc.(*plc.SimulatedPlc)
+			if !ok {
+				ws.WriteError("PLC inputs can only be set when the simulated PLC is in use.")
+				continue
+			}
+			if err = simulatedPlc.SetInput(args.Index, args.Value); err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+		case "setPlcRegister":
+			args := struct {
+				Index int
+				Value int
+			}{}
+			err = mapstructure.Decode(data, &args)
+			if err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+			simulatedPlc, ok := web.arena.Plc.(*plc.SimulatedPlc)
+			if !ok {
+				ws.WriteError("PLC registers can only be set when the simulated PLC is in use.")
+				continue
+			}
+			if args.Value < 0 || args.Value > math.MaxUint16 {
+				ws.WriteError(fmt.Sprintf("Invalid PLC register value %d.", args.Value))
+				continue
+			}
+			if err = simulatedPlc.SetRegister(args.Index, uint16(args.Value)); err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
 		default:
 			ws.WriteError(fmt.Sprintf("Invalid message type '%s'.", messageType))
 			continue
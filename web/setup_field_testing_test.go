@@ -9,6 +9,7 @@ import (
 	gorillawebsocket "github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestSetupFieldTesting(t *testing.T) {
@@ -44,3 +45,41 @@ func TestSetupFieldTestingWebsocket(t *testing.T) {
 	ws.Write("playSound", "resume")
 	assert.Equal(t, "resume", readWebsocketType(t, audienceWs, "playSound"))
 }
+
+func TestSetupFieldTestingSimulatedPlc(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/field_testing")
+	assert.NotContains(t, recorder.Body.String(), "togglePlcInput")
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/setup/field_testing/websocket", nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws := websocket.NewTestWebsocket(conn)
+	readWebsocketType(t, ws, "plcIoChange")
+
+	// Inputs can't be set on the real PLC.
+	ws.Write("setPlcInput", map[string]any{"index": 0, "value": false})
+	assert.Contains(t, readWebsocketError(t, ws), "only be set when the simulated PLC is in use")
+
+	web.arena.EventSettings.PlcSimulated = true
+	assert.Nil(t, web.arena.Database.UpdateEventSettings(web.arena.EventSettings))
+	assert.Nil(t, web.arena.LoadSettings())
+	recorder = web.getHttpResponse("/setup/field_testing")
+	assert.Contains(t, recorder.Body.String(), "PLC (Simulated)")
+	assert.Contains(t, recorder.Body.String(), "togglePlcInput")
+
+	assert.False(t, web.arena.Plc.GetFieldEStop())
+	ws.Write("setPlcInput", map[string]any{"index": 0, "value": false})
+	ws.Write("setPlcRegister", map[string]any{"index": 1, "value": 4})
+	time.Sleep(time.Millisecond * 10) // Allow some time for the commands to be processed.
+	assert.True(t, web.arena.Plc.GetFieldEStop())
+	assert.Equal(t, 4, web.arena.Plc.GetRegister("redProcessor"))
+
+	ws.Write("setPlcInput", map[string]any{"index": 100, "value": false})
+	assert.Contains(t, readWebsocketError(t, ws), "invalid PLC input index")
+	ws.Write("setPlcRegister", map[string]any{"index": 1, "value": 70000})
+	assert.Contains(t, readWebsocketError(t, ws), "Invalid PLC register value")
+}
@@ -23,19 +23,11 @@ var judgingScheduleParams = tournament.JudgingScheduleParams{
 
 // Shows the judging schedule setup page.
 func (web *Web) judgingGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	web.renderJudging(w, r, "")
 }
 
 // Generates a judging schedule based on the parameters and saves it to the database.
 func (web *Web) judgingGeneratePostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	numJudges, err := strconv.Atoi(r.PostFormValue("numJudges"))
 	if err != nil || numJudges <= 0 {
 		web.renderJudging(w, r, "Number of judges must be a positive integer.")
@@ -90,10 +82,6 @@ func (web *Web) judgingGeneratePostHandler(w http.ResponseWriter, r *http.Reques
 
 // Clears the judging schedule.
 func (web *Web) judgingClearPostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	if err := web.arena.Database.TruncateJudgingSlots(); err != nil {
 		handleWebErr(w, err)
 		return
@@ -17,10 +17,6 @@ import (
 
 // Shows the lower third configuration page.
 func (web *Web) lowerThirdsGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	template, err := web.parseFiles(
 		"templates/setup_lower_thirds.html", "templates/audience_display_radio_buttons.html", "templates/base.html",
 	)
@@ -46,10 +42,6 @@ func (web *Web) lowerThirdsGetHandler(w http.ResponseWriter, r *http.Request) {
 
 // The websocket endpoint for the lower thirds client to send control commands.
 func (web *Web) lowerThirdsWebsocketHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	ws, err := websocket.NewWebsocket(w, r)
 	if err != nil {
 		handleWebErr(w, err)
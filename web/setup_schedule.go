@@ -14,16 +14,17 @@ import (
 	"time"
 )
 
-// Global vars to hold schedules that are in the process of being generated.
-var cachedMatches = make(map[model.MatchType][]model.Match)
-var cachedTeamFirstMatches = make(map[model.MatchType]map[int]string)
+// Format of the datetime-local inputs used for team availability windows.
+const availabilityTimeFormat = "2006-01-02T15:04"
+
+// Number of schedules to generate, in addition to any pre-built template, for comparison before saving.
+const numGeneratedScheduleCandidates = 2
+
+// Global var to hold schedules that are in the process of being generated.
+var cachedScheduleCandidates = make(map[model.MatchType][]tournament.ScheduleCandidate)
 
 // Shows the schedule editing page.
 func (web *Web) scheduleGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	matchTypeString := getMatchType(r)
 	matchType, _ := model.MatchTypeFromString(matchTypeString)
 	if matchType != model.Practice && matchType != model.Qualification {
@@ -36,10 +37,6 @@ func (web *Web) scheduleGetHandler(w http.ResponseWriter, r *http.Request) {
 
 // Generates the schedule, presents it for review without saving it, and saves the schedule blocks to the database.
 func (web *Web) scheduleGeneratePostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	matchTypeString := getMatchType(r)
 	matchType, err := model.MatchTypeFromString(matchTypeString)
 	if err != nil {
@@ -67,6 +64,14 @@ func (web *Web) scheduleGeneratePostHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// Save the minimum gap between matches so that it persists for the next time the schedule is generated.
+	minMatchGap, _ := strconv.Atoi(r.PostFormValue("minMatchGap"))
+	web.arena.EventSettings.ScheduleMinMatchGap = max(0, minMatchGap)
+	if err = web.arena.Database.UpdateEventSettings(web.arena.EventSettings); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
 	// Build the schedule.
 	teams, err := web.arena.Database.GetAllTeams()
 	if err != nil {
@@ -90,40 +95,69 @@ func (web *Web) scheduleGeneratePostHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	matches, err := tournament.BuildRandomSchedule(teams, scheduleBlocks, matchType)
+	constraints, err := web.getScheduleConstraints()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	candidates, err := tournament.BuildScheduleCandidates(
+		teams, scheduleBlocks, matchType, constraints, numGeneratedScheduleCandidates,
+	)
 	if err != nil {
 		web.renderSchedule(w, r, fmt.Sprintf("Error generating schedule: %s.", err.Error()))
 		return
 	}
-	cachedMatches[matchType] = matches
+	cachedScheduleCandidates[matchType] = candidates
 
-	// Determine each team's first match.
-	teamFirstMatches := make(map[int]string)
-	for _, match := range matches {
-		checkTeam := func(team int) {
-			_, ok := teamFirstMatches[team]
-			if !ok {
-				teamFirstMatches[team] = match.ShortName
-			}
+	http.Redirect(w, r, "/setup/schedule?matchType="+matchTypeString, 303)
+}
+
+// Downloads the official qualification schedule from the FRC Events API and presents it for review as the only
+// candidate, to be saved in the same way as a generated one.
+func (web *Web) scheduleImportPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.arena.EventSettings.FrcEventsEnabled {
+		web.renderSchedule(w, r, "FRC Events API import is not enabled. Enable it on the Settings page first.")
+		return
+	}
+
+	matches, err := web.arena.FrcEventsClient.GetQualificationMatches()
+	if err != nil {
+		web.renderSchedule(w, r, fmt.Sprintf("Error importing official schedule: %s.", err.Error()))
+		return
+	}
+
+	// Check that every team in the official schedule is in the local team list.
+	var missingTeams []int
+	for _, teamId := range getScheduleTeamIds(matches) {
+		team, err := web.arena.Database.GetTeamById(teamId)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if team == nil {
+			missingTeams = append(missingTeams, teamId)
 		}
-		checkTeam(match.Red1)
-		checkTeam(match.Red2)
-		checkTeam(match.Red3)
-		checkTeam(match.Blue1)
-		checkTeam(match.Blue2)
-		checkTeam(match.Blue3)
 	}
-	cachedTeamFirstMatches[matchType] = teamFirstMatches
+	if len(missingTeams) > 0 {
+		web.renderSchedule(
+			w,
+			r,
+			fmt.Sprintf(
+				"Can't import official schedule because it includes teams that aren't in the team list: %v.",
+				missingTeams,
+			),
+		)
+		return
+	}
 
-	http.Redirect(w, r, "/setup/schedule?matchType="+matchTypeString, 303)
+	cachedScheduleCandidates[model.Qualification] = []tournament.ScheduleCandidate{
+		{Source: "FRC Events", Matches: matches, Quality: tournament.CalculateScheduleQuality(matches)},
+	}
+	http.Redirect(w, r, "/setup/schedule?matchType=qualification", 303)
 }
 
 // Saves the generated schedule to the database.
 func (web *Web) scheduleSavePostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	matchTypeString := getMatchType(r)
 	matchType, err := model.MatchTypeFromString(matchTypeString)
 	if err != nil {
@@ -150,7 +184,33 @@ func (web *Web) scheduleSavePostHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	for _, match := range cachedMatches[matchType] {
+	candidateIndex := getScheduleCandidateIndex(r, matchType)
+	if candidateIndex < 0 {
+		web.renderSchedule(w, r, "No generated schedule to save. Generate one first.")
+		return
+	}
+	matches := cachedScheduleCandidates[matchType][candidateIndex].Matches
+
+	constraints, err := web.getScheduleConstraints()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	violations := tournament.CheckScheduleConstraints(matches, constraints)
+	if len(violations) > 0 && r.PostFormValue("ignoreViolations") != "true" {
+		web.renderSchedule(
+			w,
+			r,
+			fmt.Sprintf(
+				"Can't save schedule because it has %d constraint violations, listed below. Check the box to save it "+
+					"anyway or generate a new schedule.",
+				len(violations),
+			),
+		)
+		return
+	}
+
+	for _, match := range matches {
 		err = web.arena.Database.CreateMatch(&match)
 		if err != nil {
 			handleWebErr(w, err)
@@ -168,6 +228,69 @@ func (web *Web) scheduleSavePostHandler(w http.ResponseWriter, r *http.Request)
 	http.Redirect(w, r, "/setup/schedule?matchType="+matchTypeString, 303)
 }
 
+// Adds a window of time during which a team is available to play.
+func (web *Web) scheduleAvailabilityPostHandler(w http.ResponseWriter, r *http.Request) {
+	teamId, _ := strconv.Atoi(r.PostFormValue("teamId"))
+	team, err := web.arena.Database.GetTeamById(teamId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if team == nil {
+		web.renderSchedule(w, r, fmt.Sprintf("Team %d is not present at the event.", teamId))
+		return
+	}
+
+	availability := model.TeamAvailability{TeamId: teamId}
+	location, _ := time.LoadLocation("Local")
+	if startTime := r.PostFormValue("startTime"); startTime != "" {
+		if availability.StartTime, err = time.ParseInLocation(availabilityTimeFormat, startTime, location); err != nil {
+			web.renderSchedule(w, r, "Invalid availability start time specified.")
+			return
+		}
+	}
+	if endTime := r.PostFormValue("endTime"); endTime != "" {
+		if availability.EndTime, err = time.ParseInLocation(availabilityTimeFormat, endTime, location); err != nil {
+			web.renderSchedule(w, r, "Invalid availability end time specified.")
+			return
+		}
+	}
+	if availability.StartTime.IsZero() && availability.EndTime.IsZero() {
+		web.renderSchedule(w, r, "At least one of the availability start and end times must be specified.")
+		return
+	}
+	if !availability.StartTime.IsZero() && !availability.EndTime.IsZero() &&
+		availability.EndTime.Before(availability.StartTime) {
+		web.renderSchedule(w, r, "The availability end time must be after the start time.")
+		return
+	}
+
+	if err = web.arena.Database.CreateTeamAvailability(&availability); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	http.Redirect(w, r, "/setup/schedule?matchType="+getMatchType(r), 303)
+}
+
+// Deletes a team availability window.
+func (web *Web) scheduleAvailabilityDeletePostHandler(w http.ResponseWriter, r *http.Request) {
+	availabilityId, _ := strconv.Atoi(r.PathValue("id"))
+	availability, err := web.arena.Database.GetTeamAvailabilityById(availabilityId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if availability == nil {
+		web.renderSchedule(w, r, fmt.Sprintf("Availability window with ID %d does not exist.", availabilityId))
+		return
+	}
+	if err = web.arena.Database.DeleteTeamAvailability(availabilityId); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	http.Redirect(w, r, "/setup/schedule?matchType="+getMatchType(r), 303)
+}
+
 func (web *Web) renderSchedule(w http.ResponseWriter, r *http.Request, errorMessage string) {
 	matchTypeString := getMatchType(r)
 	matchType, err := model.MatchTypeFromString(matchTypeString)
@@ -192,21 +315,49 @@ func (web *Web) renderSchedule(w http.ResponseWriter, r *http.Request, errorMess
 		handleWebErr(w, err)
 		return
 	}
+	constraints, err := web.getScheduleConstraints()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Check the candidates against the current constraints, which may have changed since they were generated.
+	candidates := cachedScheduleCandidates[matchType]
+	candidateViolationCounts := make([]int, len(candidates))
+	for i, candidate := range candidates {
+		candidateViolationCounts[i] = len(tournament.CheckScheduleConstraints(candidate.Matches, constraints))
+	}
+	candidateIndex := getScheduleCandidateIndex(r, matchType)
+	var matches []model.Match
+	if candidateIndex >= 0 {
+		matches = candidates[candidateIndex].Matches
+	}
+
 	data := struct {
 		*model.EventSettings
-		MatchType        model.MatchType
-		ScheduleBlocks   []model.ScheduleBlock
-		NumTeams         int
-		Matches          []model.Match
-		TeamFirstMatches map[int]string
-		ErrorMessage     string
+		MatchType                model.MatchType
+		ScheduleBlocks           []model.ScheduleBlock
+		NumTeams                 int
+		TeamAvailabilities       []model.TeamAvailability
+		Candidates               []tournament.ScheduleCandidate
+		CandidateViolationCounts []int
+		CandidateIndex           int
+		Matches                  []model.Match
+		Violations               []string
+		TeamFirstMatches         map[int]string
+		ErrorMessage             string
 	}{
 		web.arena.EventSettings,
 		matchType,
 		scheduleBlocks,
 		len(teams),
-		cachedMatches[matchType],
-		cachedTeamFirstMatches[matchType],
+		constraints.TeamAvailabilities,
+		candidates,
+		candidateViolationCounts,
+		candidateIndex,
+		matches,
+		tournament.CheckScheduleConstraints(matches, constraints),
+		getTeamFirstMatches(matches),
 		errorMessage,
 	}
 	err = template.ExecuteTemplate(w, "base", data)
@@ -244,6 +395,66 @@ func getScheduleBlocks(r *http.Request) ([]model.ScheduleBlock, error) {
 	return scheduleBlocks, returnErr
 }
 
+// Returns the constraints that generated schedules should satisfy.
+func (web *Web) getScheduleConstraints() (tournament.ScheduleConstraints, error) {
+	teamAvailabilities, err := web.arena.Database.GetAllTeamAvailabilities()
+	if err != nil {
+		return tournament.ScheduleConstraints{}, err
+	}
+	return tournament.ScheduleConstraints{
+		MinMatchGap:        web.arena.EventSettings.ScheduleMinMatchGap,
+		TeamAvailabilities: teamAvailabilities,
+	}, nil
+}
+
+// Returns the index of the cached schedule candidate selected in the request, defaulting to the first one, or -1 if
+// there are none.
+func getScheduleCandidateIndex(r *http.Request, matchType model.MatchType) int {
+	if len(cachedScheduleCandidates[matchType]) == 0 {
+		return -1
+	}
+	candidateIndex, _ := strconv.Atoi(r.URL.Query().Get("candidate"))
+	if candidateIndex < 0 || candidateIndex >= len(cachedScheduleCandidates[matchType]) {
+		return 0
+	}
+	return candidateIndex
+}
+
+// Returns a map of each team to the short name of its first match in the given schedule.
+func getTeamFirstMatches(matches []model.Match) map[int]string {
+	teamFirstMatches := make(map[int]string)
+	for _, match := range matches {
+		checkTeam := func(team int) {
+			_, ok := teamFirstMatches[team]
+			if !ok {
+				teamFirstMatches[team] = match.ShortName
+			}
+		}
+		checkTeam(match.Red1)
+		checkTeam(match.Red2)
+		checkTeam(match.Red3)
+		checkTeam(match.Blue1)
+		checkTeam(match.Blue2)
+		checkTeam(match.Blue3)
+	}
+	return teamFirstMatches
+}
+
+// Returns the distinct team numbers appearing in the given schedule, in order of first appearance.
+func getScheduleTeamIds(matches []model.Match) []int {
+	var teamIds []int
+	seen := make(map[int]bool)
+	for _, match := range matches {
+		for _, teamId := range []int{match.Red1, match.Red2, match.Red3, match.Blue1, match.Blue2, match.Blue3} {
+			if teamId > 0 && !seen[teamId] {
+				seen[teamId] = true
+				teamIds = append(teamIds, teamId)
+			}
+		}
+	}
+	return teamIds
+}
+
 func getMatchType(r *http.Request) string {
 	if matchType, ok := r.URL.Query()["matchType"]; ok {
 		return matchType[0]
@@ -5,6 +5,7 @@ package web
 
 import (
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
@@ -33,13 +34,29 @@ func TestSetupSchedule(t *testing.T) {
 	assert.Contains(t, recorder.Body.String(), "2014-01-01 09:48:00") // Last match of first block.
 	assert.Contains(t, recorder.Body.String(), "2014-01-02 11:48:00") // Last match of second block.
 	assert.Contains(t, recorder.Body.String(), "2014-01-03 16:54:00") // Last match of third block.
+	assert.Contains(t, recorder.Body.String(), "Template")
+	assert.Contains(t, recorder.Body.String(), "Generated 1")
+	assert.Contains(t, recorder.Body.String(), "Generated 2")
+	assert.Contains(t, recorder.Body.String(), "&candidate=0")
+	candidates := cachedScheduleCandidates[model.Qualification]
+	if assert.Equal(t, 3, len(candidates)) {
+		assert.Equal(t, 0, candidates[1].Quality.BackToBackMatches)
+		assert.Equal(t, 4, candidates[1].Quality.NumSurrogates)
+	}
+
+	// View one of the generated candidates.
+	recorder = web.getHttpResponse("/setup/schedule?matchType=qualification&candidate=2")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "&candidate=2")
 
 	// Save schedule and check that it was persisted.
-	recorder = web.postHttpResponse("/setup/schedule/save?matchType=qualification", "")
+	recorder = web.postHttpResponse("/setup/schedule/save?matchType=qualification&candidate=2", "")
 	assert.Equal(t, 303, recorder.Code)
 	matches, err := web.arena.Database.GetMatchesByType(model.Qualification, true)
 	assert.Nil(t, err)
 	assert.Equal(t, 64, len(matches))
+	assert.Equal(t, candidates[2].Matches[10].Red1, matches[10].Red1)
+	assert.Equal(t, candidates[2].Matches[10].Blue3, matches[10].Blue3)
 	location, _ := time.LoadLocation("Local")
 	assert.Equal(t, time.Date(2014, 1, 1, 9, 0, 0, 0, location).Unix(), matches[0].Time.Unix())
 	assert.Equal(t, time.Date(2014, 1, 2, 9, 56, 0, 0, location).Unix(), matches[7].Time.Unix())
@@ -72,7 +89,9 @@ func TestSetupScheduleErrors(t *testing.T) {
 		"matchType=practice"
 	recorder = web.postHttpResponse("/setup/schedule/generate", postData)
 	assert.Equal(t, 200, recorder.Code)
-	assert.Contains(t, recorder.Body.String(), "No schedule template exists for 6 teams and 700 matches")
+	assert.Contains(
+		t, recorder.Body.String(), "Can't generate a schedule for 700 matches per team; must be between 1 and 20",
+	)
 
 	// Incomplete scheduling data received.
 	postData = "numScheduleBlocks=1&startTime0=2014-01-01 09:00:00 AM&numMatches0=&matchSpacingSec0=480&" +
@@ -95,3 +114,128 @@ func TestSetupScheduleErrors(t *testing.T) {
 	assert.Equal(t, 200, recorder.Code)
 	assert.Contains(t, recorder.Body.String(), "schedule of 2 Practice matches already exists")
 }
+
+func TestSetupScheduleConstraints(t *testing.T) {
+	web := setupTestWeb(t)
+
+	for i := 0; i < 18; i++ {
+		web.arena.Database.CreateTeam(&model.Team{Id: i + 101})
+	}
+
+	// Add and remove team availability windows.
+	recorder := web.postHttpResponse("/setup/schedule/availability?matchType=qualification", "teamId=254")
+	assert.Contains(t, recorder.Body.String(), "Team 254 is not present at the event.")
+	recorder = web.postHttpResponse("/setup/schedule/availability?matchType=qualification", "teamId=101")
+	assert.Contains(t, recorder.Body.String(), "At least one of the availability start and end times")
+	recorder = web.postHttpResponse(
+		"/setup/schedule/availability?matchType=qualification",
+		"teamId=101&startTime=2014-01-01T12:00&endTime=2014-01-01T09:00",
+	)
+	assert.Contains(t, recorder.Body.String(), "The availability end time must be after the start time.")
+	recorder = web.postHttpResponse(
+		"/setup/schedule/availability?matchType=qualification", "teamId=102&endTime=2014-01-01T11:00",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.postHttpResponse(
+		"/setup/schedule/availability?matchType=qualification", "teamId=101&startTime=2015-01-01T09:00",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	availabilities, _ := web.arena.Database.GetAllTeamAvailabilities()
+	if assert.Equal(t, 2, len(availabilities)) {
+		location, _ := time.LoadLocation("Local")
+		assert.Equal(t, 101, availabilities[0].TeamId)
+		assert.Equal(t, time.Date(2015, 1, 1, 9, 0, 0, 0, location).Unix(), availabilities[0].StartTime.Unix())
+		assert.True(t, availabilities[0].EndTime.IsZero())
+		assert.Equal(t, 102, availabilities[1].TeamId)
+	}
+	recorder = web.getHttpResponse("/setup/schedule?matchType=qualification")
+	assert.Contains(t, recorder.Body.String(), "/setup/schedule/availability/1/delete")
+
+	// Generate a schedule with a minimum match gap; team 101 is never available so it can't be satisfied.
+	postData := "numScheduleBlocks=1&startTime0=2014-01-01 09:00:00 AM&numMatches0=18&matchSpacingSec0=480&" +
+		"minMatchGap=2&matchType=qualification"
+	recorder = web.postHttpResponse("/setup/schedule/generate", postData)
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, 2, web.arena.EventSettings.ScheduleMinMatchGap)
+	recorder = web.getHttpResponse("/setup/schedule?matchType=qualification&candidate=1")
+	assert.Contains(t, recorder.Body.String(), "Team 101 is scheduled in")
+	assert.Contains(t, recorder.Body.String(), "ignoreViolations")
+
+	recorder = web.postHttpResponse("/setup/schedule/save?matchType=qualification&candidate=1", "")
+	assert.Contains(t, recorder.Body.String(), "Can't save schedule because it has")
+	matches, _ := web.arena.Database.GetMatchesByType(model.Qualification, true)
+	assert.Equal(t, 0, len(matches))
+	recorder = web.postHttpResponse(
+		"/setup/schedule/save?matchType=qualification&candidate=1", "ignoreViolations=true",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	matches, _ = web.arena.Database.GetMatchesByType(model.Qualification, true)
+	assert.Equal(t, 18, len(matches))
+
+	recorder = web.postHttpResponse("/setup/schedule/availability/1/delete?matchType=qualification", "")
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.postHttpResponse("/setup/schedule/availability/1/delete?matchType=qualification", "")
+	assert.Contains(t, recorder.Body.String(), "Availability window with ID 1 does not exist.")
+	availabilities, _ = web.arena.Database.GetAllTeamAvailabilities()
+	assert.Equal(t, 1, len(availabilities))
+}
+
+func TestSetupScheduleImportOfficial(t *testing.T) {
+	web := setupTestWeb(t)
+	mock := partner.NewMockFrcEventsServer(2025, "CASJ", "user", "key")
+	defer mock.Close()
+	web.arena.FrcEventsClient = partner.NewFrcEventsClient("2025casj", "user", "key")
+	web.arena.FrcEventsClient.BaseUrl = mock.URL()
+	for i := 0; i < 6; i++ {
+		web.arena.Database.CreateTeam(&model.Team{Id: i + 101})
+	}
+
+	recorder := web.getHttpResponse("/setup/schedule?matchType=qualification")
+	assert.NotContains(t, recorder.Body.String(), "Import Official Schedule")
+	recorder = web.postHttpResponse("/setup/schedule/import?matchType=qualification", "")
+	assert.Contains(t, recorder.Body.String(), "FRC Events API import is not enabled")
+
+	web.arena.EventSettings.FrcEventsEnabled = true
+	recorder = web.getHttpResponse("/setup/schedule?matchType=qualification")
+	assert.Contains(t, recorder.Body.String(), "Import Official Schedule")
+	recorder = web.postHttpResponse("/setup/schedule/import?matchType=qualification", "")
+	assert.Contains(t, recorder.Body.String(), "has not been published")
+
+	scheduledTeams := func(teamIds ...int) []partner.FrcEventsScheduledTeam {
+		stations := []string{"Red1", "Red2", "Red3", "Blue1", "Blue2", "Blue3"}
+		teams := make([]partner.FrcEventsScheduledTeam, len(teamIds))
+		for i, teamId := range teamIds {
+			teams[i] = partner.FrcEventsScheduledTeam{TeamNumber: teamId, Station: stations[i]}
+		}
+		return teams
+	}
+	mock.SetSchedule(
+		partner.FrcEventsQualification,
+		[]partner.FrcEventsScheduledMatch{
+			{MatchNumber: 1, StartTime: "2025-03-01T09:00:00", Teams: scheduledTeams(101, 102, 103, 104, 105, 106)},
+			{MatchNumber: 2, StartTime: "2025-03-01T09:07:00", Teams: scheduledTeams(106, 105, 104, 103, 102, 254)},
+		},
+	)
+	recorder = web.postHttpResponse("/setup/schedule/import?matchType=qualification", "")
+	assert.Contains(t, recorder.Body.String(), "includes teams that aren't in the team list: [254]")
+
+	web.arena.Database.CreateTeam(&model.Team{Id: 254})
+	recorder = web.postHttpResponse("/setup/schedule/import?matchType=qualification", "")
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.getHttpResponse("/setup/schedule?matchType=qualification")
+	assert.Contains(t, recorder.Body.String(), "FRC Events")
+	assert.Contains(t, recorder.Body.String(), "2025-03-01 09:07:00")
+
+	// Save the imported schedule and check that it was persisted as published.
+	recorder = web.postHttpResponse("/setup/schedule/save?matchType=qualification&candidate=0", "")
+	assert.Equal(t, 303, recorder.Code)
+	matches, err := web.arena.Database.GetMatchesByType(model.Qualification, true)
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(matches)) {
+		assert.Equal(t, "Q2", matches[1].ShortName)
+		assert.Equal(t, 106, matches[1].Red1)
+		assert.Equal(t, 254, matches[1].Blue3)
+		location, _ := time.LoadLocation("Local")
+		assert.Equal(t, time.Date(2025, 3, 1, 9, 7, 0, 0, location).Unix(), matches[1].Time.Unix())
+	}
+}
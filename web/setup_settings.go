@@ -15,24 +15,20 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/network"
+	"github.com/Team254/cheesy-arena/partner"
+	"github.com/Team254/cheesy-arena/plc"
 )
 
 // Shows the event settings editing page.
 func (web *Web) settingsGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	web.renderSettings(w, r, "")
 }
 
 // Saves the event settings.
 func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	eventSettings := web.arena.EventSettings
 
 	previousEventName := eventSettings.Name
@@ -68,6 +64,36 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	if r.PostFormValue("adminPassword") == "" && web.hasUserAccounts() {
+		web.renderSettings(w, r, "Cannot clear the admin password while user accounts exist.")
+		return
+	}
+	if _, err := plc.ParseIoMap(r.PostFormValue("plcIoMap")); err != nil {
+		web.renderSettings(w, r, err.Error())
+		return
+	}
+	switchType := r.PostFormValue("switchType")
+	if switchType != network.SshSwitchType {
+		switchType = network.CiscoSwitchType
+	}
+	if _, err := network.ParseSwitchTeamVlans(r.PostFormValue("switchTeamVlans")); err != nil {
+		web.renderSettings(w, r, fmt.Sprintf("Invalid switch team VLANs: %v.", err))
+		return
+	}
+	switchTeamGatewayAddress := network.DefaultSwitchTeamGatewayAddress
+	if r.PostFormValue("switchTeamGatewayAddress") != "" {
+		switchTeamGatewayAddress, _ = strconv.Atoi(r.PostFormValue("switchTeamGatewayAddress"))
+	}
+	if switchTeamGatewayAddress < 1 || switchTeamGatewayAddress > 254 {
+		web.renderSettings(w, r, "Switch team gateway address must be between 1 and 254.")
+		return
+	}
+	if switchType == network.SshSwitchType {
+		if _, err := network.ParseSwitchCommandTemplate(r.PostFormValue("switchCommandTemplate")); err != nil {
+			web.renderSettings(w, r, err.Error())
+			return
+		}
+	}
 	eventSettings.PlayoffType = playoffType
 
 	eventSettings.NumPlayoffAlliances = numAlliances
@@ -80,12 +106,22 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 	eventSettings.TbaSecretId = r.PostFormValue("tbaSecretId")
 	eventSettings.TbaSecret = r.PostFormValue("tbaSecret")
 	eventSettings.NexusEnabled = r.PostFormValue("nexusEnabled") == "on"
+	eventSettings.NexusStatusEnabled = r.PostFormValue("nexusStatusEnabled") == "on"
+	eventSettings.NexusApiKey = r.PostFormValue("nexusApiKey")
+	eventSettings.FrcEventsEnabled = r.PostFormValue("frcEventsEnabled") == "on"
+	eventSettings.FrcEventsUsername = r.PostFormValue("frcEventsUsername")
+	eventSettings.FrcEventsAuthKey = r.PostFormValue("frcEventsAuthKey")
 	eventSettings.NetworkSecurityEnabled = r.PostFormValue("networkSecurityEnabled") == "on"
 	eventSettings.ApAddress = r.PostFormValue("apAddress")
 	eventSettings.ApPassword = r.PostFormValue("apPassword")
 	eventSettings.ApChannel, _ = strconv.Atoi(r.PostFormValue("apChannel"))
+	eventSettings.SwitchType = switchType
 	eventSettings.SwitchAddress = r.PostFormValue("switchAddress")
+	eventSettings.SwitchUsername = r.PostFormValue("switchUsername")
 	eventSettings.SwitchPassword = r.PostFormValue("switchPassword")
+	eventSettings.SwitchTeamVlans = r.PostFormValue("switchTeamVlans")
+	eventSettings.SwitchTeamGatewayAddress = switchTeamGatewayAddress
+	eventSettings.SwitchCommandTemplate = r.PostFormValue("switchCommandTemplate")
 	eventSettings.SCCManagementEnabled = r.PostFormValue("sccManagementEnabled") == "on"
 	eventSettings.RedSCCAddress = r.PostFormValue("redSCCAddress")
 	eventSettings.BlueSCCAddress = r.PostFormValue("blueSCCAddress")
@@ -94,6 +130,11 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 	eventSettings.SCCUpCommands = r.PostFormValue("sccUpCommands")
 	eventSettings.SCCDownCommands = r.PostFormValue("sccDownCommands")
 	eventSettings.PlcAddress = r.PostFormValue("plcAddress")
+	eventSettings.PlcSimulated = r.PostFormValue("plcSimulated") == "on"
+	eventSettings.PlcIoMap = r.PostFormValue("plcIoMap")
+	eventSettings.AlarmCriticalPlc = r.PostFormValue("alarmCriticalPlc") == "on"
+	eventSettings.AlarmCriticalAccessPoint = r.PostFormValue("alarmCriticalAccessPoint") == "on"
+	eventSettings.AlarmCriticalSwitch = r.PostFormValue("alarmCriticalSwitch") == "on"
 	eventSettings.AdminPassword = r.PostFormValue("adminPassword")
 	eventSettings.TeamSignRed1Id, _ = strconv.Atoi(r.PostFormValue("teamSignRed1Id"))
 	eventSettings.TeamSignRed2Id, _ = strconv.Atoi(r.PostFormValue("teamSignRed2Id"))
@@ -110,10 +151,20 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 	eventSettings.PauseDurationSec, _ = strconv.Atoi(r.PostFormValue("pauseDurationSec"))
 	eventSettings.TeleopDurationSec, _ = strconv.Atoi(r.PostFormValue("teleopDurationSec"))
 	eventSettings.WarningRemainingDurationSec, _ = strconv.Atoi(r.PostFormValue("warningRemainingDurationSec"))
-	eventSettings.AutoBonusCoralThreshold, _ = strconv.Atoi(r.PostFormValue("autoBonusCoralThreshold"))
-	eventSettings.CoralBonusPerLevelThreshold, _ = strconv.Atoi(r.PostFormValue("coralBonusPerLevelThreshold"))
-	eventSettings.CoralBonusCoopEnabled = r.PostFormValue("coralBonusCoopEnabled") == "on"
-	eventSettings.BargeBonusPointThreshold, _ = strconv.Atoi(r.PostFormValue("bargeBonusPointThreshold"))
+	eventSettings.GameSettings = make(map[string]int)
+	for _, setting := range game.CurrentGame.Settings() {
+		formValue := r.PostFormValue("gameSetting_" + setting.Key)
+		if setting.IsBool {
+			if formValue == "on" {
+				eventSettings.GameSettings[setting.Key] = 1
+			} else {
+				eventSettings.GameSettings[setting.Key] = 0
+			}
+		} else {
+			eventSettings.GameSettings[setting.Key], _ = strconv.Atoi(formValue)
+		}
+	}
+	eventSettings.DualEntryScoringEnabled = r.PostFormValue("dualEntryScoringEnabled") == "on"
 
 	err := web.arena.Database.UpdateEventSettings(eventSettings)
 	if err != nil {
@@ -141,10 +192,6 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 
 // Sends a copy of the event database file to the client as a download.
 func (web *Web) saveDbHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	filename := fmt.Sprintf(
 		"%s-%s.db", strings.Replace(web.arena.EventSettings.Name, " ", "_", -1), time.Now().Format("20060102150405"),
 	)
@@ -158,10 +205,6 @@ func (web *Web) saveDbHandler(w http.ResponseWriter, r *http.Request) {
 
 // Accepts an event database file as an upload and loads it.
 func (web *Web) restoreDbHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	file, _, err := r.FormFile("databaseFile")
 	if err != nil {
 		web.renderSettings(w, r, "No database backup file was specified.")
@@ -227,10 +270,6 @@ func (web *Web) restoreDbHandler(w http.ResponseWriter, r *http.Request) {
 
 // Deletes all match data including and beyond the given tournament stage.
 func (web *Web) clearDbHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	matchType, err := model.MatchTypeFromString(r.PathValue("type"))
 	if err != nil || matchType == model.Test {
 		web.renderSettings(w, r, "Invalid tournament stage to clear.")
@@ -260,6 +299,10 @@ func (web *Web) clearDbHandler(w http.ResponseWriter, r *http.Request) {
 			handleWebErr(w, err)
 			return
 		}
+		if err = web.arena.RefreshBroadcastRankings(); err != nil {
+			handleWebErr(w, err)
+			return
+		}
 	case model.Playoff:
 		if err = web.deleteMatchDataForType(model.Playoff); err != nil {
 			handleWebErr(w, err)
@@ -276,16 +319,11 @@ func (web *Web) clearDbHandler(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/setup/settings", 303)
 }
 
-// Publishes the playoff alliances to the web.
+// Queues the playoff alliances to be published to the web.
 func (web *Web) settingsPublishAlliancesHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	if web.arena.EventSettings.TbaPublishingEnabled {
-		err := web.arena.TbaClient.PublishAlliances(web.arena.Database)
-		if err != nil {
-			http.Error(w, "Failed to publish alliances: "+err.Error(), 500)
+		if err := web.arena.TbaOutbox.Enqueue(partner.TbaPublishAlliances); err != nil {
+			handleWebErr(w, err)
 			return
 		}
 	} else {
@@ -296,16 +334,11 @@ func (web *Web) settingsPublishAlliancesHandler(w http.ResponseWriter, r *http.R
 	http.Redirect(w, r, "/setup/settings", 303)
 }
 
-// Publishes the awards to the web.
+// Queues the awards to be published to the web.
 func (web *Web) settingsPublishAwardsHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	if web.arena.EventSettings.TbaPublishingEnabled {
-		err := web.arena.TbaClient.PublishAwards(web.arena.Database)
-		if err != nil {
-			http.Error(w, "Failed to publish awards: "+err.Error(), 500)
+		if err := web.arena.TbaOutbox.Enqueue(partner.TbaPublishAwards); err != nil {
+			handleWebErr(w, err)
 			return
 		}
 	} else {
@@ -316,21 +349,15 @@ func (web *Web) settingsPublishAwardsHandler(w http.ResponseWriter, r *http.Requ
 	http.Redirect(w, r, "/setup/settings", 303)
 }
 
-// Publishes the match schedule and results to the web.
+// Queues the match schedule and results to be republished to the web.
 func (web *Web) settingsPublishMatchesHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	if web.arena.EventSettings.TbaPublishingEnabled {
-		err := web.arena.TbaClient.DeletePublishedMatches()
-		if err != nil {
-			http.Error(w, "Failed to delete published matches: "+err.Error(), 500)
+		if err := web.arena.TbaOutbox.Enqueue(partner.TbaDeletePublishedMatches); err != nil {
+			handleWebErr(w, err)
 			return
 		}
-		err = web.arena.TbaClient.PublishMatches(web.arena.Database)
-		if err != nil {
-			http.Error(w, "Failed to publish matches: "+err.Error(), 500)
+		if err := web.arena.TbaOutbox.Enqueue(partner.TbaPublishMatches); err != nil {
+			handleWebErr(w, err)
 			return
 		}
 	} else {
@@ -341,16 +368,11 @@ func (web *Web) settingsPublishMatchesHandler(w http.ResponseWriter, r *http.Req
 	http.Redirect(w, r, "/setup/settings", 303)
 }
 
-// Publishes the standings to the web.
+// Queues the standings to be published to the web.
 func (web *Web) settingsPublishRankingsHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	if web.arena.EventSettings.TbaPublishingEnabled {
-		err := web.arena.TbaClient.PublishRankings(web.arena.Database)
-		if err != nil {
-			http.Error(w, "Failed to publish rankings: "+err.Error(), 500)
+		if err := web.arena.TbaOutbox.Enqueue(partner.TbaPublishRankings); err != nil {
+			handleWebErr(w, err)
 			return
 		}
 	} else {
@@ -361,16 +383,11 @@ func (web *Web) settingsPublishRankingsHandler(w http.ResponseWriter, r *http.Re
 	http.Redirect(w, r, "/setup/settings", 303)
 }
 
-// Publishes the team list to the web.
+// Queues the team list to be published to the web.
 func (web *Web) settingsPublishTeamsHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	if web.arena.EventSettings.TbaPublishingEnabled {
-		err := web.arena.TbaClient.PublishTeams(web.arena.Database)
-		if err != nil {
-			http.Error(w, "Failed to publish teams: "+err.Error(), 500)
+		if err := web.arena.TbaOutbox.Enqueue(partner.TbaPublishTeams); err != nil {
+			handleWebErr(w, err)
 			return
 		}
 	} else {
@@ -387,10 +404,17 @@ func (web *Web) renderSettings(w http.ResponseWriter, r *http.Request, errorMess
 		handleWebErr(w, err)
 		return
 	}
+	tbaOutboxStatus, err := web.arena.TbaOutbox.GetStatus()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
 	data := struct {
 		*model.EventSettings
-		ErrorMessage string
-	}{web.arena.EventSettings, errorMessage}
+		TbaOutboxStatus        partner.TbaOutboxStatus
+		GameSettingDefinitions []game.Setting
+		ErrorMessage           string
+	}{web.arena.EventSettings, tbaOutboxStatus, game.CurrentGame.Settings(), errorMessage}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
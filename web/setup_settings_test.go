@@ -7,13 +7,16 @@ import (
 	"bytes"
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/plc"
 	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/stretchr/testify/assert"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 )
 
 func TestSetupSettings(t *testing.T) {
@@ -30,7 +33,8 @@ func TestSetupSettings(t *testing.T) {
 	recorder = web.postHttpResponse(
 		"/setup/settings",
 		"name=Chezy Champs&code=CC&playoffType=single&numPlayoffAlliances=16&tbaPublishingEnabled=on&"+
-			"tbaEventCode=2014cc&tbaSecretId=secretId&tbaSecret=tbasec",
+			"tbaEventCode=2014cc&tbaSecretId=secretId&tbaSecret=tbasec&frcEventsEnabled=on&frcEventsUsername=frcuser&"+
+			"frcEventsAuthKey=frckey&nexusStatusEnabled=on&nexusApiKey=nexuskey",
 	)
 	assert.Equal(t, 303, recorder.Code)
 	recorder = web.getHttpResponse("/setup/settings")
@@ -40,6 +44,39 @@ func TestSetupSettings(t *testing.T) {
 	assert.Contains(t, recorder.Body.String(), "2014cc")
 	assert.Contains(t, recorder.Body.String(), "secretId")
 	assert.Contains(t, recorder.Body.String(), "tbasec")
+	assert.True(t, web.arena.EventSettings.FrcEventsEnabled)
+	assert.Contains(t, recorder.Body.String(), "frcuser")
+	assert.Contains(t, recorder.Body.String(), "frckey")
+	assert.True(t, web.arena.EventSettings.NexusStatusEnabled)
+	assert.Contains(t, recorder.Body.String(), "nexuskey")
+}
+
+func TestSetupSettingsGameSettings(t *testing.T) {
+	web := setupTestWeb(t)
+	defer game.CurrentGame.ApplySettings(game.DefaultSettings())
+
+	recorder := web.getHttpResponse("/setup/settings")
+	assert.Contains(t, recorder.Body.String(), "Barge Bonus RP Point Threshold")
+	assert.Contains(t, recorder.Body.String(), "name=\"gameSetting_coralBonusCoopEnabled\" checked")
+
+	recorder = web.postHttpResponse(
+		"/setup/settings", "gameSetting_autoBonusCoralThreshold=3&gameSetting_coralBonusPerLevelThreshold=5&"+
+			"gameSetting_bargeBonusPointThreshold=14",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(
+		t,
+		map[string]int{
+			"autoBonusCoralThreshold":     3,
+			"coralBonusCoopEnabled":       0,
+			"coralBonusPerLevelThreshold": 5,
+			"bargeBonusPointThreshold":    14,
+		},
+		web.arena.EventSettings.GameSettings,
+	)
+	assert.False(t, game.CurrentGame.CoopertitionEnabled())
+	recorder = web.getHttpResponse("/setup/settings")
+	assert.NotContains(t, recorder.Body.String(), "name=\"gameSetting_coralBonusCoopEnabled\" checked")
 }
 
 func TestSetupSettingsDoubleElimination(t *testing.T) {
@@ -68,6 +105,71 @@ func TestSetupSettingsInvalidValues(t *testing.T) {
 	// Changing the playoff size after alliance selection is finalized.
 	recorder = web.postHttpResponse("/setup/settings", "numPlayoffAlliances=2")
 	assert.Contains(t, recorder.Body.String(), "Cannot change playoff type or size after alliance selection")
+
+	// Clearing the admin password while user accounts exist.
+	web.arena.EventSettings.AdminPassword = "admin"
+	user := model.User{Username: "scorer1", Role: model.ScorerRole}
+	assert.Nil(t, user.SetPassword("coral"))
+	assert.Nil(t, web.arena.Database.CreateUser(&user))
+	web.arena.Database.CreateUserSession(&model.UserSession{Token: "admin", Username: adminUser, CreatedAt: time.Now()})
+	headers := map[string]string{"Cookie": sessionTokenCookie + "=admin"}
+	recorder = web.postHttpResponseWithHeaders(
+		"/setup/settings", "playoffType=SingleEliminationPlayoff&numPlayoffAlliances=8&adminPassword=", headers,
+	)
+	assert.Contains(t, recorder.Body.String(), "Cannot clear the admin password while user accounts exist")
+	assert.Equal(t, "admin", web.arena.EventSettings.AdminPassword)
+}
+
+func TestSetupSettingsPlcIoMap(t *testing.T) {
+	web := setupTestWeb(t)
+
+	// An invalid map should be rejected without being saved.
+	recorder := web.postHttpResponse("/setup/settings", "plcIoMap="+url.QueryEscape(`{"inputs": {"bogus": 0}}`))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "unknown input")
+	assert.Equal(t, "", web.arena.EventSettings.PlcIoMap)
+
+	// A valid map should be saved and applied to the PLC.
+	ioMap := plc.DefaultIoMap()
+	ioMap.Coils["heartbeat"] = 40
+	recorder = web.postHttpResponse("/setup/settings", "plcIoMap="+url.QueryEscape(ioMap.String()))
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, ioMap.String(), web.arena.EventSettings.PlcIoMap)
+	assert.Equal(t, ioMap, web.arena.Plc.GetIoMap())
+
+	recorder = web.getHttpResponse("/setup/field_testing")
+	assert.Contains(t, recorder.Body.String(), "<td class=\"bg-body-tertiary text-secondary\">40</td>")
+}
+
+func TestSetupSettingsSwitch(t *testing.T) {
+	web := setupTestWeb(t)
+
+	// Invalid team network settings should be rejected without being saved.
+	recorder := web.postHttpResponse("/setup/settings", "switchTeamVlans=10,20,30")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid switch team VLANs")
+	recorder = web.postHttpResponse("/setup/settings", "switchTeamGatewayAddress=255")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "gateway address must be between 1 and 254")
+	recorder = web.postHttpResponse(
+		"/setup/settings", "switchType=ssh&switchCommandTemplate="+url.QueryEscape("{{range .Stations}}"),
+	)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "invalid switch command template")
+	assert.Equal(t, "cisco", web.arena.EventSettings.SwitchType)
+	assert.Equal(t, "", web.arena.EventSettings.SwitchTeamVlans)
+
+	recorder = web.postHttpResponse(
+		"/setup/settings",
+		"switchType=ssh&switchUsername=admin&switchTeamVlans=101,102,103,201,202,203&switchTeamGatewayAddress=1&"+
+			"switchCommandTemplate="+url.QueryEscape("{{range .Stations}}vlan {{.Vlan}}\n{{end}}"),
+	)
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, "ssh", web.arena.EventSettings.SwitchType)
+	assert.Equal(t, "admin", web.arena.EventSettings.SwitchUsername)
+	assert.Equal(t, "101,102,103,201,202,203", web.arena.EventSettings.SwitchTeamVlans)
+	assert.Equal(t, 1, web.arena.EventSettings.SwitchTeamGatewayAddress)
+	assert.Equal(t, "{{range .Stations}}vlan {{.Vlan}}\n{{end}}", web.arena.EventSettings.SwitchCommandTemplate)
 }
 
 func TestSetupSettingsClearDb(t *testing.T) {
@@ -81,6 +183,7 @@ func TestSetupSettingsClearDb(t *testing.T) {
 		assert.Nil(t, web.arena.Database.CreateMatchResult(&model.MatchResult{MatchId: 2, PlayNumber: 1}))
 		assert.Nil(t, web.arena.Database.CreateMatchResult(&model.MatchResult{MatchId: 3, PlayNumber: 1}))
 		assert.Nil(t, web.arena.Database.CreateRanking(&game.Ranking{TeamId: 254}))
+		assert.Nil(t, web.arena.RefreshBroadcastRankings())
 		assert.Nil(t, web.arena.Database.CreateAlliance(&model.Alliance{Id: 1}))
 		web.arena.AllianceSelectionAlliances = append(web.arena.AllianceSelectionAlliances, model.Alliance{Id: 1})
 	}
@@ -133,6 +236,7 @@ func TestSetupSettingsClearDb(t *testing.T) {
 	assert.NotNil(t, matchResult)
 	rankings, _ = web.arena.Database.GetAllRankings()
 	assert.Empty(t, rankings)
+	assert.Empty(t, web.arena.GenerateBroadcastFeed().Rankings.Rankings)
 	tournament.CalculateRankings(web.arena.Database, false)
 	assert.Empty(t, rankings)
 	alliances, _ = web.arena.Database.GetAllAlliances()
@@ -213,25 +317,27 @@ func TestSetupSettingsPublishToTba(t *testing.T) {
 	web.arena.TbaClient.BaseUrl = "fakeurl"
 	web.arena.EventSettings.TbaPublishingEnabled = true
 
-	recorder := web.getHttpResponse("/setup/settings/publish_alliances")
-	assert.Equal(t, 500, recorder.Code)
-	assert.Contains(t, recorder.Body.String(), "Failed to publish alliances")
-
-	recorder = web.getHttpResponse("/setup/settings/publish_awards")
-	assert.Equal(t, 500, recorder.Code)
-	assert.Contains(t, recorder.Body.String(), "Failed to publish awards")
-
-	recorder = web.getHttpResponse("/setup/settings/publish_matches")
-	assert.Equal(t, 500, recorder.Code)
-	assert.Contains(t, recorder.Body.String(), "Failed to delete published matches")
-
-	recorder = web.getHttpResponse("/setup/settings/publish_rankings")
-	assert.Equal(t, 500, recorder.Code)
-	assert.Contains(t, recorder.Body.String(), "Failed to publish rankings")
+	// Each operation should be queued rather than sent right away.
+	for _, operation := range []string{"alliances", "awards", "matches", "rankings", "teams"} {
+		recorder := web.getHttpResponse("/setup/settings/publish_" + operation)
+		assert.Equal(t, 303, recorder.Code, operation)
+	}
+	var kinds []string
+	publications, err := web.arena.Database.GetAllTbaPublications()
+	assert.Nil(t, err)
+	for _, publication := range publications {
+		kinds = append(kinds, publication.Kind)
+	}
+	assert.Equal(t, []string{"alliances", "awards", "deleteMatches", "matches", "rankings", "teams"}, kinds)
 
-	recorder = web.getHttpResponse("/setup/settings/publish_teams")
-	assert.Equal(t, 500, recorder.Code)
-	assert.Contains(t, recorder.Body.String(), "Failed to publish teams")
+	// The queue and the reason it is stuck should be shown on the settings page.
+	publications[0].Attempts = 3
+	publications[0].LastError = "TBA is unreachable"
+	assert.Nil(t, web.arena.Database.UpdateTbaPublication(&publications[0]))
+	recorder := web.getHttpResponse("/setup/settings")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "6 update(s) waiting to be sent")
+	assert.Contains(t, recorder.Body.String(), "Failed 3 time(s): TBA is unreachable")
 }
 
 func (web *Web) postFileHttpResponse(path string, paramName string, file *bytes.Buffer) *httptest.ResponseRecorder {
@@ -13,10 +13,6 @@ import (
 
 // Shows the sponsor slides configuration page.
 func (web *Web) sponsorSlidesGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	template, err := web.parseFiles("templates/setup_sponsor_slides.html", "templates/base.html")
 	if err != nil {
 		handleWebErr(w, err)
@@ -44,10 +40,6 @@ func (web *Web) sponsorSlidesGetHandler(w http.ResponseWriter, r *http.Request)
 
 // Saves the new or modified sponsor slides to the database.
 func (web *Web) sponsorSlidesPostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	sponsorSlideId, _ := strconv.Atoi(r.PostFormValue("id"))
 	sponsorSlide, err := web.arena.Database.GetSponsorSlideById(sponsorSlideId)
 	if err != nil {
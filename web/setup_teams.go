@@ -7,10 +7,13 @@ package web
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
 	"github.com/dchest/uniuri"
 	"net/http"
+	"os"
 	"regexp"
 	"strconv"
 	"strings"
@@ -19,46 +22,38 @@ import (
 
 const wpaKeyLength = 8
 
+// Portable file format for official team data that is prepared ahead of time with internet access and loaded at a
+// venue without it.
+type teamDataBundle struct {
+	Year      int              `json:"year"`
+	CreatedAt time.Time        `json:"createdAt"`
+	Teams     []model.TeamData `json:"teams"`
+}
+
 // Global var to hold the team download progress percentage.
 var progressPercentage float64 = 5
 
 // Shows the team list.
 func (web *Web) teamsGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	web.renderTeams(w, r, false)
 }
 
 // Adds teams to the team list.
 func (web *Web) teamsPostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	if !web.canModifyTeamList() {
 		web.renderTeams(w, r, true)
 		return
 	}
 
-	var teamNumbers []int
-	for _, teamNumberString := range strings.Split(r.PostFormValue("teamNumbers"), "\r\n") {
-		teamNumber, err := strconv.Atoi(teamNumberString)
-		if err == nil {
-			teamNumbers = append(teamNumbers, teamNumber)
-		}
-	}
+	teamNumbers := parseTeamNumbers(r.PostFormValue("teamNumbers"))
 
 	progressPercentage = 5
 	progressIncrement := 95.0 / float64(len(teamNumbers))
 	for _, teamNumber := range teamNumbers {
 		team := model.Team{Id: teamNumber}
-		if web.arena.EventSettings.TbaDownloadEnabled {
-			if err := web.populateOfficialTeamInfo(&team); err != nil {
-				handleWebErr(w, err)
-				return
-			}
+		if err := web.populateOfficialTeamInfo(&team); err != nil {
+			handleWebErr(w, err)
+			return
 		}
 		if err := web.arena.Database.CreateTeam(&team); err != nil {
 			handleWebErr(w, err)
@@ -72,12 +67,62 @@ func (web *Web) teamsPostHandler(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/setup/teams", 303)
 }
 
-// Re-downloads the data for all teams from TBA and overwrites any local edits.
-func (web *Web) teamsRefreshHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
+// Adds the teams registered for the event in the FRC Events API to the team list.
+func (web *Web) teamsImportHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.canModifyTeamList() {
+		web.renderTeams(w, r, true)
 		return
 	}
+	if !web.arena.EventSettings.FrcEventsEnabled {
+		handleWebErr(w, fmt.Errorf("FRC Events API import is not enabled"))
+		return
+	}
+
+	frcEventsTeams, err := web.arena.FrcEventsClient.GetTeams()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	progressPercentage = 5
+	progressIncrement := 95.0 / float64(len(frcEventsTeams))
+	for _, frcEventsTeam := range frcEventsTeams {
+		existingTeam, err := web.arena.Database.GetTeamById(frcEventsTeam.TeamNumber)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if existingTeam == nil {
+			team := model.Team{
+				Id:         frcEventsTeam.TeamNumber,
+				Name:       frcEventsTeam.NameFull,
+				Nickname:   frcEventsTeam.NameShort,
+				City:       frcEventsTeam.City,
+				StateProv:  frcEventsTeam.StateProv,
+				Country:    frcEventsTeam.Country,
+				SchoolName: frcEventsTeam.SchoolName,
+				RookieYear: frcEventsTeam.RookieYear,
+				RobotName:  frcEventsTeam.RobotName,
+			}
+			if err = web.populateOfficialTeamInfo(&team); err != nil {
+				handleWebErr(w, err)
+				return
+			}
+			if err = web.arena.Database.CreateTeam(&team); err != nil {
+				handleWebErr(w, err)
+				return
+			}
+		}
+
+		progressPercentage += progressIncrement
+	}
+	progressPercentage = 100
+
+	http.Redirect(w, r, "/setup/teams", 303)
+}
 
+// Re-populates the data for all teams from the team data bundle or TBA and overwrites any local edits.
+func (web *Web) teamsRefreshHandler(w http.ResponseWriter, r *http.Request) {
 	teams, err := web.arena.Database.GetAllTeams()
 	if err != nil {
 		handleWebErr(w, err)
@@ -104,12 +149,96 @@ func (web *Web) teamsRefreshHandler(w http.ResponseWriter, r *http.Request) {
 	progressPercentage = 5
 }
 
-// Clears the team list.
-func (web *Web) teamsClearHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
+// Downloads the official data for the given teams, or for the current team list if none are given, from TBA and sends
+// it to the client as a bundle file that can be imported later without internet access.
+func (web *Web) teamDataBundleExportHandler(w http.ResponseWriter, r *http.Request) {
+	teamNumbers := parseTeamNumbers(r.PostFormValue("teamNumbers"))
+	if len(teamNumbers) == 0 {
+		teams, err := web.arena.Database.GetAllTeams()
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		for _, team := range teams {
+			teamNumbers = append(teamNumbers, team.Id)
+		}
+	}
+	if len(teamNumbers) == 0 {
+		handleWebErr(w, fmt.Errorf("No teams were specified for the team data bundle."))
 		return
 	}
 
+	bundle := teamDataBundle{Year: time.Now().Year(), CreatedAt: time.Now()}
+	progressPercentage = 5
+	progressIncrement := 95.0 / float64(len(teamNumbers))
+	for _, teamNumber := range teamNumbers {
+		teamData, err := web.arena.TbaClient.GetTeamData(teamNumber, bundle.Year)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if teamData != nil {
+			bundle.Teams = append(bundle.Teams, *teamData)
+		}
+
+		progressPercentage += progressIncrement
+	}
+	progressPercentage = 100
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(
+		"Content-Disposition", fmt.Sprintf("attachment; filename=\"team_data_%s.json\"", time.Now().Format("20060102")),
+	)
+	if err := json.NewEncoder(w).Encode(bundle); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Accepts a team data bundle file as an upload and replaces any previously loaded bundle with it.
+func (web *Web) teamDataBundleImportHandler(w http.ResponseWriter, r *http.Request) {
+	file, _, err := r.FormFile("bundleFile")
+	if err != nil {
+		handleWebErr(w, fmt.Errorf("No team data bundle file was specified."))
+		return
+	}
+	defer file.Close()
+	var bundle teamDataBundle
+	if err = json.NewDecoder(file).Decode(&bundle); err != nil {
+		handleWebErr(w, fmt.Errorf("Could not read team data bundle: %v", err))
+		return
+	}
+	for _, teamData := range bundle.Teams {
+		if teamData.Id <= 0 {
+			handleWebErr(w, fmt.Errorf("Team data bundle contains an invalid team number: %d", teamData.Id))
+			return
+		}
+	}
+
+	if err = web.arena.Database.TruncateTeamData(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	for _, teamData := range bundle.Teams {
+		if err = web.arena.Database.CreateTeamData(&teamData); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+	http.Redirect(w, r, "/setup/teams", 303)
+}
+
+// Discards the loaded team data bundle so that team data is downloaded from TBA again.
+func (web *Web) teamDataBundleClearHandler(w http.ResponseWriter, r *http.Request) {
+	if err := web.arena.Database.TruncateTeamData(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	http.Redirect(w, r, "/setup/teams", 303)
+}
+
+// Clears the team list.
+func (web *Web) teamsClearHandler(w http.ResponseWriter, r *http.Request) {
 	if !web.canModifyTeamList() {
 		web.renderTeams(w, r, true)
 		return
@@ -125,10 +254,6 @@ func (web *Web) teamsClearHandler(w http.ResponseWriter, r *http.Request) {
 
 // Shows the page to edit a team's fields.
 func (web *Web) teamEditGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	teamId, _ := strconv.Atoi(r.PathValue("id"))
 	team, err := web.arena.Database.GetTeamById(teamId)
 	if err != nil {
@@ -158,10 +283,6 @@ func (web *Web) teamEditGetHandler(w http.ResponseWriter, r *http.Request) {
 
 // Updates a team's fields.
 func (web *Web) teamEditPostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	teamId, _ := strconv.Atoi(r.PathValue("id"))
 	team, err := web.arena.Database.GetTeamById(teamId)
 	if err != nil {
@@ -200,10 +321,6 @@ func (web *Web) teamEditPostHandler(w http.ResponseWriter, r *http.Request) {
 
 // Removes a team from the team list.
 func (web *Web) teamDeletePostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	if !web.canModifyTeamList() {
 		web.renderTeams(w, r, true)
 		return
@@ -229,10 +346,6 @@ func (web *Web) teamDeletePostHandler(w http.ResponseWriter, r *http.Request) {
 
 // Generates random WPA keys and saves them to the team models.
 func (web *Web) teamsGenerateWpaKeysHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
-		return
-	}
-
 	generateAllKeys := false
 	if all, ok := r.URL.Query()["all"]; ok {
 		generateAllKeys = all[0] == "true"
@@ -271,11 +384,17 @@ func (web *Web) renderTeams(w http.ResponseWriter, r *http.Request, showErrorMes
 		handleWebErr(w, err)
 		return
 	}
+	teamDataRecords, err := web.arena.Database.GetAllTeamData()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
 	data := struct {
 		*model.EventSettings
-		Teams            []model.Team
-		ShowErrorMessage bool
-	}{web.arena.EventSettings, teams, showErrorMessage}
+		Teams               []model.Team
+		NumTeamDataInBundle int
+		ShowErrorMessage    bool
+	}{web.arena.EventSettings, teams, len(teamDataRecords), showErrorMessage}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
@@ -283,6 +402,18 @@ func (web *Web) renderTeams(w http.ResponseWriter, r *http.Request, showErrorMes
 	}
 }
 
+// Returns the valid team numbers from the given list of one team number per line.
+func parseTeamNumbers(teamNumbersString string) []int {
+	var teamNumbers []int
+	for _, teamNumberString := range strings.Split(teamNumbersString, "\r\n") {
+		teamNumber, err := strconv.Atoi(teamNumberString)
+		if err == nil {
+			teamNumbers = append(teamNumbers, teamNumber)
+		}
+	}
+	return teamNumbers
+}
+
 // Returns true if it is safe to change the team list (i.e. no matches/results exist yet).
 func (web *Web) canModifyTeamList() bool {
 	matches, err := web.arena.Database.GetMatchesByType(model.Qualification, true)
@@ -292,42 +423,41 @@ func (web *Web) canModifyTeamList() bool {
 	return true
 }
 
-// Returns the data for the given team number.
+// Fills in the official data for the given team, from the loaded team data bundle if it includes the team, or
+// otherwise from TBA if downloading is enabled.
 func (web *Web) populateOfficialTeamInfo(team *model.Team) error {
-	tbaTeam, err := web.arena.TbaClient.GetTeam(team.Id)
+	teamData, err := web.arena.Database.GetTeamDataById(team.Id)
 	if err != nil {
 		return err
 	}
+	if teamData == nil && web.arena.EventSettings.TbaDownloadEnabled {
+		if teamData, err = web.arena.TbaClient.GetTeamData(team.Id, time.Now().Year()); err != nil {
+			return err
+		}
+	}
 
-	// Check if the result is valid. If a team is not found, it will just not have its detail fields filled out.
-	if tbaTeam.TeamNumber == 0 {
+	// If a team is not found, it will just not have its detail fields filled out.
+	if teamData == nil {
 		return nil
 	}
 
-	team.Name = tbaTeam.Name
-	team.Nickname = tbaTeam.Nickname
-	team.City = tbaTeam.City
-	team.StateProv = tbaTeam.StateProv
-	team.Country = tbaTeam.Country
+	team.Name = teamData.Name
+	team.Nickname = teamData.Nickname
+	team.City = teamData.City
+	team.StateProv = teamData.StateProv
+	team.Country = teamData.Country
 	schoolNameRe := regexp.MustCompile("^.*\\S&(\\S.*?$)")
-	matches := schoolNameRe.FindStringSubmatch(tbaTeam.Name)
+	matches := schoolNameRe.FindStringSubmatch(teamData.Name)
 	if len(matches) > 0 {
 		team.SchoolName = matches[1]
 	}
-	team.RookieYear = tbaTeam.RookieYear
-	team.RobotName, err = web.arena.TbaClient.GetRobotName(team.Id, time.Now().Year())
-	if err != nil {
-		return err
-	}
+	team.RookieYear = teamData.RookieYear
+	team.RobotName = teamData.RobotName
 
 	// Generate string of recent awards in reverse chronological order.
-	recentAwards, err := web.arena.TbaClient.GetTeamAwards(team.Id)
-	if err != nil {
-		return err
-	}
 	var accomplishmentsBuffer bytes.Buffer
-	for i := len(recentAwards) - 1; i >= 0; i-- {
-		award := recentAwards[i]
+	for i := len(teamData.Awards) - 1; i >= 0; i-- {
+		award := teamData.Awards[i]
 		if time.Now().Year()-award.Year <= 1 {
 			accomplishmentsBuffer.WriteString(
 				fmt.Sprintf("<p>%d %s - %s</p>", award.Year, award.EventName, award.Name),
@@ -336,9 +466,12 @@ func (web *Web) populateOfficialTeamInfo(team *model.Team) error {
 	}
 	team.Accomplishments = accomplishmentsBuffer.String()
 
-	// Download and store the team's avatar; if there isn't one, ignore the error.
-	if err = web.arena.TbaClient.DownloadTeamAvatar(team.Id, time.Now().Year()); err != nil {
-		return err
+	// Store the team's avatar to disk as a PNG file; if there isn't one, ignore it.
+	if len(teamData.Avatar) > 0 {
+		avatarPath := fmt.Sprintf("%s/%d.png", partner.AvatarsDir, team.Id)
+		if err = os.WriteFile(avatarPath, teamData.Avatar, 0644); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -4,8 +4,11 @@
 package web
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
@@ -172,6 +175,128 @@ func TestSetupTeamsDisallowModification(t *testing.T) {
 	assert.Contains(t, recorder.Body.String(), "Teh Chezy Pofs")
 }
 
+func TestSetupTeamsImportFrcEvents(t *testing.T) {
+	web := setupTestWeb(t)
+	mock := partner.NewMockFrcEventsServer(2025, "CASJ", "user", "key")
+	defer mock.Close()
+	mock.SetTeams(
+		[]partner.FrcEventsTeam{
+			{TeamNumber: 254, NameShort: "The Cheesy Poofs", City: "San Jose", RookieYear: 1999},
+			{TeamNumber: 1114, NameShort: "Simbotics"},
+			{TeamNumber: 2056, NameShort: "OP Robotics"},
+		},
+		2,
+	)
+	web.arena.EventSettings.TbaDownloadEnabled = false
+	web.arena.FrcEventsClient = partner.NewFrcEventsClient("2025casj", "user", "key")
+	web.arena.FrcEventsClient.BaseUrl = mock.URL()
+	web.arena.Database.CreateTeam(&model.Team{Id: 1114, Nickname: "Local Edit"})
+
+	recorder := web.getHttpResponse("/setup/teams")
+	assert.NotContains(t, recorder.Body.String(), "Import Teams from FRC Events")
+	recorder = web.postHttpResponse("/setup/teams/import", "")
+	assert.Equal(t, 500, recorder.Code)
+
+	// Check that the roster is added without overwriting existing teams.
+	web.arena.EventSettings.FrcEventsEnabled = true
+	recorder = web.getHttpResponse("/setup/teams")
+	assert.Contains(t, recorder.Body.String(), "Import Teams from FRC Events")
+	recorder = web.postHttpResponse("/setup/teams/import", "")
+	assert.Equal(t, 303, recorder.Code)
+	teams, _ := web.arena.Database.GetAllTeams()
+	if assert.Equal(t, 3, len(teams)) {
+		assert.Equal(t, 254, teams[0].Id)
+		assert.Equal(t, "The Cheesy Poofs", teams[0].Nickname)
+		assert.Equal(t, "San Jose", teams[0].City)
+		assert.Equal(t, 1999, teams[0].RookieYear)
+		assert.Equal(t, "Local Edit", teams[1].Nickname)
+		assert.Equal(t, "OP Robotics", teams[2].Nickname)
+	}
+
+	// Disallow importing once the qualification schedule exists.
+	web.arena.Database.CreateMatch(&model.Match{Type: model.Qualification})
+	recorder = web.postHttpResponse("/setup/teams/import", "")
+	assert.Contains(t, recorder.Body.String(), "can't modify")
+}
+
+func TestSetupTeamsDataBundle(t *testing.T) {
+	web := setupTestWeb(t)
+	tbaServer := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/frc254") {
+					fmt.Fprintln(w, `{"team_number": 254, "nickname": "The Cheesy Poofs", "rookie_year": 1999}`)
+				} else if strings.HasSuffix(r.URL.Path, "/frc1114") {
+					fmt.Fprintln(w, `{"team_number": 1114, "nickname": "Simbotics", "rookie_year": 2003}`)
+				} else {
+					fmt.Fprintln(w, "[]")
+				}
+			},
+		),
+	)
+	defer tbaServer.Close()
+	web.arena.TbaClient.BaseUrl = tbaServer.URL
+
+	// Export a bundle for the given teams while TBA is reachable.
+	recorder := web.postHttpResponse("/setup/teams/data_bundle/export", "teamNumbers=254\r\n1114")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Header().Get("Content-Disposition"), "attachment; filename=\"team_data_")
+	var bundle teamDataBundle
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &bundle))
+	if assert.Equal(t, 2, len(bundle.Teams)) {
+		assert.Equal(t, "The Cheesy Poofs", bundle.Teams[0].Nickname)
+		assert.Equal(t, 2003, bundle.Teams[1].RookieYear)
+	}
+	bundleFile := bytes.NewBuffer(recorder.Body.Bytes())
+	recorder = web.postHttpResponse("/setup/teams/data_bundle/export", "")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No teams were specified")
+
+	// Import the bundle and check that teams are populated from it without contacting TBA.
+	tbaServer.Close()
+	web.arena.EventSettings.TbaDownloadEnabled = false
+	recorder = web.postFileHttpResponse("/setup/teams/data_bundle/import", "bundleFile", bundleFile)
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.getHttpResponse("/setup/teams")
+	assert.Contains(t, recorder.Body.String(), "Official data for 2 teams is loaded")
+	recorder = web.postHttpResponse("/setup/teams", "teamNumbers=254\r\n1114\r\n2056")
+	assert.Equal(t, 303, recorder.Code)
+	teams, _ := web.arena.Database.GetAllTeams()
+	if assert.Equal(t, 3, len(teams)) {
+		assert.Equal(t, "The Cheesy Poofs", teams[0].Nickname)
+		assert.Equal(t, 1999, teams[0].RookieYear)
+		assert.Equal(t, "Simbotics", teams[1].Nickname)
+		assert.Equal(t, "", teams[2].Nickname)
+	}
+
+	// Check that local edits are overwritten by a refresh from the bundle.
+	teams[0].Nickname = "Teh Chezy Pofs"
+	assert.Nil(t, web.arena.Database.UpdateTeam(&teams[0]))
+	recorder = web.getHttpResponse("/setup/teams/refresh")
+	assert.Equal(t, 303, recorder.Code)
+	team, _ := web.arena.Database.GetTeamById(254)
+	assert.Equal(t, "The Cheesy Poofs", team.Nickname)
+
+	// Check that invalid bundles are rejected without discarding the loaded one.
+	recorder = web.postFileHttpResponse(
+		"/setup/teams/data_bundle/import", "bundleFile", bytes.NewBufferString("not a bundle"),
+	)
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Could not read team data bundle")
+	recorder = web.postFileHttpResponse(
+		"/setup/teams/data_bundle/import", "bundleFile", bytes.NewBufferString(`{"teams": [{"Id": 0}]}`),
+	)
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "invalid team number")
+	teamData, _ := web.arena.Database.GetAllTeamData()
+	assert.Equal(t, 2, len(teamData))
+
+	recorder = web.postHttpResponse("/setup/teams/data_bundle/clear", "")
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.getHttpResponse("/setup/teams")
+	assert.Contains(t, recorder.Body.String(), "No team data bundle is loaded")
+}
+
 func TestSetupTeamsBadReqest(t *testing.T) {
 	web := setupTestWeb(t)
 
@@ -0,0 +1,131 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+//
+// Web routes for managing named user accounts and their roles.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Shows the user accounts configuration page.
+func (web *Web) usersGetHandler(w http.ResponseWriter, r *http.Request) {
+	web.renderUsers(w, r, "")
+}
+
+// Saves the new or modified user account to the database, or deletes it.
+func (web *Web) usersPostHandler(w http.ResponseWriter, r *http.Request) {
+	userId, _ := strconv.Atoi(r.PostFormValue("id"))
+	if r.PostFormValue("action") == "delete" {
+		user, err := web.arena.Database.GetUserById(userId)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if user == nil {
+			web.renderUsers(w, r, fmt.Sprintf("User with ID %d does not exist.", userId))
+			return
+		}
+		if err = web.arena.Database.DeleteUser(user.Id); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if err = web.arena.Database.DeleteUserSessionsByUsername(user.Username); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		http.Redirect(w, r, "/setup/users", 303)
+		return
+	}
+
+	if userId == 0 && web.arena.EventSettings.AdminPassword == "" {
+		// Otherwise nobody would be able to log in to manage the accounts once they force a login.
+		web.renderUsers(w, r, "An admin password must be set on the settings page before creating user accounts.")
+		return
+	}
+
+	user := &model.User{}
+	if userId > 0 {
+		var err error
+		user, err = web.arena.Database.GetUserById(userId)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if user == nil {
+			web.renderUsers(w, r, fmt.Sprintf("User with ID %d does not exist.", userId))
+			return
+		}
+	}
+	previousUsername := user.Username
+	user.Username = strings.TrimSpace(r.PostFormValue("username"))
+	if strings.EqualFold(user.Username, adminUser) {
+		web.renderUsers(w, r, "The admin account is configured via the admin password on the settings page.")
+		return
+	}
+	role, _ := strconv.Atoi(r.PostFormValue("role"))
+	user.Role = model.UserRole(role)
+
+	// Leave the password unchanged if it is left blank while editing an existing user.
+	password := r.PostFormValue("password")
+	passwordChanged := password != "" || user.Id == 0
+	if passwordChanged {
+		if err := user.SetPassword(password); err != nil {
+			web.renderUsers(w, r, fmt.Sprintf("Failed to save user: %s", err.Error()))
+			return
+		}
+	}
+
+	var err error
+	if user.Id == 0 {
+		err = web.arena.Database.CreateUser(user)
+	} else {
+		err = web.arena.Database.UpdateUser(user)
+	}
+	if err != nil {
+		web.renderUsers(w, r, fmt.Sprintf("Failed to save user: %s", err.Error()))
+		return
+	}
+
+	if previousUsername != "" && (passwordChanged || previousUsername != user.Username) {
+		// Force the user to log in again with their new credentials.
+		if err = web.arena.Database.DeleteUserSessionsByUsername(previousUsername); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+
+	http.Redirect(w, r, "/setup/users", 303)
+}
+
+func (web *Web) renderUsers(w http.ResponseWriter, r *http.Request, errorMessage string) {
+	template, err := web.parseFiles("templates/setup_users.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	users, err := web.arena.Database.GetAllUsers()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Append a blank user to the end that can be used to add a new one.
+	users = append(users, model.User{Role: model.ScorerRole})
+
+	data := struct {
+		*model.EventSettings
+		Users        []model.User
+		Roles        []model.UserRole
+		ErrorMessage string
+	}{web.arena.EventSettings, users, model.AllUserRoles, errorMessage}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
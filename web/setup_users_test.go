@@ -0,0 +1,70 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSetupUsers(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/users")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "User Accounts")
+
+	// Check that accounts can't be created until there is an admin password to manage them with.
+	recorder = web.postHttpResponse("/setup/users", "id=0&username=ref&role=2&password=whistle")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "An admin password must be set")
+	users, _ := web.arena.Database.GetAllUsers()
+	assert.Empty(t, users)
+
+	web.arena.EventSettings.AdminPassword = "admin"
+	web.arena.Database.CreateUserSession(&model.UserSession{Token: "admin", Username: adminUser, CreatedAt: time.Now()})
+	headers := map[string]string{"Cookie": sessionTokenCookie + "=admin"}
+	recorder = web.postHttpResponseWithHeaders("/setup/users", "id=0&username=ref&role=2&password=whistle", headers)
+	assert.Equal(t, 303, recorder.Code)
+	user, _ := web.arena.Database.GetUserByUsername("ref")
+	if assert.NotNil(t, user) {
+		assert.Equal(t, model.HeadRefereeRole, user.Role)
+		assert.True(t, user.CheckPassword("whistle"))
+	}
+	recorder = web.getHttpResponseWithHeaders("/setup/users", headers)
+	assert.Contains(t, recorder.Body.String(), "value=\"ref\"")
+
+	// Check that a blank password leaves the existing one intact and that sessions are retained.
+	web.arena.Database.CreateUserSession(&model.UserSession{Token: "abc", Username: "ref", CreatedAt: time.Now()})
+	recorder = web.postHttpResponseWithHeaders("/setup/users", "id=1&username=ref&role=1&password=", headers)
+	assert.Equal(t, 303, recorder.Code)
+	user, _ = web.arena.Database.GetUserById(1)
+	assert.Equal(t, model.ScorerRole, user.Role)
+	assert.True(t, user.CheckPassword("whistle"))
+	session, _ := web.arena.Database.GetUserSessionByToken("abc")
+	assert.NotNil(t, session)
+
+	// Check that changing the password forces a logout.
+	recorder = web.postHttpResponseWithHeaders("/setup/users", "id=1&username=ref&role=1&password=flag", headers)
+	assert.Equal(t, 303, recorder.Code)
+	session, _ = web.arena.Database.GetUserSessionByToken("abc")
+	assert.Nil(t, session)
+
+	// Check validation errors.
+	recorder = web.postHttpResponseWithHeaders("/setup/users", "id=0&username=admin&role=1&password=flag", headers)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "The admin account is configured via the admin password")
+	recorder = web.postHttpResponseWithHeaders("/setup/users", "id=0&username=REF&role=1&password=flag", headers)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "already exists")
+	recorder = web.postHttpResponseWithHeaders("/setup/users", "id=0&username=scorer&role=1&password=", headers)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "password must not be blank")
+
+	recorder = web.postHttpResponseWithHeaders("/setup/users", "action=delete&id=1", headers)
+	assert.Equal(t, 303, recorder.Code)
+	users, _ = web.arena.Database.GetAllUsers()
+	assert.Empty(t, users)
+}
@@ -68,6 +68,9 @@ func NewWeb(arena *field.Arena) *Web {
 		"toUpper": func(str string) string {
 			return strings.ToUpper(str)
 		},
+		"scoreBreakdown": func(scoreSummary *game.ScoreSummary) []game.ScoreBreakdownItem {
+			return game.CurrentGame.ScoreBreakdown(scoreSummary)
+		},
 
 		// MatchType enum values.
 		"testMatch":          model.Test.Get,
@@ -126,18 +129,29 @@ func addNoCacheHeader(handler http.Handler) http.Handler {
 // Sets up the mapping between URLs and handlers.
 func (web *Web) newHandler() http.Handler {
 	mux := http.NewServeMux()
+
+	// Registers a route that is only accessible to users logged in with a role that permits it.
+	handle := func(pattern string, role model.UserRole, handler http.HandlerFunc) {
+		mux.HandleFunc(pattern, web.requireRole(role, handler))
+	}
+
 	mux.HandleFunc("GET /", web.indexHandler)
-	mux.HandleFunc("GET /alliance_selection", web.allianceSelectionGetHandler)
-	mux.HandleFunc("POST /alliance_selection", web.allianceSelectionPostHandler)
-	mux.HandleFunc("GET /alliance_selection/websocket", web.allianceSelectionWebsocketHandler)
-	mux.HandleFunc("POST /alliance_selection/finalize", web.allianceSelectionFinalizeHandler)
-	mux.HandleFunc("POST /alliance_selection/reset", web.allianceSelectionResetHandler)
-	mux.HandleFunc("POST /alliance_selection/start", web.allianceSelectionStartHandler)
+	handle("GET /alliance_selection", model.ScorekeeperRole, web.allianceSelectionGetHandler)
+	handle("POST /alliance_selection", model.ScorekeeperRole, web.allianceSelectionPostHandler)
+	handle("GET /alliance_selection/websocket", model.ScorekeeperRole, web.allianceSelectionWebsocketHandler)
+	handle("POST /alliance_selection/finalize", model.ScorekeeperRole, web.allianceSelectionFinalizeHandler)
+	handle("POST /alliance_selection/reset", model.ScorekeeperRole, web.allianceSelectionResetHandler)
+	handle("POST /alliance_selection/start", model.ScorekeeperRole, web.allianceSelectionStartHandler)
 	mux.HandleFunc("GET /api/alliances", web.alliancesApiHandler)
 	mux.HandleFunc("GET /api/arena/websocket", web.arenaWebsocketApiHandler)
 	mux.HandleFunc("GET /api/bracket/svg", web.bracketSvgApiHandler)
+	mux.HandleFunc("GET /api/broadcast/v1", web.broadcastFeedApiHandler)
+	mux.HandleFunc("GET /api/broadcast/v1/vmix", web.broadcastVmixApiHandler)
+	mux.HandleFunc("GET /api/broadcast/v1/websocket", web.broadcastWebsocketApiHandler)
+	mux.HandleFunc("GET /api/matches/{id}/timeline", web.matchTimelineApiHandler)
 	mux.HandleFunc("GET /api/matches/{type}", web.matchesApiHandler)
 	mux.HandleFunc("GET /api/rankings", web.rankingsApiHandler)
+	mux.HandleFunc("GET /api/rankings/projection", web.rankingsProjectionApiHandler)
 	mux.HandleFunc("GET /api/sponsor_slides", web.sponsorSlidesApiHandler)
 	mux.HandleFunc("GET /api/teams/{teamId}/avatar", web.teamAvatarsApiHandler)
 	mux.HandleFunc("GET /display", web.placeholderDisplayHandler)
@@ -169,25 +183,30 @@ func (web *Web) newHandler() http.Handler {
 	mux.HandleFunc("GET /displays/webpage/websocket", web.webpageDisplayWebsocketHandler)
 	mux.HandleFunc("GET /login", web.loginHandler)
 	mux.HandleFunc("POST /login", web.loginPostHandler)
-	mux.HandleFunc("GET /match_play", web.matchPlayHandler)
-	mux.HandleFunc("GET /match_play/match_load", web.matchPlayMatchLoadHandler)
-	mux.HandleFunc("GET /match_play/websocket", web.matchPlayWebsocketHandler)
+	handle("GET /match_play", model.ScorekeeperRole, web.matchPlayHandler)
+	handle("GET /match_play/match_load", model.ScorekeeperRole, web.matchPlayMatchLoadHandler)
+	handle("GET /match_play/websocket", model.ScorekeeperRole, web.matchPlayWebsocketHandler)
 	mux.HandleFunc("GET /match_logs", web.matchLogsHandler)
+	handle("GET /match_logs/health", model.FtaRole, web.matchLogsHealthHandler)
 	mux.HandleFunc("GET /match_logs/{matchId}/{stationId}/log", web.matchLogsViewGetHandler)
 	mux.HandleFunc("GET /match_review", web.matchReviewHandler)
-	mux.HandleFunc("GET /match_review/{matchId}/edit", web.matchReviewEditGetHandler)
-	mux.HandleFunc("POST /match_review/{matchId}/edit", web.matchReviewEditPostHandler)
-	mux.HandleFunc("GET /panels/scoring/{position}", web.scoringPanelHandler)
-	mux.HandleFunc("GET /panels/scoring/{position}/websocket", web.scoringPanelWebsocketHandler)
-	mux.HandleFunc("GET /panels/referee", web.refereePanelHandler)
+	handle("GET /match_review/{matchId}/edit", model.ScorekeeperRole, web.matchReviewEditGetHandler)
+	handle("POST /match_review/{matchId}/edit", model.ScorekeeperRole, web.matchReviewEditPostHandler)
+	handle("GET /panels/scoring/{position}", model.ScorerRole, web.scoringPanelHandler)
+	handle("GET /panels/scoring/{position}/websocket", model.ScorerRole, web.scoringPanelWebsocketHandler)
+	handle("GET /panels/referee", model.HeadRefereeRole, web.refereePanelHandler)
 	mux.HandleFunc("GET /panels/referee/foul_list", web.refereePanelFoulListHandler)
-	mux.HandleFunc("GET /panels/referee/websocket", web.refereePanelWebsocketHandler)
+	handle("GET /panels/referee/websocket", model.HeadRefereeRole, web.refereePanelWebsocketHandler)
+	handle("GET /reports/csv/alarms", model.FtaRole, web.alarmsCsvReportHandler)
+	handle("GET /reports/csv/audit", model.ReadOnlyRole, web.auditCsvReportHandler)
 	mux.HandleFunc("GET /reports/csv/backups", web.backupTeamsCsvReportHandler)
 	mux.HandleFunc("GET /reports/csv/fta", web.ftaCsvReportHandler)
+	handle("GET /reports/csv/official_results", model.ReadOnlyRole, web.officialResultsCsvReportHandler)
 	mux.HandleFunc("GET /reports/csv/rankings", web.rankingsCsvReportHandler)
+	handle("GET /reports/csv/robot_health", model.FtaRole, web.robotHealthCsvReportHandler)
 	mux.HandleFunc("GET /reports/csv/schedule/{type}", web.scheduleCsvReportHandler)
 	mux.HandleFunc("GET /reports/csv/teams", web.teamsCsvReportHandler)
-	mux.HandleFunc("GET /reports/csv/wpa_keys", web.wpaKeysCsvReportHandler)
+	handle("GET /reports/csv/wpa_keys", model.AdminRole, web.wpaKeysCsvReportHandler)
 	mux.HandleFunc("GET /reports/pdf/alliances", web.alliancesPdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/backups", web.backupsPdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/bracket", web.bracketPdfReportHandler)
@@ -197,43 +216,55 @@ func (web *Web) newHandler() http.Handler {
 	mux.HandleFunc("GET /reports/pdf/rankings", web.rankingsPdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/schedule/{type}", web.schedulePdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/teams", web.teamsPdfReportHandler)
-	mux.HandleFunc("GET /setup/awards", web.awardsGetHandler)
-	mux.HandleFunc("POST /setup/awards", web.awardsPostHandler)
-	mux.HandleFunc("GET /setup/breaks", web.breaksGetHandler)
-	mux.HandleFunc("POST /setup/breaks", web.breaksPostHandler)
-	mux.HandleFunc("POST /setup/db/clear/{type}", web.clearDbHandler)
-	mux.HandleFunc("POST /setup/db/restore", web.restoreDbHandler)
-	mux.HandleFunc("GET /setup/db/save", web.saveDbHandler)
-	mux.HandleFunc("GET /setup/displays", web.displaysGetHandler)
-	mux.HandleFunc("GET /setup/displays/websocket", web.displaysWebsocketHandler)
-	mux.HandleFunc("GET /setup/field_testing", web.fieldTestingGetHandler)
-	mux.HandleFunc("GET /setup/field_testing/websocket", web.fieldTestingWebsocketHandler)
-	mux.HandleFunc("GET /setup/judging", web.judgingGetHandler)
-	mux.HandleFunc("POST /setup/judging/clear", web.judgingClearPostHandler)
-	mux.HandleFunc("POST /setup/judging/generate", web.judgingGeneratePostHandler)
-	mux.HandleFunc("GET /setup/lower_thirds", web.lowerThirdsGetHandler)
-	mux.HandleFunc("GET /setup/lower_thirds/websocket", web.lowerThirdsWebsocketHandler)
-	mux.HandleFunc("GET /setup/schedule", web.scheduleGetHandler)
-	mux.HandleFunc("POST /setup/schedule/generate", web.scheduleGeneratePostHandler)
-	mux.HandleFunc("POST /setup/schedule/save", web.scheduleSavePostHandler)
-	mux.HandleFunc("GET /setup/settings", web.settingsGetHandler)
-	mux.HandleFunc("POST /setup/settings", web.settingsPostHandler)
-	mux.HandleFunc("GET /setup/settings/publish_alliances", web.settingsPublishAlliancesHandler)
-	mux.HandleFunc("GET /setup/settings/publish_awards", web.settingsPublishAwardsHandler)
-	mux.HandleFunc("GET /setup/settings/publish_matches", web.settingsPublishMatchesHandler)
-	mux.HandleFunc("GET /setup/settings/publish_rankings", web.settingsPublishRankingsHandler)
-	mux.HandleFunc("GET /setup/settings/publish_teams", web.settingsPublishTeamsHandler)
-	mux.HandleFunc("GET /setup/sponsor_slides", web.sponsorSlidesGetHandler)
-	mux.HandleFunc("POST /setup/sponsor_slides", web.sponsorSlidesPostHandler)
-	mux.HandleFunc("GET /setup/teams", web.teamsGetHandler)
-	mux.HandleFunc("POST /setup/teams", web.teamsPostHandler)
-	mux.HandleFunc("POST /setup/teams/{id}/delete", web.teamDeletePostHandler)
-	mux.HandleFunc("GET /setup/teams/{id}/edit", web.teamEditGetHandler)
-	mux.HandleFunc("POST /setup/teams/{id}/edit", web.teamEditPostHandler)
-	mux.HandleFunc("POST /setup/teams/clear", web.teamsClearHandler)
-	mux.HandleFunc("GET /setup/teams/generate_wpa_keys", web.teamsGenerateWpaKeysHandler)
+	handle("GET /setup/audit", model.ReadOnlyRole, web.auditGetHandler)
+	handle("GET /setup/awards", model.AdminRole, web.awardsGetHandler)
+	handle("POST /setup/awards", model.AdminRole, web.awardsPostHandler)
+	handle("GET /setup/breaks", model.AdminRole, web.breaksGetHandler)
+	handle("POST /setup/breaks", model.AdminRole, web.breaksPostHandler)
+	handle("POST /setup/db/clear/{type}", model.AdminRole, web.clearDbHandler)
+	handle("POST /setup/db/restore", model.AdminRole, web.restoreDbHandler)
+	handle("GET /setup/db/save", model.AdminRole, web.saveDbHandler)
+	handle("GET /setup/displays", model.AvRole, web.displaysGetHandler)
+	handle("GET /setup/displays/websocket", model.AvRole, web.displaysWebsocketHandler)
+	handle("GET /setup/field_testing", model.FtaRole, web.fieldTestingGetHandler)
+	handle("GET /setup/field_testing/websocket", model.FtaRole, web.fieldTestingWebsocketHandler)
+	handle("GET /setup/judging", model.AdminRole, web.judgingGetHandler)
+	handle("POST /setup/judging/clear", model.AdminRole, web.judgingClearPostHandler)
+	handle("POST /setup/judging/generate", model.AdminRole, web.judgingGeneratePostHandler)
+	handle("GET /setup/lower_thirds", model.AvRole, web.lowerThirdsGetHandler)
+	handle("GET /setup/lower_thirds/websocket", model.AvRole, web.lowerThirdsWebsocketHandler)
+	handle("GET /setup/schedule", model.AdminRole, web.scheduleGetHandler)
+	handle("POST /setup/schedule/availability", model.AdminRole, web.scheduleAvailabilityPostHandler)
+	handle(
+		"POST /setup/schedule/availability/{id}/delete", model.AdminRole, web.scheduleAvailabilityDeletePostHandler,
+	)
+	handle("POST /setup/schedule/generate", model.AdminRole, web.scheduleGeneratePostHandler)
+	handle("POST /setup/schedule/import", model.AdminRole, web.scheduleImportPostHandler)
+	handle("POST /setup/schedule/save", model.AdminRole, web.scheduleSavePostHandler)
+	handle("GET /setup/settings", model.AdminRole, web.settingsGetHandler)
+	handle("POST /setup/settings", model.AdminRole, web.settingsPostHandler)
+	handle("GET /setup/settings/publish_alliances", model.AdminRole, web.settingsPublishAlliancesHandler)
+	handle("GET /setup/settings/publish_awards", model.AdminRole, web.settingsPublishAwardsHandler)
+	handle("GET /setup/settings/publish_matches", model.AdminRole, web.settingsPublishMatchesHandler)
+	handle("GET /setup/settings/publish_rankings", model.AdminRole, web.settingsPublishRankingsHandler)
+	handle("GET /setup/settings/publish_teams", model.AdminRole, web.settingsPublishTeamsHandler)
+	handle("GET /setup/sponsor_slides", model.AvRole, web.sponsorSlidesGetHandler)
+	handle("POST /setup/sponsor_slides", model.AvRole, web.sponsorSlidesPostHandler)
+	handle("GET /setup/teams", model.AdminRole, web.teamsGetHandler)
+	handle("POST /setup/teams", model.AdminRole, web.teamsPostHandler)
+	handle("POST /setup/teams/{id}/delete", model.AdminRole, web.teamDeletePostHandler)
+	handle("GET /setup/teams/{id}/edit", model.AdminRole, web.teamEditGetHandler)
+	handle("POST /setup/teams/{id}/edit", model.AdminRole, web.teamEditPostHandler)
+	handle("POST /setup/teams/clear", model.AdminRole, web.teamsClearHandler)
+	handle("POST /setup/teams/data_bundle/clear", model.AdminRole, web.teamDataBundleClearHandler)
+	handle("POST /setup/teams/data_bundle/export", model.AdminRole, web.teamDataBundleExportHandler)
+	handle("POST /setup/teams/data_bundle/import", model.AdminRole, web.teamDataBundleImportHandler)
+	handle("POST /setup/teams/import", model.AdminRole, web.teamsImportHandler)
+	handle("GET /setup/teams/generate_wpa_keys", model.AdminRole, web.teamsGenerateWpaKeysHandler)
 	mux.HandleFunc("GET /setup/teams/progress", web.teamsUpdateProgressBarHandler)
-	mux.HandleFunc("GET /setup/teams/refresh", web.teamsRefreshHandler)
+	handle("GET /setup/teams/refresh", model.AdminRole, web.teamsRefreshHandler)
+	handle("GET /setup/users", model.AdminRole, web.usersGetHandler)
+	handle("POST /setup/users", model.AdminRole, web.usersPostHandler)
 	return mux
 }
 
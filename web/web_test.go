@@ -6,6 +6,8 @@ package web
 import (
 	"github.com/Team254/cheesy-arena/field"
 	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/game/reefscape"
+	"github.com/Team254/cheesy-arena/model"
 	"github.com/Team254/cheesy-arena/websocket"
 	"github.com/stretchr/testify/assert"
 	"net/http"
@@ -48,6 +50,19 @@ func (web *Web) postHttpResponse(path string, body string) *httptest.ResponseRec
 	return recorder
 }
 
+func (web *Web) postHttpResponseWithHeaders(
+	path string, body string, headers map[string]string,
+) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; param=value")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	web.newHandler().ServeHTTP(recorder, req)
+	return recorder
+}
+
 // Starts a real local HTTP server that can be used by more sophisticated tests.
 func (web *Web) startTestServer() (*httptest.Server, string) {
 	server := httptest.NewServer(web.newHandler())
@@ -89,3 +104,12 @@ func setupTestWeb(t *testing.T) *Web {
 	arena := field.SetupTestArena(t)
 	return NewWeb(arena)
 }
+
+func buildTestMatchResult(matchId int, playNumber int) *model.MatchResult {
+	matchResult := &model.MatchResult{MatchId: matchId, PlayNumber: playNumber, MatchType: model.Qualification}
+	matchResult.RedScore = reefscape.TestScore1()
+	matchResult.BlueScore = reefscape.TestScore2()
+	matchResult.RedCards = map[string]string{"1868": "yellow"}
+	matchResult.BlueCards = map[string]string{}
+	return matchResult
+}
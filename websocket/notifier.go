@@ -17,6 +17,7 @@ type Notifier struct {
 	messageType     string
 	messageProducer func() any
 	listeners       map[chan messageEnvelope]struct{} // The map is essentially a set; the value is ignored.
+	derived         []*Notifier
 	mutex           sync.Mutex
 }
 
@@ -31,6 +32,18 @@ func NewNotifier(messageType string, messageProducer func() any) *Notifier {
 	return notifier
 }
 
+// Creates a notifier that produces and sends its own message whenever any of the given source notifiers sends one, for
+// publishing a different view of the same underlying state.
+func NewDerivedNotifier(messageType string, messageProducer func() any, sources ...*Notifier) *Notifier {
+	notifier := NewNotifier(messageType, messageProducer)
+	for _, source := range sources {
+		source.mutex.Lock()
+		source.derived = append(source.derived, notifier)
+		source.mutex.Unlock()
+	}
+	return notifier
+}
+
 // Calls the messageProducer function and sends a message containing the results to all registered listeners, and cleans
 // up any listeners that have closed.
 func (notifier *Notifier) Notify() {
@@ -41,12 +54,19 @@ func (notifier *Notifier) Notify() {
 // messageProducer function defined it is ignored.
 func (notifier *Notifier) NotifyWithMessage(messageBody any) {
 	notifier.mutex.Lock()
-	defer notifier.mutex.Unlock()
-
 	message := messageEnvelope{messageType: notifier.messageType, messageBody: messageBody}
 	for listener := range notifier.listeners {
 		notifier.notifyListener(listener, message)
 	}
+	derived := notifier.derived
+	notifier.mutex.Unlock()
+
+	// Skip producing messages for derived notifiers that nobody is listening to.
+	for _, derivedNotifier := range derived {
+		if derivedNotifier.hasListeners() {
+			derivedNotifier.Notify()
+		}
+	}
 }
 
 func (notifier *Notifier) notifyListener(listener chan messageEnvelope, message messageEnvelope) {
@@ -78,6 +98,13 @@ func (notifier *Notifier) listen() chan messageEnvelope {
 	return listener
 }
 
+// Returns true if any listeners are registered, including any that have closed but haven't been reaped yet.
+func (notifier *Notifier) hasListeners() bool {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+	return len(notifier.listeners) > 0
+}
+
 // Invokes the message producer to get the message, or returns nil if no producer is defined.
 func (notifier *Notifier) getMessageBody() any {
 	if notifier.messageProducer == nil {
@@ -54,6 +54,37 @@ func TestNotifier(t *testing.T) {
 	assert.Equal(t, "next message", (<-listener).messageBody)
 }
 
+func TestDerivedNotifier(t *testing.T) {
+	source1 := NewNotifier("source1", generateTestMessage)
+	source2 := NewNotifier("source2", nil)
+	numProduced := 0
+	derived := NewDerivedNotifier(
+		"derived",
+		func() any {
+			numProduced++
+			return numProduced
+		},
+		source1,
+		source2,
+	)
+
+	// Should not produce a message when nobody is listening.
+	source1.Notify()
+	assert.Equal(t, 0, numProduced)
+
+	listener := derived.listen()
+	source1.Notify()
+	message := <-listener
+	assert.Equal(t, "derived", message.messageType)
+	assert.Equal(t, 1, message.messageBody)
+	source2.NotifyWithMessage("ignored")
+	assert.Equal(t, 2, (<-listener).messageBody)
+
+	// Should still be able to send messages directly.
+	derived.NotifyWithMessage("direct")
+	assert.Equal(t, "direct", (<-listener).messageBody)
+}
+
 func TestNotifyMultipleListeners(t *testing.T) {
 	notifier := NewNotifier("testMessageType2", nil)
 	listeners := [50]chan messageEnvelope{}